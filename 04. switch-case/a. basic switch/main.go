@@ -5,12 +5,14 @@ import "fmt"
 func main() {
 
 	//! switch statement with break :
+	//! in Go, 'break' at the end of a case is IMPLICIT : a case never runs into the next one by itself. All these breaks can be removed and nothing changes
+	//! (to run into the next case on purpose, Go has 'fallthrough' -> see b. advanced switch)
 	day := "Monday"
 
 	switch day {
 	case "Monday":
 		fmt.Println("it is Monday")
-		break //! if we use break, then the switch statement will not execute the next case
+		break //! redundant : Go stops here anyway, with or without break
 	case "Tuesday":
 		fmt.Println("it is Tuesday")
 		break
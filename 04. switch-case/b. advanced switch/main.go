@@ -0,0 +1,92 @@
+package main
+
+import "fmt"
+
+//! Person is a struct (a custom type made of fields). Structs are explained in the struct section (11. struct), here we only need it as one more type for the type switch
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! interface{} means "a value of ANY type" (explained in the interface section, 17. interface). A type switch finds out which type it really is
+func describe(i interface{}) {
+	switch v := i.(type) { //! i.(type) only works inside a switch. In each case, 'v' already has that case's type
+	case int:
+		fmt.Println("an int, doubled :", v*2) //! v is an int here, so we can do math with it
+	case string:
+		fmt.Println("a string of", len(v), "bytes :", v)
+	case bool:
+		if v {
+			fmt.Println("a bool : true")
+		} else {
+			fmt.Println("a bool : false")
+		}
+	case Person:
+		fmt.Println(`Person Name :`, v.Name, `Person Age :`, v.Age, `Person Email :`, v.Email)
+	default:
+		fmt.Printf("something else : %T\n", v) //! %T prints the type
+	}
+}
+
+func main() {
+	//! 1. switch WITHOUT an expression : every case is a condition, the first true one runs
+	//! it's the same as the if - else if - else ladder of the if-else section, just easier to read with many conditions
+	age := 20
+
+	switch {
+	case age >= 18:
+		fmt.Println("you are an adult")
+	case age >= 13:
+		fmt.Println("you are a teenager")
+	default:
+		fmt.Println("you are a child")
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 2. several values in ONE case, separated by commas
+	day := "Sunday"
+
+	switch day {
+	case "Saturday", "Sunday":
+		fmt.Println(day, "is a weekend day")
+	case "Monday", "Tuesday", "Wednesday", "Thursday", "Friday":
+		fmt.Println(day, "is a weekday")
+	default:
+		fmt.Println(day, "is not a day")
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 3. fallthrough : runs the NEXT case too, without checking its condition. Go never does this by itself, we have to ask for it
+	level := 2
+
+	switch level {
+	case 3:
+		fmt.Println("access to the admin page")
+		fallthrough
+	case 2:
+		fmt.Println("access to the editor") //! level 2 starts here ...
+		fallthrough
+	case 1:
+		fmt.Println("access to the articles") //! ... and falls into case 1, even though level is not 1
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 4. type switch
+	describe(42)
+	describe("John")
+	describe(true)
+	describe(Person{Name: "John", Age: 20, Email: "john@example.com"})
+	describe(3.14)
+}
+
+/*
+	Try :
+		1. Change age to 15 and to 8
+		2. Change level to 3, then to 1. Which lines are printed?
+		3. Put fallthrough in the last case of a switch. What does the compiler say?
+		4. Add a case float64 to describe
+*/
@@ -0,0 +1,7 @@
+module functionbestpractice
+
+go 1.22
+
+require localization v0.0.0
+
+replace localization => "../../21. localization"
@@ -1,29 +1,49 @@
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
 
-func printWelcomeMessage() {
-	fmt.Println("Welcome to the application.")
+	"localization/localize" //! the message catalogs of '21. localization', through the replace in go.mod
+)
+
+//! one reader for the whole program. bufio reads stdin in big chunks, so a second reader (or fmt.Scanln) would miss the lines this one already took
+var reader = bufio.NewReader(os.Stdin)
+
+//! message -> the text of 'key' in the language 'lang'. The catalogs are checked when the program starts, so an error here means a wrong key : the key itself is shown, so it's easy to find
+func message(lang, key string, vars map[string]string) string {
+	text, err := localize.Localize(lang, key, vars)
+	if err != nil {
+		return key
+	}
+	return text
+}
+
+func printWelcomeMessage(lang string) {
+	fmt.Println(message(lang, "welcome", nil))
 }
 
-func gerUserName() string {
+func getUserName(lang string) string {
 	// get user name as input
-	var name string
+	fmt.Print(message(lang, "enterName", nil))
 
-	fmt.Print("Enter your name: ")
-	fmt.Scanln(&name)
+	//! fmt.Scanln(&name) stops at the first space, so "John Doe" would give only "John". ReadString('\n') reads the whole line (see the bufio lesson)
+	name, _ := reader.ReadString('\n')
 
-	return name
+	return strings.TrimSpace(name) //! removes the '\n' at the end (and '\r' on Windows)
 }
 
-func getTwoNumbers() (int, int) {
+func getTwoNumbers(lang string) (int, int) {
 	var number1 int
 	var number2 int
 
-	fmt.Print("Enter first number: ")
-	fmt.Scanln(&number1) // & -> ampersand -> We need ampersand to get the address of the variable
-	fmt.Print("Enter second number: ")
-	fmt.Scanln(&number2)
+	fmt.Print(message(lang, "enterFirstNumber", nil))
+	fmt.Fscanln(reader, &number1) // & -> ampersand -> We need ampersand to get the address of the variable. Fscanln is Scanln, but it reads from our reader
+	fmt.Print(message(lang, "enterSecondNumber", nil))
+	fmt.Fscanln(reader, &number2)
 
 	return number1, number2
 }
@@ -33,15 +53,22 @@ func calculateSum(number1 int, number2 int) int {
 	return sum
 }
 
-func printOutput(name string, sum int) {
-	fmt.Println("Hello ", name, "! The sum of is ", sum, "!")
+func printOutput(lang string, name string, sum int) {
+	fmt.Println(message(lang, "output", map[string]string{"name": name, "sum": fmt.Sprint(sum)}))
 }
 
-func printGoodbyeMessage() {
-	fmt.Println("Thank you for using the application!")
+func printGoodbyeMessage(lang string, name string) {
+	fmt.Println(message(lang, "goodbye", map[string]string{"name": name}))
 }
 
 func main() {
+	langFlag := flag.String("lang", "", "language of the messages : en, bn or es")
+	flag.Parse()
+	if err := localize.Check(); err != nil {
+		fmt.Println("could not load catalogs :", err)
+		os.Exit(1)
+	}
+	lang := localize.ChooseLanguage(*langFlag) //! every function gets the language as a parameter, not from a global variable
 
 	//! now as we have written the application, this is not proper. Rather, we could make functions and make it more readable
 	// fmt.Println("Welcome to the application.")
@@ -62,11 +89,11 @@ func main() {
 	// fmt.Println("Thank you for using the application!")
 
 	//? now we will make functions with SOLID principle, and call those in this main function
-	printWelcomeMessage()
-	name := gerUserName()
-	number1, number2 := getTwoNumbers()
+	printWelcomeMessage(lang)
+	name := getUserName(lang)
+	number1, number2 := getTwoNumbers(lang)
 	sum := calculateSum(number1, number2)
-	printOutput(name, sum)
-	printGoodbyeMessage()
+	printOutput(lang, name, sum)
+	printGoodbyeMessage(lang, name)
 	//? now this main function is only working with business and every function declared outside, only working with one task at once. Now it's looking nicer and cleaner. It also increases maintainability
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+//! two steps of a made-up "save" operation. step1 works, step2 fails
+func step1() error {
+	return nil
+}
+
+func step2() ([]byte, error) {
+	return nil, errors.New("step2 : disk full")
+}
+
+//! buggySave -> the classic bug : ':=' inside the if block declares a NEW 'err', it doesn't set the outer one
+func buggySave() error {
+	err := step1()
+	if err == nil {
+		data, err := step2()                  //! 'data' is new, so ':=' is allowed ... and it makes a new 'err' too, which shadows the outer one
+		fmt.Println("  inside the if :", err) //! the inner err : step2 : disk full
+		fmt.Println("  data :", data)
+	} //! the inner err disappears here
+
+	return err //! the OUTER err : still nil from step1
+}
+
+//! fixedSave -> declare 'data' first, then assign with '=' : no new 'err', the outer one gets step2's error
+func fixedSave() error {
+	err := step1()
+	if err == nil {
+		var data []byte
+		data, err = step2() //! '=' -> assigns to the existing err
+		fmt.Println("  inside the if :", err)
+		fmt.Println("  data :", data)
+	}
+
+	return err
+}
+
+//! betterSave -> the usual Go style avoids the problem : check each error right away and return early, no nesting
+func betterSave() error {
+	if err := step1(); err != nil {
+		return err
+	}
+	data, err := step2() //! same scope as nothing else called err -> ':=' declares it here, nothing is shadowed
+	if err != nil {
+		return err
+	}
+	fmt.Println("  data :", data)
+	return nil
+}
+
+func main() {
+	//! 1. err shadowing
+	fmt.Println("buggySave :")
+	err := buggySave()
+	fmt.Println("buggySave returned :", err) //! <nil> -> the caller thinks the save worked!
+	if err == nil {
+		fmt.Println("-> \"saved\" ... but step2 failed")
+	}
+
+	fmt.Println("fixedSave :")
+	fmt.Println("fixedSave returned :", fixedSave())
+
+	fmt.Println("betterSave :")
+	fmt.Println("betterSave returned :", betterSave())
+
+	fmt.Println("--------------------------------")
+
+	//! 2. one loop variable shared by goroutines
+	//! since Go 1.22, 'for i := 0; ...' makes a NEW 'i' for every iteration, so closures see their own value
+	//! but a variable declared OUTSIDE the loop is still ONE variable, shared by every closure which uses it
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([]int, 3)
+
+	var i int
+	for i = 0; i < 3; i++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			<-start           //! wait until the loop is over, so the output is the same on every run
+			results[slot] = i //! reads the shared 'i' : by now it is 3
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	fmt.Println("shared i      :", results) //! [3 3 3] and not [0 1 2]
+
+	//! the fix : give every goroutine its own copy. Here 'i := i' SHADOWS the outer i on purpose : the only good shadowing in this lesson
+	start = make(chan struct{})
+	for i = 0; i < 3; i++ {
+		i := i //! a new i for this iteration, with the current value
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			<-start
+			results[slot] = i
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	fmt.Println("copied i      :", results) //! [0 1 2]
+}
+
+/*
+	Try :
+		1. In buggySave, change 'data, err := step2()' to 'data, err = step2()'. What does the compiler say? Why?
+		2. Run 'go vet' on this file. Does it find the shadowed err? (the extra 'shadow' analyzer does : go install golang.org/x/tools/go/analysis/passes/shadow/cmd/shadow@latest)
+		3. Change the loop to 'for i := 0; i < 3; i++' and remove 'var i int'. Why is the output [0 1 2] now?
+*/
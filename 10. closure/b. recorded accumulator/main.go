@@ -0,0 +1,74 @@
+//! Recorded accumulator -> in the closure lesson (a. closure basics), 'money' changes inside the closure and we can't see it from outside
+//! here every change of the captured variable is recorded as a Snapshot, printed as a table, and replayed to check the history is consistent
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+)
+
+func printHistory(history []Snapshot) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "call\tdelta\tbefore\tafter\twhen\t")
+	for _, s := range history {
+		fmt.Fprintf(w, "%d\t%+d\t%d\t%d\t%s\t\n", s.CallIndex, s.Delta, s.Before, s.After, s.When.Format("15:04:05.000000"))
+	}
+	w.Flush()
+}
+
+//! hammer -> 'workers' goroutines, each calling step 'calls' times with +1
+func hammer(step func(int) int, workers, calls int) {
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range calls {
+				step(1)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func main() {
+	unsafe := flag.Bool("unsafe", false, "hammer the accumulator WITHOUT a mutex from 8 goroutines (try it with go run -race)")
+	flag.Parse()
+
+	if *unsafe {
+		step, history := NewRecordedAccumulator(0)
+		hammer(step, 8, 1000)
+		snapshots := history()
+		final, err := Replay(snapshots)
+		fmt.Println("want : 8000 | snapshots :", len(snapshots), "| replay :", final, err) //! on one core it often looks fine : the race detector still sees the race
+		return
+	}
+
+	step, history := NewRecordedAccumulator(100)
+	step(10)
+	step(20)
+	step(-5)
+	step(30)
+	printHistory(history())
+	final, err := Replay(history())
+	fmt.Println("replayed final :", final, "| error :", err)
+	fmt.Println("--------------------------------")
+
+	//! the same 8 goroutines as with -unsafe, but with the mutex
+	safe, safeHistory := NewSafeRecordedAccumulator(0)
+	hammer(safe, 8, 1000)
+	snapshots := safeHistory()
+	final, err = Replay(snapshots)
+	fmt.Println("safe : want : 8000 | snapshots :", len(snapshots), "| replay :", final, err)
+}
+
+/*
+	Try :
+		1. go run main.go recorder.go -unsafe               -> can lose updates, or break the history (more likely on a machine with many cores)
+		2. go run -race main.go recorder.go -unsafe         -> the race detector reports a DATA RACE on total and snapshots
+		3. go run -race main.go recorder.go                 -> the safe accumulator : no race reported
+		4. Add an Undo closure which goes back one snapshot. What should it record?
+*/
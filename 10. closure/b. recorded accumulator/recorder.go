@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+//! Snapshot -> one call of step : the captured total before and after, and what changed it
+type Snapshot struct {
+	CallIndex int //! 0 for the first call, 1 for the second ...
+	Delta     int
+	Before    int
+	After     int
+	When      time.Time
+}
+
+//! NewRecordedAccumulator -> the accumulator closure of the closure lesson, plus a second closure which shows its history
+//! both closures capture the SAME total and the SAME snapshots slice : that's how history can see what step did
+//! NOT safe for concurrent use : two goroutines calling step can read the same total and lose an update (see NewSafeRecordedAccumulator)
+func NewRecordedAccumulator(start int) (step func(delta int) int, history func() []Snapshot) {
+	total := start
+	var snapshots []Snapshot
+
+	step = func(delta int) int {
+		before := total
+		total = before + delta
+		snapshots = append(snapshots, Snapshot{CallIndex: len(snapshots), Delta: delta, Before: before, After: total, When: time.Now()})
+		return total
+	}
+	history = func() []Snapshot {
+		return slices.Clone(snapshots) //! a copy : the caller can't change the recorded history by accident
+	}
+	return step, history
+}
+
+//! NewSafeRecordedAccumulator -> the same, with a mutex captured by both closures. The read of total, the write and the append happen as one step
+func NewSafeRecordedAccumulator(start int) (step func(delta int) int, history func() []Snapshot) {
+	var mu sync.Mutex
+	unsafeStep, unsafeHistory := NewRecordedAccumulator(start)
+
+	step = func(delta int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return unsafeStep(delta)
+	}
+	history = func() []Snapshot {
+		mu.Lock()
+		defer mu.Unlock()
+		return unsafeHistory()
+	}
+	return step, history
+}
+
+var ErrEmptyHistory = errors.New("empty history: no start value to replay from")
+
+//! ReplayError -> the history is broken at Index. The chain was expected to continue from Want, the snapshot says Got
+type ReplayError struct {
+	Index  int
+	Reason string
+	Want   int
+	Got    int
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("snapshot %d: %s: want %d, got %d", e.Index, e.Reason, e.Want, e.Got)
+}
+
+//! Replay -> walks the history again and checks that it is one unbroken chain :
+//! every Before equals the previous After, every After equals Before + Delta, and the call indexes have no gaps
+//! the result is the final total, rebuilt from the first Before and the deltas only
+func Replay(snapshots []Snapshot) (final int, err error) {
+	if len(snapshots) == 0 {
+		return 0, ErrEmptyHistory
+	}
+	total := snapshots[0].Before
+	for i, snapshot := range snapshots {
+		switch {
+		case snapshot.CallIndex != i:
+			return 0, &ReplayError{Index: i, Reason: "call index", Want: i, Got: snapshot.CallIndex}
+		case snapshot.Before != total:
+			return 0, &ReplayError{Index: i, Reason: "before is not the previous after", Want: total, Got: snapshot.Before}
+		case snapshot.After != snapshot.Before+snapshot.Delta:
+			return 0, &ReplayError{Index: i, Reason: "after is not before + delta", Want: snapshot.Before + snapshot.Delta, Got: snapshot.After}
+		}
+		total += snapshot.Delta
+	}
+	return total, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+//! two accumulators : each has its own captured total and history
+func TestIndependentAccumulators(t *testing.T) {
+	john, johnHistory := NewRecordedAccumulator(100)
+	jane, janeHistory := NewRecordedAccumulator(0)
+	john(10)
+	janeTotal := jane(5)
+	john(-30)
+	johnTotal := john(5)
+
+	if johnTotal != 85 || janeTotal != 5 {
+		t.Errorf("totals = %d, %d; want 85, 5", johnTotal, janeTotal)
+	}
+	if len(johnHistory()) != 3 || len(janeHistory()) != 1 {
+		t.Errorf("history lengths = %d, %d; want 3, 1", len(johnHistory()), len(janeHistory()))
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	step, history := NewRecordedAccumulator(100)
+	step(10)
+	step(20)
+	step(-5)
+
+	want := []Snapshot{
+		{CallIndex: 0, Delta: 10, Before: 100, After: 110},
+		{CallIndex: 1, Delta: 20, Before: 110, After: 130},
+		{CallIndex: 2, Delta: -5, Before: 130, After: 125},
+	}
+	got := history()
+	if len(got) != len(want) {
+		t.Fatalf("%d snapshots, want %d", len(got), len(want))
+	}
+	for i := range want {
+		got[i].When = want[i].When //! the time is different on every run
+		if got[i] != want[i] {
+			t.Errorf("snapshot %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+//! history returns a copy, so changing it doesn't touch the real history
+func TestHistoryIsACopy(t *testing.T) {
+	step, history := NewRecordedAccumulator(100)
+	step(10)
+	step(20)
+	copied := history()
+	copied[1].Before = 999
+	if history()[1].Before != 110 {
+		t.Errorf("the real history changed to %d, want 110", history()[1].Before)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	step, history := NewRecordedAccumulator(100)
+	step(10)
+	step(-30)
+	step(5)
+	valid := history()
+
+	tests := []struct {
+		name       string
+		snapshots  func() []Snapshot
+		want       int
+		wantIndex  int //! -1 -> no error
+		wantReason string
+		wantWant   int
+	}{
+		{"valid history", func() []Snapshot { return valid }, 85, -1, "", 0},
+		{"tampered before", func() []Snapshot {
+			s := history()
+			s[1].Before = 999
+			return s
+		}, 0, 1, "before is not the previous after", 110},
+		{"tampered after", func() []Snapshot {
+			s := history()
+			s[2].After = 0
+			return s
+		}, 0, 2, "after is not before + delta", 85},
+		{"tampered delta", func() []Snapshot {
+			s := history()
+			s[0].Delta = 11
+			return s
+		}, 0, 0, "after is not before + delta", 111},
+		{"missing snapshot", func() []Snapshot {
+			s := history()
+			return append(s[:1], s[2:]...)
+		}, 0, 1, "call index", 1},
+		{"swapped snapshots", func() []Snapshot {
+			s := history()
+			s[1], s[2] = s[2], s[1]
+			return s
+		}, 0, 1, "call index", 1},
+		{"one snapshot", func() []Snapshot { return valid[:1] }, 110, -1, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := Replay(tt.snapshots())
+			if tt.wantIndex == -1 {
+				if err != nil || final != tt.want {
+					t.Errorf("Replay = %d, %v; want %d, nil", final, err, tt.want)
+				}
+				return
+			}
+			var replayErr *ReplayError
+			if !errors.As(err, &replayErr) {
+				t.Fatalf("Replay error = %v, want a *ReplayError", err)
+			}
+			if replayErr.Index != tt.wantIndex || replayErr.Reason != tt.wantReason || replayErr.Want != tt.wantWant {
+				t.Errorf("ReplayError = %+v, want index %d, reason %q, want %d", replayErr, tt.wantIndex, tt.wantReason, tt.wantWant)
+			}
+		})
+	}
+}
+
+func TestReplayErrorMessage(t *testing.T) {
+	err := &ReplayError{Index: 1, Reason: "before is not the previous after", Want: 110, Got: 999}
+	want := "snapshot 1: before is not the previous after: want 110, got 999"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestReplayEmptyHistory(t *testing.T) {
+	_, history := NewRecordedAccumulator(42)
+	for _, snapshots := range [][]Snapshot{history(), nil, {}} {
+		if _, err := Replay(snapshots); !errors.Is(err, ErrEmptyHistory) {
+			t.Errorf("Replay(%v) error = %v, want ErrEmptyHistory", snapshots, err)
+		}
+	}
+}
+
+//! the safe accumulator never loses an update, and its history always replays. Run with -race
+func TestSafeAccumulatorConcurrent(t *testing.T) {
+	tests := []struct {
+		workers, calls int
+	}{
+		{1, 100},
+		{8, 1000},
+		{50, 20},
+	}
+	for _, tt := range tests {
+		safe, history := NewSafeRecordedAccumulator(0)
+		hammer(safe, tt.workers, tt.calls)
+		want := tt.workers * tt.calls
+		final, err := Replay(history())
+		if err != nil || final != want || len(history()) != want {
+			t.Errorf("%d x %d calls : replay = %d, %v with %d snapshots; want %d, nil with %d", tt.workers, tt.calls, final, err, len(history()), want, want)
+		}
+	}
+}
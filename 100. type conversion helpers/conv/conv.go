@@ -0,0 +1,83 @@
+//! Package conv -> small helpers around type assertions, for code which passes a lot of 'any' around
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//! As -> the comma-ok assertion v.(T) as a function, so it can be passed around and used in generic code
+//! T can be an interface too : As[fmt.Stringer](v) is true when v's type has a String method
+func As[T any](v any) (T, bool) {
+	t, ok := v.(T)
+	return t, ok
+}
+
+//! MustAs -> like v.(T), it panics when v is not a T, but the message names both types in plain words
+//! use it only where a wrong type is a bug in OUR code, never for values coming from outside
+func MustAs[T any](v any) T {
+	t, ok := v.(T)
+	if !ok {
+		panic(fmt.Sprintf("conv.MustAs: value of type %s is not %s", dynamicType(v), reflect.TypeFor[T]()))
+	}
+	return t
+}
+
+func dynamicType(v any) string {
+	if v == nil {
+		return "<nil>" //! an interface with no type at all, not a typed nil pointer
+	}
+	return reflect.TypeOf(v).String()
+}
+
+//! Default -> the key of the handler which runs when no other handler matches
+var Default = reflect.TypeFor[defaultKey]()
+
+type defaultKey struct{}
+
+var (
+	ErrNoHandler = errors.New("conv: no handler")
+	ErrAmbiguous = errors.New("conv: more than one handler")
+)
+
+//! Switch -> a type switch built at runtime : handlers are looked up by the concrete type of v
+//! 1. a handler for exactly that type wins
+//! 2. else a handler for an interface type which v implements. Two such interfaces -> ErrAmbiguous, Go's switch would pick the first case, a map has no order
+//! 3. else the Default handler, else ErrNoHandler
+func Switch(v any, handlers map[reflect.Type]func(any)) error {
+	typ := reflect.TypeOf(v) //! nil when v is a nil interface : only Default can handle it
+	if typ != nil {
+		if handle, ok := handlers[typ]; ok {
+			handle(v)
+			return nil
+		}
+
+		var matches []reflect.Type
+		for key := range handlers {
+			if key != Default && key.Kind() == reflect.Interface && typ.Implements(key) {
+				matches = append(matches, key)
+			}
+		}
+		if len(matches) > 1 {
+			names := make([]string, len(matches))
+			for i, match := range matches {
+				names[i] = match.String()
+			}
+			sort.Strings(names) //! the same message on every run
+			return fmt.Errorf("%w for %s: %s", ErrAmbiguous, typ, strings.Join(names, ", "))
+		}
+		if len(matches) == 1 {
+			handlers[matches[0]](v)
+			return nil
+		}
+	}
+
+	if handle, ok := handlers[Default]; ok {
+		handle(v)
+		return nil
+	}
+	return fmt.Errorf("%w for %s", ErrNoHandler, dynamicType(v))
+}
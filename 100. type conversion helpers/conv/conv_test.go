@@ -0,0 +1,193 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func (p *Person) String() string { return p.Name }
+
+func TestAs(t *testing.T) {
+	person := Person{Name: "John", Age: 20, Email: "john@example.com"}
+
+	if got, ok := As[int](42); !ok || got != 42 {
+		t.Errorf("As[int](42) = %v, %v, want 42, true", got, ok)
+	}
+	if got, ok := As[int]("42"); ok || got != 0 {
+		t.Errorf(`As[int]("42") = %v, %v, want 0, false`, got, ok)
+	}
+	if got, ok := As[Person](person); !ok || got != person {
+		t.Errorf("As[Person](person) = %v, %v, want person, true", got, ok)
+	}
+	if got, ok := As[int64](int32(7)); ok || got != 0 {
+		t.Errorf("As[int64](int32(7)) = %v, %v, want 0, false : no conversion, only assertion", got, ok)
+	}
+}
+
+func TestAsPointerVsValue(t *testing.T) {
+	person := Person{Name: "John"}
+
+	if _, ok := As[*Person](person); ok {
+		t.Error("As[*Person](Person{}) = true, want false")
+	}
+	if _, ok := As[Person](&person); ok {
+		t.Error("As[Person](&Person{}) = true, want false")
+	}
+	if got, ok := As[*Person](&person); !ok || got != &person {
+		t.Errorf("As[*Person](&person) = %p, %v, want the same pointer, true", got, ok)
+	}
+	//! String has a pointer receiver : only *Person is a fmt.Stringer
+	if _, ok := As[fmt.Stringer](person); ok {
+		t.Error("As[fmt.Stringer](Person{}) = true, want false")
+	}
+	if got, ok := As[fmt.Stringer](&person); !ok || got.String() != "John" {
+		t.Errorf("As[fmt.Stringer](&person) = %v, %v, want John, true", got, ok)
+	}
+}
+
+func TestAsNil(t *testing.T) {
+	var nilPerson *Person
+	var typedNil any = nilPerson //! not a nil interface : it holds the type *Person and a nil pointer
+
+	if got, ok := As[*Person](typedNil); !ok || got != nil {
+		t.Errorf("As[*Person](typed nil) = %v, %v, want nil, true", got, ok)
+	}
+	if _, ok := As[*Person](nil); ok {
+		t.Error("As[*Person](nil) = true, want false")
+	}
+	if got, ok := As[error](nil); ok || got != nil {
+		t.Errorf("As[error](nil) = %v, %v, want nil, false", got, ok)
+	}
+	if _, ok := As[any](nil); ok {
+		t.Error("As[any](nil) = true, want false : a nil interface holds no type at all")
+	}
+}
+
+//! panicMessage -> runs fn and returns what it panicked with, "" when it didn't panic
+func panicMessage(fn func()) (message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			message = fmt.Sprint(r)
+		}
+	}()
+	fn()
+	return ""
+}
+
+func TestMustAs(t *testing.T) {
+	if got := MustAs[string]("Gopher"); got != "Gopher" {
+		t.Errorf(`MustAs[string]("Gopher") = %q`, got)
+	}
+
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{name: "string as int", fn: func() { MustAs[int]("42") }, want: "value of type string is not int"},
+		{name: "value as pointer", fn: func() { MustAs[*Person](Person{}) }, want: "value of type conv.Person is not *conv.Person"},
+		{name: "nil interface", fn: func() { MustAs[*Person](nil) }, want: "value of type <nil> is not *conv.Person"},
+		{name: "missing method", fn: func() { MustAs[fmt.Stringer](Person{}) }, want: "value of type conv.Person is not fmt.Stringer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := panicMessage(tt.fn)
+			if message == "" {
+				t.Fatal("no panic")
+			}
+			if !strings.HasPrefix(message, "conv.MustAs: ") || !strings.Contains(message, tt.want) {
+				t.Errorf("panic message = %q, want it to contain %q", message, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	var got string
+	handlers := map[reflect.Type]func(any){
+		reflect.TypeFor[int]():          func(v any) { got = fmt.Sprint("int ", v) },
+		reflect.TypeFor[Person]():       func(v any) { got = "Person " + v.(Person).Name },
+		reflect.TypeFor[fmt.Stringer](): func(v any) { got = "Stringer " + v.(fmt.Stringer).String() },
+		Default:                         func(v any) { got = fmt.Sprintf("default %T", v) },
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "exact type", value: 42, want: "int 42"},
+		{name: "struct value", value: Person{Name: "John"}, want: "Person John"},
+		{name: "pointer goes to the interface", value: &Person{Name: "Jane"}, want: "Stringer Jane"},
+		{name: "unknown type -> default", value: 3.14, want: "default float64"},
+		{name: "nil interface -> default", value: nil, want: "default <nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			if err := Switch(tt.value, handlers); err != nil {
+				t.Fatalf("Switch(%v) error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Switch(%v) ran %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwitchExactTypeBeatsInterface(t *testing.T) {
+	var got string
+	handlers := map[reflect.Type]func(any){
+		reflect.TypeFor[*Person]():      func(any) { got = "*Person" },
+		reflect.TypeFor[fmt.Stringer](): func(any) { got = "Stringer" },
+	}
+	if err := Switch(&Person{}, handlers); err != nil || got != "*Person" {
+		t.Errorf("Switch(&Person{}) = %v, ran %q, want the *Person handler", err, got)
+	}
+}
+
+func TestSwitchErrors(t *testing.T) {
+	noDefault := map[reflect.Type]func(any){
+		reflect.TypeFor[int](): func(any) {},
+	}
+	err := Switch("text", noDefault)
+	if !errors.Is(err, ErrNoHandler) || err.Error() != "conv: no handler for string" {
+		t.Errorf("Switch(string) error = %v, want ErrNoHandler for string", err)
+	}
+	if err := Switch(nil, noDefault); !errors.Is(err, ErrNoHandler) {
+		t.Errorf("Switch(nil) error = %v, want ErrNoHandler", err)
+	}
+
+	ran := false
+	twoInterfaces := map[reflect.Type]func(any){
+		reflect.TypeFor[fmt.Stringer](): func(any) { ran = true },
+		reflect.TypeFor[error]():        func(any) { ran = true },
+	}
+	err = Switch(errors.New("boom"), twoInterfaces) //! *errors.errorString has Error, not String : only one match
+	if err != nil || !ran {
+		t.Errorf("Switch(error) = %v, ran %v, want the error handler", err, ran)
+	}
+
+	ran = false
+	err = Switch(stringError{}, twoInterfaces)
+	if !errors.Is(err, ErrAmbiguous) || ran {
+		t.Errorf("Switch(stringError) = %v, ran %v, want ErrAmbiguous and no handler", err, ran)
+	}
+	if err != nil && !strings.HasSuffix(err.Error(), "error, fmt.Stringer") {
+		t.Errorf("ambiguous error = %q, want both interfaces listed in order", err)
+	}
+}
+
+type stringError struct{}
+
+func (stringError) Error() string  { return "stringError" }
+func (stringError) String() string { return "stringError" }
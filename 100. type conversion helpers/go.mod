@@ -0,0 +1,3 @@
+module typeconv
+
+go 1.22
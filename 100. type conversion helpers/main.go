@@ -0,0 +1,120 @@
+//! Type conversion helpers -> v.(T), the comma-ok form and the type switch, wrapped in small functions (conv/conv.go)
+//! the basics are in 17. interface/b. empty interface and 04. switch-case/b. advanced switch. This lesson is for code which passes a lot of 'any' around
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"typeconv/conv"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! Celsius -> has a String method, so it is a fmt.Stringer
+type Celsius float64
+
+func (c Celsius) String() string {
+	return fmt.Sprintf("%.1f°C", float64(c))
+}
+
+//! Describe -> the type switch of the empty interface lesson, as a map of handlers. A map can be built at runtime, extended, or shared
+func Describe(value any) string {
+	var description string
+	handlers := map[reflect.Type]func(any){
+		reflect.TypeFor[int](): func(v any) {
+			description = fmt.Sprint("an int, doubled : ", conv.MustAs[int](v)*2) //! MustAs can't fail here : this handler only gets ints
+		},
+		reflect.TypeFor[string](): func(v any) {
+			description = fmt.Sprint("a string, length : ", len(conv.MustAs[string](v)))
+		},
+		reflect.TypeFor[Person](): func(v any) {
+			description = "a Person, name : " + conv.MustAs[Person](v).Name
+		},
+		reflect.TypeFor[fmt.Stringer](): func(v any) { //! an INTERFACE type : every type with a String method ends up here
+			description = "a Stringer : " + conv.MustAs[fmt.Stringer](v).String()
+		},
+		conv.Default: func(v any) {
+			description = fmt.Sprintf("don't know this type : %T", v)
+		},
+	}
+	if err := conv.Switch(value, handlers); err != nil {
+		return err.Error()
+	}
+	return description
+}
+
+//! SumNumbers -> adds up the ints and float64s, counts everything else as skipped
+func SumNumbers(values []any) (sum float64, skipped int) {
+	for _, value := range values {
+		if number, ok := conv.As[int](value); ok {
+			sum += float64(number)
+		} else if number, ok := conv.As[float64](value); ok {
+			sum += number
+		} else {
+			skipped++
+		}
+	}
+	return sum, skipped
+}
+
+//! tryMustAs -> calls MustAs and turns its panic into text (recover : 36. panic recover)
+func tryMustAs[T any](value any) (result string) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Sprint("panic : ", r)
+		}
+	}()
+	return fmt.Sprint("ok : ", conv.MustAs[T](value))
+}
+
+func main() {
+	//! 1. As : the comma-ok form as a function
+	var value any = "Go is fun"
+	text, ok := conv.As[string](value)
+	fmt.Println("As[string] :", text, ok)
+	number, ok := conv.As[int](value)
+	fmt.Println("As[int]    :", number, ok) //! 0 false : the zero value of int
+	stringer, ok := conv.As[fmt.Stringer](Celsius(21.5))
+	fmt.Println("As[fmt.Stringer](Celsius) :", stringer, ok)
+
+	var nobody *Person
+	fmt.Println("As[*Person](typed nil pointer) :", func() bool { _, ok := conv.As[*Person](any(nobody)); return ok }()) //! true : the interface holds the type *Person
+	fmt.Println("As[*Person](nil interface)     :", func() bool { _, ok := conv.As[*Person](nil); return ok }())         //! false : no type at all
+	fmt.Println("--------------------------------")
+
+	//! 2. MustAs : panics like v.(T), with a clearer message
+	fmt.Println(tryMustAs[string]("Gopher"))
+	fmt.Println(tryMustAs[int]("Gopher"))
+	fmt.Println(tryMustAs[*Person](Person{Name: "John"})) //! a Person is not a *Person
+	fmt.Println(tryMustAs[*Person](nil))
+	fmt.Println("--------------------------------")
+
+	//! 3. Switch : handlers looked up by type
+	for _, v := range []any{21, "Gopher", Person{Name: "Jane", Age: 21, Email: "jane@example.com"}, Celsius(36.6), 3.14, nil} {
+		fmt.Printf("%-28s -> %s\n", fmt.Sprint(v), Describe(v))
+	}
+	err := conv.Switch(true, map[reflect.Type]func(any){reflect.TypeFor[int](): func(any) {}})
+	fmt.Println("no handler, no default :", err)
+	fmt.Println("--------------------------------")
+
+	//! 4. SumNumbers
+	values := []any{10, 2.5, "30", Person{Name: "John"}, 7, nil}
+	sum, skipped := SumNumbers(values)
+	for _, v := range values {
+		fmt.Printf("%-14s %v\n", fmt.Sprintf("%T", v), v)
+	}
+	fmt.Println("sum :", sum, "skipped :", skipped) //! "30" is a string, not a number : As never converts, it only checks
+}
+
+/*
+	Try :
+		1. go test -v ./conv
+		2. Give Person a String method. Which handler does Describe(Person{...}) use now? Why not the Stringer one?
+		3. Add a handler for error to Describe, then describe a type which has both Error and String methods
+		4. Make SumNumbers accept int64 and float32 too
+*/
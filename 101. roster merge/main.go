@@ -0,0 +1,131 @@
+//! Roster merge -> several rosters (CSV or JSON) into one. People are matched by email, like in the roster diff (70. roster diff)
+//! when two records of the same person disagree, a strategy decides : the first roster wins, the newest record wins, or we are asked
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//! runMerge -> the `merge` subcommand : go run main.go merge.go roster.go merge [-strategy first|newest|interactive] [-out merged.json] [-report conflicts.txt] roster.csv roster.json ...
+//! stdin, stdout and stderr are parameters, so the tests can run it without a terminal
+func runMerge(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strategyName := flags.String("strategy", "first", "how conflicts are resolved : first, newest or interactive")
+	out := flags.String("out", "", "write the merged roster to this .csv or .json file (default : JSON on stdout)")
+	reportPath := flags.String("report", "", "write the conflict report to this file (default : stderr)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 2 {
+		return errors.New("usage: merge [-strategy first|newest|interactive] [-out file] [-report file] roster1 roster2 ...")
+	}
+
+	var strategy Strategy
+	switch *strategyName {
+	case "first":
+		strategy = First{}
+	case "newest":
+		strategy = Newest{}
+	case "interactive":
+		strategy = NewInteractive(stdin, stderr) //! the questions go to stderr, so stdout stays clean for the merged roster
+	default:
+		return fmt.Errorf("unknown strategy %q, want first, newest or interactive", *strategyName)
+	}
+	if *out != "" {
+		if _, err := format(*out); err != nil { //! checked before the merge, so an interactive user doesn't answer every question for nothing
+			return err
+		}
+	}
+
+	rosters := make([][]Person, 0, flags.NArg())
+	for _, path := range flags.Args() {
+		people, err := readRoster(path)
+		if err != nil {
+			return err
+		}
+		rosters = append(rosters, people)
+	}
+
+	people, conflicts, warnings, err := Merge(rosters, strategy)
+	if err != nil {
+		return err
+	}
+
+	report := RenderReport(conflicts, warnings)
+	if *reportPath != "" {
+		if err := os.WriteFile(*reportPath, []byte(report), 0o644); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(stderr, report)
+	}
+
+	if *out != "" {
+		return writeRoster(*out, people)
+	}
+	return writeJSON(stdout, people)
+}
+
+func demo() {
+	a, errA := readRoster("team-a.csv")
+	b, errB := readRoster("team-b.json")
+	if err := errors.Join(errA, errB); err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+
+	//! 1. the first roster wins
+	people, conflicts, warnings, err := Merge([][]Person{a, b}, First{})
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Print(RenderReport(conflicts, warnings))
+	fmt.Println()
+	for _, person := range people {
+		fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, strconv.Quote(person.Email)) //! quoted : an empty email shows as ""
+	}
+	fmt.Println("--------------------------------")
+
+	//! 2. the newest record wins, but only when every record says when it was updated
+	people, _, _, _ = Merge([][]Person{a, b}, Newest{})
+	fmt.Println("newest :", people[3].Name, people[3].Age)
+	undated := [][]Person{{{Name: "John", Age: 20, Email: "john@example.com"}}, {{Name: "Johnny", Age: 20, Email: "john@example.com", UpdatedAt: time.Now()}}}
+	_, _, _, err = Merge(undated, Newest{})
+	fmt.Println("error :", err)
+	fmt.Println("--------------------------------")
+
+	//! 3. interactive : a script plays the user. "7" is not an option, so the question is asked again
+	var questions strings.Builder
+	people, _, _, err = Merge([][]Person{a, b}, NewInteractive(strings.NewReader("2\n7\n1\n2\n"), &questions))
+	fmt.Print(questions.String())
+	fmt.Println()
+	fmt.Println("interactive :", people[1].Name, people[1].Age, "|", people[3].Name, people[3].Age, "| error :", err)
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		if err := runMerge(os.Args[2:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		return
+	}
+	demo()
+}
+
+/*
+	Try :
+		1. go run main.go merge.go roster.go merge team-a.csv team-b.json
+		2. go run main.go merge.go roster.go merge -strategy newest -out merged.csv -report conflicts.txt team-a.csv team-b.json
+		3. go run main.go merge.go roster.go merge -strategy interactive team-a.csv team-b.json
+		4. Add a Phone field to Person (and a phone column). Merge finds its conflicts without any change in mergeGroup
+*/
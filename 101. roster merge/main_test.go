@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! the command, with the sample files
+func TestRunMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantStdout string //! a part of stdout
+		wantStderr string //! the end of stderr
+		wantErr    string //! a part of the error, "" -> no error
+	}{
+		{"JSON on stdout, report on stderr", []string{"-strategy", "newest", "team-a.csv", "team-b.json"}, `"name": "John Doe"`, "3 conflicts, 1 warnings\n", ""},
+		{"unknown output extension", []string{"-out", "merged.txt", "team-a.csv", "team-b.json"}, "", "", `".txt"`},
+		{"unknown strategy", []string{"-strategy", "random", "team-a.csv", "team-b.json"}, "", "", `unknown strategy "random"`},
+		{"one roster is not enough", []string{"team-a.csv"}, "", "", "usage: merge"},
+		{"missing roster", []string{"team-a.csv", "missing.json"}, "", "", "missing.json"},
+		{"unknown flag", []string{"-x", "team-a.csv", "team-b.json"}, "", "flag provided but not defined: -x", "-x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			err := runMerge(tt.args, strings.NewReader(""), &stdout, &stderr)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want one containing %s", err, tt.wantErr)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout doesn't contain %q :\n%s", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr doesn't contain %q :\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+//! -out and -report write files. The merged roster reads back the same, as CSV or JSON
+func TestRunMergeFiles(t *testing.T) {
+	want, _, _, err := Merge(sampleRosters(t), Newest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"merged.csv", "merged.json"} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			out, report := filepath.Join(dir, name), filepath.Join(dir, "conflicts.txt")
+			var stdout, stderr strings.Builder
+			if err := runMerge([]string{"-strategy", "newest", "-out", out, "-report", report, "team-a.csv", "team-b.json"}, nil, &stdout, &stderr); err != nil {
+				t.Fatal(err)
+			}
+			if stdout.Len() != 0 || stderr.Len() != 0 {
+				t.Errorf("stdout %q, stderr %q : want both empty", stdout.String(), stderr.String())
+			}
+			got, err := readRoster(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s reads back as\n%v\nwant\n%v", name, got, want)
+			}
+			saved, err := os.ReadFile(report)
+			if err != nil || !strings.HasSuffix(string(saved), "3 conflicts, 1 warnings\n") {
+				t.Errorf("report file = %q, %v", saved, err)
+			}
+		})
+	}
+}
+
+//! an interactive merge asks on stderr, so stdout is only the roster
+func TestRunMergeInteractive(t *testing.T) {
+	var stdout, stderr strings.Builder
+	err := runMerge([]string{"-strategy", "interactive", "team-a.csv", "team-b.json"}, strings.NewReader("1\n1\n1\n"), &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stdout.String(), "keep [1-2]") || !strings.Contains(stderr.String(), "keep [1-2]") {
+		t.Errorf("the questions are not on stderr only")
+	}
+	if !strings.HasPrefix(stdout.String(), "[\n") {
+		t.Errorf("stdout is not the JSON roster : %q", stdout.String())
+	}
+}
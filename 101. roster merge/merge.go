@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//! Person -> the roster diff Person (70. roster diff), with the time the record was last changed
+type Person struct {
+	Name      string    `json:"name"`
+	Age       int       `json:"age"`
+	Email     string    `json:"email"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"` //! omitzero : a zero time is left out of the JSON, omitempty can't do that for a struct
+}
+
+//! Option -> one of the different values a field has across the rosters
+type Option struct {
+	Value     any
+	Rosters   []int     //! the rosters with this value, numbered from 1 like the files on the command line
+	UpdatedAt time.Time //! the newest record with this value
+}
+
+//! Conflict -> one field of one person has different values in different records. Chosen is the index of the winning option
+type Conflict struct {
+	Email   string
+	Field   string
+	Options []Option
+	Chosen  int
+}
+
+//! Warning -> a record which couldn't be merged at all. It's kept as it is, and the report says why
+type Warning struct {
+	Person  Person
+	Roster  int
+	Message string
+}
+
+//! Strategy -> picks the winning option of a conflict. Merge itself never reads or writes anything : the interactive strategy gets its reader and writer from outside
+type Strategy interface {
+	Resolve(conflict Conflict) (int, error)
+}
+
+var (
+	ErrNoUpdatedAt = errors.New("no updated_at")
+	ErrAborted     = errors.New("merge aborted")
+)
+
+//! First -> the value from the earliest roster wins, like "the first file is the master copy"
+type First struct{}
+
+func (First) Resolve(conflict Conflict) (int, error) {
+	return 0, nil //! options are in roster order, so the first one is from the earliest roster
+}
+
+//! Newest -> the value from the most recently updated record wins. Without an updated_at we can't tell, so that's an error and not a guess
+type Newest struct{}
+
+func (Newest) Resolve(conflict Conflict) (int, error) {
+	chosen := 0
+	for i, option := range conflict.Options {
+		if option.UpdatedAt.IsZero() {
+			return 0, fmt.Errorf("newest: roster %d: %w", option.Rosters[0], ErrNoUpdatedAt)
+		}
+		if option.UpdatedAt.After(conflict.Options[chosen].UpdatedAt) {
+			chosen = i //! a tie keeps the earlier roster
+		}
+	}
+	return chosen, nil
+}
+
+//! Interactive -> asks which value to keep, for every conflict. Wrong answers are asked again, the end of the input aborts the merge
+type Interactive struct {
+	In  *bufio.Reader
+	Out io.Writer
+}
+
+func NewInteractive(in io.Reader, out io.Writer) *Interactive {
+	return &Interactive{In: bufio.NewReader(in), Out: out}
+}
+
+func (s *Interactive) Resolve(conflict Conflict) (int, error) {
+	fmt.Fprintf(s.Out, "conflict for <%s>, field %s :\n", conflict.Email, conflict.Field)
+	for i, option := range conflict.Options {
+		fmt.Fprintf(s.Out, "  %d) %s\n", i+1, formatOption(option))
+	}
+	for {
+		fmt.Fprintf(s.Out, "keep [1-%d] > ", len(conflict.Options))
+		line, err := s.In.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintln(s.Out)
+			return 0, ErrAborted
+		}
+		number, convErr := strconv.Atoi(strings.TrimSpace(line))
+		if convErr == nil && number >= 1 && number <= len(conflict.Options) {
+			return number - 1, nil
+		}
+		fmt.Fprintf(s.Out, "%q is not an option\n", strings.TrimSpace(line))
+		if err != nil {
+			return 0, ErrAborted
+		}
+	}
+}
+
+//! emailKey -> emails are matched case-insensitively, like in the roster diff
+func emailKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+//! record -> a Person and the roster it came from
+type record struct {
+	person Person
+	roster int
+}
+
+//! Merge -> people with the same email become one person. Different values in a field are a conflict, resolved by the strategy
+//! identical duplicates collapse silently. People without an email can't be matched : they are kept as they are, with a warning
+//! the result is sorted by email, so the same input always gives the same output. People without an email come last, in input order
+func Merge(rosters [][]Person, strategy Strategy) (people []Person, conflicts []Conflict, warnings []Warning, err error) {
+	groups := map[string][]record{}
+	var noEmail []record
+	for i, roster := range rosters {
+		for _, person := range roster {
+			key := emailKey(person.Email)
+			if key == "" {
+				noEmail = append(noEmail, record{person, i + 1})
+				continue
+			}
+			groups[key] = append(groups[key], record{person, i + 1})
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	people = []Person{}
+	conflicts = []Conflict{}
+	warnings = []Warning{}
+	for _, key := range keys {
+		person, found, err := mergeGroup(groups[key], strategy)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("merge <%s>: %w", key, err)
+		}
+		people = append(people, person)
+		conflicts = append(conflicts, found...)
+	}
+	for _, r := range noEmail {
+		people = append(people, r.person)
+		warnings = append(warnings, Warning{Person: r.person, Roster: r.roster, Message: "no email, kept without merging"})
+	}
+	return people, conflicts, warnings, nil
+}
+
+//! mergeGroup -> the records of one email. Fields are walked with reflection, like changedFields in the roster diff :
+//! a new field in Person is merged without touching this code. Email is the key and UpdatedAt the newest of the group, so both are skipped
+func mergeGroup(records []record, strategy Strategy) (Person, []Conflict, error) {
+	merged := records[0].person //! the email is kept as the first record wrote it
+	for _, r := range records[1:] {
+		if r.person.UpdatedAt.After(merged.UpdatedAt) {
+			merged.UpdatedAt = r.person.UpdatedAt
+		}
+	}
+
+	var conflicts []Conflict
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	personType := mergedValue.Type()
+	for i := 0; i < personType.NumField(); i++ {
+		field := personType.Field(i)
+		if !field.IsExported() || field.Name == "Email" || field.Name == "UpdatedAt" {
+			continue
+		}
+		options := fieldOptions(records, i)
+		if len(options) == 1 {
+			continue
+		}
+		conflict := Conflict{Email: merged.Email, Field: field.Name, Options: options}
+		chosen, err := strategy.Resolve(conflict)
+		if err != nil {
+			return Person{}, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if chosen < 0 || chosen >= len(options) {
+			return Person{}, nil, fmt.Errorf("field %s: strategy chose option %d of %d", field.Name, chosen, len(options))
+		}
+		conflict.Chosen = chosen
+		mergedValue.Field(i).Set(reflect.ValueOf(options[chosen].Value))
+		conflicts = append(conflicts, conflict)
+	}
+	return merged, conflicts, nil
+}
+
+//! fieldOptions -> the different values of field i, in the order they first appear. Equal values share one option
+func fieldOptions(records []record, i int) []Option {
+	var options []Option
+	for _, r := range records {
+		value := reflect.ValueOf(r.person).Field(i).Interface()
+		found := -1
+		for j := range options {
+			if reflect.DeepEqual(options[j].Value, value) {
+				found = j
+				break
+			}
+		}
+		if found == -1 {
+			options = append(options, Option{Value: value})
+			found = len(options) - 1
+		}
+		option := &options[found]
+		if len(option.Rosters) == 0 || option.Rosters[len(option.Rosters)-1] != r.roster {
+			option.Rosters = append(option.Rosters, r.roster)
+		}
+		if r.person.UpdatedAt.After(option.UpdatedAt) {
+			option.UpdatedAt = r.person.UpdatedAt
+		}
+	}
+	return options
+}
+
+//! formatOption -> the value and where it came from : "John Doe" (roster 2). %#v quotes strings, so "" and " John" are visible in the report
+func formatOption(option Option) string {
+	return fmt.Sprintf("%#v %s", option.Value, formatRosters(option.Rosters))
+}
+
+func formatRosters(rosters []int) string {
+	names := make([]string, len(rosters))
+	for i, roster := range rosters {
+		names[i] = strconv.Itoa(roster)
+	}
+	if len(rosters) == 1 {
+		return "(roster " + names[0] + ")"
+	}
+	return "(rosters " + strings.Join(names, ", ") + ")"
+}
+
+//! RenderReport -> the conflict report : * the kept value, ! a warning, and a summary line at the end
+func RenderReport(conflicts []Conflict, warnings []Warning) string {
+	var builder strings.Builder
+	for _, conflict := range conflicts {
+		fmt.Fprintf(&builder, "~ <%s> %s\n", conflict.Email, conflict.Field)
+		for i, option := range conflict.Options {
+			mark := " "
+			if i == conflict.Chosen {
+				mark = "*"
+			}
+			fmt.Fprintf(&builder, "  %s %s\n", mark, formatOption(option))
+		}
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(&builder, "! %q %s : %s\n", warning.Person.Name, formatRosters([]int{warning.Roster}), warning.Message)
+	}
+	fmt.Fprintf(&builder, "%d conflicts, %d warnings\n", len(conflicts), len(warnings))
+	return builder.String()
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func names(people []Person) []string {
+	result := make([]string, len(people))
+	for i, person := range people {
+		result[i] = person.Name
+	}
+	return result
+}
+
+//! sampleRosters -> team-a.csv and team-b.json, the files of the demo
+func sampleRosters(t *testing.T) [][]Person {
+	t.Helper()
+	a, err := readRoster("team-a.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := readRoster("team-b.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return [][]Person{a, b}
+}
+
+//! golden output : the exact report, so a change in the format is noticed
+const firstReportGolden = `~ <bob@example.com> Age
+  * 35 (roster 1)
+    34 (roster 2)
+~ <john@example.com> Name
+  * "John" (roster 1)
+    "John Doe" (roster 2)
+~ <john@example.com> Age
+  * 20 (roster 1)
+    21 (roster 2)
+! "Mystery Guest" (roster 1) : no email, kept without merging
+3 conflicts, 1 warnings
+`
+
+func TestMergeSampleRosters(t *testing.T) {
+	rosters := sampleRosters(t)
+	tests := []struct {
+		name     string
+		strategy Strategy
+		wantJohn Person
+		wantBob  int //! Bob's age
+	}{
+		{"first : the earliest roster's values", First{}, Person{Name: "John", Age: 20, Email: "john@example.com"}, 35},
+		{"newest : the most recent values", Newest{}, Person{Name: "John Doe", Age: 21, Email: "john@example.com"}, 35},
+		//! bob, john Name (7 is not an option, asked again), john Age
+		{"interactive : the answers are used", NewInteractive(strings.NewReader("2\n7\n1\n2\n"), io.Discard), Person{Name: "John", Age: 21, Email: "john@example.com"}, 34},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people, _, _, err := Merge(rosters, tt.strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			//! sorted by email, no email last. Emails match case-insensitively : John@Example.com is john@example.com
+			if got, want := names(people), []string{"Alice", "Bob", "Jane", tt.wantJohn.Name, "Mystery Guest"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("names = %v, want %v", got, want)
+			}
+			john := people[3]
+			john.UpdatedAt = time.Time{}
+			if john != tt.wantJohn || people[1].Age != tt.wantBob {
+				t.Errorf("John = %+v, Bob's age = %d, want %+v and %d", john, people[1].Age, tt.wantJohn, tt.wantBob)
+			}
+			//! the merged updated_at is the newest, whichever values won
+			if got := people[3].UpdatedAt; !got.Equal(time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)) {
+				t.Errorf("John's updated_at = %v, want the newest", got)
+			}
+		})
+	}
+}
+
+func TestRenderReportGolden(t *testing.T) {
+	_, conflicts, warnings, err := Merge(sampleRosters(t), First{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := RenderReport(conflicts, warnings); got != firstReportGolden {
+		t.Errorf("report =\n%s\nwant\n%s", got, firstReportGolden)
+	}
+}
+
+func TestNewest(t *testing.T) {
+	tests := []struct {
+		name     string
+		rosters  [][]Person
+		wantName string
+		wantErr  error
+	}{
+		{"without updated_at -> error", [][]Person{
+			{{Name: "John", Email: "john@example.com"}},
+			{{Name: "Johnny", Email: "john@example.com", UpdatedAt: date(2026, 1, 1)}},
+		}, "", ErrNoUpdatedAt},
+		{"a tie keeps the earlier roster", [][]Person{
+			{{Name: "John", Email: "john@example.com", UpdatedAt: date(2026, 1, 1)}},
+			{{Name: "Johnny", Email: "john@example.com", UpdatedAt: date(2026, 1, 1)}},
+		}, "John", nil},
+		{"the later roster is newer", [][]Person{
+			{{Name: "John", Email: "john@example.com", UpdatedAt: date(2026, 1, 1)}},
+			{{Name: "Johnny", Email: "john@example.com", UpdatedAt: date(2026, 1, 2)}},
+		}, "Johnny", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people, _, _, err := Merge(tt.rosters, Newest{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && people[0].Name != tt.wantName {
+				t.Errorf("name = %s, want %s", people[0].Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestNewestErrorMessage(t *testing.T) {
+	undated := [][]Person{{{Name: "John", Email: "john@example.com"}}, {{Name: "Johnny", Email: "john@example.com", UpdatedAt: date(2026, 1, 1)}}}
+	_, _, _, err := Merge(undated, Newest{})
+	if want := "merge <john@example.com>: field Name: newest: roster 1: no updated_at"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %s", err, want)
+	}
+	//! First doesn't need updated_at
+	if _, _, _, err := Merge(undated, First{}); err != nil {
+		t.Errorf("First : err = %v, want nil", err)
+	}
+}
+
+func TestMergeEdgeCases(t *testing.T) {
+	john := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	tests := []struct {
+		name          string
+		rosters       [][]Person
+		wantPeople    int
+		wantConflicts int
+		wantWarnings  int
+	}{
+		{"identical duplicates collapse silently", [][]Person{{john, john}, {john}}, 1, 0, 0},
+		{"no rosters", nil, 0, 0, 0},
+		{"empty rosters", [][]Person{{}, {}}, 0, 0, 0},
+		{"two people without email are both kept", [][]Person{{{Name: "A"}}, {{Name: "B"}}}, 2, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people, conflicts, warnings, err := Merge(tt.rosters, Newest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if people == nil || conflicts == nil || warnings == nil {
+				t.Errorf("people %v, conflicts %v, warnings %v : want empty, not nil", people, conflicts, warnings)
+			}
+			if len(people) != tt.wantPeople || len(conflicts) != tt.wantConflicts || len(warnings) != tt.wantWarnings {
+				t.Errorf("%d people, %d conflicts, %d warnings, want %d, %d and %d", len(people), len(conflicts), len(warnings), tt.wantPeople, tt.wantConflicts, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+//! a person without an email is a warning, not a conflict : there is no field with two values to choose from
+func TestNoEmailWarning(t *testing.T) {
+	guest := Person{Name: "Mystery Guest", Age: 40}
+	_, conflicts, warnings, err := Merge([][]Person{{{Name: "John", Email: "john@example.com"}}, {guest}}, First{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none", conflicts)
+	}
+	want := []Warning{{Person: guest, Roster: 2, Message: "no email, kept without merging"}}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %+v, want %+v", warnings, want)
+	}
+}
+
+func TestEqualValuesShareOneOption(t *testing.T) {
+	three := [][]Person{{{Name: "John", Email: "john@example.com"}}, {{Name: "Johnny", Email: "john@example.com"}}, {{Name: "John", Email: "JOHN@example.com"}}}
+	_, conflicts, _, err := Merge(three, First{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || !reflect.DeepEqual(conflicts[0].Options[0].Rosters, []int{1, 3}) {
+		t.Errorf("conflicts = %+v, want one, with John from rosters 1 and 3", conflicts)
+	}
+}
+
+//! the same people in another order -> the same result
+func TestOrderIndependent(t *testing.T) {
+	rosters := sampleRosters(t)
+	b := rosters[1]
+	reversed := make([]Person, len(b))
+	for i, person := range b {
+		reversed[len(b)-1-i] = person
+	}
+	first, _, _, _ := Merge(rosters, First{})
+	second, _, _, _ := Merge([][]Person{rosters[0], reversed}, First{})
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("another input order gives another result :\n%v\n%v", first, second)
+	}
+}
+
+func TestInteractive(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantErr     error
+		wantWrong   int //! "is not an option" lines
+		wantPrompts int
+	}{
+		{"every answer right", "2\n1\n2\n", nil, 0, 3},
+		{"a wrong answer -> asked again", "2\n7\n1\n2\n", nil, 1, 4},
+		{"not a number -> asked again", "x\n2\n1\n2\n", nil, 1, 4},
+		{"input ends -> ErrAborted", "1\n", ErrAborted, 0, 2},
+		{"a wrong last line without newline -> ErrAborted", "0", ErrAborted, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var questions strings.Builder
+			_, _, _, err := Merge(sampleRosters(t), NewInteractive(strings.NewReader(tt.input), &questions))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := strings.Count(questions.String(), "is not an option"); got != tt.wantWrong {
+				t.Errorf("%d wrong answers reported, want %d", got, tt.wantWrong)
+			}
+			if got := strings.Count(questions.String(), "keep [1-2] > "); got != tt.wantPrompts {
+				t.Errorf("%d prompts, want %d", got, tt.wantPrompts)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//! dateLayouts -> updated_at can be a full timestamp, or only a date
+var dateLayouts = []string{time.RFC3339, time.DateOnly}
+
+func parseUpdatedAt(text string) (time.Time, error) {
+	if text == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad updated_at %q: want 2006-01-02 or RFC 3339", text)
+}
+
+//! readCSV -> the columns are found by their header name, so their order doesn't matter. updated_at is optional
+func readCSV(r io.Reader) ([]Person, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 //! we check the column count ourselves, with a better message
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no header")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"name", "age", "email"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("no %q column", name)
+		}
+	}
+
+	people := make([]Person, 0, len(records)-1)
+	for line, record := range records[1:] {
+		if len(record) != len(records[0]) {
+			return nil, fmt.Errorf("line %d: want %d columns, got %d", line+2, len(records[0]), len(record))
+		}
+		age, err := strconv.Atoi(record[columns["age"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: bad age %q", line+2, record[columns["age"]])
+		}
+		person := Person{Name: record[columns["name"]], Age: age, Email: record[columns["email"]]}
+		if i, ok := columns["updated_at"]; ok {
+			if person.UpdatedAt, err = parseUpdatedAt(record[i]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line+2, err)
+			}
+		}
+		people = append(people, person)
+	}
+	return people, nil
+}
+
+func writeCSV(w io.Writer, people []Person) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"name", "age", "email", "updated_at"})
+	for _, person := range people {
+		updatedAt := ""
+		if !person.UpdatedAt.IsZero() {
+			updatedAt = person.UpdatedAt.Format(time.RFC3339)
+		}
+		writer.Write([]string{person.Name, strconv.Itoa(person.Age), person.Email, updatedAt})
+	}
+	writer.Flush()
+	return writer.Error() //! Write only buffers, the first write error is reported here
+}
+
+//! format -> CSV or JSON, decided by the file extension
+func format(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv", ".json":
+		return ext, nil
+	default:
+		return "", fmt.Errorf("%s: unknown extension %q, want .csv or .json", path, ext)
+	}
+}
+
+func readRoster(path string) ([]Person, error) {
+	ext, err := format(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var people []Person
+	if ext == ".csv" {
+		people, err = readCSV(file)
+	} else {
+		err = json.NewDecoder(file).Decode(&people)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s : %w", path, err)
+	}
+	return people, nil
+}
+
+func writeRoster(path string, people []Person) error {
+	ext, err := format(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if ext == ".csv" {
+		err = writeCSV(file, people)
+	} else {
+		err = writeJSON(file, people)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr //! a failed Close can mean the data never reached the disk
+	}
+	return err
+}
+
+func writeJSON(w io.Writer, people []Person) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(people)
+}
@@ -0,0 +1,150 @@
+//! Closure gotchas -> the closure lesson (10. closure) shows closures working. These are three ways they surprise us, each followed by the fix
+//! the common cause : a closure (and a deferred call) keeps the VARIABLE, not the value it had when the closure was made
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+//! fakeFile -> no real files : opening and closing only move counters, so we can see how many files are open at the same time
+type fakeFile struct {
+	name string
+}
+
+var openFiles, maxOpen int
+
+func open(name string) *fakeFile {
+	openFiles++
+	maxOpen = max(maxOpen, openFiles)
+	return &fakeFile{name: name}
+}
+
+func (f *fakeFile) Close() {
+	openFiles--
+}
+
+//! 1. the bug : defer runs when the FUNCTION returns, not at the end of the loop iteration. Every Close waits until processAll is over
+func processAllBuggy(names []string) {
+	for _, name := range names {
+		f := open(name)
+		defer f.Close()
+		//! ... read f ...
+	}
+	fmt.Println("  open at the end of the loop :", openFiles)
+}
+
+//! 1. the fix : one function call per file, so its defer runs after EACH file
+func processAll(names []string) {
+	for _, name := range names {
+		processOne(name)
+	}
+	fmt.Println("  open at the end of the loop :", openFiles)
+}
+
+func processOne(name string) {
+	f := open(name)
+	defer f.Close() //! runs when processOne returns : after this one file
+	//! ... read f ...
+}
+
+func main() {
+	//! 1. defer in a loop
+	names := make([]string, 1000)
+	for i := range names {
+		names[i] = fmt.Sprintf("report-%03d.csv", i)
+	}
+
+	openFiles, maxOpen = 0, 0
+	fmt.Println("defer in the loop :")
+	processAllBuggy(names)
+	fmt.Println("  most files open at once :", maxOpen, "| open after return :", openFiles) //! 1000 : a real program can run out of file descriptors ("too many open files")
+
+	openFiles, maxOpen = 0, 0
+	fmt.Println("defer in a function per file :")
+	processAll(names)
+	fmt.Println("  most files open at once :", maxOpen, "| open after return :", openFiles) //! 1
+
+	fmt.Println("--------------------------------")
+
+	//! 2. goroutines capturing a loop variable
+	//! since Go 1.22, 'for _, name := range' makes a NEW name every iteration, so this bug needs a variable declared OUTSIDE the loop (like older Go, or code written 'for _, name = range')
+	people := []string{"John", "Jane", "Jim"}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	start := make(chan struct{})
+	var greeted []string
+
+	var name string
+	for _, name = range people {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start //! wait until the loop is over, so the output is the same on every run
+			mu.Lock()
+			greeted = append(greeted, name) //! every goroutine reads the ONE shared name : "Jim" by now
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	fmt.Println("captured   :", greeted)
+
+	//! the fix : pass the value as an argument. The argument is copied when the goroutine starts, not when it runs
+	start = make(chan struct{})
+	greeted = nil
+	for _, name = range people {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			<-start
+			mu.Lock()
+			greeted = append(greeted, name)
+			mu.Unlock()
+		}(name)
+	}
+	close(start)
+	wg.Wait()
+	slices.Sort(greeted) //! the goroutines run in any order : sorted, the output is the same on every run
+	fmt.Println("argument   :", greeted)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. a captured variable changed later
+	greeting := "Hello"
+	greet := func(name string) string {
+		return greeting + ", " + name //! reads greeting when greet is CALLED
+	}
+	fmt.Println(greet("John"))
+	greeting = "Goodbye" //! ... 200 lines later, somebody reuses the variable
+	fmt.Println(greet("John"), "-> greet changed without anybody touching it")
+
+	//! the fix : copy the value when the closure is made. A parameter of the maker function is a new variable for each call
+	makeGreeter := func(greeting string) func(string) string {
+		return func(name string) string {
+			return greeting + ", " + name
+		}
+	}
+	greeting = "Hello"
+	hello := makeGreeter(greeting)
+	greeting = "Goodbye"
+	fmt.Println(hello("John"), "-> still Hello")
+
+	//! the same trap with defer : the ARGUMENTS of a deferred call are evaluated right away, a deferred closure reads the variable at the end
+	func() {
+		count := 1
+		defer fmt.Println("deferred call    sees count =", count) //! 1 : evaluated now
+		defer func() {
+			fmt.Println("deferred closure sees count =", count) //! 3 : read when the function returns
+		}()
+		count = 3
+	}()
+}
+
+/*
+	Try :
+		1. Change processAllBuggy to close the file at the end of the loop body without defer. What happens to the file if the reading code returns early?
+		2. In part 2, replace 'var name string' and 'for _, name = range' with 'for _, name := range'. Is the bug still there?
+		3. Make makeGreeter take a *string instead. Does the fix still work?
+*/
@@ -0,0 +1,154 @@
+//! Two dimensional slices -> a slice of slices : [][]int is a slice where every element is a []int (a row)
+//! Go has no built-in matrix type. Every row is its own slice, with its own length and its own array behind it
+package main
+
+import "fmt"
+
+//! newMatrix -> 'make' only makes the OUTER slice, with 'rows' nil rows inside. Each row needs its own make
+func newMatrix(rows, cols int) [][]int {
+	matrix := make([][]int, rows)
+	for i := range matrix {
+		matrix[i] = make([]int, cols)
+	}
+	return matrix
+}
+
+func printMatrix(m [][]int) {
+	for _, row := range m {
+		fmt.Println(row)
+	}
+}
+
+//! transpose -> rows become columns : m[i][j] goes to t[j][i]. A 3x4 matrix becomes 4x3
+//! it expects a rectangular matrix (every row as long as the first one)
+func transpose(m [][]int) [][]int {
+	if len(m) == 0 {
+		return [][]int{}
+	}
+	t := newMatrix(len(m[0]), len(m))
+	for i, row := range m {
+		for j, value := range row {
+			t[j][i] = value
+		}
+	}
+	return t
+}
+
+//! sumMatrix -> works for jagged slices too : the inner loop uses each row's own length
+func sumMatrix(m [][]int) int {
+	sum := 0
+	for _, row := range m {
+		for _, value := range row {
+			sum += value
+		}
+	}
+	return sum
+}
+
+const empty = '.'
+
+//! newBoard -> a 3x3 board of runes, every square empty
+func newBoard() [][]rune {
+	board := make([][]rune, 3)
+	for i := range board {
+		board[i] = []rune{empty, empty, empty} //! a new slice for every row
+	}
+	return board
+}
+
+func printBoard(board [][]rune) {
+	for i, row := range board {
+		fmt.Printf(" %c | %c | %c\n", row[0], row[1], row[2])
+		if i < len(board)-1 {
+			fmt.Println("---+---+---")
+		}
+	}
+}
+
+//! winner -> three in a row, a column or a diagonal. 'empty' means nobody (yet)
+func winner(board [][]rune) rune {
+	lines := [][3][2]int{
+		{{0, 0}, {0, 1}, {0, 2}}, {{1, 0}, {1, 1}, {1, 2}}, {{2, 0}, {2, 1}, {2, 2}}, //! rows
+		{{0, 0}, {1, 0}, {2, 0}}, {{0, 1}, {1, 1}, {2, 1}}, {{0, 2}, {1, 2}, {2, 2}}, //! columns
+		{{0, 0}, {1, 1}, {2, 2}}, {{0, 2}, {1, 1}, {2, 0}}, //! diagonals
+	}
+	for _, line := range lines {
+		a, b, c := line[0], line[1], line[2]
+		first := board[a[0]][a[1]]
+		if first != empty && first == board[b[0]][b[1]] && first == board[c[0]][c[1]] {
+			return first
+		}
+	}
+	return empty
+}
+
+func main() {
+	//! 1. a 3x4 matrix, filled with nested loops
+	matrix := newMatrix(3, 4)
+	for i := range matrix {
+		for j := range matrix[i] {
+			matrix[i][j] = i*4 + j + 1 //! 1, 2, 3 ... row by row
+		}
+	}
+	fmt.Println("matrix : 3 rows, 4 columns")
+	printMatrix(matrix)
+	fmt.Println("sum :", sumMatrix(matrix))
+
+	fmt.Println("transposed : 4 rows, 3 columns")
+	printMatrix(transpose(matrix))
+	fmt.Println("--------------------------------")
+
+	//! 2. the rows are separate slices : changing one row doesn't touch the others, and a row can be replaced completely
+	matrix[0][0] = 100
+	matrix[2] = []int{7, 7} //! now row 2 is shorter : the matrix is JAGGED
+	printMatrix(matrix)
+	fmt.Println("--------------------------------")
+
+	//! 3. jagged slices : rows of different lengths. Always loop to len(row), never to a fixed width
+	triangle := [][]int{
+		{1},
+		{1, 1},
+		{1, 2, 1},
+		{1, 3, 3, 1},
+	}
+	for i, row := range triangle {
+		fmt.Printf("row %d, length %d :", i, len(row))
+		for j := 0; j < len(row); j++ { //! j < 4 would panic on the short rows : index out of range
+			fmt.Print(" ", row[j])
+		}
+		fmt.Println()
+	}
+	fmt.Println("sum :", sumMatrix(triangle))
+	// fmt.Println(triangle[0][3]) //! panic: runtime error: index out of range [3] with length 1
+	fmt.Println("--------------------------------")
+
+	//! 4. a tic-tac-toe board of runes
+	board := newBoard()
+	moves := [][2]int{{1, 1}, {0, 0}, {0, 2}, {0, 1}, {2, 0}} //! row, column. X starts
+	player := 'X'
+	for _, move := range moves {
+		board[move[0]][move[1]] = player
+		if winner(board) != empty {
+			break
+		}
+		if player == 'X' {
+			player = 'O'
+		} else {
+			player = 'X'
+		}
+	}
+	printBoard(board)
+	if w := winner(board); w != empty {
+		fmt.Printf("%c wins\n", w)
+	} else {
+		fmt.Println("draw")
+	}
+}
+
+/*
+	Try :
+		1. Call transpose on the jagged matrix from part 2. What happens, and why?
+		2. Write a function which returns column j of a matrix as a []int
+		3. Change the moves so that O wins on a column, then so that nobody wins
+		4. The board never changes size. Rewrite it as an ARRAY of arrays, [3][3]rune. What gets simpler?
+*/
@@ -0,0 +1,75 @@
+//! Lesson metadata -> a small lesson.meta file per lesson directory : title, difficulty, minutes, interactive and prerequisites
+//! the format is a strict subset of YAML, parsed with only the standard library (meta.go). A lesson without the file gets defaults
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+//! printContents -> a table of contents from the metadata, with a warning for every prerequisite which is not a lesson
+func printContents(lessons []Lesson) {
+	known := map[string]bool{}
+	for _, lesson := range lessons {
+		known[lesson.Dir] = true
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "lesson\ttitle\tdifficulty\tminutes\tprerequisites")
+	var warnings []string
+	for _, lesson := range lessons {
+		meta := lesson.Meta
+		title := meta.Title
+		if meta.Interactive {
+			title += " (interactive)"
+		}
+		minutes := "?"
+		if meta.Minutes > 0 {
+			minutes = fmt.Sprint(meta.Minutes)
+		}
+		prerequisites := "-"
+		if len(meta.Prerequisites) > 0 {
+			prerequisites = strings.Join(meta.Prerequisites, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", lesson.Dir, title, meta.Difficulty, minutes, prerequisites)
+
+		for _, prerequisite := range meta.Prerequisites {
+			if !known[prerequisite] {
+				warnings = append(warnings, fmt.Sprintf("%s : unknown prerequisite %q", lesson.Dir, prerequisite))
+			}
+		}
+	}
+	w.Flush()
+	for _, warning := range warnings {
+		fmt.Println("warning :", warning)
+	}
+}
+
+func main() {
+	root := flag.String("root", "testdata/lessons", "the directory with the lesson directories")
+	flag.Parse()
+
+	lessons, err := LoadAllMeta(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	printContents(lessons)
+	fmt.Println("--------------------------------")
+
+	//! a broken file : the error says which line, and why
+	_, err = ParseMeta(strings.NewReader("title: Maps\nminutes: 10\ntitle: Slices\n"))
+	fmt.Println("error :", err)
+	_, err = ParseMeta(strings.NewReader("prerequisites:\n\t- 02. variables\n"))
+	fmt.Println("error :", err)
+}
+
+/*
+	Try :
+		1. go run main.go meta.go -root ..          -> every lesson of this repository, all with the defaults
+		2. Write a lesson.meta for this lesson, then run 1. again
+		3. Add a 'tags' list. What has to change in ParseMeta, now that there are two list keys?
+*/
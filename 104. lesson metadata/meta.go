@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//! lesson.meta -> a strict subset of YAML, only what lesson metadata needs :
+//!
+//!   # a comment
+//!   title: Closure gotchas         <- top-level key: value, no nesting
+//!   minutes: 20                    <- an int
+//!   interactive: false             <- a bool : true or false, nothing else
+//!   prerequisites:                 <- a key without a value : a list follows
+//!     - 10. closure                <- "- item", indented with spaces (never tabs)
+//!
+//! anything else ({ }, [ ], |, >, anchors, nested keys ...) is an error with its line number, never silently ignored
+
+const MetaFile = "lesson.meta"
+
+type Meta struct {
+	Title         string
+	Difficulty    string //! beginner, intermediate or advanced
+	Minutes       int
+	Interactive   bool
+	Prerequisites []string
+}
+
+var difficulties = []string{"beginner", "intermediate", "advanced"}
+
+//! LineError -> the same as the INI parser's (55. ini config) : the line number makes it easy to find in the file
+type LineError struct {
+	Line int
+	Text string
+	Msg  string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Msg, e.Text)
+}
+
+//! ParseMeta -> fields which are not in the file keep their zero value. LoadAllMeta fills in the defaults
+func ParseMeta(r io.Reader) (Meta, error) {
+	var meta Meta
+	seen := map[string]int{} //! key -> line number, to report duplicates
+	list := ""               //! the list key the "- item" lines belong to, "" when not in a list
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := strings.TrimSuffix(scanner.Text(), "\r")
+		fail := func(msg string) (Meta, error) {
+			return Meta{}, &LineError{Line: lineNumber, Text: raw, Msg: msg}
+		}
+
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		indent := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]
+		if strings.Contains(indent, "\t") {
+			return fail("tab in indentation, use spaces")
+		}
+
+		if strings.HasPrefix(line, "-") {
+			if list == "" {
+				return fail("list item without a list key before it")
+			}
+			if indent == "" {
+				return fail("list item must be indented")
+			}
+			item, ok := strings.CutPrefix(line, "- ")
+			if !ok {
+				return fail(`list item must start with "- "`)
+			}
+			value, err := parseScalar(item)
+			if err != nil {
+				return fail(err.Error())
+			}
+			if value == "" {
+				return fail("empty list item")
+			}
+			meta.Prerequisites = append(meta.Prerequisites, value)
+			continue
+		}
+
+		if indent != "" {
+			return fail("nested keys are not supported")
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return fail("expected key: value")
+		}
+		key = strings.TrimSpace(key)
+		if first, ok := seen[key]; ok {
+			return fail(fmt.Sprintf("duplicate key %q, first on line %d", key, first))
+		}
+		seen[key] = lineNumber
+		list = ""
+
+		value, err := parseScalar(value)
+		if err != nil {
+			return fail(err.Error())
+		}
+
+		switch key {
+		case "title":
+			meta.Title = value
+		case "difficulty":
+			if !contains(difficulties, value) {
+				return fail(fmt.Sprintf("difficulty must be one of %s", strings.Join(difficulties, ", ")))
+			}
+			meta.Difficulty = value
+		case "minutes":
+			minutes, err := strconv.Atoi(value)
+			if err != nil || minutes < 0 {
+				return fail("minutes must be a whole number, 0 or more")
+			}
+			meta.Minutes = minutes
+		case "interactive":
+			interactive, err := strconv.ParseBool(value)
+			if err != nil || (value != "true" && value != "false") { //! ParseBool also takes 1, t, TRUE ... the subset only allows true and false
+				return fail("interactive must be true or false")
+			}
+			meta.Interactive = interactive
+		case "prerequisites":
+			if value != "" {
+				return fail(`prerequisites is a list : write "prerequisites:" and one "- item" per line`)
+			}
+			list = key
+			meta.Prerequisites = []string{} //! "prerequisites:" with no items is an empty list, not a missing one
+		default:
+			return fail(fmt.Sprintf("unknown key %q", key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Meta{}, err
+	}
+	return meta, nil
+}
+
+//! parseScalar -> a plain value, or a value in double quotes (Go rules, like the INI parser). A '#' after a space starts a comment
+//! the YAML features outside the subset start with one of these characters, so they are refused here
+func parseScalar(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", errors.New("bad quoted value")
+		}
+		return unquoted, nil
+	}
+	if before, _, found := strings.Cut(value, " #"); found {
+		value = strings.TrimSpace(before)
+	}
+	if value != "" && strings.ContainsRune("{[|>&*!'%@`", rune(value[0])) {
+		return "", fmt.Errorf("unsupported syntax %q", value[:1])
+	}
+	return value, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+//! Lesson -> one lesson directory and its metadata. HasFile is false when the defaults were used
+type Lesson struct {
+	Dir     string
+	Meta    Meta
+	HasFile bool
+}
+
+//! defaults -> the title comes from the directory name ("86. http recorder" -> "http recorder")
+func defaults(dir string) Meta {
+	title := dir
+	if _, after, found := strings.Cut(dir, ". "); found {
+		title = after
+	}
+	return Meta{Title: title, Difficulty: "beginner", Prerequisites: []string{}}
+}
+
+//! merge -> the file's values, and the defaults for every field the file didn't set
+func merge(meta, defaults Meta) Meta {
+	if meta.Title == "" {
+		meta.Title = defaults.Title
+	}
+	if meta.Difficulty == "" {
+		meta.Difficulty = defaults.Difficulty
+	}
+	if meta.Prerequisites == nil {
+		meta.Prerequisites = defaults.Prerequisites
+	}
+	return meta
+}
+
+//! lessonNumber -> "9. x" sorts before "10. x", which plain string sorting gets wrong
+func lessonNumber(dir string) int {
+	number, err := strconv.Atoi(strings.SplitN(dir, ".", 2)[0])
+	if err != nil {
+		return -1
+	}
+	return number
+}
+
+//! LoadAllMeta -> every directory directly under root whose name starts with a number ("86. http recorder") is a lesson
+//! a lesson without lesson.meta gets the defaults
+//! lessons are sorted by their number, and a broken file stops the load with the file's path in the error
+func LoadAllMeta(root string) ([]Lesson, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var lessons []Lesson
+	for _, entry := range entries {
+		if !entry.IsDir() || lessonNumber(entry.Name()) < 0 { //! skips .git, testdata ...
+			continue
+		}
+		lesson := Lesson{Dir: entry.Name(), Meta: defaults(entry.Name())}
+		path := filepath.Join(root, entry.Name(), MetaFile)
+		file, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			lessons = append(lessons, lesson)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		meta, err := ParseMeta(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		lesson.Meta = merge(meta, lesson.Meta)
+		lesson.HasFile = true
+		lessons = append(lessons, lesson)
+	}
+
+	sort.SliceStable(lessons, func(i, j int) bool {
+		return lessonNumber(lessons[i].Dir) < lessonNumber(lessons[j].Dir)
+	})
+	return lessons, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parse(text string) (Meta, error) {
+	return ParseMeta(strings.NewReader(text))
+}
+
+//! lineOf -> the line number of a *LineError, 0 for any other error
+func lineOf(err error) int {
+	var lineErr *LineError
+	if errors.As(err, &lineErr) {
+		return lineErr.Line
+	}
+	return 0
+}
+
+func TestParseMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Meta
+	}{
+		{"string, int, bool values", "title: Maps\nminutes: 15\ninteractive: true\ndifficulty: beginner\n", Meta{Title: "Maps", Minutes: 15, Interactive: true, Difficulty: "beginner"}},
+		{"quoted string keeps ':' '#' and escapes", `title: "Maps: \"key\" # not a comment"`, Meta{Title: `Maps: "key" # not a comment`}},
+		{"comments and blank lines", "title: Maps   # a comment\n# a whole line comment\n\n", Meta{Title: "Maps"}},
+		{"interactive: false", "interactive: false\n", Meta{}},
+		{"Windows line endings", "title: Maps\r\nminutes: 5\r\n", Meta{Title: "Maps", Minutes: 5}},
+		{"list items, any space indentation", "prerequisites:\n  - 02. variables\n    - 05. functions\n", Meta{Prerequisites: []string{"02. variables", "05. functions"}}},
+		{"list key without items -> empty list", "prerequisites:\ntitle: Maps\n", Meta{Title: "Maps", Prerequisites: []string{}}},
+		{"empty file", "", Meta{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMeta() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+//! every error is a *LineError with the line it's on
+func TestParseMetaErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantLine int
+		wantMsg  string //! a part of the message
+	}{
+		{"duplicate key", "title: Maps\nminutes: 10\ntitle: Slices\n", 3, "first on line 1"},
+		{"tab indentation", "prerequisites:\n\t- 02. variables\n", 2, "tab"},
+		{"nested key", "title: Maps\n  minutes: 10\n", 2, ""},
+		{"list item without a list key", "- 02. variables\n", 1, ""},
+		{"flow list [ ]", "prerequisites: [02. variables]\n", 1, ""},
+		{"block scalar |", "title: |\n", 1, "unsupported syntax"},
+		{"minutes not a number", "minutes: ten\n", 1, ""},
+		{"interactive: yes (only true/false)", "interactive: yes\n", 1, ""},
+		{"unknown difficulty", "difficulty: hard\n", 1, ""},
+		{"unknown key", "author: John\n", 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.text)
+			if lineOf(err) != tt.wantLine {
+				t.Fatalf("err = %v, want a LineError on line %d", err, tt.wantLine)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+//! golden : the full sample file
+func TestFullSampleFile(t *testing.T) {
+	file, err := os.Open("testdata/full.meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	got, err := ParseMeta(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Meta{
+		Title:         `Closures: "the basics"`,
+		Difficulty:    "intermediate",
+		Minutes:       25,
+		Interactive:   true,
+		Prerequisites: []string{"02. variables", "16. types of functions"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMeta() = %#v, want %#v", got, want)
+	}
+}
+
+//! LoadAllMeta : defaults for missing files and fields, sorted by lesson number (100 after 10)
+func TestLoadAllMeta(t *testing.T) {
+	lessons, err := LoadAllMeta("testdata/lessons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Lesson{
+		{Dir: "01. hello world", HasFile: true, Meta: Meta{Title: "Hello World", Difficulty: "beginner", Minutes: 5, Prerequisites: []string{}}},
+		{Dir: "02. variables", Meta: Meta{Title: "variables", Difficulty: "beginner", Prerequisites: []string{}}},
+		{Dir: "10. closure", HasFile: true, Meta: Meta{Title: "closure", Difficulty: "intermediate", Minutes: 25, Prerequisites: []string{"02. variables"}}},
+		{Dir: "100. closure gotchas", HasFile: true, Meta: Meta{Title: "Closure gotchas: defer, loops and captures", Difficulty: "advanced", Minutes: 30, Prerequisites: []string{"10. closure", "34. defer"}}},
+	}
+	if !reflect.DeepEqual(lessons, want) {
+		t.Errorf("LoadAllMeta() =\n%#v\nwant\n%#v", lessons, want)
+	}
+}
+
+func TestLoadAllMetaErrors(t *testing.T) {
+	broken := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(broken, "05. broken"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "05. broken", MetaFile), []byte("title: ok\nminutes: soon\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadAllMeta(broken)
+	if lineOf(err) != 2 || !strings.Contains(err.Error(), "05. broken") {
+		t.Errorf("broken file : err = %v, want the path and line 2", err)
+	}
+	if _, err := LoadAllMeta(filepath.Join(broken, "missing")); err == nil {
+		t.Error("missing root : no error")
+	}
+}
+
+//! only directories starting with a number are lessons
+func TestLessonNumber(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want int
+	}{
+		{"9. x", 9},
+		{"10. closure", 10},
+		{"100. closure gotchas", 100},
+		{"testdata", -1},
+		{".git", -1},
+	}
+	for _, tt := range tests {
+		if got := lessonNumber(tt.dir); got != tt.want {
+			t.Errorf("lessonNumber(%q) = %d, want %d", tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//! Worker timeline -> every job of a worker pool records when it started and ended, and the events are drawn as an ASCII Gantt chart
+//! one row per worker, time from left to right : idle gaps ('.'), imbalance and the effect of the number of workers become visible
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const ms = time.Millisecond
+
+//! printRun -> runs the jobs with some workers and prints the chart and how busy every worker was
+func printRun(jobs []time.Duration, workers int) {
+	events := RunPool(jobs, workers)
+	chart, err := RenderTimeline(events, 60)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Printf("%d workers :\n", workers)
+	fmt.Print(chart)
+
+	busy := Busy(events)
+	ids := make([]int, 0, len(busy))
+	for worker := range busy {
+		ids = append(ids, worker)
+	}
+	sort.Ints(ids)
+	parts := make([]string, len(ids))
+	for i, worker := range ids {
+		parts[i] = fmt.Sprintf("%d:%.0f%%", worker, busy[worker]*100)
+	}
+	fmt.Println("busy :", strings.Join(parts, " "))
+}
+
+//! a fixed scenario, 100ms over 20 columns -> one column is 5ms. The same chart on every run
+func printScenario() {
+	scenario := []JobEvent{
+		{Job: 0, Worker: 1, Start: 0, End: 50 * ms},
+		{Job: 1, Worker: 2, Start: 0, End: 30 * ms},
+		{Job: 2, Worker: 1, Start: 50 * ms, End: 100 * ms},
+		{Job: 3, Worker: 2, Start: 60 * ms, End: 80 * ms},
+	}
+	chart, err := RenderTimeline(scenario, 20)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Print(chart)
+
+	//! wrong input : two jobs at the same time on one worker
+	overlap := []JobEvent{{Job: 0, Worker: 1, Start: 0, End: 30 * ms}, {Job: 1, Worker: 1, Start: 20 * ms, End: 40 * ms}}
+	_, err = RenderTimeline(overlap, 10)
+	fmt.Println("error :", err)
+}
+
+func main() {
+	//! eleven short jobs and one long one, queued last
+	jobs := []time.Duration{}
+	for range 11 {
+		jobs = append(jobs, 30*ms)
+	}
+	jobs = append(jobs, 120*ms) //! job 'b'
+
+	printRun(jobs, 2)
+	fmt.Println("--------------------------------")
+	printRun(jobs, 8) //! 4x the workers, but not 4x faster : job 'b' alone decides when the pool is done
+	fmt.Println("--------------------------------")
+
+	printScenario()
+}
+
+/*
+	Try :
+		1. Put the long job FIRST in the list. How do the two charts change?
+		2. Run with 12 workers. And with 1
+		3. Make every job take 100ms / (job+1). Which worker gets the most jobs?
+*/
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+//! RunPool -> the fan-out of the fan-in fan-out lesson (30. fan-in fan-out) : 'workers' goroutines read jobs from the SAME channel
+//! a job is only a duration here : the worker sleeps that long, like the 10ms sleep in that lesson. Every job records when it started and ended
+func RunPool(jobs []time.Duration, workers int) []JobEvent {
+	queue := make(chan int)
+	recorded := make([][]JobEvent, workers) //! each worker appends only to its own slice, so no mutex is needed
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				begin := time.Since(start)
+				time.Sleep(jobs[job])
+				recorded[worker] = append(recorded[worker], JobEvent{Job: job, Worker: worker + 1, Start: begin, End: time.Since(start)})
+			}
+		}()
+	}
+	for job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait() //! after Wait every worker has finished, so reading 'recorded' is safe
+
+	var events []JobEvent
+	for _, own := range recorded {
+		events = append(events, own...)
+	}
+	return events
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRunPool(t *testing.T) {
+	jobs := []time.Duration{5 * ms, 1 * ms, 3 * ms, 1 * ms, 2 * ms, 4 * ms, 1 * ms}
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{"1 worker", 1},
+		{"3 workers", 3},
+		{"more workers than jobs", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := RunPool(jobs, tt.workers)
+			if len(events) != len(jobs) {
+				t.Fatalf("%d events, want %d", len(events), len(jobs))
+			}
+			seen := map[int]bool{}
+			for _, event := range events {
+				if event.Worker < 1 || event.Worker > tt.workers {
+					t.Errorf("job %d : worker %d, want 1..%d", event.Job, event.Worker, tt.workers)
+				}
+				if event.End-event.Start < jobs[event.Job] {
+					t.Errorf("job %d took %v, want at least %v", event.Job, event.End-event.Start, jobs[event.Job])
+				}
+				seen[event.Job] = true
+			}
+			if len(seen) != len(jobs) {
+				t.Errorf("jobs run : %v, want every job once", seen)
+			}
+			if _, err := RenderTimeline(events, 60); err != nil {
+				t.Errorf("RenderTimeline() : %v", err) //! no worker runs two jobs at once
+			}
+		})
+	}
+}
+
+//! one worker takes the jobs in the order of the queue
+func TestRunPoolOneWorkerInOrder(t *testing.T) {
+	events := RunPool([]time.Duration{2 * ms, 1 * ms, 1 * ms}, 1)
+	sort.Slice(events, func(i, j int) bool { return events[i].Start < events[j].Start })
+	for i, event := range events {
+		if event.Job != i {
+			t.Errorf("run %d : job %d, want job %d", i, event.Job, i)
+		}
+	}
+}
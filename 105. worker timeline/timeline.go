@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+//! JobEvent -> one job on one worker. Start and End are measured from the moment the pool started, so two runs can be compared
+type JobEvent struct {
+	Job    int
+	Worker int
+	Start  time.Duration
+	End    time.Duration
+}
+
+//! jobSymbols -> the character of job N in the chart. After 62 jobs every job is '#'
+const jobSymbols = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+const idle = '.'
+
+func symbol(job int) byte {
+	if job >= 0 && job < len(jobSymbols) {
+		return jobSymbols[job]
+	}
+	return '#'
+}
+
+var ErrOverlap = errors.New("overlapping jobs on one worker")
+
+//! validate -> a worker does one job at a time. Two jobs overlapping on the same worker means the events are wrong, so the chart would lie
+func validate(events []JobEvent) error {
+	byWorker := map[int][]JobEvent{}
+	for _, event := range events {
+		if event.Start < 0 || event.End < event.Start {
+			return fmt.Errorf("job %d: bad times %v -> %v", event.Job, event.Start, event.End)
+		}
+		byWorker[event.Worker] = append(byWorker[event.Worker], event)
+	}
+	for worker, jobs := range byWorker {
+		sort.Slice(jobs, func(i, j int) bool { return jobs[i].Start < jobs[j].Start })
+		for i := 1; i < len(jobs); i++ {
+			if jobs[i].Start < jobs[i-1].End {
+				return fmt.Errorf("%w: worker %d runs job %d and job %d at the same time", ErrOverlap, worker, jobs[i-1].Job, jobs[i].Job)
+			}
+		}
+	}
+	return nil
+}
+
+//! RenderTimeline -> an ASCII Gantt chart : one row per worker, 'width' columns of equal time, every job drawn with its own character
+//!
+//!   worker 1 |000011111..22|
+//!   worker 2 |3333344444444|
+//!
+//! a job covers the columns from round(start) to round(end), and at least one column, so even a very short job is visible
+//! when two short jobs land in the same column, the later one is shown
+func RenderTimeline(events []JobEvent, width int) (string, error) {
+	if width < 1 {
+		return "", fmt.Errorf("width %d: want at least 1 column", width)
+	}
+	if err := validate(events); err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		return "(no jobs)\n", nil
+	}
+
+	var span time.Duration
+	var workers []int
+	rows := map[int][]byte{}
+	for _, event := range events {
+		span = max(span, event.End)
+		if rows[event.Worker] == nil {
+			rows[event.Worker] = []byte(strings.Repeat(string(idle), width))
+			workers = append(workers, event.Worker)
+		}
+	}
+	sort.Ints(workers)
+
+	//! column -> which column a moment falls on. With span 0 (only zero-length jobs) everything is in column 0
+	column := func(t time.Duration) int {
+		if span == 0 {
+			return 0
+		}
+		return int(math.Round(float64(t) / float64(span) * float64(width)))
+	}
+
+	sorted := append([]JobEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	for _, event := range sorted {
+		first := min(column(event.Start), width-1) //! a job starting at the very end still gets the last column
+		last := max(column(event.End), first+1)    //! exclusive, at least one column
+		for c := first; c < last && c < width; c++ {
+			rows[event.Worker][c] = symbol(event.Job)
+		}
+	}
+
+	var builder strings.Builder
+	for _, worker := range workers {
+		fmt.Fprintf(&builder, "worker %-2d |%s|\n", worker, rows[worker])
+	}
+	//! rounded for reading : the events keep the exact times
+	fmt.Fprintf(&builder, "%d jobs, %v in total, one column = %v\n", len(events), span.Round(time.Millisecond), (span / time.Duration(width)).Round(100*time.Microsecond))
+	return builder.String(), nil
+}
+
+//! Busy -> how much of the total time each worker was working, from 0 to 1. The rest of the time it was idle
+func Busy(events []JobEvent) map[int]float64 {
+	var span time.Duration
+	working := map[int]time.Duration{}
+	for _, event := range events {
+		span = max(span, event.End)
+		working[event.Worker] += event.End - event.Start
+	}
+	busy := map[int]float64{}
+	for worker, total := range working {
+		if span > 0 {
+			busy[worker] = float64(total) / float64(span)
+		}
+	}
+	return busy
+}
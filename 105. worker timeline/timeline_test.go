@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//! a fixed scenario, 100ms over 20 columns -> one column is 5ms
+var scenario = []JobEvent{
+	{Job: 0, Worker: 1, Start: 0, End: 50 * ms},
+	{Job: 1, Worker: 2, Start: 0, End: 30 * ms},
+	{Job: 2, Worker: 1, Start: 50 * ms, End: 100 * ms},
+	{Job: 3, Worker: 2, Start: 60 * ms, End: 80 * ms},
+}
+
+func TestRenderTimelineGolden(t *testing.T) {
+	chart, err := RenderTimeline(scenario, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := `worker 1  |00000000002222222222|
+worker 2  |111111......3333....|
+4 jobs, 100ms in total, one column = 5ms
+`
+	if chart != golden {
+		t.Errorf("RenderTimeline() =\n%s\nwant\n%s", chart, golden)
+	}
+}
+
+//! only the rows are compared, the last line is the summary
+func TestRenderTimelineRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []JobEvent
+		width  int
+		want   string
+	}{
+		{
+			"one row per worker, sorted, right job",
+			[]JobEvent{{Job: 5, Worker: 3, Start: 0, End: 10 * ms}, {Job: 7, Worker: 1, Start: 0, End: 10 * ms}},
+			4,
+			"worker 1  |7777|\nworker 3  |5555|\n",
+		},
+		{
+			"short jobs at the edges keep one column",
+			[]JobEvent{
+				{Job: 0, Worker: 1, Start: 0, End: 4 * ms},
+				{Job: 1, Worker: 1, Start: 50 * ms, End: 51 * ms},
+				{Job: 2, Worker: 1, Start: 96 * ms, End: 100 * ms},
+			},
+			10,
+			"worker 1  |0....1...2|\n",
+		},
+		{
+			"zero-length jobs -> first column",
+			[]JobEvent{{Job: 0, Worker: 1}, {Job: 1, Worker: 2}},
+			3,
+			"worker 1  |0..|\nworker 2  |1..|\n",
+		},
+		{
+			"one job right after another is fine",
+			[]JobEvent{{Job: 0, Worker: 1, Start: 0, End: 30 * ms}, {Job: 1, Worker: 1, Start: 30 * ms, End: 40 * ms}},
+			4,
+			"worker 1  |0001|\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart, err := RenderTimeline(tt.events, tt.width)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(chart, tt.want) {
+				t.Errorf("RenderTimeline() =\n%s\nwant the rows\n%s", chart, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTimelineNoEvents(t *testing.T) {
+	chart, err := RenderTimeline(nil, 10)
+	if err != nil || chart != "(no jobs)\n" {
+		t.Errorf("RenderTimeline(nil) = %q, %v, want %q", chart, err, "(no jobs)\n")
+	}
+}
+
+func TestRenderTimelineErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []JobEvent
+		width   int
+		overlap bool //! errors.Is(err, ErrOverlap)
+	}{
+		{"overlapping jobs on one worker", []JobEvent{{Job: 0, Worker: 1, Start: 0, End: 30 * ms}, {Job: 1, Worker: 1, Start: 20 * ms, End: 40 * ms}}, 10, true},
+		{"end before start", []JobEvent{{Job: 0, Worker: 1, Start: 20 * ms, End: 10 * ms}}, 10, false},
+		{"width 0", scenario, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderTimeline(tt.events, tt.width)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if errors.Is(err, ErrOverlap) != tt.overlap {
+				t.Errorf("errors.Is(%v, ErrOverlap) = %v, want %v", err, !tt.overlap, tt.overlap)
+			}
+		})
+	}
+}
+
+func TestSymbol(t *testing.T) {
+	tests := []struct {
+		job  int
+		want byte
+	}{
+		{0, '0'},
+		{9, '9'},
+		{10, 'a'},
+		{61, 'Z'},
+		{62, '#'}, //! more than 62 jobs
+		{100, '#'},
+	}
+	for _, tt := range tests {
+		if got := symbol(tt.job); got != tt.want {
+			t.Errorf("symbol(%d) = %q, want %q", tt.job, got, tt.want)
+		}
+	}
+}
+
+func TestBusy(t *testing.T) {
+	busy := Busy(scenario)
+	if busy[1] != 1 || busy[2] != 0.5 {
+		t.Errorf("Busy() = %v, want worker 1 100%%, worker 2 50%%", busy)
+	}
+	if busy := Busy(nil); len(busy) != 0 {
+		t.Errorf("Busy(nil) = %v, want empty", busy)
+	}
+}
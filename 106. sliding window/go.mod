@@ -0,0 +1,3 @@
+module slidingwindow
+
+go 1.22
@@ -0,0 +1,107 @@
+//! Sliding window -> "what was the sum / average / maximum in the last N seconds?", for any number type
+//! main uses it twice : to smooth a req/s sparkline like the one of the terminal dashboard lesson, and as a rate counter like the one of the window counter lesson
+//! both lessons import this package too : the window counter as a third WindowCounter, the dashboard to smooth its req/s row
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"slidingwindow/window"
+)
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+//! sparkline -> the same one as in the terminal dashboard lesson
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return "(no data)"
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range values {
+		index := 0
+		if high > low {
+			index = int((value - low) / (high - low) * float64(len(sparkBars)-1))
+		}
+		builder.WriteRune(sparkBars[index])
+	}
+	return builder.String()
+}
+
+//! fakeClock -> every example moves the time by hand, so the output is the same on every run
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func main() {
+	//! requests per second from a noisy service : the spikes hide the trend
+	perSecond := []int{12, 80, 15, 10, 95, 14, 20, 18, 110, 25, 30, 28, 120, 35, 40, 38, 130, 45, 50, 48}
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	requests := window.New[int](5*time.Second, 100, clock.Now)
+
+	raw := make([]float64, 0, len(perSecond))
+	smooth := make([]float64, 0, len(perSecond))
+	for _, count := range perSecond {
+		requests.Push(count, clock.Now())
+		raw = append(raw, float64(count))
+		smooth = append(smooth, requests.Avg(5*time.Second)) //! the average of the last 5 seconds, one value per second
+		clock.now = clock.now.Add(time.Second)
+	}
+
+	fmt.Println("req/s raw        :", sparkline(raw))
+	fmt.Println("req/s 5s average :", sparkline(smooth)) //! the spikes are gone, the slow rise is visible
+	fmt.Printf("last 5s average  : %.1f\n", smooth[len(smooth)-1])
+
+	fmt.Println("--------------------------------")
+
+	//! rate counter : one Push per request, like Incr in the window counter lesson. Sum over a minute is "requests per minute"
+	//! the same window also keeps the latency, so Avg and Max come for free
+	clock = &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	hits := window.New[int](time.Minute, 10_000, clock.Now)
+	latency := window.New[time.Duration](time.Minute, 10_000, clock.Now) //! time.Duration is an int64, so it is a window.Number too
+
+	for i := range 90 { //! one request every second for 90 seconds
+		hits.Push(1, clock.Now())
+		latency.Push(time.Duration(20+i%10)*time.Millisecond, clock.Now())
+		clock.now = clock.now.Add(time.Second)
+	}
+	clock.now = clock.now.Add(-time.Second) //! "now" is the time of the last request
+
+	slowest, _ := latency.Max(time.Minute)
+	fmt.Println("requests in the last minute :", hits.Sum(time.Minute)) //! 60, not 90 : the first 30 seconds were evicted
+	fmt.Println("requests in the last 10s    :", hits.Sum(10*time.Second))
+	fmt.Println("samples kept                :", hits.Len())
+	fmt.Println("average latency             :", time.Duration(latency.Avg(time.Minute)))
+	fmt.Println("slowest latency             :", slowest)
+
+	fmt.Println("--------------------------------")
+
+	//! the max-samples cap : a burst of 1000 requests in the same second, but only the newest 100 are kept
+	burst := window.New[int](time.Minute, 100, clock.Now)
+	for range 1000 {
+		burst.Push(1, clock.Now())
+	}
+	fmt.Println("burst : pushed 1000, kept", burst.Len(), "sum", burst.Sum(time.Minute))
+
+	//! no samples : Max says so with false, because 0 could be a real maximum
+	empty := window.New[float64](time.Minute, 100, clock.Now)
+	biggest, ok := empty.Max(time.Minute)
+	fmt.Println("empty : sum", empty.Sum(time.Minute), "avg", empty.Avg(time.Minute), "max", biggest, ok)
+}
+
+/*
+	Try :
+		1. Run the tests with the race detector (go test -race ./...). They push and query from several goroutines at the same time
+		2. Change the smoothing window from 5s to 2s and to 10s. A shorter window follows the spikes, a longer one is flatter but reacts later
+		3. Remove the cap check in window.Push and push a million samples in the same second. Len keeps growing, because nothing is old enough to evict
+*/
@@ -0,0 +1,145 @@
+//! Package window keeps timed samples and answers "what was the sum / average / maximum in the last <window>?"
+package window
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+//! Number -> every type Sum can add up. The ~ also allows named types like 'type Millis int64'
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+type sample[T Number] struct {
+	value T
+	at    time.Time
+}
+
+//! Sliding -> the samples of the last maxWindow, oldest first. It's safe for concurrent use
+//!
+//! old samples are not removed by a timer : every Push and every query first drops what is older than maxWindow (lazy eviction)
+//! and there are never more than maxSamples samples : when a Push goes over the cap, the oldest sample is dropped, so a burst can't eat all the memory
+//!
+//! timestamps don't have to come in order : a late sample is put at its place in time, so the queries still see the samples sorted by time
+//! a sample older than maxWindow is dropped right away, and a sample in the future is kept but only counted once the clock reaches it
+type Sliding[T Number] struct {
+	mu         sync.Mutex
+	maxWindow  time.Duration
+	maxSamples int
+	now        func() time.Time
+	samples    []sample[T]
+}
+
+//! New -> now is the clock : time.Now in a real program, a fake clock in the tests. nil means time.Now
+func New[T Number](maxWindow time.Duration, maxSamples int, now func() time.Time) *Sliding[T] {
+	if maxSamples < 1 {
+		maxSamples = 1
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &Sliding[T]{maxWindow: maxWindow, maxSamples: maxSamples, now: now}
+}
+
+func (s *Sliding[T]) Push(v T, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evict(now)
+	if !at.After(now.Add(-s.maxWindow)) {
+		return //! already too old for any query
+	}
+
+	//! usually 'at' is the newest time, so this is a plain append. A late sample is inserted after the samples with the same or an earlier time
+	i := len(s.samples)
+	if i > 0 && at.Before(s.samples[i-1].at) {
+		i = sort.Search(len(s.samples), func(j int) bool { return s.samples[j].at.After(at) })
+	}
+	s.samples = append(s.samples, sample[T]{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = sample[T]{value: v, at: at}
+
+	if len(s.samples) > s.maxSamples {
+		s.samples = s.samples[len(s.samples)-s.maxSamples:]
+	}
+}
+
+//! evict drops the samples at or before now-maxWindow. The mutex must be held
+func (s *Sliding[T]) evict(now time.Time) {
+	edge := now.Add(-s.maxWindow)
+	i := sort.Search(len(s.samples), func(j int) bool { return s.samples[j].at.After(edge) })
+	if i > 0 {
+		//! a copy instead of s.samples[i:] : reslicing would keep the dropped samples in the array, and the array would only grow
+		s.samples = append(s.samples[:0], s.samples[i:]...)
+	}
+}
+
+//! inWindow calls fn for every sample in (now-window, now]. A window longer than maxWindow is cut to maxWindow
+func (s *Sliding[T]) inWindow(window time.Duration, fn func(v T)) {
+	now := s.now()
+	s.evict(now)
+	window = min(window, s.maxWindow)
+	edge := now.Add(-window)
+	for _, sample := range s.samples {
+		if sample.at.After(edge) && !sample.at.After(now) {
+			fn(sample.value)
+		}
+	}
+}
+
+//! Sum -> the total of the last 'window', 0 when there is nothing. Integer types can overflow, like any + in Go
+func (s *Sliding[T]) Sum(window time.Duration) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total T
+	s.inWindow(window, func(v T) { total += v })
+	return total
+}
+
+//! Avg -> the mean of the last 'window' as a float64, 0 when there is nothing
+//! the values are added as float64, so the average of the ints 1 and 2 is 1.5, not 1
+func (s *Sliding[T]) Avg(window time.Duration) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total, count := 0.0, 0
+	s.inWindow(window, func(v T) {
+		total += float64(v)
+		count++
+	})
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+//! Max -> the biggest value of the last 'window'. false when there is nothing, because 0 could be a real maximum
+func (s *Sliding[T]) Max(window time.Duration) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var biggest T
+	found := false
+	s.inWindow(window, func(v T) {
+		if !found || v > biggest {
+			biggest = v
+			found = true
+		}
+	})
+	return biggest, found
+}
+
+//! Len -> how many samples are kept right now, after dropping the old ones
+func (s *Sliding[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(s.now())
+	return len(s.samples)
+}
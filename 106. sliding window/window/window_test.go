@@ -0,0 +1,180 @@
+package window
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+//! fakeClock -> the tests move the time by hand
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestEvictionAtWindowEdge(t *testing.T) {
+	clock := newFakeClock()
+	s := New[int](10*time.Second, 100, clock.Now)
+
+	s.Push(1, clock.Now())
+	clock.Advance(5 * time.Second)
+	s.Push(2, clock.Now())
+
+	//! the window is (now-10s, now] : a sample exactly 10s old is out
+	clock.Advance(5*time.Second - time.Nanosecond)
+	if got := s.Sum(10 * time.Second); got != 3 {
+		t.Errorf("Sum 1ns before the edge = %d, want 3", got)
+	}
+	clock.Advance(time.Nanosecond)
+	if got := s.Sum(10 * time.Second); got != 2 {
+		t.Errorf("Sum exactly at the edge = %d, want 2", got)
+	}
+	if got := s.Len(); got != 1 {
+		t.Errorf("Len after the edge = %d, want 1 : the old sample must be evicted", got)
+	}
+
+	//! a shorter query window uses the same rule
+	if got := s.Sum(5 * time.Second); got != 0 {
+		t.Errorf("Sum(5s) with a sample exactly 5s old = %d, want 0", got)
+	}
+}
+
+func TestMaxSamplesDropsOldest(t *testing.T) {
+	clock := newFakeClock()
+	s := New[int](time.Minute, 3, clock.Now)
+
+	for i := 1; i <= 5; i++ {
+		s.Push(i, clock.Now())
+		clock.Advance(time.Second)
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("Len = %d, want 3", got)
+	}
+	if got := s.Sum(time.Minute); got != 3+4+5 {
+		t.Errorf("Sum = %d, want 12 : only the 3 newest are kept", got)
+	}
+}
+
+func TestEmptyWindow(t *testing.T) {
+	clock := newFakeClock()
+	s := New[float64](time.Minute, 10, clock.Now)
+
+	if got := s.Sum(time.Minute); got != 0 {
+		t.Errorf("Sum of nothing = %v, want 0", got)
+	}
+	if got := s.Avg(time.Minute); got != 0 {
+		t.Errorf("Avg of nothing = %v, want 0", got)
+	}
+	if got, ok := s.Max(time.Minute); ok || got != 0 {
+		t.Errorf("Max of nothing = %v, %v, want 0, false", got, ok)
+	}
+
+	s.Push(-4, clock.Now())
+	clock.Advance(2 * time.Minute)
+	if _, ok := s.Max(time.Minute); ok {
+		t.Error("Max after every sample expired = true, want false")
+	}
+
+	//! a negative maximum is still a maximum : that's why Max has the bool
+	s.Push(-4, clock.Now())
+	if got, ok := s.Max(time.Minute); !ok || got != -4 {
+		t.Errorf("Max = %v, %v, want -4, true", got, ok)
+	}
+}
+
+func TestNonMonotonicTimestamps(t *testing.T) {
+	clock := newFakeClock()
+	s := New[int](10*time.Second, 3, clock.Now)
+	start := clock.Now()
+
+	s.Push(10, start.Add(-2*time.Second))
+	s.Push(1, start.Add(-8*time.Second)) //! late : goes before 10
+	s.Push(100, start.Add(-20*time.Second))
+	if got := s.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2 : a sample older than maxWindow is dropped", got)
+	}
+
+	//! the cap drops the oldest by TIME, not the first pushed
+	s.Push(20, start)
+	s.Push(5, start.Add(-9*time.Second))
+	if got := s.Sum(10 * time.Second); got != 31 {
+		t.Errorf("Sum = %d, want 31 (1 + 10 + 20) : the sample at -9s is the oldest and goes over the cap", got)
+	}
+
+	if got := s.Sum(5 * time.Second); got != 30 {
+		t.Errorf("Sum(5s) = %d, want 30", got)
+	}
+
+	//! a future sample is counted only once the clock reaches it
+	s.Push(1000, start.Add(time.Second))
+	if got, _ := s.Max(10 * time.Second); got != 20 {
+		t.Errorf("Max before the future sample = %d, want 20", got)
+	}
+	clock.Advance(time.Second)
+	if got, _ := s.Max(10 * time.Second); got != 1000 {
+		t.Errorf("Max once the clock reaches it = %d, want 1000", got)
+	}
+}
+
+func TestAvgWithIntegers(t *testing.T) {
+	clock := newFakeClock()
+	s := New[int](time.Minute, 10, clock.Now)
+	s.Push(1, clock.Now())
+	s.Push(2, clock.Now())
+	if got := s.Avg(time.Minute); got != 1.5 {
+		t.Errorf("Avg(1, 2) = %v, want 1.5", got)
+	}
+
+	//! uint8 would overflow in Sum (200 + 100 = 44), but Avg adds float64 values
+	bytes := New[uint8](time.Minute, 10, clock.Now)
+	bytes.Push(200, clock.Now())
+	bytes.Push(100, clock.Now())
+	if got := bytes.Avg(time.Minute); got != 150 {
+		t.Errorf("Avg(200, 100) as uint8 = %v, want 150", got)
+	}
+}
+
+func TestConcurrentPushAndQuery(t *testing.T) {
+	clock := newFakeClock()
+	s := New[int](time.Minute, 1000, clock.Now)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				s.Push(1, clock.Now())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				s.Sum(time.Minute)
+				s.Avg(time.Minute)
+				s.Max(time.Minute)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := s.Sum(time.Minute); got != 400 {
+		t.Errorf("Sum after 4 x 100 concurrent pushes = %d, want 400", got)
+	}
+}
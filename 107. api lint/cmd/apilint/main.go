@@ -0,0 +1,50 @@
+//! apilint -> reports exported functions which call os, io or net/http but don't return an error
+//!
+//!	go run ./cmd/apilint lint/testdata/...
+//!	go run ./cmd/apilint "../73. ansi color" "../80. doc server"
+//!
+//! exit code 0 : nothing found, 1 : findings, 2 : the packages couldn't be loaded (like go vet)
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"apilint/lint"
+)
+
+func main() {
+	patterns := os.Args[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"lint/testdata/..."} //! the fixtures of the tests, so a plain "go run ./cmd/apilint" shows something
+	}
+
+	findings, err := lint.Analyze(patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "apilint:", err)
+		os.Exit(2)
+	}
+	if len(findings) == 0 {
+		fmt.Println("no findings")
+		return
+	}
+
+	//! text/tabwriter lines the columns up
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POSITION\tPACKAGE\tFUNCTION\tCALLS")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s:%d\t%s\t%s\t%s\n", f.Pos.Filename, f.Pos.Line, f.Package, f.Function, f.Call)
+	}
+	w.Flush()
+	fmt.Printf("%d exported functions do I/O without returning an error\n", len(findings))
+	os.Exit(1)
+}
+
+/*
+	Try :
+		1. Add ioPackages "fmt" in lint.go. Which functions of the fixtures show up now? Is fmt.Println I/O?
+		2. The fixtures' Store.Dump is reported. Make it return an error and run the tool again
+		3. Remove "net/http.NewServeMux" from notIO in lint.go and run it on "../80. doc server". Which call is reported now?
+		4. Run it on "../99. blank identifier", which imports packages of its own module. Print the "go list" arguments in goList : which pattern does it get?
+*/
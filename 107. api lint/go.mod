@@ -0,0 +1,3 @@
+module apilint
+
+go 1.22
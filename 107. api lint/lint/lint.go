@@ -0,0 +1,392 @@
+//! Package lint -> a small static analysis with go/types : exported functions which do I/O but can't report an error
+//!
+//! go/ast only sees names : in "f.Close()" it can't tell whether f is an *os.File or our own type with a Close method
+//! go/types type-checks the code, so every call is resolved to the function it really calls, and that function knows its package
+//! the imports are loaded with "go list -export", so a package of the same module (or of a replace directive) is found like the go command finds it
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//! ioPackages -> a call into one of these (or their sub packages, like os/exec or io/fs) counts as I/O
+var ioPackages = []string{"os", "io", "net/http"}
+
+//! notIO -> functions of the I/O packages which do no I/O : constructors, route registration and small helpers. The key is types.Func.FullName()
+var notIO = map[string]bool{
+	"net/http.NewServeMux":            true,
+	"(*net/http.ServeMux).Handle":     true,
+	"(*net/http.ServeMux).HandleFunc": true,
+	"(*net/http.Request).PathValue":   true,
+	"(*net/http.Request).Context":     true,
+	"net/http.StatusText":             true,
+	"net/http.CanonicalHeaderKey":     true,
+	"(net/http.Header).Get":           true,
+	"(net/http.Header).Set":           true,
+	"(net/http.Header).Add":           true,
+	"(net/http.Header).Del":           true,
+	"io.NopCloser":                    true,
+	"io.MultiReader":                  true,
+	"io.MultiWriter":                  true,
+	"io.TeeReader":                    true,
+	"io.LimitReader":                  true,
+	"os.Getenv":                       true,
+	"os.LookupEnv":                    true,
+}
+
+//! Finding -> one exported function which calls I/O but has no error result
+type Finding struct {
+	Package  string //! the package name
+	Function string //! "Save", or "(*Store).Save" for a method
+	Call     string //! the first I/O call found, like "os.WriteFile" or "(io.Writer).Write"
+	Pos      token.Position
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s.%s calls %s but does not return an error", f.Pos.Filename, f.Pos.Line, f.Package, f.Function, f.Call)
+}
+
+//! Analyze -> loads every package the patterns name, and returns the findings sorted by package, file and line
+//! a pattern is a directory ("./store"), or a directory with "/..." for it and every directory below it
+func Analyze(patterns []string) ([]Finding, error) {
+	var dirs []string
+	for _, pattern := range patterns {
+		found, err := expand(pattern)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, found...)
+	}
+
+	var findings []Finding
+	for _, dir := range dirs {
+		found, err := analyzeDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename
+		}
+		return a.Pos.Line < b.Pos.Line
+	})
+	return findings, nil
+}
+
+//! expand -> the directories of one pattern which have Go files. "testdata" and hidden directories are skipped, like the go command does
+func expand(pattern string) ([]string, error) {
+	root, recursive := strings.CutSuffix(pattern, "/...")
+	if !recursive {
+		return []string{pattern}, nil
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		name := entry.Name()
+		if path != root && (name == "testdata" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		if hasGoFiles(path) {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("expand %q: %w", pattern, err)
+	}
+	return dirs, nil
+}
+
+func hasGoFiles(dir string) bool {
+	files, _ := goFiles(dir)
+	return len(files) > 0
+}
+
+//! goFiles -> the .go files of a directory, without tests : tests are not part of the API
+func goFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	return files, nil
+}
+
+//! listedPackage -> the fields of "go list -json" the loader needs
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	GoFiles    []string
+	Export     string            //! the file with the compiled export data
+	ImportMap  map[string]string //! an import path in the source -> the real path, for vendored packages
+	DepOnly    bool              //! false only for the package that was asked for
+}
+
+//! goList -> runs "go list -export -deps -json" in dir : the package itself, and the export data file of every package it imports
+//! in a module the pattern is ".", so the go command resolves the module's own packages and its replace directives
+//! outside a module (most lessons here) the files are named one by one. The go command doesn't check build constraints for named files, so goList does
+func goList(dir string) (listedPackage, map[string]string, error) {
+	args := []string{"list", "-export", "-deps", "-json", "--"}
+	inModule, err := inModule(dir)
+	if err != nil {
+		return listedPackage{}, nil, err
+	}
+	if inModule {
+		args = append(args, ".")
+	} else {
+		paths, err := goFiles(dir)
+		if err != nil {
+			return listedPackage{}, nil, err
+		}
+		for _, path := range paths {
+			if match, err := build.Default.MatchFile(dir, filepath.Base(path)); err == nil && match {
+				args = append(args, filepath.Base(path))
+			}
+		}
+	}
+
+	output, err := goCommand(dir, args...)
+	if err != nil {
+		return listedPackage{}, nil, err
+	}
+	var root listedPackage
+	exports := map[string]string{}
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var listed listedPackage
+		if err := decoder.Decode(&listed); err == io.EOF {
+			break
+		} else if err != nil {
+			return listedPackage{}, nil, fmt.Errorf("go list output: %w", err)
+		}
+		exports[listed.ImportPath] = listed.Export
+		if !listed.DepOnly {
+			root = listed
+		}
+	}
+	return root, exports, nil
+}
+
+//! inModule -> "go env GOMOD" is the go.mod file of dir, or os.DevNull when dir is in no module
+func inModule(dir string) (bool, error) {
+	output, err := goCommand(dir, "env", "GOMOD")
+	if err != nil {
+		return false, err
+	}
+	gomod := strings.TrimSpace(string(output))
+	return gomod != "" && gomod != os.DevNull, nil
+}
+
+//! goCommand -> runs the go command in dir. A failure carries what the go command printed, that's the useful part
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+//! analyzeDir -> load, parse and type-check one package, then look at its exported functions
+func analyzeDir(dir string) ([]Finding, error) {
+	if !hasGoFiles(dir) {
+		return nil, fmt.Errorf("load %s: no Go files", dir)
+	}
+	root, exports, err := goList(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range root.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0) //! dir, not root.Dir : the positions keep the path the user typed
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	//! the "gc" importer reads the compiled export data go list pointed to, so only our own files are type-checked
+	lookup := func(path string) (io.ReadCloser, error) {
+		if mapped, ok := root.ImportMap[path]; ok {
+			path = mapped
+		}
+		export, ok := exports[path]
+		if !ok || export == "" {
+			return nil, fmt.Errorf("no export data for %q", path)
+		}
+		return os.Open(export)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "gc", lookup)}
+	info := &types.Info{
+		Defs: map[*ast.Ident]types.Object{},
+		Uses: map[*ast.Ident]types.Object{},
+	}
+	pkg, err := config.Check(root.ImportPath, fset, files, info)
+	if err != nil {
+		return nil, fmt.Errorf("type-check %s: %w", dir, err)
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || !isAPI(fn, info) {
+				continue
+			}
+			function := info.Defs[fn.Name].(*types.Func)
+			if returnsError(function) {
+				continue
+			}
+			if call, pos, ok := firstIOCall(fn.Body, info); ok {
+				findings = append(findings, Finding{
+					Package:  pkg.Name(),
+					Function: displayName(function),
+					Call:     call,
+					Pos:      fset.Position(pos),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+//! isAPI -> an exported function, or an exported method of an exported type. A method of an unexported type can't be called from outside
+func isAPI(fn *ast.FuncDecl, info *types.Info) bool {
+	if !fn.Name.IsExported() {
+		return false
+	}
+	if fn.Recv == nil {
+		return true
+	}
+	signature := info.Defs[fn.Name].(*types.Func).Type().(*types.Signature)
+	named, ok := derefNamed(signature.Recv().Type())
+	return ok && named.Obj().Exported()
+}
+
+func derefNamed(t types.Type) (*types.Named, bool) {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return named, ok
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+//! returnsError -> true if ANY result is the error type, (int, error) counts too
+func returnsError(function *types.Func) bool {
+	results := function.Type().(*types.Signature).Results()
+	for i := range results.Len() {
+		if types.Identical(results.At(i).Type(), errorType) {
+			return true
+		}
+	}
+	return false
+}
+
+//! firstIOCall -> walks the whole body, closures included : a func literal is part of the function it's written in, so its calls count for that function
+func firstIOCall(body *ast.BlockStmt, info *types.Info) (string, token.Pos, bool) {
+	var call string
+	var pos token.Pos
+	ast.Inspect(body, func(node ast.Node) bool {
+		if call != "" {
+			return false
+		}
+		expr, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if callee := calleeFunc(expr, info); callee != nil && isIOPackage(callee.Pkg()) && !notIO[callee.FullName()] {
+			call, pos = displayCall(callee), expr.Pos()
+		}
+		return true
+	})
+	return call, pos, call != ""
+}
+
+//! calleeFunc -> the *types.Func behind "pkg.F()", "value.Method()" or "F()". nil for builtins, conversions and calls of func values
+func calleeFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	function, _ := info.Uses[ident].(*types.Func)
+	return function
+}
+
+func isIOPackage(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	for _, path := range ioPackages {
+		if pkg.Path() == path || strings.HasPrefix(pkg.Path(), path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+//! displayName -> "Save" for a function, "(*Store).Save" or "Store.Save" for a method
+func displayName(function *types.Func) string {
+	recv := function.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return function.Name()
+	}
+	named, _ := derefNamed(recv.Type())
+	if _, pointer := recv.Type().(*types.Pointer); pointer {
+		return "(*" + named.Obj().Name() + ")." + function.Name()
+	}
+	return named.Obj().Name() + "." + function.Name()
+}
+
+//! displayCall -> "os.WriteFile", "(*os.File).Write" or "(io.Writer).Write"
+func displayCall(function *types.Func) string {
+	recv := function.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return function.Pkg().Name() + "." + function.Name()
+	}
+	return "(" + types.TypeString(recv.Type(), (*types.Package).Name) + ")." + function.Name()
+}
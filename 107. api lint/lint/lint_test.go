@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//! summary -> one line per finding, with only the file name, so the expected lists stay short
+func summary(findings []Finding) []string {
+	lines := []string{}
+	for _, f := range findings {
+		lines = append(lines, fmt.Sprintf("%s:%d %s.%s %s", filepath.Base(f.Pos.Filename), f.Pos.Line, f.Package, f.Function, f.Call))
+	}
+	return lines
+}
+
+//! functions -> like summary, without the position : the code of other lessons may move, the finding must not
+func functions(findings []Finding) []string {
+	lines := []string{}
+	for _, f := range findings {
+		lines = append(lines, fmt.Sprintf("%s.%s %s", f.Package, f.Function, f.Call))
+	}
+	return lines
+}
+
+func TestAnalyzeFixtures(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want []string
+	}{
+		{"testdata/methods", []string{
+			"store.go:14 methods.(*Store).Save os.WriteFile",
+			"store.go:19 methods.Store.Dump (io.Writer).Write",
+		}},
+		{"testdata/closures", []string{
+			"closures.go:12 closures.Pipe io.Copy",
+			"closures.go:20 closures.Handler http.Error",
+		}},
+		{"testdata/haserror", []string{}},
+		{"testdata/notio", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			findings, err := Analyze([]string{tt.dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := summary(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze(%s)\n got  %q\n want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeRecursiveIsSorted(t *testing.T) {
+	findings, err := Analyze([]string{"testdata/..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"closures.go:12 closures.Pipe io.Copy",
+		"closures.go:20 closures.Handler http.Error",
+		"store.go:14 methods.(*Store).Save os.WriteFile",
+		"store.go:19 methods.Store.Dump (io.Writer).Write",
+	}
+	if got := summary(findings); !reflect.DeepEqual(got, want) {
+		t.Errorf("Analyze(testdata/...)\n got  %q\n want %q", got, want)
+	}
+}
+
+//! the repo's own code, matched without line numbers
+func TestAnalyzeRepoPackages(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want []string
+	}{
+		{"../../pkg/sliceutil", []string{}},                                     //! pure functions, fmt.Errorf is not I/O
+		{"../../106. sliding window/window", []string{}},                        //! only time and sync
+		{"../../99. blank identifier", []string{}},                              //! imports packages of its own module : go list finds them
+		{"../../73. ansi color", []string{"main.IsTerminal (*os.File).Stat"}}, //! a failed Stat just means "not a terminal", so no error is on purpose
+		{"../../80. doc server", []string{"main.Handler http.NotFound"}},      //! the 404 is written in a handler closure, like the closures fixture
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			findings, err := Analyze([]string{tt.dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := functions(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze(%s)\n got  %q\n want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	for _, pattern := range []string{"testdata/missing", "testdata/missing/...", "testdata"} {
+		if _, err := Analyze([]string{pattern}); err == nil {
+			t.Errorf("Analyze(%q) = nil error, want an error", pattern)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package closures
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+//! the io.Copy is inside a closure : reported for Pipe, the function it's written in
+func Pipe(dst io.Writer, text string) {
+	copyAll := func() {
+		io.Copy(dst, strings.NewReader(text))
+	}
+	copyAll()
+}
+
+//! a closure which is returned : still written inside Handler, so reported for Handler
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusTeapot)
+	}
+}
+
+//! strings.Reader has Read, but it's from package strings : fine
+func Length(text string) int {
+	return strings.NewReader(text).Len()
+}
@@ -0,0 +1,26 @@
+package haserror
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func Write(path string, data []byte) error {
+	return os.WriteFile(path, data, 0o644)
+}
+
+func Count(r io.Reader) (int, error) {
+	data, err := io.ReadAll(r)
+	return len(data), err
+}
+
+//! fmt is not in the list, even though Println writes to os.Stdout : the check only sees direct calls
+func Greet(name string) {
+	fmt.Println("Hello,", name)
+}
+
+func read() []byte {
+	data, _ := os.ReadFile("x")
+	return data
+}
@@ -0,0 +1,42 @@
+package methods
+
+import (
+	"io"
+	"os"
+)
+
+type Store struct {
+	path string
+}
+
+//! pointer receiver, no error : reported
+func (s *Store) Save(data []byte) {
+	os.WriteFile(s.path, data, 0o644)
+}
+
+//! value receiver, calls a method of an interface from io : reported
+func (s Store) Dump(w io.Writer) {
+	w.Write([]byte(s.path))
+}
+
+//! has an error : fine
+func (s *Store) Load() ([]byte, error) {
+	return os.ReadFile(s.path)
+}
+
+//! unexported method : not part of the API
+func (s *Store) flush() {
+	os.Remove(s.path)
+}
+
+type cache struct{}
+
+//! exported method of an unexported type : can't be called from outside
+func (c cache) Clear() {
+	os.RemoveAll("cache")
+}
+
+//! no I/O : fine
+func (s *Store) Path() string {
+	return s.path
+}
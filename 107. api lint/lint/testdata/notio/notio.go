@@ -0,0 +1,18 @@
+package notio
+
+import (
+	"net/http"
+	"os"
+)
+
+//! NewServeMux and HandleFunc are in net/http, but they only build the router : no I/O
+func Routes(handler http.HandlerFunc) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", handler)
+	return mux
+}
+
+//! StatusText and Getenv only look up a value
+func Status(code int) string {
+	return http.StatusText(code) + " for " + os.Getenv("USER")
+}
@@ -0,0 +1,39 @@
+//! Package calc -> a few calculations which can fail, to have something to test
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrDivideByZero = errors.New("divide by zero")
+	ErrNegative     = errors.New("negative number")
+)
+
+func Divide(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("divide %g by %g: %w", a, b, ErrDivideByZero)
+	}
+	return a / b, nil
+}
+
+func Sqrt(x float64) (float64, error) {
+	if x < 0 {
+		return 0, fmt.Errorf("sqrt %g: %w", x, ErrNegative)
+	}
+	return math.Sqrt(x), nil
+}
+
+//! Average -> the mean of the numbers. No numbers has no mean, so that's an error like Divide by zero
+func Average(numbers []float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, fmt.Errorf("average of nothing: %w", ErrDivideByZero)
+	}
+	sum := 0.0
+	for _, n := range numbers {
+		sum += n
+	}
+	return sum / float64(len(numbers)), nil
+}
@@ -0,0 +1,48 @@
+package calc
+
+import (
+	"math"
+	"testing"
+
+	"tablerunner/tabletest"
+)
+
+//! pair -> Divide takes two arguments, but a Case has one input. A small struct makes them one
+type pair struct {
+	a, b float64
+}
+
+func TestDivide(t *testing.T) {
+	tabletest.RunCases(t, []tabletest.Case[pair, float64]{
+		{Name: "whole", In: pair{10, 2}, Want: 5},
+		{Name: "fraction", In: pair{1, 4}, Want: 0.25},
+		{Name: "negative", In: pair{-9, 3}, Want: -3},
+		{Name: "by zero", In: pair{1, 0}, ExpectedErr: ErrDivideByZero},
+		{Name: "zero by zero", In: pair{0, 0}, ExpectedErr: ErrDivideByZero},
+	}, func(p pair) (float64, error) { return Divide(p.a, p.b) })
+}
+
+//! almostEqual -> the comparer hook : Sqrt(2) * Sqrt(2) is not exactly 2, so the floats are compared with a tolerance
+func almostEqual(got, want float64) bool {
+	return math.Abs(got-want) < 1e-9
+}
+
+func TestSqrt(t *testing.T) {
+	tabletest.RunCasesWith(t, []tabletest.Case[float64, float64]{
+		{In: 0, Want: 0},
+		{In: 4, Want: 2},
+		{In: 2, Want: 1.4142135623730951},
+		{In: 0.01, Want: 0.1},
+		{In: -1, ExpectedErr: ErrNegative},
+		{Name: "NaN", In: math.NaN(), Skip: "what should Sqrt(NaN) be ? not decided yet"},
+	}, Sqrt, almostEqual)
+}
+
+func TestAverage(t *testing.T) {
+	tabletest.RunCasesWith(t, []tabletest.Case[[]float64, float64]{
+		{Name: "one number", In: []float64{7}, Want: 7},
+		{Name: "several", In: []float64{1, 2, 3, 4}, Want: 2.5},
+		{Name: "tenths", In: []float64{0.1, 0.2}, Want: 0.15}, //! (0.1 + 0.2) / 2 is 0.15000000000000002
+		{Name: "empty", In: nil, ExpectedErr: ErrDivideByZero},
+	}, Average, almostEqual)
+}
@@ -0,0 +1,3 @@
+module tablerunner
+
+go 1.22
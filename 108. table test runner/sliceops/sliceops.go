@@ -0,0 +1,49 @@
+//! Package sliceops -> a few slice operations, the second suite written with RunCases
+package sliceops
+
+import (
+	"errors"
+	"fmt"
+)
+
+//! ErrOutOfRange -> the error of Delete for a bad index. Check it with errors.Is
+var ErrOutOfRange = errors.New("out of range")
+
+//! Delete -> a new slice without the element at index i. A bad index is an error, not a panic : the index often comes from the user
+func Delete[T any](s []T, i int) ([]T, error) {
+	if i < 0 || i >= len(s) {
+		return s, fmt.Errorf("delete index %d of %d: %w", i, len(s), ErrOutOfRange)
+	}
+	result := make([]T, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...), nil
+}
+
+//! Unique -> the elements in their first order, every value once
+func Unique[T comparable](s []T) []T {
+	seen := make(map[T]bool, len(s))
+	result := make([]T, 0, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+//! Chunk -> s cut into pieces of size elements, the last one can be shorter. A size below 1 panics
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic(fmt.Sprintf("chunk size %d, want 1 or more", size))
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for size < len(s) {
+		chunks = append(chunks, s[:size:size])
+		s = s[size:]
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
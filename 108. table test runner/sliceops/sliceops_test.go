@@ -0,0 +1,43 @@
+//! the slice suite written with RunCases. It's package sliceops_test : it uses sliceops from outside, like any user would
+package sliceops_test
+
+import (
+	"testing"
+
+	"tablerunner/sliceops"
+	"tablerunner/tabletest"
+)
+
+type deleteInput struct {
+	s []string
+	i int
+}
+
+func TestDelete(t *testing.T) {
+	tabletest.RunCases(t, []tabletest.Case[deleteInput, []string]{
+		{Name: "first", In: deleteInput{[]string{"a", "b", "c"}, 0}, Want: []string{"b", "c"}},
+		{Name: "middle", In: deleteInput{[]string{"a", "b", "c"}, 1}, Want: []string{"a", "c"}},
+		{Name: "last", In: deleteInput{[]string{"a", "b", "c"}, 2}, Want: []string{"a", "b"}},
+		{Name: "single element", In: deleteInput{[]string{"a"}, 0}, Want: []string{}},
+		{Name: "index == len", In: deleteInput{[]string{"a"}, 1}, ExpectedErr: sliceops.ErrOutOfRange},
+		{Name: "negative index", In: deleteInput{[]string{"a"}, -1}, ExpectedErr: sliceops.ErrOutOfRange},
+		{Name: "empty", In: deleteInput{nil, 0}, ExpectedErr: sliceops.ErrOutOfRange},
+	}, func(in deleteInput) ([]string, error) { return sliceops.Delete(in.s, in.i) })
+}
+
+//! Unique and Chunk can't fail : the function given to RunCases just returns a nil error
+func TestUnique(t *testing.T) {
+	tabletest.RunCases(t, []tabletest.Case[[]int, []int]{
+		{In: nil, Want: []int{}},
+		{In: []int{1}, Want: []int{1}},
+		{In: []int{3, 1, 3, 2, 1}, Want: []int{3, 1, 2}},
+	}, func(s []int) ([]int, error) { return sliceops.Unique(s), nil })
+}
+
+func TestChunk(t *testing.T) {
+	tabletest.RunCases(t, []tabletest.Case[[]int, [][]int]{
+		{Name: "empty", In: []int{}, Want: [][]int{}},
+		{Name: "exact fit", In: []int{1, 2, 3, 4}, Want: [][]int{{1, 2}, {3, 4}}},
+		{Name: "short last chunk", In: []int{1, 2, 3}, Want: [][]int{{1, 2}, {3}}},
+	}, func(s []int) ([][]int, error) { return sliceops.Chunk(s, 2), nil })
+}
@@ -0,0 +1,83 @@
+//! Package tabletest -> one helper for table-driven tests, so a big table doesn't need the same 15 lines of loop, t.Run and if-got-want every time
+package tabletest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+//! Case -> one row of the table
+type Case[I, O any] struct {
+	Name        string //! the subtest name. Empty -> "case_3" for cases[3], so a failure still points at one row
+	In          I
+	Want        O
+	ExpectedErr error  //! nil -> fn must succeed. Otherwise the error must match it with errors.Is, and Want is not checked
+	Skip        string //! not empty -> the case is skipped, with this reason
+}
+
+//! runner -> the part of *testing.T RunCases needs. *testing.T's Run takes a func(*testing.T), so the real one is wrapped in tRunner,
+//! and the meta-tests pass a runner which only records what happened
+type runner interface {
+	Helper()
+	Run(name string, fn func(testing.TB)) bool
+}
+
+type tRunner struct {
+	*testing.T //! Helper comes from *testing.T itself : a Helper method written here would mark itself as the helper, not runCases
+}
+
+//! every frame between the test and Errorf is marked as a helper, so a failure points at the RunCases line of the test, not into this file
+func (r tRunner) Run(name string, fn func(testing.TB)) bool {
+	r.T.Helper()
+	return r.T.Run(name, func(t *testing.T) {
+		t.Helper()
+		fn(t)
+	})
+}
+
+//! RunCases -> one subtest per case. The outputs are compared with reflect.DeepEqual
+func RunCases[I, O any](t *testing.T, cases []Case[I, O], fn func(I) (O, error)) {
+	t.Helper()
+	runCases(tRunner{t}, cases, fn, func(got, want O) bool { return reflect.DeepEqual(got, want) })
+}
+
+//! RunCasesWith -> RunCases with our own comparer, for outputs DeepEqual can't judge : floats with rounding errors, structs with a time in them ...
+func RunCasesWith[I, O any](t *testing.T, cases []Case[I, O], fn func(I) (O, error), equal func(got, want O) bool) {
+	t.Helper()
+	runCases(tRunner{t}, cases, fn, equal)
+}
+
+//! CaseName -> the subtest name of cases[i]
+func CaseName(name string, i int) string {
+	if name == "" {
+		return fmt.Sprintf("case_%d", i)
+	}
+	return name
+}
+
+func runCases[I, O any](r runner, cases []Case[I, O], fn func(I) (O, error), equal func(got, want O) bool) {
+	r.Helper()
+	for i, c := range cases {
+		r.Run(CaseName(c.Name, i), func(t testing.TB) {
+			t.Helper()
+			if c.Skip != "" {
+				t.Skip(c.Skip)
+				return //! the real t.Skip never returns, a recording one does
+			}
+
+			got, err := fn(c.In)
+			switch {
+			case c.ExpectedErr != nil && err == nil:
+				t.Errorf("f(%#v) = %#v, nil; want error %q", c.In, got, c.ExpectedErr)
+			case c.ExpectedErr != nil && !errors.Is(err, c.ExpectedErr):
+				t.Errorf("f(%#v) error = %q; want an error matching %q", c.In, err, c.ExpectedErr)
+			case c.ExpectedErr == nil && err != nil:
+				t.Errorf("f(%#v) unexpected error : %q", c.In, err)
+			case c.ExpectedErr == nil && !equal(got, c.Want):
+				t.Errorf("f(%#v) = %#v; want %#v", c.In, got, c.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tabletest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! recorder -> a fake testing.TB which remembers what the helper did to it instead of failing the real test
+//! testing.TB has an unexported method, so only embedding it makes a type a testing.TB. The methods the helper calls are overridden, any other one would panic on the nil interface
+type recorder struct {
+	testing.TB
+	errors  []string
+	skipped string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Skip(args ...any) {
+	r.skipped = fmt.Sprint(args...)
+}
+
+//! recordingRunner -> runs each subtest with a fresh recorder and keeps them by name, in order
+type recordingRunner struct {
+	names    []string
+	subtests map[string]*recorder
+}
+
+func (r *recordingRunner) Helper() {}
+
+func (r *recordingRunner) Run(name string, fn func(testing.TB)) bool {
+	if r.subtests == nil {
+		r.subtests = map[string]*recorder{}
+	}
+	sub := &recorder{}
+	fn(sub)
+	r.names = append(r.names, name)
+	r.subtests[name] = sub
+	return len(sub.errors) == 0
+}
+
+var errNegative = errors.New("negative")
+
+//! double -> the function under test of the meta-tests
+func double(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("double %d: %w", n, errNegative)
+	}
+	return 2 * n, nil
+}
+
+func run(cases []Case[int, int]) *recordingRunner {
+	r := &recordingRunner{}
+	runCases(r, cases, double, func(got, want int) bool { return got == want })
+	return r
+}
+
+func TestSubtestNames(t *testing.T) {
+	r := run([]Case[int, int]{
+		{Name: "two", In: 2, Want: 4},
+		{In: 3, Want: 6},
+		{Name: "zero", In: 0, Want: 0},
+		{In: 5, Want: 10},
+	})
+	want := []string{"two", "case_1", "zero", "case_3"}
+	if !reflect.DeepEqual(r.names, want) {
+		t.Errorf("names = %q, want %q", r.names, want)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	r := run([]Case[int, int]{
+		{Name: "skipped", In: 2, Want: 999, Skip: "not ready yet"},
+	})
+	sub := r.subtests["skipped"]
+	if sub.skipped != "not ready yet" {
+		t.Errorf("skip reason = %q, want %q", sub.skipped, "not ready yet")
+	}
+	if len(sub.errors) != 0 {
+		t.Errorf("a skipped case was still checked : %q", sub.errors)
+	}
+}
+
+func TestExpectedErr(t *testing.T) {
+	other := errors.New("other")
+	r := run([]Case[int, int]{
+		{Name: "wrapped error matches", In: -1, ExpectedErr: errNegative},
+		{Name: "want is ignored on error", In: -1, Want: 123, ExpectedErr: errNegative},
+		{Name: "wrong error", In: -1, ExpectedErr: other},
+		{Name: "no error", In: 1, ExpectedErr: errNegative},
+		{Name: "unexpected error", In: -1, Want: 0},
+	})
+
+	tests := []struct {
+		name     string
+		wantFail bool
+	}{
+		{"wrapped error matches", false},
+		{"want is ignored on error", false},
+		{"wrong error", true},
+		{"no error", true},
+		{"unexpected error", true},
+	}
+	for _, tt := range tests {
+		if failed := len(r.subtests[tt.name].errors) > 0; failed != tt.wantFail {
+			t.Errorf("%s : failed = %v, want %v (%q)", tt.name, failed, tt.wantFail, r.subtests[tt.name].errors)
+		}
+	}
+}
+
+func TestFailureMessages(t *testing.T) {
+	r := run([]Case[int, int]{
+		{Name: "wrong output", In: 2, Want: 5},
+		{Name: "wrong error", In: -1, ExpectedErr: errors.New("other")},
+		{Name: "no error", In: 1, ExpectedErr: errNegative},
+		{Name: "unexpected error", In: -1},
+	})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"wrong output", "f(2) = 4; want 5"},
+		{"wrong error", `f(-1) error = "double -1: negative"; want an error matching "other"`},
+		{"no error", `f(1) = 2, nil; want error "negative"`},
+		{"unexpected error", `f(-1) unexpected error : "double -1: negative"`},
+	}
+	for _, tt := range tests {
+		errs := r.subtests[tt.name].errors
+		if len(errs) != 1 || errs[0] != tt.want {
+			t.Errorf("%s : messages = %q, want [%q]", tt.name, errs, tt.want)
+		}
+	}
+}
+
+//! the comparer hook : with 'equal' always true, a wrong Want passes
+func TestComparerHook(t *testing.T) {
+	r := &recordingRunner{}
+	runCases(r, []Case[int, int]{{Name: "any", In: 2, Want: 5}}, double, func(got, want int) bool { return true })
+	if errs := r.subtests["any"].errors; len(errs) != 0 {
+		t.Errorf("custom comparer ignored : %q", errs)
+	}
+}
+
+//! the real RunCases on a real *testing.T, with the structs compared by DeepEqual
+func TestRunCasesReal(t *testing.T) {
+	split := func(s string) ([]string, error) { return strings.Fields(s), nil }
+	RunCases(t, []Case[string, []string]{
+		{Name: "two words", In: "Hello Gopher", Want: []string{"Hello", "Gopher"}},
+		{In: "  spaced   out  ", Want: []string{"spaced", "out"}},
+		{Name: "not yet", Skip: "shows up as SKIP in go test -v"},
+	}, split)
+}
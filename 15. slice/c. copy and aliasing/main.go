@@ -0,0 +1,69 @@
+package main
+
+import "fmt"
+
+func main() {
+	//! 1. a slice doesn't copy the array : it POINTS into it. Changing the slice changes the array
+	arr := [5]string{"a", "b", "c", "d", "e"}
+	sliced := arr[1:4] //! b c d, len 3, cap 4 (from index 1 to the end of the array)
+
+	sliced[0] = "B"
+	fmt.Println("sliced :", sliced)
+	fmt.Println("arr    :", arr) //! [a B c d e] -> the array changed too, they share the same memory
+
+	fmt.Println("--------------------------------")
+
+	//! 2. append with spare capacity : no new array, append writes into the SAME array ... over the parent's elements
+	arr = [5]string{"a", "b", "c", "d", "e"}
+	sliced = arr[1:3] //! b c, len 2, cap 4 -> there is room for 2 more
+	fmt.Println("before append : sliced", sliced, "len", len(sliced), "cap", cap(sliced))
+
+	sliced = append(sliced, "X") //! index 3 of arr is free for 'sliced' ... but for arr it's "d"
+	fmt.Println("after append  : sliced", sliced, "len", len(sliced), "cap", cap(sliced))
+	fmt.Println("arr           :", arr) //! [a b c X e] -> "d" was overwritten
+
+	//! two slices of the same array overwrite each other in the same way
+	numbers := []int{1, 2, 3, 4, 5}
+	first := numbers[:2]              //! 1 2, cap 5
+	first = append(first, 100)        //! writes into index 2
+	fmt.Println("numbers :", numbers) //! [1 2 100 4 5]
+
+	fmt.Println("--------------------------------")
+
+	//! 3. copy(dst, src) -> copies the VALUES into dst's own array. After that, they are independent
+	arr = [5]string{"a", "b", "c", "d", "e"}
+	source := arr[1:4]
+	independent := make([]string, len(source)) //! copy never grows dst : make it long enough first
+	copied := copy(independent, source)        //! returns how many elements were copied : min(len(dst), len(src))
+	fmt.Println("copied", copied, "elements :", independent)
+
+	independent[0] = "B"
+	fmt.Println("independent :", independent)
+	fmt.Println("arr         :", arr) //! unchanged
+
+	short := make([]string, 2)
+	fmt.Println("copy into len 2 :", copy(short, source), short) //! 2 [b c] -> only what fits
+	var empty []string
+	fmt.Println("copy into nil   :", copy(empty, source), empty) //! 0 [] -> a nil slice has length 0, nothing fits
+
+	fmt.Println("--------------------------------")
+
+	//! 4. the fix for part 2 : the full slice expression arr[low:high:max] also limits the capacity to max - low
+	arr = [5]string{"a", "b", "c", "d", "e"}
+	limited := arr[1:3:3] //! b c, len 2, cap 3 - 1 = 2 -> no spare room
+	fmt.Println("before append : limited", limited, "len", len(limited), "cap", cap(limited))
+
+	limited = append(limited, "X") //! no room -> append makes a NEW array and copies b c into it first
+	fmt.Println("after append  : limited", limited, "len", len(limited), "cap", cap(limited))
+	fmt.Println("arr           :", arr) //! [a b c d e] -> "d" is safe
+
+	limited[0] = "B" //! the new array : arr doesn't see this either
+	fmt.Println("limited[0] = \"B\" : limited", limited, "arr", arr)
+}
+
+/*
+	Try :
+		1. In part 2, append TWO values. Then three. When does arr stop changing?
+		2. Write a function which takes a slice and appends to it. Does the caller see the new element? Does it see a changed element?
+		3. Use slices.Clone(source) instead of make + copy
+*/
@@ -0,0 +1,7 @@
+module slicetricks
+
+go 1.22
+
+require github.com/Faizul-Bitto/learn-GoLang/pkg/sliceutil v0.0.0
+
+replace github.com/Faizul-Bitto/learn-GoLang/pkg/sliceutil => ../../pkg/sliceutil
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Faizul-Bitto/learn-GoLang/pkg/sliceutil" //! a real package from this repo : pkg/sliceutil, found through the 'replace' line in go.mod
+)
+
+func main() {
+	//! 1. Insert -> a NEW slice with the value at the index. The original is not changed
+	languages := []string{"Go", "Rust", "Python"}
+	withC := sliceutil.Insert(languages, 1, "C")
+	fmt.Println("languages :", languages)
+	fmt.Println("withC     :", withC)                                  //! [Go C Rust Python]
+	fmt.Println("at end    :", sliceutil.Insert(languages, 3, "Java")) //! index len(s) -> added at the end
+
+	fmt.Println("--------------------------------")
+
+	//! 2. Delete -> also a new slice. A bad index is an error, so we check it like any other error
+	withoutRust, err := sliceutil.Delete(languages, 1)
+	fmt.Println("delete 1  :", withoutRust, err) //! [Go Python] <nil>
+
+	_, err = sliceutil.Delete(languages, 3)
+	if err != nil {
+		fmt.Println("delete 3  :", err) //! no panic, just an error
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 3. Reverse -> changes the slice IN PLACE and returns nothing
+	numbers := []int{1, 2, 3, 4, 5}
+	sliceutil.Reverse(numbers)
+	fmt.Println("reversed  :", numbers) //! [5 4 3 2 1]
+
+	fmt.Println("--------------------------------")
+
+	//! 4. Unique -> every value once, in the order they first appear
+	tags := []string{"go", "web", "go", "cli", "web", "go"}
+	fmt.Println("tags      :", tags)
+	fmt.Println("unique    :", sliceutil.Unique(tags)) //! [go web cli]
+
+	fmt.Println("--------------------------------")
+
+	//! 5. Chunk -> pieces of 3. The last piece is shorter
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	chunks := sliceutil.Chunk(items, 3)
+	fmt.Println("chunks    :", chunks, "count", len(chunks)) //! [[1 2 3] [4 5 6] [7]] count 3
+
+	//! the pieces point into 'items', but with a limited capacity, so this append makes a new array instead of overwriting 4
+	first := append(chunks[0], 100)
+	fmt.Println("first     :", first)
+	fmt.Println("items     :", items) //! unchanged
+}
+
+/*
+	Try :
+		1. Call sliceutil.Insert(languages, 10, "Zig"). Why does Insert panic while Delete returns an error?
+		2. Change chunks[1][0] = 40. Does 'items' change? Why? (the pieces share the array, see the copy and aliasing lesson)
+		3. Use the standard library instead : slices.Insert, slices.Delete, slices.Reverse, slices.Compact. How is slices.Compact different from Unique?
+*/
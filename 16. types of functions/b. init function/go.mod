@@ -0,0 +1,7 @@
+module initfunction
+
+go 1.22
+
+require executiontrace v0.0.0
+
+replace executiontrace => "../../57. execution trace"
@@ -1,10 +1,24 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
 
-var a int = 0
+	"executiontrace/trace"
+)
+
+//! tracer -> every step marks itself, so main_test.go can check the order instead of reading the output
+var tracer = trace.New()
+
+var a int = zero()
+
+//! zero -> the package variable gets its value first, before any 'init' function
+func zero() int {
+	tracer.Mark("package variable")
+	return 0
+}
 
 func main() {
+	tracer.Mark("main")
 	fmt.Println("main function")
 	fmt.Println("a =", a)
 }
@@ -12,6 +26,7 @@ func main() {
 //! 'init' function -> we cannot call this function, it will be called automatically
 //! 'init' function doesn't take any input, doesn't give any output also. It automatically gets executed
 func init() {
+	tracer.Mark("init")
 	a = 10
 	fmt.Println("a =", a)
 	fmt.Println("init function")
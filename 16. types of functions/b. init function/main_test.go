@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+//! the package variable and init() run before any test, main() doesn't run at all
+func TestInitRunsFirst(t *testing.T) {
+	tracer.AssertOrder(t, "package variable", "init")
+	if a != 10 {
+		t.Errorf("a = %d before main, want 10 : init has already changed it", a)
+	}
+}
+
+//! calling main() in a test : its mark comes after the marks of the package variable and init()
+func TestMainRunsAfterInit(t *testing.T) {
+	main()
+	tracer.AssertOrder(t, "package variable", "init", "main")
+}
@@ -0,0 +1,81 @@
+//! In the receiver function section, we attached methods to a struct. Interfaces are the payoff of those receiver methods. An interface is a type that only describes behaviour -> a list of method signatures. Any type that has all of those methods can be used wherever that interface is expected.
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+//! 'Shape' is an interface. It doesn't store any data, it only says : "anything that has an Area() float64 and a Perimeter() float64 method is a Shape"
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Rectangle struct {
+	Width  float64
+	Height float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+//! receiver functions of Rectangle
+func (rectangle Rectangle) Area() float64 {
+	return rectangle.Width * rectangle.Height
+}
+
+func (rectangle Rectangle) Perimeter() float64 {
+	return 2 * (rectangle.Width + rectangle.Height)
+}
+
+//! receiver functions of Circle
+func (circle Circle) Area() float64 {
+	return math.Pi * circle.Radius * circle.Radius
+}
+
+func (circle Circle) Perimeter() float64 {
+	return 2 * math.Pi * circle.Radius
+}
+
+//! Notice, we never wrote something like 'Rectangle implements Shape' or 'Circle implements Shape' anywhere. In Go, interfaces are satisfied 'implicitly'. The compiler checks the method set of the type : Rectangle has Area() float64 and Perimeter() float64, so Rectangle is a Shape. Circle also has both, so Circle is also a Shape. If we delete the Perimeter() method of Circle, then passing a Circle to describe() will give a compile time error : "Circle does not implement Shape (missing method Perimeter)"
+
+//! 'describe' accepts any Shape. It doesn't know (and doesn't care) whether it is a Rectangle or a Circle. It only calls the methods that the interface promises
+func describe(s Shape) {
+	fmt.Printf("Shape : %T\n", s) //! %T prints the concrete type that is stored inside the interface
+	fmt.Printf("Area : %.2f\n", s.Area())
+	fmt.Printf("Perimeter : %.2f\n", s.Perimeter())
+}
+
+func main() {
+	rectangle := Rectangle{Width: 10, Height: 5}
+	circle := Circle{Radius: 7}
+
+	describe(rectangle) //! Rectangle is passed as a Shape
+	fmt.Println("--------------------------------")
+	describe(circle) //! Circle is passed as a Shape
+	fmt.Println("--------------------------------")
+
+	//! we can also keep different types in one slice, as long as all of them are Shape
+	shapes := []Shape{
+		Rectangle{Width: 2, Height: 3},
+		Circle{Radius: 1},
+		Rectangle{Width: 4, Height: 4},
+	}
+
+	totalArea := 0.0
+	for _, shape := range shapes {
+		totalArea = totalArea + shape.Area() //! the correct Area() method is called for each concrete type
+	}
+	fmt.Printf("Total Area of all shapes : %.2f\n", totalArea)
+
+	/*
+		An interface value is made of two parts :
+
+		1. Type  -> the concrete type that is stored inside (Rectangle or Circle)
+		2. Value -> the actual data of that concrete type (Width, Height or Radius)
+
+		When we call s.Area(), Go looks at the 'Type' part and calls the Area() method of that concrete type with the 'Value' part.
+	*/
+}
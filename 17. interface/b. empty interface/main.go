@@ -0,0 +1,69 @@
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! 'interface{}' is an interface with zero methods. Every type has at least zero methods, so every type satisfies the empty interface implicitly. That means a variable of type interface{} can hold a value of any type
+//! 'any' is just another name of 'interface{}' (added in Go 1.18). Both are exactly the same thing
+func printAnything(value interface{}) {
+	fmt.Println("value :", value)
+}
+
+//! type switch -> checks the concrete type stored inside the interface and runs the matching case
+func describe(value any) {
+	switch v := value.(type) { //! 'v' will have the concrete type of the matched case
+	case int:
+		fmt.Println("it is an int, doubled :", v*2)
+	case string:
+		fmt.Println("it is a string, length :", len(v))
+	case Person:
+		fmt.Println("it is a Person, name :", v.Name)
+	default:
+		fmt.Printf("don't know this type : %T\n", v)
+	}
+}
+
+func main() {
+	printAnything(10)
+	printAnything("Hello World")
+	printAnything(true)
+	printAnything(Person{Name: "John", Age: 20, Email: "john@example.com"})
+
+	fmt.Println("--------------------------------")
+
+	//! type assertion -> taking the concrete value back out of the interface
+	var value any = "Go is fun"
+
+	text := value.(string) //! we are asserting that the value inside is a string
+	fmt.Println("asserted string :", text)
+
+	//! if we assert the wrong type like this, the program will panic at runtime :
+	// number := value.(int) //! panic: interface conversion: interface {} is string, not int
+
+	//! so, the safe way is the 'comma-ok' form. If the assertion fails, 'ok' will be false and 'number' will be the zero value of int
+	number, ok := value.(int)
+	fmt.Println("number :", number, "ok :", ok) //! number : 0 ok : false
+
+	text, ok = value.(string)
+	fmt.Println("text :", text, "ok :", ok) //! text : Go is fun ok : true
+
+	fmt.Println("--------------------------------")
+
+	//! type switch with int, string, Person and an unknown type
+	describe(21)
+	describe("Gopher")
+	describe(Person{Name: "Jane", Age: 21, Email: "jane@example.com"})
+	describe(3.14)
+
+	/*
+		When to use interface{} / any ?
+
+		Use it when you really don't know the type beforehand (for example : fmt.Println accepts ...any).
+		But don't overuse it. With 'any', the compiler cannot check the type for us anymore, we have to check it ourselves at runtime with type assertion or type switch.
+	*/
+}
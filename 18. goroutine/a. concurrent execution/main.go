@@ -0,0 +1,42 @@
+//! goroutine -> a function that is running concurrently (at the same time) with other functions. We start a goroutine by simply writing the 'go' keyword before a function call.
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func printNumbers() {
+	for i := 1; i <= 5; i++ {
+		fmt.Println("goroutine  :", i)
+		time.Sleep(100 * time.Millisecond) //! sleeping a little, so that the other side gets a chance to run
+	}
+}
+
+func main() {
+	go printNumbers() //! 'go' keyword -> printNumbers() will run in a new goroutine. main will NOT wait for it, main will immediately go to the next line
+
+	for i := 1; i <= 5; i++ {
+		fmt.Println("main       :", i)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	time.Sleep(200 * time.Millisecond) //! giving the goroutine some extra time to finish. (this is not the right way to wait, we will see the right way in the next example)
+
+	/*
+		Output will be interleaved, something like :
+
+		main       : 1
+		goroutine  : 1
+		goroutine  : 2
+		main       : 2
+		...
+
+		The order can be different in each run. Both loops are running at the same time, and the Go scheduler decides which one runs when.
+	*/
+
+	/*
+		Remember the closure section? Every function call gets its own 'stack frame' in the Stack. A goroutine is also like that, but each goroutine gets its OWN small stack (starting from only a few KB, it grows when needed). That's why goroutines are very cheap, we can create thousands of them.
+		main() is also a goroutine -> the 'main goroutine'.
+	*/
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+func printMessage(message string) {
+	fmt.Println(message)
+}
+
+//! the bug -> main exits before the goroutine gets a chance to run
+func withoutWaiting() {
+	go printMessage("Hello from goroutine (without waiting)") //! this line will most probably never be printed
+	fmt.Println("withoutWaiting finished")
+}
+
+//! the fix -> sync.WaitGroup
+func withWaitGroup() {
+	var wg sync.WaitGroup
+
+	wg.Add(1) //! telling the WaitGroup : "1 goroutine is going to run, wait for it"
+
+	go func() {
+		defer wg.Done() //! telling the WaitGroup : "this goroutine has finished". defer makes sure Done() is called even if something goes wrong
+		printMessage("Hello from goroutine (with WaitGroup)")
+	}()
+
+	wg.Wait() //! blocks here until the counter becomes 0 -> until Done() is called
+	fmt.Println("withWaitGroup finished")
+}
+
+func main() {
+	withoutWaiting()
+	fmt.Println("--------------------------------")
+	withWaitGroup()
+
+	//! When the main function returns, the whole program exits. It doesn't matter whether other goroutines have finished or not, all of them are killed immediately. That's why in withoutWaiting(), the goroutine usually never prints anything. main doesn't wait for anyone by default.
+
+	/*
+		sync.WaitGroup has 3 methods :
+
+		1. Add(n) -> increase the counter by n (call it BEFORE starting the goroutine)
+		2. Done() -> decrease the counter by 1 (call it when the goroutine finishes)
+		3. Wait() -> block until the counter becomes 0
+
+		Using time.Sleep() to wait (like the previous example) is only a guess. Maybe the goroutine needs more time, maybe less. WaitGroup waits exactly as long as needed.
+	*/
+}
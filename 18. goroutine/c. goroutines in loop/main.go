@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+//! the loop-variable capture issue
+func sharedVariable() {
+	var wg sync.WaitGroup
+
+	var i int //! ONE variable 'i' declared outside the loop, shared by every iteration
+	for i = 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Print(i, " ") //! the closure captures the variable 'i' itself, not the value of 'i' at this moment
+		}()
+	}
+
+	wg.Wait()
+	fmt.Println()
+
+	//! Output will be something like : 10 10 10 10 7 10 10 10 10 10
+	//! Most of the goroutines start running after the loop has already finished, and by then 'i' is 10. Same as the closure section : 'money' was captured by the 'show' function and moved to the Heap, so every call saw the latest value. Here all 10 goroutines capture the same 'i', so it is moved to the Heap and all of them read the latest value.
+}
+
+//! the fix -> pass 'i' as an argument
+func passAsArgument() {
+	var wg sync.WaitGroup
+
+	var i int
+	for i = 0; i < 10; i++ {
+		wg.Add(1)
+		go func(number int) { //! 'number' is a parameter -> a copy of 'i' is made at the moment 'go' is executed
+			defer wg.Done()
+			fmt.Print(number, " ")
+		}(i) //! passing the current value of 'i'
+	}
+
+	wg.Wait()
+	fmt.Println()
+
+	//! Output will be all numbers from 0 to 9 (the order can be different, because goroutines run concurrently) : 3 0 1 2 9 5 6 7 4 8
+}
+
+//! Go 1.22 and newer -> 'for i := 0; ...' creates a NEW 'i' for every iteration
+func perIterationVariable() {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ { //! since Go 1.22, each iteration has its own 'i'
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Print(i, " ")
+		}()
+	}
+
+	wg.Wait()
+	fmt.Println()
+}
+
+func main() {
+	fmt.Println("shared variable (bug) :")
+	sharedVariable()
+	fmt.Println("--------------------------------")
+
+	fmt.Println("passing i as an argument (fix) :")
+	passAsArgument()
+	fmt.Println("--------------------------------")
+
+	fmt.Println("per-iteration loop variable (Go 1.22+) :")
+	perIterationVariable()
+
+	/*
+		Before Go 1.22, 'for i := 0; i < 10; i++' also had only ONE 'i' for the whole loop. So the bug of sharedVariable() happened even with ':='. That's why in older code you will see this pattern a lot :
+
+			for i := 0; i < 10; i++ {
+				go func(i int) {
+					...
+				}(i)
+			}
+
+		Since Go 1.22, every iteration gets a new variable, so the capture problem is gone for ':=' loops. But if the variable is declared outside the loop (like sharedVariable()), it is still shared, and passing it as an argument is still the fix.
+	*/
+}
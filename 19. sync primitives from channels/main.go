@@ -0,0 +1,205 @@
+//! The 'sync' package gives us Mutex, WaitGroup and Once. To understand what they really do, in this section we will build our own versions of them by using only channels of 'struct{}'.
+//! 'struct{}' is an empty struct -> it takes 0 bytes of memory. We don't care about the value we send, we only care about the 'signal' that something was sent or closed.
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+//! ChanMutex -> a channel with a buffer of 1 element works like a lock. Only one goroutine can put a value into it, everyone else has to wait until that value is taken out.
+type ChanMutex struct {
+	ch chan struct{}
+}
+
+func NewChanMutex() *ChanMutex {
+	return &ChanMutex{ch: make(chan struct{}, 1)}
+}
+
+func (m *ChanMutex) Lock() {
+	m.ch <- struct{}{} //! if the buffer is already full (someone holds the lock), this line blocks
+}
+
+//! Unlock without Lock is a programming mistake. sync.Mutex crashes the program with "fatal error: sync: unlock of unlocked mutex". We panic with a similar message.
+func (m *ChanMutex) Unlock() {
+	select {
+	case <-m.ch: //! taking the value out -> releasing the lock
+	default:
+		panic("sync: unlock of unlocked ChanMutex")
+	}
+}
+
+//! ChanWaitGroup -> one 'counter' goroutine owns the counter. Nobody else touches it, others only send messages to it. So, no lock is needed.
+type ChanWaitGroup struct {
+	deltas  chan int
+	waiters chan chan struct{}
+}
+
+func NewChanWaitGroup() *ChanWaitGroup {
+	wg := &ChanWaitGroup{
+		deltas:  make(chan int),
+		waiters: make(chan chan struct{}),
+	}
+	go wg.counter()
+	return wg
+}
+
+func (wg *ChanWaitGroup) counter() {
+	count := 0
+	var waiting []chan struct{}
+
+	for {
+		select {
+		case delta := <-wg.deltas:
+			count = count + delta
+			if count < 0 {
+				panic("sync: negative ChanWaitGroup counter")
+			}
+			if count == 0 {
+				for _, waiter := range waiting {
+					close(waiter) //! closing a channel wakes up everyone who is receiving from it
+				}
+				waiting = nil
+			}
+		case waiter := <-wg.waiters:
+			if count == 0 {
+				close(waiter)
+			} else {
+				waiting = append(waiting, waiter)
+			}
+		}
+	}
+	//! this goroutine never stops. In a real library that would be a leak, it's one of the reasons the real sync.WaitGroup doesn't work like this
+}
+
+func (wg *ChanWaitGroup) Add(delta int) {
+	wg.deltas <- delta
+}
+
+func (wg *ChanWaitGroup) Done() {
+	wg.Add(-1)
+}
+
+func (wg *ChanWaitGroup) Wait() {
+	waiter := make(chan struct{})
+	wg.waiters <- waiter
+	<-waiter //! blocks until the counter goroutine closes this channel
+}
+
+//! ChanOnce -> there is only one 'token' in the buffered channel. Whoever takes it runs the function, everybody else waits until 'done' is closed.
+type ChanOnce struct {
+	token chan struct{}
+	done  chan struct{}
+}
+
+func NewChanOnce() *ChanOnce {
+	once := &ChanOnce{
+		token: make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+	once.token <- struct{}{}
+	return once
+}
+
+func (o *ChanOnce) Do(f func()) {
+	select {
+	case <-o.token: //! only the first caller can receive the token
+		func() {
+			defer close(o.done) //! even if f panics, the others will not wait forever
+			f()
+		}()
+	case <-o.done: //! already done, nothing to do
+	}
+	<-o.done //! the others wait here until the first caller's f has finished, same as sync.Once
+}
+
+//! the concurrent counter : 1000 goroutines, each one increments the counter 100 times
+func countWithChanPrimitives() int {
+	counter := 0
+	mutex := NewChanMutex()
+	wg := NewChanWaitGroup()
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mutex.Lock()
+				counter++
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return counter
+}
+
+//! exactly the same counter with the real sync package
+func countWithSyncPackage() int {
+	counter := 0
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mutex.Lock()
+				counter++
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return counter
+}
+
+func main() {
+	//! 1. both versions give the same result -> 1000 * 100 = 100000
+	fmt.Println("counter with channel primitives :", countWithChanPrimitives())
+	fmt.Println("counter with sync package       :", countWithSyncPackage())
+	fmt.Println("--------------------------------")
+
+	//! 2. ChanOnce : 100 goroutines call Do, but the function runs exactly once
+	once := NewChanOnce()
+	wg := NewChanWaitGroup()
+	calls := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			once.Do(func() {
+				calls++
+			})
+		}()
+	}
+	wg.Wait()
+	fmt.Println("ChanOnce called by 100 goroutines, function ran :", calls, "time")
+	fmt.Println("--------------------------------")
+
+	//! 3. Unlock without Lock : a panic, recovered here so the program goes on
+	mutex := NewChanMutex()
+	mutex.Lock()
+	mutex.Unlock()
+	func() {
+		defer func() {
+			fmt.Println("second Unlock panicked :", recover())
+		}()
+		mutex.Unlock()
+	}()
+
+	//! 4. the speed comparison is in primitives_test.go, as benchmarks : go test -bench . -benchmem *.go
+
+	/*
+		Why is the real sync package faster?
+
+		1. A channel operation always takes the channel's internal lock, copies the value into the buffer and checks the waiting queues. sync.Mutex, when nobody else holds the lock, only does ONE atomic 'compare-and-swap' instruction on an integer. That's it.
+		2. When a goroutine has to wait on a channel, it is parked and the scheduler has to wake it up later. sync.Mutex first spins for a very short time, because the lock is usually released within nanoseconds, so most of the time no parking is needed.
+		3. Our ChanWaitGroup needs a whole extra goroutine and two channel messages for every Add/Done. sync.WaitGroup is just an atomic counter.
+
+		So, channels are great for passing data and signals between goroutines, but for protecting a simple variable, the sync package is the right tool.
+	*/
+}
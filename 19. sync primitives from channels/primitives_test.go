@@ -0,0 +1,178 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChanMutexUnlockWithoutLockPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		unlock func(m *ChanMutex)
+	}{
+		{"never locked", func(m *ChanMutex) { m.Unlock() }},
+		{"unlocked twice", func(m *ChanMutex) { m.Lock(); m.Unlock(); m.Unlock() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != "sync: unlock of unlocked ChanMutex" {
+					t.Errorf("recover() = %v, want the unlock panic", got)
+				}
+			}()
+			tt.unlock(NewChanMutex())
+		})
+	}
+}
+
+func TestChanMutexCounter(t *testing.T) {
+	if got := countWithChanPrimitives(); got != 100000 {
+		t.Errorf("counter = %d, want 100000", got)
+	}
+}
+
+//! Wait must block until the LAST Done, not the first one
+func TestChanWaitGroupWaitsForAllDone(t *testing.T) {
+	wg := NewChanWaitGroup()
+	wg.Add(3)
+
+	released := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(released)
+	}()
+
+	for done := 1; done <= 2; done++ {
+		wg.Done()
+		select {
+		case <-released:
+			t.Fatalf("Wait returned after %d of 3 Done calls", done)
+		case <-time.After(20 * time.Millisecond): //! still waiting, as it should
+		}
+	}
+
+	wg.Done()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("Wait didn't return after the third Done")
+	}
+}
+
+func TestChanWaitGroupZeroDoesNotBlock(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		NewChanWaitGroup().Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait on a zero counter blocked")
+	}
+}
+
+//! 100 callers at the same time : f runs once, and NOBODY returns from Do before f has finished
+func TestChanOnceConcurrentCallers(t *testing.T) {
+	once := NewChanOnce()
+	var calls, finished atomic.Int32
+	var early atomic.Int32
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start //! all 100 start together
+			once.Do(func() {
+				calls.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				finished.Store(1)
+			})
+			if finished.Load() == 0 {
+				early.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("f ran %d times, want 1", got)
+	}
+	if got := early.Load(); got != 0 {
+		t.Errorf("%d callers returned before f finished", got)
+	}
+}
+
+//! a panic in f still closes 'done', so the next callers don't wait forever
+func TestChanOncePanicReleasesOthers(t *testing.T) {
+	once := NewChanOnce()
+	func() {
+		defer func() { recover() }()
+		once.Do(func() { panic("boom") })
+	}()
+
+	returned := make(chan struct{})
+	go func() {
+		once.Do(func() { t.Error("f ran a second time") })
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("Do blocked after the first f panicked")
+	}
+}
+
+//! go test -bench . -benchmem *.go -> the channel versions against the sync package
+
+func BenchmarkChanMutex(b *testing.B) {
+	m := NewChanMutex()
+	for i := 0; i < b.N; i++ {
+		m.Lock()
+		m.Unlock()
+	}
+}
+
+func BenchmarkSyncMutex(b *testing.B) {
+	var m sync.Mutex
+	for i := 0; i < b.N; i++ {
+		m.Lock()
+		m.Unlock()
+	}
+}
+
+func BenchmarkChanWaitGroup(b *testing.B) {
+	wg := NewChanWaitGroup()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		wg.Done()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSyncWaitGroup(b *testing.B) {
+	var wg sync.WaitGroup
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		wg.Done()
+	}
+	wg.Wait()
+}
+
+//! the whole counter : 1000 goroutines x 100 increments, with real contention
+func BenchmarkCounterChanPrimitives(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		countWithChanPrimitives()
+	}
+}
+
+func BenchmarkCounterSyncPackage(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		countWithSyncPackage()
+	}
+}
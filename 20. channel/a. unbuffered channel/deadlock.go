@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+//! deadlock -> every goroutine is blocked and waiting, and nobody is left who could wake them up
+func deadlockExample() {
+	ch := make(chan int) //! unbuffered channel
+
+	ch <- 10 //! main goroutine is sending, so it will wait until someone receives. But, there is no other goroutine which will receive from 'ch'. The receive below can never be reached, because main itself is stuck on this line
+
+	fmt.Println(<-ch) //! this line will never be executed
+
+	/*
+		Go runtime detects this situation. When ALL goroutines are asleep, the program crashes with :
+
+		fatal error: all goroutines are asleep - deadlock!
+
+		goroutine 1 [chan send]:
+		main.deadlockExample()
+
+		That's why in main.go, the call of deadlockExample() is commented out. Uncomment it to see the error yourself.
+
+		The fix : the send and the receive must happen in different goroutines, like we did in main.go with 'go func() { ch <- 42 }()'. Or, use a buffered channel (next section), which has space to keep the value.
+	*/
+}
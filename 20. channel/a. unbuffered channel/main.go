@@ -0,0 +1,55 @@
+//! Channel is a pipe that connects goroutines. One goroutine sends a value into the channel, another goroutine receives that value from the channel.
+//! Goroutines should not share memory to communicate, instead they should communicate (through channels) to share memory.
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//! timestamp() returns the current time with milliseconds, so that we can see WHEN each line was printed
+func timestamp() string {
+	return time.Now().Format("15:04:05.000")
+}
+
+func main() {
+	ch := make(chan int) //! 'make(chan int)' -> creates an unbuffered channel which can carry 'int' values. Unbuffered means : the channel has no space to store any value. A value can only pass when a sender and a receiver meet at the same time
+
+	go func() {
+		fmt.Println(timestamp(), "goroutine : going to send 42")
+		ch <- 42 //! '<-' on the right side of the channel -> send. This line will BLOCK (wait) until someone receives from 'ch'
+		fmt.Println(timestamp(), "goroutine : send finished, main has received the value")
+	}()
+
+	time.Sleep(1 * time.Second) //! main is doing some other work for 1 second. During this time, the goroutine is stuck at 'ch <- 42' because nobody is receiving
+
+	fmt.Println(timestamp(), "main      : going to receive")
+	value := <-ch //! '<-' on the left side of the channel -> receive. This line will BLOCK until someone sends into 'ch'
+	fmt.Println(timestamp(), "main      : received", value)
+
+	time.Sleep(100 * time.Millisecond) //! giving the goroutine a moment to print its last line
+
+	/*
+		Output :
+
+		10:00:00.000 goroutine : going to send 42
+		10:00:01.000 main      : going to receive
+		10:00:01.000 main      : received 42
+		10:00:01.000 goroutine : send finished, main has received the value
+
+		Look at the timestamps. The goroutine wanted to send at 10:00:00.000, but the send finished only at 10:00:01.000 -> exactly when main received. That 1 second the goroutine was blocked.
+		So, an unbuffered channel is also a synchronization point : sender and receiver wait for each other. This is called 'synchronous' communication.
+	*/
+
+	fmt.Println("--------------------------------")
+
+	// deadlockExample() //! uncomment this line to see the deadlock, written in 'deadlock.go'
+
+	sendPerson() //! written in 'person.go'
+}
+
+/*
+	This directory has 3 files of the same 'main' package, so run all of them together :
+
+	go run main.go deadlock.go person.go
+*/
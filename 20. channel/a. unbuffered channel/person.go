@@ -0,0 +1,27 @@
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! channels are not only for 'int' or 'string'. A channel can carry any type, including our own struct types
+func sendPerson() {
+	people := make(chan Person) //! a channel which carries 'Person' values
+
+	go func() {
+		people <- Person{Name: "John", Age: 20, Email: "john@example.com"} //! the whole Person struct is copied into the channel, just like passing a struct to a function (pass by value)
+		people <- Person{Name: "Jane", Age: 21, Email: "jane@example.com"}
+	}()
+
+	person1 := <-people //! receives the first Person
+	person2 := <-people //! receives the second Person
+
+	fmt.Println(`Person Name :`, person1.Name, `Person Age :`, person1.Age, `Person Email :`, person1.Email)
+	fmt.Println(`Person Name :`, person2.Name, `Person Age :`, person2.Age, `Person Email :`, person2.Email)
+
+	//! values arrive in the same order as they were sent -> First In First Out (FIFO)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func timestamp() string {
+	return time.Now().Format("15:04:05.000")
+}
+
+func main() {
+	//! buffered channel -> 'make(chan string, 3)'. The second argument is the capacity, just like 'make([]int, 3, 5)' in the slice section. The channel can keep 3 values inside without anyone receiving
+	ch := make(chan string, 3)
+
+	fmt.Println("len :", len(ch), "cap :", cap(ch)) //! len : 0 cap : 3
+
+	ch <- "a" //! doesn't block, there is free space in the buffer
+	fmt.Println("sent a -> len :", len(ch), "cap :", cap(ch))
+
+	ch <- "b"
+	fmt.Println("sent b -> len :", len(ch), "cap :", cap(ch))
+
+	ch <- "c"
+	fmt.Println("sent c -> len :", len(ch), "cap :", cap(ch))
+
+	/*
+		len(ch) -> how many values are waiting inside the buffer right now
+		cap(ch) -> how many values the buffer can hold in total
+
+		Same as slice : len is the number of elements used, cap is the number of elements available in the underlying storage.
+		Unlike slice, a channel's capacity never grows. When it is full, the sender has to wait.
+	*/
+
+	fmt.Println("--------------------------------")
+
+	//! the buffer is full now (len = 3 = cap). If we send the 4th value right now without any receiver, main will block forever -> deadlock :
+	// ch <- "d" //! fatal error: all goroutines are asleep - deadlock!
+
+	//! so, let's start a goroutine that drains (receives) one value after 1 second
+	go func() {
+		time.Sleep(1 * time.Second)
+		value := <-ch
+		fmt.Println(timestamp(), "drainer : received", value)
+	}()
+
+	fmt.Println(timestamp(), "main    : sending d, buffer is full, so waiting...")
+	ch <- "d" //! blocks here until the goroutine receives "a" and makes one free space
+	fmt.Println(timestamp(), "main    : sent d -> len :", len(ch), "cap :", cap(ch))
+
+	//! receiving the rest. Values come out in the same order they were sent -> First In First Out
+	fmt.Println(<-ch, <-ch, <-ch) //! b c d
+
+	fmt.Println("--------------------------------")
+
+	producerConsumer()
+}
+
+//! producer -> consumer with a buffer of 3. The producer is fast, the consumer is slow
+func producerConsumer() {
+	items := make(chan int, 3)
+	done := make(chan bool)
+
+	start := time.Now()
+
+	//! consumer
+	go func() {
+		for item := range items { //! keeps receiving until the channel is closed and empty
+			time.Sleep(200 * time.Millisecond) //! slow consumer
+			fmt.Printf("%8v consumer : processed %d (len : %d)\n", time.Since(start).Round(10*time.Millisecond), item, len(items))
+		}
+		done <- true
+	}()
+
+	//! producer
+	for i := 1; i <= 10; i++ {
+		items <- i //! the first few sends are instant (free space in the buffer). After that, every send has to wait until the consumer takes one value out
+		fmt.Printf("%8v producer : sent %d (len : %d)\n", time.Since(start).Round(10*time.Millisecond), i, len(items))
+	}
+	close(items) //! telling the consumer : "no more values will come"
+
+	<-done //! waiting for the consumer to finish
+
+	/*
+		Look at the printed time of the producer :
+
+		      0s producer : sent 1
+		      0s producer : sent 2
+		      0s producer : sent 3
+		      0s producer : sent 4
+		   200ms producer : sent 5
+		   400ms producer : sent 6
+		   ...
+
+		The first values are sent instantly, because the buffer (and the consumer's hand) has free space. After that, the producer can only send as fast as the consumer receives -> one value every 200ms. This is called 'backpressure' : a slow consumer automatically slows down a fast producer, and the buffer never grows beyond 3 values in memory.
+	*/
+}
@@ -0,0 +1,3 @@
+module localization
+
+go 1.22
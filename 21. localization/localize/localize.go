@@ -0,0 +1,130 @@
+//! Package localize -> the messages of an application in the user's own language. Every message lives in a 'catalog' file per language and is looked up by a key
+//! the lesson's main.go and '05. functions/c. function best practice' both import it
+package localize
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+//! '//go:embed' is a special comment (a directive). It tells the compiler to put the files that match 'catalogs/*.json' INSIDE the compiled binary. So, the program doesn't need the json files next to it at runtime
+//
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+//! DefaultLanguage -> the fallback of every missing message. Its catalog must exist
+const DefaultLanguage = "en"
+
+//! every catalog must have these keys. Other keys are optional and fall back to English
+var requiredKeys = []string{"welcome", "output", "goodbye"}
+
+//! catalogs -> language code ("en", "bn", "es") -> message key -> message template. Loaded once, when the package is initialized
+var catalogs, loadErr = loadCatalogs(catalogFiles)
+
+//! Check -> the error of loading the embedded catalogs, nil when they are fine. A program calls it first, so a broken catalog stops it before the first message
+func Check() error {
+	return loadErr
+}
+
+//! loadCatalogs reads every json file of the 'catalogs' directory and validates that the required keys are present
+//! it takes an fs.FS instead of using catalogFiles directly : the package passes the embedded files, a test can pass catalogs made in memory
+func loadCatalogs(files fs.FS) (map[string]map[string]string, error) {
+	entries, err := fs.ReadDir(files, "catalogs")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := map[string]map[string]string{}
+	for _, entry := range entries {
+		language := strings.TrimSuffix(entry.Name(), ".json") //! "bn.json" -> "bn"
+
+		data, err := fs.ReadFile(files, "catalogs/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("catalog %q: %v", entry.Name(), err)
+		}
+
+		for _, key := range requiredKeys {
+			if messages[key] == "" {
+				return nil, fmt.Errorf("catalog %q: missing required key %q", entry.Name(), key)
+			}
+		}
+
+		loaded[language] = messages
+	}
+
+	if _, ok := loaded[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("catalog for the default language %q is missing", DefaultLanguage)
+	}
+	return loaded, nil
+}
+
+//! Localize finds the message of 'key' in the catalog of 'lang' and replaces its {placeholders} with the values of 'vars'
+//! unknown language or a key missing in that language -> falls back to English
+func Localize(lang, key string, vars map[string]string) (string, error) {
+	if loadErr != nil {
+		return "", loadErr
+	}
+	template, ok := catalogs[lang][key] //! reading from a nil map (unknown language) is safe, it just gives "" and false
+	if !ok {
+		template, ok = catalogs[DefaultLanguage][key]
+		if !ok {
+			return "", fmt.Errorf("unknown message key %q", key)
+		}
+	}
+	return fillPlaceholders(template, vars)
+}
+
+//! fillPlaceholders replaces every {name} in the template with vars["name"]. Placeholders are matched by name, not by position, so a translation can move them anywhere in the sentence
+func fillPlaceholders(template string, vars map[string]string) (string, error) {
+	var result strings.Builder
+
+	for {
+		start := strings.Index(template, "{")
+		if start == -1 {
+			result.WriteString(template)
+			return result.String(), nil
+		}
+
+		end := strings.Index(template[start:], "}")
+		if end == -1 {
+			return "", fmt.Errorf("unclosed placeholder in %q", template)
+		}
+		end = start + end
+
+		name := template[start+1 : end]
+		value, ok := vars[name]
+		if !ok {
+			return "", fmt.Errorf("no value for placeholder {%s}", name)
+		}
+
+		result.WriteString(template[:start]) //! strings are indexed by bytes, but '{' and '}' are single byte characters, so slicing around them never cuts a Bengali letter in half
+		result.WriteString(value)
+		template = template[end+1:]
+	}
+}
+
+//! ChooseLanguage -> the -lang flag wins. If it is not given, the LANG environment variable is used ("bn_BD.UTF-8" -> "bn"). If nothing is set, English
+func ChooseLanguage(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if env := os.Getenv("LANG"); env != "" {
+		prefix := strings.ToLower(strings.SplitN(env, "_", 2)[0]) //! "bn_BD.UTF-8" -> "bn"
+		prefix = strings.SplitN(prefix, ".", 2)[0]                //! "C.UTF-8" -> "c"
+		if _, ok := catalogs[prefix]; ok {
+			return prefix
+		}
+	}
+
+	return DefaultLanguage
+}
@@ -0,0 +1,173 @@
+package localize
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalize(t *testing.T) {
+	vars := map[string]string{"name": "John", "sum": "30"}
+
+	tests := []struct {
+		name string
+		lang string
+		key  string
+		want string
+	}{
+		{"english", "en", "output", "Hello John! The sum is 30!"},
+		{"bengali", "bn", "output", "হ্যালো John! যোগফল হলো 30!"},
+		{"spanish", "es", "output", "¡Hola John! ¡La suma es 30!"},
+		{"no placeholders", "bn", "welcome", "অ্যাপ্লিকেশনে আপনাকে স্বাগতম।"},
+		{"key missing in es falls back to english", "es", "enterName", "Enter your name: "},
+		{"unknown language falls back to english", "fr", "output", "Hello John! The sum is 30!"},
+		{"empty language falls back to english", "", "goodbye", "Thank you for using the application, John!"},
+		{"language codes are case sensitive", "BN", "welcome", "Welcome to the application."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Localize(tt.lang, tt.key, vars)
+			if err != nil || got != tt.want {
+				t.Errorf("Localize(%q, %q) = %q, %v; want %q, nil", tt.lang, tt.key, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		key     string
+		vars    map[string]string
+		wantErr string
+	}{
+		{"unknown key", "en", "doesNotExist", nil, `unknown message key "doesNotExist"`},
+		{"unknown key in an unknown language", "fr", "doesNotExist", nil, `unknown message key "doesNotExist"`},
+		{"missing variable", "bn", "output", map[string]string{"name": "John"}, "no value for placeholder {sum}"},
+		{"no variables at all", "es", "goodbye", nil, "no value for placeholder {name}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Localize(tt.lang, tt.key, tt.vars)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Localize(%q, %q) = %q, %v; want error %q", tt.lang, tt.key, got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		vars     map[string]string
+		want     string
+		wantErr  string
+	}{
+		{"no placeholders", "Hello!", nil, "Hello!", ""},
+		{"one placeholder", "Hello {name}!", map[string]string{"name": "John"}, "Hello John!", ""},
+		{"moved by the translation", "{sum} is the sum, {name}", map[string]string{"name": "John", "sum": "30"}, "30 is the sum, John", ""},
+		{"the same placeholder twice", "{x}-{x}", map[string]string{"x": "1"}, "1-1", ""},
+		{"bengali around the placeholder", "হ্যালো {name}! যোগফল হলো {sum}!", map[string]string{"name": "জন", "sum": "৩০"}, "হ্যালো জন! যোগফল হলো ৩০!", ""},
+		{"bengali value in an english template", "Hello {name}!", map[string]string{"name": "ফয়জুল"}, "Hello ফয়জুল!", ""},
+		{"bengali placeholder name", "{নাম}!", map[string]string{"নাম": "John"}, "John!", ""},
+		{"a value with braces is not filled again", "{a}", map[string]string{"a": "{b}", "b": "no"}, "{b}", ""},
+		{"extra vars are ignored", "Hi", map[string]string{"unused": "x"}, "Hi", ""},
+		{"empty value", "[{name}]", map[string]string{"name": ""}, "[]", ""},
+		{"unclosed placeholder", "Hello {name", map[string]string{"name": "John"}, "", `unclosed placeholder in "Hello {name"`},
+		{"missing value", "Hello {name}", nil, "", "no value for placeholder {name}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fillPlaceholders(tt.template, tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("fillPlaceholders(%q) = %q, %v; want error %q", tt.template, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("fillPlaceholders(%q) = %q, %v; want %q, nil", tt.template, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCatalogs(t *testing.T) {
+	const valid = `{"welcome": "w", "output": "o", "goodbye": "g"}`
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		wantErr string
+	}{
+		{"valid", fstest.MapFS{"catalogs/en.json": {Data: []byte(valid)}, "catalogs/bn.json": {Data: []byte(valid)}}, ""},
+		{"optional keys may be missing", fstest.MapFS{"catalogs/en.json": {Data: []byte(`{"welcome": "w", "output": "o", "goodbye": "g", "enterName": "e"}`)}, "catalogs/es.json": {Data: []byte(valid)}}, ""},
+		{"missing required key", fstest.MapFS{"catalogs/en.json": {Data: []byte(valid)}, "catalogs/es.json": {Data: []byte(`{"welcome": "w", "output": "o"}`)}}, `catalog "es.json": missing required key "goodbye"`},
+		{"empty required message", fstest.MapFS{"catalogs/en.json": {Data: []byte(`{"welcome": "", "output": "o", "goodbye": "g"}`)}}, `catalog "en.json": missing required key "welcome"`},
+		{"broken json", fstest.MapFS{"catalogs/en.json": {Data: []byte(`{"welcome": `)}}, `catalog "en.json": unexpected end of JSON input`},
+		{"not a string", fstest.MapFS{"catalogs/en.json": {Data: []byte(`{"welcome": 1}`)}}, `catalog "en.json": json: cannot unmarshal number`},
+		{"no english catalog", fstest.MapFS{"catalogs/bn.json": {Data: []byte(valid)}}, `catalog for the default language "en" is missing`},
+		{"no catalogs directory", fstest.MapFS{}, "open catalogs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := loadCatalogs(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("loadCatalogs error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				if loaded != nil {
+					t.Errorf("loadCatalogs = %v, want nil with an error", loaded)
+				}
+				return
+			}
+			if err != nil || len(loaded) != len(tt.files) {
+				t.Errorf("loadCatalogs = %d catalogs, %v; want %d, nil", len(loaded), err, len(tt.files))
+			}
+		})
+	}
+}
+
+//! the embedded catalogs themselves : all three load, and es really misses enterName (the fallback example of the lesson)
+func TestEmbeddedCatalogs(t *testing.T) {
+	if err := Check(); err != nil {
+		t.Fatalf("Check() = %v, want nil", err)
+	}
+	for _, lang := range []string{"en", "bn", "es"} {
+		if _, ok := catalogs[lang]; !ok {
+			t.Errorf("no embedded catalog for %q", lang)
+		}
+	}
+	if _, ok := catalogs["es"]["enterName"]; ok {
+		t.Error(`catalogs["es"] has "enterName", the fallback example needs it missing`)
+	}
+}
+
+//! -lang wins over LANG, LANG wins over the default. An unknown LANG prefix gives English, an unknown -lang is kept (Localize falls back later)
+func TestChooseLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{"nothing set", "", "", "en"},
+		{"flag only", "bn", "", "bn"},
+		{"env only", "", "bn_BD.UTF-8", "bn"},
+		{"flag wins over env", "es", "bn_BD.UTF-8", "es"},
+		{"env without a region", "", "es", "es"},
+		{"env in upper case", "", "ES_es.UTF-8", "es"},
+		{"env with only an encoding", "", "C.UTF-8", "en"},
+		{"env with an unknown language", "", "fr_FR.UTF-8", "en"},
+		{"unknown flag is kept", "fr", "bn_BD.UTF-8", "fr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LANG", tt.env)
+			if got := ChooseLanguage(tt.flag); got != tt.want {
+				t.Errorf("ChooseLanguage(%q) with LANG=%q = %q, want %q", tt.flag, tt.env, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+//! Localization -> showing the messages of an application in the user's own language. Instead of writing "Welcome to the application." directly inside the code, we keep every message in a 'catalog' file per language and look them up by a key.
+//! the catalogs and Localize live in the 'localize' package, so other lessons can import them too
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"localization/localize"
+)
+
+//! printMessage -> the same job as the print functions of '05. functions/c. function best practice', which read this catalog too
+func printMessage(lang, key string, vars map[string]string) {
+	message, err := localize.Localize(lang, key, vars)
+	if err != nil {
+		fmt.Println("localization error :", err)
+		return
+	}
+	fmt.Println(message)
+}
+
+func main() {
+	langFlag := flag.String("lang", "", "language of the messages : en, bn or es")
+	flag.Parse()
+
+	if err := localize.Check(); err != nil {
+		fmt.Println("could not load catalogs :", err)
+		os.Exit(1)
+	}
+
+	lang := localize.ChooseLanguage(*langFlag)
+	fmt.Println("language :", lang)
+	fmt.Println("--------------------------------")
+
+	//! the calculator flow of '05. functions/c', with fixed input so that we can focus on the messages
+	name := "John"
+	sum := 10 + 20
+
+	printMessage(lang, "welcome", nil)
+	printMessage(lang, "enterName", nil) //! "es" catalog doesn't have this key -> English is printed
+	printMessage(lang, "output", map[string]string{"name": name, "sum": fmt.Sprint(sum)})
+	printMessage(lang, "goodbye", map[string]string{"name": name})
+
+	fmt.Println("--------------------------------")
+
+	//! every language one after another
+	for _, language := range []string{"en", "bn", "es", "fr"} { //! "fr" has no catalog -> English
+		message, _ := localize.Localize(language, "output", map[string]string{"name": "Faizul", "sum": "30"})
+		fmt.Println(language, ":", message)
+	}
+}
+
+/*
+	Try :
+
+	go run .
+	go run . -lang=bn
+	go run . -lang=es
+	LANG=bn_BD.UTF-8 go run .
+	LANG=bn_BD.UTF-8 go run . -lang=es    -> the flag wins over LANG
+*/
@@ -0,0 +1,168 @@
+//! In this section we will encode 100,000 Person values to JSON in three different ways and measure how much time and how many memory allocations each way needs.
+//! Allocation -> every time the program asks for new memory from the Heap. Each allocation costs time, and later the Garbage Collector has to clean it up. So, fewer allocations = less work for the program and for the Garbage Collector.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func generatePeople(n int) []Person {
+	people := make([]Person, n) //! preallocated, so that generating the data doesn't disturb our measurement later
+	for i := 0; i < n; i++ {
+		people[i] = Person{
+			Name:  "Person " + strconv.Itoa(i),
+			Age:   18 + i%60,
+			Email: "person" + strconv.Itoa(i) + "@example.com",
+		}
+	}
+	return people
+}
+
+//! 1. baseline -> json.Marshal builds the whole JSON in memory and returns it
+func encodeWithMarshal(people []Person) ([]byte, error) {
+	return json.Marshal(people)
+}
+
+//! 2. json.Encoder -> writes every Person to a bufio.Writer one by one. bufio.Writer collects small writes in a 4KB buffer and sends them to the real writer in big pieces
+func encodeWithEncoder(w io.Writer, people []Person) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	buffered.WriteByte('[')
+	for i, person := range people {
+		if i > 0 {
+			buffered.WriteByte(',')
+		}
+		if err := encoder.Encode(person); err != nil { //! Encode adds a '\n' after every value, which is valid whitespace inside a JSON array
+			return err
+		}
+	}
+	buffered.WriteByte(']')
+
+	return buffered.Flush() //! don't forget Flush, otherwise the last bytes stay inside the buffer
+}
+
+//! 3. hand-rolled -> we write the bytes ourselves. 'dst' is a slice we keep appending to, exactly like the append() lesson. If 'dst' already has enough capacity, NOTHING is allocated
+func AppendJSON(dst []byte, p Person) []byte {
+	dst = append(dst, `{"Name":`...)
+	dst = appendString(dst, p.Name)
+	dst = append(dst, `,"Age":`...)
+	dst = strconv.AppendInt(dst, int64(p.Age), 10) //! strconv.Append* writes the number directly into 'dst', no temporary string is created like strconv.Itoa would do
+	dst = append(dst, `,"Email":`...)
+	dst = appendString(dst, p.Email)
+	dst = append(dst, '}')
+	return dst
+}
+
+const hexDigits = "0123456789abcdef"
+
+//! appendString writes a JSON string with the necessary escaping : " and \ must be escaped, and control characters (below 0x20) are not allowed raw inside a JSON string
+func appendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c < utf8.RuneSelf { //! a single byte (ASCII) character
+			switch {
+			case c == '"' || c == '\\':
+				dst = append(dst, '\\', c)
+			case c == '\n':
+				dst = append(dst, '\\', 'n')
+			case c == '\r':
+				dst = append(dst, '\\', 'r')
+			case c == '\t':
+				dst = append(dst, '\\', 't')
+			case c < 0x20:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF]) //! for example 0x01 -> \u0001
+			default:
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, `\ufffd`...) //! invalid UTF-8 byte -> replacement character, same as encoding/json does
+		} else {
+			dst = append(dst, s[i:i+size]...) //! valid multi-byte character (for example Bengali letters), copied as it is
+		}
+		i += size
+	}
+	return append(dst, '"')
+}
+
+func encodeHandRolled(dst []byte, people []Person) []byte {
+	dst = append(dst, '[')
+	for i, person := range people {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = AppendJSON(dst, person)
+	}
+	return append(dst, ']')
+}
+
+func main() {
+	//! 1. allocations per single Person. testing.AllocsPerRun runs the function many times and returns the average number of allocations per run. It's in the 'testing' package, but we can call it from a normal program too
+	person := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	buffer := make([]byte, 0, 256) //! reused buffer with enough capacity
+
+	marshalAllocs := testing.AllocsPerRun(1000, func() {
+		json.Marshal(person)
+	})
+	encoderAllocs := testing.AllocsPerRun(1000, func() {
+		json.NewEncoder(io.Discard).Encode(person)
+	})
+	handRolledAllocs := testing.AllocsPerRun(1000, func() {
+		buffer = AppendJSON(buffer[:0], person) //! buffer[:0] -> length 0 but the capacity is kept, so the old memory is reused
+	})
+
+	//! 2. time for 100,000 people
+	people := generatePeople(100000)
+
+	start := time.Now()
+	output1, _ := encodeWithMarshal(people)
+	marshalTime := time.Since(start)
+
+	var output2 bytes.Buffer
+	output2.Grow(len(output1) + len(people)) //! enough space for the data plus the '\n' after each value
+	start = time.Now()
+	encodeWithEncoder(&output2, people)
+	encoderTime := time.Since(start)
+
+	output3 := make([]byte, 0, len(output1))
+	start = time.Now()
+	encodeHandRolled(output3, people) //! the three outputs are compared in main_test.go
+	handRolledTime := time.Since(start)
+
+	//! 3. comparison table
+	fmt.Printf("%-28s %18s %16s\n", "approach", "allocs per Person", "100k people")
+	fmt.Printf("%-28s %18.0f %16v\n", "json.Marshal", marshalAllocs, marshalTime.Round(time.Microsecond))
+	fmt.Printf("%-28s %18.0f %16v\n", "json.Encoder + bufio.Writer", encoderAllocs, encoderTime.Round(time.Microsecond))
+	fmt.Printf("%-28s %18.0f %16v\n", "AppendJSON (hand-rolled)", handRolledAllocs, handRolledTime.Round(time.Microsecond))
+
+	/*
+		Why is the hand-rolled version so cheap?
+
+		1. json.Marshal uses reflection to find the fields of Person at runtime, and returns a brand new []byte every call.
+		2. json.Encoder avoids returning a new slice, but still uses reflection and its own internal buffers.
+		3. AppendJSON knows the fields at compile time and appends into a slice that we reuse. With enough capacity, it allocates nothing at all.
+
+		But, the hand-rolled version must be updated by hand every time Person changes, and escaping bugs are easy to make. So, measure first, and only hand-roll the really hot paths.
+	*/
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+)
+
+//! decode -> the JSON back into []Person. Byte-by-byte comparison would fail for meaningless differences like the '\n' the Encoder adds
+func decode(t *testing.T, data []byte) []Person {
+	t.Helper()
+	var people []Person
+	if err := json.Unmarshal(data, &people); err != nil {
+		t.Fatalf("invalid JSON : %v\n%s", err, data)
+	}
+	return people
+}
+
+func TestEncodersAgree(t *testing.T) {
+	tests := []struct {
+		name   string
+		people []Person
+	}{
+		{"empty", []Person{}},
+		{"quotes", []Person{{Name: `John "The Gopher" Doe`, Age: 20, Email: "john@example.com"}}},
+		{"backslashes", []Person{{Name: `C:\Users\jane`, Age: 21, Email: "jane@example.com"}}},
+		{"control characters", []Person{{Name: "Tab\tNew\nLine\rBell\x07\x01\x1f", Age: 22, Email: "ctrl@example.com"}}},
+		{"html characters", []Person{{Name: "<b>&</b>", Age: 23, Email: "html@example.com"}}},
+		{"bengali", []Person{{Name: "ফয়জুল", Age: 24, Email: "bangla@example.com"}}},
+		{"empty strings and zero age", []Person{{}}},
+		{"negative age", []Person{{Name: "x", Age: -1}}},
+		{"1000 generated people", generatePeople(1000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marshaled, err := encodeWithMarshal(tt.people)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var encoded bytes.Buffer
+			if err := encodeWithEncoder(&encoded, tt.people); err != nil {
+				t.Fatal(err)
+			}
+			handRolled := encodeHandRolled(nil, tt.people)
+
+			want := decode(t, marshaled)
+			if got := decode(t, encoded.Bytes()); !reflect.DeepEqual(got, want) {
+				t.Errorf("Encoder = %v, want %v", got, want)
+			}
+			if got := decode(t, handRolled); !reflect.DeepEqual(got, want) {
+				t.Errorf("hand-rolled = %v, want %v\n%s", got, want, handRolled)
+			}
+			if !reflect.DeepEqual(want, tt.people) {
+				t.Errorf("Marshal round trip = %v, want %v", want, tt.people)
+			}
+		})
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "John", `"John"`},
+		{"empty", "", `""`},
+		{"quote", `a"b`, `"a\"b"`},
+		{"backslash", `a\b`, `"a\\b"`},
+		{"new line, tab, carriage return", "\n\t\r", `"\n\t\r"`},
+		{"other control characters", "\x00\x01\x1f", `"\u0000\u0001\u001f"`},
+		{"0x7f is not a control character in JSON", "\x7f", "\"\x7f\""},
+		{"bengali is copied as it is", "ফয়জুল", `"ফয়জুল"`},
+		{"invalid UTF-8", "a\xffb", `"a\ufffdb"`},
+		{"cut multi-byte character", "\xe0\xa6", `"\ufffd\ufffd"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendString(nil, tt.input)
+			if string(got) != tt.want {
+				t.Errorf("appendString(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+			//! and encoding/json reads back the same text (invalid bytes become U+FFFD, like json.Marshal does)
+			var decoded string
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatalf("invalid JSON string %s : %v", got, err)
+			}
+			var viaMarshal string
+			marshaled, _ := json.Marshal(tt.input)
+			json.Unmarshal(marshaled, &viaMarshal)
+			if decoded != viaMarshal {
+				t.Errorf("decoded = %q, json.Marshal gives %q", decoded, viaMarshal)
+			}
+		})
+	}
+}
+
+//! AppendJSON appends : what is already in dst stays there
+func TestAppendJSONKeepsDst(t *testing.T) {
+	got := AppendJSON([]byte("prefix "), Person{Name: "John", Age: 20, Email: "john@example.com"})
+	want := `prefix {"Name":"John","Age":20,"Email":"john@example.com"}`
+	if string(got) != want {
+		t.Errorf("AppendJSON = %s, want %s", got, want)
+	}
+}
+
+//! the allocations are part of the contract : a change that makes AppendJSON allocate fails the test
+func TestAllocations(t *testing.T) {
+	person := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	buffer := make([]byte, 0, 256)
+
+	marshalAllocs := testing.AllocsPerRun(1000, func() {
+		json.Marshal(person)
+	})
+	handRolledAllocs := testing.AllocsPerRun(1000, func() {
+		buffer = AppendJSON(buffer[:0], person)
+	})
+
+	if handRolledAllocs != 0 {
+		t.Errorf("AppendJSON with a reused buffer = %.0f allocs per run, want 0", handRolledAllocs)
+	}
+	const atLeast = 2
+	if handRolledAllocs*atLeast > marshalAllocs || marshalAllocs == 0 {
+		t.Errorf("AppendJSON = %.0f allocs, json.Marshal = %.0f; want at least %dx fewer", handRolledAllocs, marshalAllocs, atLeast)
+	}
+}
+
+var benchPeople = generatePeople(100_000)
+
+//! one op encodes all 100k people
+func BenchmarkEncode(b *testing.B) {
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encodeWithMarshal(benchPeople)
+		}
+	})
+	b.Run("Encoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encodeWithEncoder(io.Discard, benchPeople)
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		var buffer []byte
+		for i := 0; i < b.N; i++ {
+			buffer = encodeHandRolled(buffer[:0], benchPeople) //! the buffer grows in the first op, then it's reused
+		}
+	})
+}
+
+//! one op encodes one Person
+func BenchmarkPerson(b *testing.B) {
+	person := Person{Name: `John "The Gopher" Doe`, Age: 20, Email: "john@example.com"}
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			json.Marshal(person)
+		}
+	})
+	b.Run("Encoder", func(b *testing.B) {
+		b.ReportAllocs()
+		encoder := json.NewEncoder(io.Discard)
+		for i := 0; i < b.N; i++ {
+			encoder.Encode(person)
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		buffer := make([]byte, 0, 256)
+		for i := 0; i < b.N; i++ {
+			buffer = AppendJSON(buffer[:0], person)
+		}
+	})
+}
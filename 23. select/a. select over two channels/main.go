@@ -0,0 +1,50 @@
+//! 'select' is like a 'switch', but for channels. Each 'case' is a send or a receive. select waits until ONE of the cases is ready and then runs only that case.
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func main() {
+	fast := make(chan string)
+	slow := make(chan string)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		fast <- "message from fast (after 100ms)"
+	}()
+
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		slow <- "message from slow (after 300ms)"
+	}()
+
+	start := time.Now()
+
+	//! we expect 2 messages, so select 2 times
+	for i := 1; i <= 2; i++ {
+		select {
+		case message := <-fast: //! ready after 100ms
+			fmt.Println("fast case fired :", message, "| waited", time.Since(start).Round(time.Millisecond))
+		case message := <-slow: //! ready after 300ms
+			fmt.Println("slow case fired :", message, "| waited", time.Since(start).Round(time.Millisecond))
+		}
+	}
+
+	/*
+		Output :
+
+		fast case fired : message from fast (after 100ms) | waited 100ms
+		slow case fired : message from slow (after 300ms) | waited 300ms
+
+		In the first round, none of the channels is ready, so select blocks. After 100ms 'fast' becomes ready -> the fast case fires.
+		In the second round, only 'slow' can still send -> after 300ms (in total) the slow case fires.
+
+		Notice, the total time is 300ms, not 100ms + 300ms = 400ms. Both goroutines were sleeping at the same time.
+
+		If we received with '<-fast' and then '<-slow' one after another (without select), it would work here too. But, if the order were different (slow first, then fast), we would be stuck waiting on 'slow' while 'fast' is already waiting to be received. select receives from whichever channel is ready first.
+
+		If more than one case is ready at the same moment, select picks one of them randomly. There is no priority based on the order of the cases.
+	*/
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//! slowOperation sends its result after 'duration'
+func slowOperation(duration time.Duration) chan string {
+	result := make(chan string, 1) //! buffer of 1 -> the goroutine can send and finish even if nobody receives anymore (after a timeout). With an unbuffered channel, it would be stuck forever
+	go func() {
+		time.Sleep(duration)
+		result <- fmt.Sprint("finished after ", duration)
+	}()
+	return result
+}
+
+func waitWithTimeout(result chan string) {
+	select {
+	case message := <-result:
+		fmt.Println("result case fired  :", message)
+	case <-time.After(500 * time.Millisecond): //! time.After returns a channel which receives a value after 500ms
+		fmt.Println("timeout case fired : no result within 500ms, giving up")
+	}
+}
+
+func main() {
+	waitWithTimeout(slowOperation(200 * time.Millisecond)) //! 200ms < 500ms -> the result wins
+	fmt.Println("--------------------------------")
+	waitWithTimeout(slowOperation(2 * time.Second)) //! 2s > 500ms -> the timeout wins
+
+	/*
+		Output :
+
+		result case fired  : finished after 200ms
+		--------------------------------
+		timeout case fired : no result within 500ms, giving up
+
+		time.After(500 * time.Millisecond) is just another channel. select doesn't know anything special about timeouts. Whichever channel is ready first, that case fires. That's why adding a timeout to any channel operation is so easy in Go.
+	*/
+}
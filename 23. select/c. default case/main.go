@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//! tryReceive never blocks. If a value is waiting, it takes it. If not, 'default' runs immediately
+func tryReceive(ch chan string) {
+	select {
+	case message := <-ch:
+		fmt.Println("receive case fired :", message)
+	default:
+		fmt.Println("default case fired : nothing to receive right now, not waiting")
+	}
+}
+
+//! trySend never blocks either. If the buffer is full, the value is dropped
+func trySend(ch chan string, message string) {
+	select {
+	case ch <- message:
+		fmt.Println("send case fired    :", message, "was sent")
+	default:
+		fmt.Println("default case fired :", message, "was dropped, the buffer is full")
+	}
+}
+
+func main() {
+	ch := make(chan string, 1)
+
+	tryReceive(ch) //! empty channel -> default
+
+	trySend(ch, "first")  //! free space -> sent
+	trySend(ch, "second") //! buffer (of 1) is full -> default
+
+	tryReceive(ch) //! "first" is waiting -> received
+
+	fmt.Println("--------------------------------")
+
+	//! polling : doing some work while checking the channel from time to time
+	done := make(chan bool)
+	go func() {
+		time.Sleep(350 * time.Millisecond)
+		done <- true
+	}()
+
+	for {
+		select {
+		case <-done:
+			fmt.Println("done case fired    : work is finished")
+			return
+		default:
+			fmt.Println("default case fired : still working...")
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	/*
+		Without 'default', select waits until one case is ready.
+		With 'default', select never waits. If no case is ready at this exact moment, 'default' runs.
+
+		Be careful : a 'for' loop with a select that has 'default' and no sleep will spin and use 100% of a CPU core. Only use it when you really have other work to do.
+	*/
+}
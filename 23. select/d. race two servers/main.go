@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//! raceTwoServers returns the response of whichever server answers first
+//! it only receives channels, it doesn't start any server itself. So, later, a test can pass its own channels and control exactly which one answers first
+func raceTwoServers(fast, slow chan string) string {
+	select {
+	case response := <-fast:
+		return response
+	case response := <-slow:
+		return response
+	}
+}
+
+//! fakeServer answers after 'delay'. The channel has a buffer of 1, so the loser can still send its answer and its goroutine can finish (no goroutine is stuck forever)
+func fakeServer(name string, delay time.Duration) chan string {
+	response := make(chan string, 1)
+	go func() {
+		time.Sleep(delay)
+		response <- fmt.Sprint(name, " answered after ", delay)
+	}()
+	return response
+}
+
+func main() {
+	winner := raceTwoServers(fakeServer("server A", 50*time.Millisecond), fakeServer("server B", 300*time.Millisecond))
+	fmt.Println("winner :", winner, "-> server A was faster")
+
+	//! the parameter names are only names. If the 'slow' one answers first, it wins
+	winner = raceTwoServers(fakeServer("server A", 400*time.Millisecond), fakeServer("server B", 100*time.Millisecond))
+	fmt.Println("winner :", winner, "-> server B was faster")
+
+	//! a test can decide the winner without any sleeping at all
+	ready := make(chan string, 1)
+	never := make(chan string) //! nobody sends to this channel
+	ready <- "prepared answer"
+	fmt.Println("winner :", raceTwoServers(never, ready), "-> the only channel with a value")
+
+	/*
+		This is a common real world pattern : send the same request to two replicas (or two mirrors) and use the first answer. The user gets the speed of the fastest server.
+	*/
+}
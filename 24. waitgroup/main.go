@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+//! sequential version -> one loop, one goroutine (main). We will use it to verify the parallel result
+func calculateSum(numbers []int) int {
+	sum := 0
+	for _, number := range numbers {
+		sum = sum + number
+	}
+	return sum
+}
+
+//! sumChunk sums one part of the array and sends the partial sum into 'results'
+//! 'wg *sync.WaitGroup' -> a POINTER to the WaitGroup. Every goroutine must call Done() on the SAME WaitGroup that main is waiting on
+func sumChunk(chunk []int, wg *sync.WaitGroup, results chan int) {
+	defer wg.Done()
+	results <- calculateSum(chunk)
+}
+
+func main() {
+	//! the same kind of array as the array section, just a little bigger
+	arr := [10]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	numbers := arr[:] //! slicing the whole array -> a slice, so that we can cut it into chunks easily
+
+	chunkSize := 3
+	chunkCount := (len(numbers) + chunkSize - 1) / chunkSize //! rounding up -> 10 numbers, 3 per chunk = 4 chunks
+
+	var wg sync.WaitGroup
+	results := make(chan int, chunkCount) //! buffered with room for every partial sum, so no goroutine has to wait to send
+
+	for start := 0; start < len(numbers); start = start + chunkSize {
+		end := start + chunkSize
+		if end > len(numbers) {
+			end = len(numbers) //! the last chunk can be smaller -> [10]
+		}
+		chunk := numbers[start:end]
+
+		wg.Add(1) //! Add BEFORE starting the goroutine
+		fmt.Println("starting goroutine for chunk", chunk)
+		go sumChunk(chunk, &wg, results) //! '&wg' -> passing the address of the WaitGroup
+	}
+
+	wg.Wait()      //! waiting until every sumChunk has called Done()
+	close(results) //! every sender has finished, so it is safe to close. Now 'range results' below will stop after the last value
+
+	total := 0
+	for partialSum := range results {
+		fmt.Println("partial sum :", partialSum)
+		total = total + partialSum
+	}
+
+	fmt.Println("--------------------------------")
+	fmt.Println("parallel total   :", total)
+	fmt.Println("sequential total :", calculateSum(numbers))
+	fmt.Println("same result      :", total == calculateSum(numbers))
+
+	/*
+		Common mistake 1 : calling wg.Add inside the goroutine
+
+			for ... {
+				go func(chunk []int) {
+					wg.Add(1) //! WRONG
+					defer wg.Done()
+					results <- calculateSum(chunk)
+				}(chunk)
+			}
+			wg.Wait()
+
+		The goroutine may not have started yet when main reaches wg.Wait(). At that moment the counter is still 0, so Wait() returns immediately and main continues with missing partial sums. Always call Add in the goroutine that is going to Wait, before the 'go' statement.
+	*/
+
+	/*
+		Common mistake 2 : passing the WaitGroup by value
+
+			func sumChunk(chunk []int, wg sync.WaitGroup, results chan int) { //! WRONG -> no '*'
+				defer wg.Done()
+				results <- calculateSum(chunk)
+			}
+
+			go sumChunk(chunk, wg, results)
+
+		Remember the pass by value section : the function receives a COPY. Here, Done() is called on the copy, the original WaitGroup in main never goes down to 0, and wg.Wait() blocks forever :
+
+			fatal error: all goroutines are asleep - deadlock!
+
+		'go vet' catches this mistake : "sumChunk passes lock by value: sync.WaitGroup contains sync.noCopy". Pass the pointer (&wg), like the pass by reference section.
+	*/
+}
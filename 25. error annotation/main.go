@@ -0,0 +1,148 @@
+//! When an error travels up through many functions, the final message often doesn't tell WHERE it started. In this section we write small helpers, Wrap and Wrapf, which add the file name and line number of the place where they are called, and Trace, which lists all those places again.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+//! annotatedError keeps the original error inside ('err') plus a message and the location of the Wrap call
+type annotatedError struct {
+	message string
+	file    string
+	line    int
+	err     error
+}
+
+func (e *annotatedError) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %v", e.file, e.line, e.message, e.err)
+}
+
+//! Unwrap returns the inner error. errors.Is and errors.As call Unwrap again and again to walk through the chain, so wrapping doesn't hide the original error from them
+func (e *annotatedError) Unwrap() error {
+	return e.err
+}
+
+//! wrap does the real work. 'skip' tells runtime.Caller how many stack frames to go up : 0 = wrap itself, 1 = Wrap/Wrapf, 2 = the function that called Wrap/Wrapf
+func wrap(err error, message string, skip int) error {
+	if err == nil {
+		return nil //! nil in, nil out -> 'return Wrap(err, "...")' is safe even when everything went fine
+	}
+
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		file, line = "???", 0
+	}
+
+	return &annotatedError{
+		message: message,
+		file:    filepath.Base(file), //! "/home/user/learn-GoLang/25. error annotation/main.go" -> "main.go"
+		line:    line,
+		err:     err,
+	}
+}
+
+//! Wrap adds 'message' and the caller's file:line in front of 'err'
+func Wrap(err error, message string) error {
+	return wrap(err, message, 2)
+}
+
+//! Wrapf is Wrap with a format string, like fmt.Sprintf
+func Wrapf(err error, format string, args ...any) error {
+	return wrap(err, fmt.Sprintf(format, args...), 2)
+}
+
+//! Frame is one Wrap/Wrapf call site
+type Frame struct {
+	File    string
+	Line    int
+	Message string
+}
+
+//! Trace walks the chain from the outermost error to the innermost one and collects every annotation
+func Trace(err error) []Frame {
+	var frames []Frame
+	for err != nil {
+		if annotated, ok := err.(*annotatedError); ok {
+			frames = append(frames, Frame{File: annotated.file, Line: annotated.line, Message: annotated.message})
+		}
+		err = errors.Unwrap(err)
+	}
+	return frames
+}
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! a tiny CSV-like loader with three levels : loadPeople -> parsePerson -> parseAge
+
+func parseAge(field string) (int, error) {
+	age, err := strconv.Atoi(strings.TrimSpace(field))
+	if err != nil {
+		return 0, Wrapf(err, "invalid age %q", field)
+	}
+	return age, nil
+}
+
+func parsePerson(line string) (Person, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 3 {
+		return Person{}, Wrap(errors.New("expected 3 fields"), "parse person")
+	}
+
+	age, err := parseAge(fields[1])
+	if err != nil {
+		return Person{}, Wrap(err, "parse person")
+	}
+
+	return Person{Name: strings.TrimSpace(fields[0]), Age: age, Email: strings.TrimSpace(fields[2])}, nil
+}
+
+func loadPeople(lines []string) ([]Person, error) {
+	var people []Person
+	for i, line := range lines {
+		person, err := parsePerson(line)
+		if err != nil {
+			return nil, Wrapf(err, "line %d", i+1)
+		}
+		people = append(people, person)
+	}
+	return people, nil
+}
+
+func main() {
+	//! 1. everything fine -> no error
+	people, err := loadPeople([]string{"John, 20, john@example.com", "Jane, 21, jane@example.com"})
+	fmt.Println("loaded :", people, "error :", err)
+	fmt.Println("--------------------------------")
+
+	//! 2. the second line has a bad age
+	_, err = loadPeople([]string{"John, 20, john@example.com", "Jane, twenty, jane@example.com"})
+	fmt.Println("error :", err)
+	//! error : main.go:113: line 2: main.go:102: parse person: main.go:89: invalid age " twenty": strconv.Atoi: parsing "twenty": invalid syntax
+	fmt.Println("--------------------------------")
+
+	//! 3. Trace -> where did it happen? From the outermost (loadPeople) to the innermost (parseAge)
+	fmt.Println("trace :")
+	for i, frame := range Trace(err) {
+		fmt.Printf("  %d. %s:%-4d %s\n", i+1, frame.File, frame.Line, frame.Message)
+	}
+
+	/*
+		runtime.Caller(skip) looks at the call stack (remember the Stack from the internal memory section : every function call has its own stack frame) and returns the file and line number of the frame 'skip' levels above.
+
+		Wrap -> wrap -> runtime.Caller(2) :
+			0 = wrap
+			1 = Wrap
+			2 = the function that called Wrap (parseAge, parsePerson, loadPeople)
+
+		That's why both Wrap and Wrapf call wrap() directly. If Wrap called Wrapf, there would be one more frame in between and the line number would point inside Wrapf instead of the real caller.
+	*/
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//! here -> the line of the call to here, so a test can point at the line above it
+func here() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+//! the file:line in Error() is the line of the Wrap call, not a line inside Wrap or wrap
+func TestWrapLocation(t *testing.T) {
+	base := errors.New("boom")
+	tests := []struct {
+		name string
+		wrap func() (error, int)
+		want string
+	}{
+		{"Wrap", func() (error, int) {
+			err := Wrap(base, "loading")
+			return err, here() - 1
+		}, "loading: boom"},
+		{"Wrapf", func() (error, int) {
+			err := Wrapf(base, "line %d of %s", 3, "people.csv")
+			return err, here() - 1
+		}, "line 3 of people.csv: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, line := tt.wrap()
+			want := fmt.Sprintf("main_test.go:%d: %s", line, tt.want)
+			if err.Error() != want {
+				t.Errorf("Error() = %q, want %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := Wrap(nil, "nothing happened"); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+	if err := Wrapf(nil, "line %d", 1); err != nil {
+		t.Errorf("Wrapf(nil) = %v, want nil", err)
+	}
+	if frames := Trace(nil); frames != nil {
+		t.Errorf("Trace(nil) = %v, want nil", frames)
+	}
+}
+
+//! errors.Is and errors.As see the original error through three wraps, and through a fmt.Errorf %w in the middle too
+func TestIsAsThroughThreeWraps(t *testing.T) {
+	_, atoiErr := strconv.Atoi("twenty")
+	_, openErr := os.Open("does-not-exist.txt")
+	tests := []struct {
+		name   string
+		inner  error
+		target error
+	}{
+		{"strconv error", atoiErr, strconv.ErrSyntax},
+		{"file error", openErr, fs.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Wrap(Wrapf(fmt.Errorf("middle: %w", Wrap(tt.inner, "one")), "two"), "three")
+			if !errors.Is(err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.target)
+			}
+			if !errors.Is(err, tt.inner) {
+				t.Errorf("errors.Is(err, inner) = false, want true")
+			}
+		})
+	}
+
+	err := Wrap(Wrap(Wrap(atoiErr, "one"), "two"), "three")
+	var numError *strconv.NumError
+	if !errors.As(err, &numError) || numError.Func != "Atoi" || numError.Num != "twenty" {
+		t.Errorf("errors.As = %v, want the *strconv.NumError of Atoi(\"twenty\")", numError)
+	}
+	var pathError *fs.PathError
+	if errors.As(err, &pathError) {
+		t.Errorf("errors.As found a *fs.PathError in a strconv error chain : %v", pathError)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	inner := Wrap(errors.New("boom"), "innermost")
+	innerLine := here() - 1
+	middle := fmt.Errorf("not annotated: %w", inner) //! skipped by Trace, but the walk goes through it
+	outer := Wrapf(middle, "outermost %d", 1)
+	outerLine := here() - 1
+
+	want := []Frame{
+		{File: "main_test.go", Line: outerLine, Message: "outermost 1"},
+		{File: "main_test.go", Line: innerLine, Message: "innermost"},
+	}
+	if got := Trace(outer); !reflect.DeepEqual(got, want) {
+		t.Errorf("Trace = %+v, want %+v", got, want)
+	}
+	if got := Trace(errors.New("plain")); got != nil {
+		t.Errorf("Trace of a plain error = %+v, want nil", got)
+	}
+}
+
+//! the loader : every level adds its own frame, from loadPeople outside to parseAge inside
+func TestLoadPeople(t *testing.T) {
+	tests := []struct {
+		name         string
+		lines        []string
+		wantPeople   []Person
+		wantMessages []string
+	}{
+		{"valid", []string{"John, 20, john@example.com", "Jane, 21, jane@example.com"}, []Person{{"John", 20, "john@example.com"}, {"Jane", 21, "jane@example.com"}}, nil},
+		{"empty", nil, nil, nil},
+		{"bad age", []string{"John, 20, john@example.com", "Jane, twenty, jane@example.com"}, nil, []string{"line 2", "parse person", `invalid age " twenty"`}},
+		{"wrong number of fields", []string{"John, 20"}, nil, []string{"line 1", "parse person"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people, err := loadPeople(tt.lines)
+			if !reflect.DeepEqual(people, tt.wantPeople) {
+				t.Errorf("people = %v, want %v", people, tt.wantPeople)
+			}
+			var messages []string
+			for _, frame := range Trace(err) {
+				if frame.File != "main.go" || frame.Line == 0 {
+					t.Errorf("frame %+v, want a line in main.go", frame)
+				}
+				messages = append(messages, frame.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.wantMessages) {
+				t.Errorf("Trace messages = %q, want %q", messages, tt.wantMessages)
+			}
+			if tt.wantMessages != nil && !strings.HasPrefix(err.Error(), "main.go:") {
+				t.Errorf("Error() = %q, want it to start with main.go:", err)
+			}
+		})
+	}
+}
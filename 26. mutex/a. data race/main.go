@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+var counter int = 0 //! shared by every goroutine
+
+func increment(wg *sync.WaitGroup) {
+	defer wg.Done()
+	//! 'counter++' looks like one step, but it is actually three steps. Let's write those three steps separately so that we can see them :
+	value := counter  //! 1. read counter
+	value = value + 1 //! 2. add 1
+	runtime.Gosched() //! lets the scheduler run another goroutine right here, exactly between reading and writing. This makes the problem happen much more often, but even without this line the bug is there
+	counter = value   //! 3. write counter back
+}
+
+func main() {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go increment(&wg)
+	}
+
+	wg.Wait()
+
+	fmt.Println("expected : 1000")
+	fmt.Println("counter  :", counter) //! much less than 1000, for example 2 or 37. Run it a few times, the number changes
+
+	/*
+		Why is some increment lost? Two goroutines running at the same time :
+
+		goroutine A : reads counter  -> 5
+		goroutine B : reads counter  -> 5
+		goroutine A : adds 1         -> 6
+		goroutine B : adds 1         -> 6
+		goroutine A : writes counter -> 6
+		goroutine B : writes counter -> 6   //! two increments happened, but counter only went from 5 to 6
+
+		This is called a 'data race' : two or more goroutines access the same memory at the same time, and at least one of them is writing.
+		The result depends on the timing, so the bug appears sometimes and hides sometimes. That makes it one of the hardest bugs to find.
+	*/
+}
+
+/*
+	Now run with : go run -race main.go
+
+	The race detector watches every memory access while the program runs and prints a report like :
+
+	WARNING: DATA RACE
+	Write at 0x... by goroutine 785:
+	  main.increment()
+	      main.go:17
+	Previous read at 0x... by goroutine 703:
+	  main.increment()
+	      main.go:14
+*/
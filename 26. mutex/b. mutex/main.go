@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+var counter int = 0
+var mutex sync.Mutex //! mutex -> 'mutual exclusion'. Only one goroutine can hold (lock) it at a time
+
+func increment(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	mutex.Lock() //! if another goroutine holds the lock, wait here until it is unlocked
+
+	//! the same three steps as the data race example. Everything between Lock and Unlock is the 'critical section' : only one goroutine at a time can be here
+	value := counter
+	value = value + 1
+	runtime.Gosched() //! even if the scheduler switches to another goroutine here, that goroutine will wait at mutex.Lock()
+	counter = value
+
+	mutex.Unlock()
+}
+
+func main() {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go increment(&wg)
+	}
+
+	wg.Wait()
+
+	fmt.Println("expected : 1000")
+	fmt.Println("counter  :", counter) //! always 1000
+
+	/*
+		Now the three steps (read, add, write) can't be mixed between goroutines :
+
+		goroutine A : Lock
+		goroutine A : reads 5, adds 1, writes 6
+		goroutine B : Lock -> has to wait, A holds the lock
+		goroutine A : Unlock
+		goroutine B : Lock -> gets it now
+		goroutine B : reads 6, adds 1, writes 7
+		goroutine B : Unlock
+
+		Very often you will see it written like this :
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+		With defer, Unlock is called even if the function returns early or panics, so the lock is never forgotten.
+
+		Like the WaitGroup, a Mutex must never be copied. Pass it by pointer, or keep it as a global / inside a struct that is used by pointer.
+	*/
+}
+
+/*
+	Now run with : go run -race main.go
+
+	No 'WARNING: DATA RACE' this time.
+*/
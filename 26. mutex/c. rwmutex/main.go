@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! a small in-memory phone book of people, keyed by email
+var people = map[string]Person{
+	"john@example.com": {Name: "John", Age: 20, Email: "john@example.com"},
+	"jane@example.com": {Name: "Jane", Age: 21, Email: "jane@example.com"},
+}
+
+//! sync.RWMutex -> a 'read-write' mutex. It has two kinds of locks :
+//! RLock / RUnlock -> read lock. MANY goroutines can hold it at the same time
+//! Lock / Unlock   -> write lock. Only ONE goroutine, and no reader at the same time
+var rwMutex sync.RWMutex
+
+func readPerson(reader int, email string, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	rwMutex.RLock()
+	defer rwMutex.RUnlock()
+
+	person, ok := people[email]
+	time.Sleep(100 * time.Millisecond) //! pretending that reading takes some time
+	fmt.Printf("reader %d : %s -> %v (found : %v)\n", reader, email, person, ok)
+}
+
+func addPerson(person Person, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	rwMutex.Lock() //! waits until every reader has called RUnlock
+	defer rwMutex.Unlock()
+
+	people[person.Email] = person
+	fmt.Println("writer   : added", person.Name)
+}
+
+func main() {
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	//! 5 readers at the same time
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go readPerson(i, "john@example.com", &wg)
+	}
+	wg.Wait()
+	fmt.Println("5 readers took :", time.Since(start).Round(10*time.Millisecond)) //! ~100ms, not 500ms -> the readers did NOT wait for each other
+
+	fmt.Println("--------------------------------")
+
+	//! readers and one writer mixed
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go readPerson(i, "alice@example.com", &wg)
+	}
+	wg.Add(1)
+	go addPerson(Person{Name: "Alice", Age: 22, Email: "alice@example.com"}, &wg)
+	for i := 4; i <= 6; i++ {
+		wg.Add(1)
+		go readPerson(i, "alice@example.com", &wg)
+	}
+	wg.Wait()
+
+	/*
+		Some readers print 'found : false' (they read before the writer), some print 'found : true' (after the writer). But, no reader ever sees the map in a half-written state, and the program never crashes.
+
+		Without any lock, writing to a map while other goroutines read it makes Go stop the whole program with :
+
+		fatal error: concurrent map read and map write
+
+		When to use which?
+
+		sync.Mutex   -> simple, use it when reads and writes are about equally common
+		sync.RWMutex -> use it when there are many more reads than writes (like a cache or a config), so readers don't block each other
+	*/
+}
+
+/*
+	Now run with : go run -race main.go
+*/
@@ -0,0 +1,429 @@
+//! Event sourcing -> instead of saving only the current balance of a bank account, we save EVERY change (event) that ever happened to it, in order, in an append-only log file. The current balance is not stored anywhere, it is calculated by replaying all events from the beginning.
+//! If the program crashes, nothing is lost : we read the log again and get exactly the same state back.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+//! Event is one line in the log file
+type Event struct {
+	Seq          int    `json:"seq"` //! sequence number : 1, 2, 3, ... without any gap
+	Type         string `json:"type"`
+	Amount       int    `json:"amount"`
+	Counterparty string `json:"counterparty,omitempty"` //! the other account of a transfer
+}
+
+const (
+	EventDeposit     = "deposit"
+	EventWithdraw    = "withdraw"
+	EventTransferOut = "transfer_out"
+	EventTransferIn  = "transfer_in"
+	//! EventTransferReversed -> gives a transfer back to the sender when the receiver's log could not be written
+	EventTransferReversed = "transfer_reversed"
+)
+
+//! EventStore appends events to one file. The mutex makes sure two goroutines never write half lines into each other or take the same sequence number
+type EventStore struct {
+	mutex   sync.Mutex
+	file    *os.File
+	lastSeq int
+}
+
+//! OpenEventStore opens (or creates) the log and finds the last sequence number, so new events continue from there
+func OpenEventStore(path string) (*EventStore, error) {
+	lastSeq := 0
+	if _, err := os.Stat(path); err == nil {
+		account, err := Replay(path)
+		var truncated *TruncatedTailError
+		if errors.As(err, &truncated) {
+			//! cut the half written line away, otherwise the next event would be glued to it and the log would become corrupt
+			if err := cutTruncatedTail(path); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		lastSeq = account.LastSeq
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) //! O_APPEND -> every write goes to the end of the file, we never overwrite old events
+	if err != nil {
+		return nil, err
+	}
+	return &EventStore{file: file, lastSeq: lastSeq}, nil
+}
+
+//! cutTruncatedTail removes everything after the last '\n', the same torn tail Replay ignores
+func cutTruncatedTail(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.Truncate(path, int64(bytes.LastIndexByte(data, '\n')+1))
+}
+
+//! Append gives the event the next sequence number, writes it as one JSON line and calls Sync
+func (s *EventStore) Append(event Event) (Event, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	event.Seq = s.lastSeq + 1
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return Event{}, err
+	}
+	line = append(line, '\n')
+
+	if _, err := s.file.Write(line); err != nil {
+		return Event{}, err
+	}
+
+	//! Sync (fsync) -> asks the operating system to really write the data to the disk, not only keep it in memory. Without it, a power cut could lose events we already reported as saved
+	if err := s.file.Sync(); err != nil {
+		return Event{}, err
+	}
+
+	s.lastSeq = event.Seq
+	return event, nil
+}
+
+func (s *EventStore) Close() error {
+	return s.file.Close()
+}
+
+//! Account is the in-memory state. It is only changed through events
+type Account struct {
+	Owner   string
+	Balance int
+	History []Event
+	LastSeq int
+	store   *EventStore
+}
+
+//! apply changes the state for one event. Replay uses exactly the same function, so the live state and the replayed state can never be calculated differently
+func (a *Account) apply(event Event) {
+	switch event.Type {
+	case EventDeposit, EventTransferIn, EventTransferReversed:
+		a.Balance = a.Balance + event.Amount
+	case EventWithdraw, EventTransferOut:
+		a.Balance = a.Balance - event.Amount
+	}
+	a.History = append(a.History, event)
+	a.LastSeq = event.Seq
+}
+
+//! record = first save the event, then apply it. If saving fails, the state doesn't change
+func (a *Account) record(event Event) error {
+	saved, err := a.store.Append(event)
+	if err != nil {
+		return err
+	}
+	a.apply(saved)
+	return nil
+}
+
+func (a *Account) Deposit(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("deposit amount must be positive, got %d", amount)
+	}
+	return a.record(Event{Type: EventDeposit, Amount: amount})
+}
+
+func (a *Account) Withdraw(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("withdraw amount must be positive, got %d", amount)
+	}
+	if amount > a.Balance {
+		return fmt.Errorf("insufficient funds : balance %d, withdraw %d", a.Balance, amount)
+	}
+	return a.record(Event{Type: EventWithdraw, Amount: amount})
+}
+
+//! Transfer writes one event into EACH account's own log. Two files can never be written as one unit, so :
+//!   - both events are checked and built before anything is saved
+//!   - when the receiver's log fails after the sender's transfer_out was saved, a transfer_reversed event gives the money back to the sender
+//! The logs stay correct either way : the money is in exactly one account. Only when the reversal fails too is the sender's log left short, and the error says so
+func (a *Account) Transfer(to *Account, amount int) error {
+	if to == nil || to == a {
+		return errors.New("transfer needs another account")
+	}
+	if amount <= 0 || amount > a.Balance {
+		return fmt.Errorf("cannot transfer %d with balance %d", amount, a.Balance)
+	}
+	out := Event{Type: EventTransferOut, Amount: amount, Counterparty: to.Owner}
+	in := Event{Type: EventTransferIn, Amount: amount, Counterparty: a.Owner}
+
+	if err := a.record(out); err != nil {
+		return err
+	}
+	if err := to.record(in); err != nil {
+		reversal := Event{Type: EventTransferReversed, Amount: amount, Counterparty: to.Owner}
+		if reverseErr := a.record(reversal); reverseErr != nil {
+			return fmt.Errorf("transfer to %s failed (%v) and could not be reversed : %w", to.Owner, err, reverseErr)
+		}
+		return fmt.Errorf("transfer to %s failed, reversed : %w", to.Owner, err)
+	}
+	return nil
+}
+
+//! typed errors, so the caller can find out exactly what is wrong with the log (with errors.As)
+
+//! SequenceGapError -> an event is missing, for example seq 3 comes after seq 1
+type SequenceGapError struct {
+	Expected int
+	Got      int
+}
+
+func (e *SequenceGapError) Error() string {
+	return fmt.Sprintf("sequence gap : expected seq %d, got %d", e.Expected, e.Got)
+}
+
+//! CorruptEventError -> a line in the middle of the log is not valid JSON
+type CorruptEventError struct {
+	Line    int
+	LastSeq int //! the last good sequence number before the corrupt line
+	Err     error
+}
+
+func (e *CorruptEventError) Error() string {
+	return fmt.Sprintf("corrupt event on line %d (after seq %d) : %v", e.Line, e.LastSeq, e.Err)
+}
+
+func (e *CorruptEventError) Unwrap() error {
+	return e.Err
+}
+
+//! TruncatedTailError -> the LAST line has no '\n', so it was cut in the middle (a crash happened while writing it). This is expected after a crash, so Replay still returns the account built from all complete events
+type TruncatedTailError struct {
+	Line    int
+	LastSeq int
+}
+
+func (e *TruncatedTailError) Error() string {
+	return fmt.Sprintf("truncated final line %d ignored, state recovered up to seq %d", e.Line, e.LastSeq)
+}
+
+//! Replay reads the log from the beginning and rebuilds the account
+func Replay(path string) (*Account, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	//! every complete event ends with '\n'. The bytes after the last '\n' were being written when the crash happened.
+	//! They are a torn tail even when they happen to be valid JSON : without the '\n', the next O_APPEND write would be glued onto them
+	complete := data[:bytes.LastIndexByte(data, '\n')+1]
+	tail := data[len(complete):]
+
+	account := &Account{Owner: accountName(path)}
+	scanner := bufio.NewScanner(bytes.NewReader(complete))
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, &CorruptEventError{Line: lineNumber, LastSeq: account.LastSeq, Err: err}
+		}
+
+		if event.Seq != account.LastSeq+1 {
+			return nil, &SequenceGapError{Expected: account.LastSeq + 1, Got: event.Seq}
+		}
+
+		account.apply(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(tail) > 0 {
+		return account, &TruncatedTailError{Line: lineNumber + 1, LastSeq: account.LastSeq}
+	}
+	return account, nil
+}
+
+//! "/tmp/bank/john.log" -> "john"
+func accountName(path string) string {
+	base := filepath.Base(path)
+	return base[:len(base)-len(filepath.Ext(base))]
+}
+
+func openAccount(directory, owner string) (*Account, error) {
+	path := filepath.Join(directory, owner+".log")
+	store, err := OpenEventStore(path)
+	if err != nil {
+		return nil, err
+	}
+	account, err := Replay(path)
+	if err != nil {
+		return nil, err
+	}
+	account.store = store
+	return account, nil
+}
+
+func printAccount(label string, account *Account) {
+	fmt.Printf("%s %s : balance %d, last seq %d\n", label, account.Owner, account.Balance, account.LastSeq)
+}
+
+func run() error {
+	directory, err := os.MkdirTemp("", "event-sourcing") //! a temporary directory, so the example doesn't leave files in the repo
+	if err != nil {
+		return fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(directory)
+
+	john, err := openAccount(directory, "john")
+	if err != nil {
+		return err
+	}
+	jane, err := openAccount(directory, "jane")
+	if err != nil {
+		return err
+	}
+
+	//! 1. some operations
+	if err := john.Deposit(1000); err != nil {
+		return err
+	}
+	if err := john.Withdraw(200); err != nil {
+		return err
+	}
+	if err := john.Transfer(jane, 300); err != nil {
+		return err
+	}
+	if err := jane.Deposit(50); err != nil {
+		return err
+	}
+	if err := john.Withdraw(5000); err != nil {
+		fmt.Println("rejected :", err) //! rejected operations don't create events
+	}
+
+	//! 2. concurrent deposits. The mutex inside EventStore gives every event its own sequence number
+	var wg sync.WaitGroup
+	var accountMutex sync.Mutex //! the Account itself (Balance, History) is not safe for concurrent use, so we guard it here
+	var depositErrors []error
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accountMutex.Lock()
+			defer accountMutex.Unlock()
+			if err := jane.Deposit(10); err != nil {
+				depositErrors = append(depositErrors, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(depositErrors...); err != nil { //! errors.Join -> nil when the slice is empty
+		return err
+	}
+
+	printAccount("in memory", john)
+	printAccount("in memory", jane)
+	fmt.Println("--------------------------------")
+
+	//! 3. "crash" : the program dies while writing an event, half a line is left at the end of john's log
+	if _, err := john.store.file.WriteString(`{"seq":5,"type":"depo`); err != nil {
+		return err
+	}
+	if err := john.store.Close(); err != nil {
+		return err
+	}
+	if err := jane.store.Close(); err != nil {
+		return err
+	}
+	fmt.Println("crash ! (half written event at the end of john.log)")
+
+	//! 4. restart : replay the logs
+	replayedJohn, err := Replay(filepath.Join(directory, "john.log"))
+	var truncated *TruncatedTailError
+	if errors.As(err, &truncated) {
+		fmt.Println("warning :", err) //! tolerated, the account is still returned
+	} else if err != nil {
+		return err
+	}
+	replayedJane, err := Replay(filepath.Join(directory, "jane.log"))
+	if err != nil {
+		return err
+	}
+
+	printAccount("replayed ", replayedJohn)
+	printAccount("replayed ", replayedJane)
+	fmt.Println("john replay == memory :", replayedJohn.Balance == john.Balance && len(replayedJohn.History) == len(john.History))
+	fmt.Println("jane replay == memory :", replayedJane.Balance == jane.Balance && len(replayedJane.History) == len(jane.History))
+	fmt.Println("--------------------------------")
+
+	fmt.Println("john's history :")
+	for _, event := range replayedJohn.History {
+		fmt.Printf("  seq %d : %-12s %5d %s\n", event.Seq, event.Type, event.Amount, event.Counterparty)
+	}
+	fmt.Println("--------------------------------")
+
+	//! reopening john's account cuts the broken tail away, and new events continue from seq 4
+	john, err = openAccount(directory, "john")
+	if err != nil {
+		return err
+	}
+	if err := john.Deposit(25); err != nil {
+		return err
+	}
+	printAccount("reopened ", john)
+	if err := john.store.Close(); err != nil {
+		return err
+	}
+	fmt.Println("--------------------------------")
+
+	//! 5. broken logs
+	gapLog := filepath.Join(directory, "gap.log")
+	if err := os.WriteFile(gapLog, []byte(`{"seq":1,"type":"deposit","amount":100}`+"\n"+`{"seq":3,"type":"deposit","amount":100}`+"\n"), 0o644); err != nil {
+		return err
+	}
+	_, err = Replay(gapLog)
+	var gap *SequenceGapError
+	if errors.As(err, &gap) {
+		fmt.Println("gap detected     :", err, "-> missing seq", gap.Expected)
+	}
+
+	corruptLog := filepath.Join(directory, "corrupt.log")
+	if err := os.WriteFile(corruptLog, []byte(`{"seq":1,"type":"deposit","amount":100}`+"\n"+`not json`+"\n"+`{"seq":2,"type":"deposit","amount":100}`+"\n"), 0o644); err != nil {
+		return err
+	}
+	_, err = Replay(corruptLog)
+	var corrupt *CorruptEventError
+	if errors.As(err, &corrupt) {
+		fmt.Println("corrupt detected :", err)
+	}
+
+	emptyLog := filepath.Join(directory, "empty.log")
+	if err := os.WriteFile(emptyLog, nil, 0o644); err != nil {
+		return err
+	}
+	empty, err := Replay(emptyLog)
+	if err != nil {
+		return err
+	}
+	fmt.Println("empty log        : balance", empty.Balance, "error", err)
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+}
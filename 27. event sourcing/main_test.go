@@ -0,0 +1,331 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func writeLog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "john.log")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const (
+	deposit1  = `{"seq":1,"type":"deposit","amount":1000}` + "\n"
+	withdraw2 = `{"seq":2,"type":"withdraw","amount":200}` + "\n"
+	transfer3 = `{"seq":3,"type":"transfer_out","amount":300,"counterparty":"jane"}` + "\n"
+)
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		log         string
+		wantBalance int
+		wantLastSeq int
+		wantErr     any //! a pointer to the typed error errors.As should find, nil when Replay must succeed
+	}{
+		{"empty", "", 0, 0, nil},
+		{"one event", deposit1, 1000, 1, nil},
+		{"all event types", deposit1 + withdraw2 + transfer3 + `{"seq":4,"type":"transfer_in","amount":50,"counterparty":"jane"}` + "\n", 550, 4, nil},
+		{"blank lines are skipped", deposit1 + "\n" + withdraw2, 800, 2, nil},
+		{"half written last line", deposit1 + withdraw2 + `{"seq":3,"type":"depo`, 800, 2, new(*TruncatedTailError)},
+		{"complete JSON without newline", deposit1 + withdraw2 + `{"seq":3,"type":"deposit","amount":5}`, 800, 2, new(*TruncatedTailError)},
+		{"only a torn line", `{"seq":1`, 0, 0, new(*TruncatedTailError)},
+		{"sequence gap", deposit1 + transfer3, 0, 0, new(*SequenceGapError)},
+		{"sequence starts at 2", withdraw2, 0, 0, new(*SequenceGapError)},
+		{"corrupt line in the middle", deposit1 + "not json\n" + withdraw2, 0, 0, new(*CorruptEventError)},
+		{"corrupt last line with newline", deposit1 + "not json\n", 0, 0, new(*CorruptEventError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := Replay(writeLog(t, tt.log))
+
+			switch target := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("Replay error = %v, want nil", err)
+				}
+			case **TruncatedTailError:
+				if !errors.As(err, target) {
+					t.Fatalf("Replay error = %v, want *TruncatedTailError", err)
+				}
+			case **SequenceGapError:
+				if !errors.As(err, target) {
+					t.Fatalf("Replay error = %v, want *SequenceGapError", err)
+				}
+				return
+			case **CorruptEventError:
+				if !errors.As(err, target) {
+					t.Fatalf("Replay error = %v, want *CorruptEventError", err)
+				}
+				return
+			}
+
+			//! no error, or a torn tail : the account built from the complete events is returned
+			if account == nil {
+				t.Fatal("Replay account = nil, want the recovered account")
+			}
+			if account.Owner != "john" {
+				t.Errorf("Owner = %q, want %q", account.Owner, "john")
+			}
+			if account.Balance != tt.wantBalance || account.LastSeq != tt.wantLastSeq {
+				t.Errorf("balance %d, last seq %d; want balance %d, last seq %d", account.Balance, account.LastSeq, tt.wantBalance, tt.wantLastSeq)
+			}
+		})
+	}
+}
+
+func TestReplayErrorDetails(t *testing.T) {
+	_, err := Replay(writeLog(t, deposit1+withdraw2+"not json\n"+transfer3))
+	var corrupt *CorruptEventError
+	if !errors.As(err, &corrupt) || corrupt.Line != 3 || corrupt.LastSeq != 2 {
+		t.Errorf("Replay error = %v, want a corrupt event on line 3 after seq 2", err)
+	}
+
+	_, err = Replay(writeLog(t, deposit1+transfer3))
+	var gap *SequenceGapError
+	if !errors.As(err, &gap) || gap.Expected != 2 || gap.Got != 3 {
+		t.Errorf("Replay error = %v, want expected seq 2, got 3", err)
+	}
+
+	_, err = Replay(writeLog(t, deposit1+"\n"+`{"seq":2`))
+	var truncated *TruncatedTailError
+	if !errors.As(err, &truncated) || truncated.Line != 3 || truncated.LastSeq != 1 {
+		t.Errorf("Replay error = %v, want a truncated line 3 after seq 1", err)
+	}
+}
+
+//! a torn tail must be cut away when the store is opened, otherwise the next O_APPEND write is glued onto it
+func TestTornWriteIsCutOnOpen(t *testing.T) {
+	tests := []struct {
+		name string
+		torn string
+	}{
+		{"half written line", `{"seq":3,"type":"depo`},
+		{"complete JSON without newline", `{"seq":3,"type":"deposit","amount":5}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeLog(t, deposit1+withdraw2+tt.torn)
+			account, err := openAccount(filepath.Dir(path), "john")
+			if err != nil {
+				t.Fatalf("openAccount error = %v", err)
+			}
+			if account.LastSeq != 2 {
+				t.Errorf("LastSeq after open = %d, want 2", account.LastSeq)
+			}
+			if err := account.Deposit(25); err != nil {
+				t.Fatalf("Deposit error = %v", err)
+			}
+			if err := account.store.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := deposit1 + withdraw2 + `{"seq":3,"type":"deposit","amount":25}` + "\n"
+			if string(data) != want {
+				t.Errorf("log after reopen and deposit =\n%s\nwant\n%s", data, want)
+			}
+
+			replayed, err := Replay(path)
+			if err != nil {
+				t.Fatalf("Replay error = %v, want nil", err)
+			}
+			if replayed.Balance != 825 || replayed.LastSeq != 3 {
+				t.Errorf("replayed balance %d, last seq %d; want 825, 3", replayed.Balance, replayed.LastSeq)
+			}
+		})
+	}
+}
+
+//! the live state and the replayed state must always be the same, because both go through apply
+func TestReplayMatchesLiveState(t *testing.T) {
+	directory := t.TempDir()
+	john, err := openAccount(directory, "john")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jane, err := openAccount(directory, "jane")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer jane.store.Close()
+	defer john.store.Close()
+
+	steps := []struct {
+		name    string
+		do      func() error
+		wantErr bool
+	}{
+		{"deposit", func() error { return john.Deposit(1000) }, false},
+		{"withdraw", func() error { return john.Withdraw(200) }, false},
+		{"transfer", func() error { return john.Transfer(jane, 300) }, false},
+		{"overdraw is rejected", func() error { return john.Withdraw(5000) }, true},
+		{"zero deposit is rejected", func() error { return jane.Deposit(0) }, true},
+		{"deposit jane", func() error { return jane.Deposit(50) }, false},
+	}
+	for _, step := range steps {
+		if err := step.do(); (err != nil) != step.wantErr {
+			t.Fatalf("%s error = %v, want error %v", step.name, err, step.wantErr)
+		}
+	}
+
+	for _, live := range []*Account{john, jane} {
+		replayed, err := Replay(filepath.Join(directory, live.Owner+".log"))
+		if err != nil {
+			t.Fatalf("Replay(%s) error = %v", live.Owner, err)
+		}
+		if replayed.Balance != live.Balance || replayed.LastSeq != live.LastSeq || len(replayed.History) != len(live.History) {
+			t.Errorf("%s replayed = balance %d, seq %d; live = balance %d, seq %d", live.Owner, replayed.Balance, replayed.LastSeq, live.Balance, live.LastSeq)
+		}
+	}
+	if john.Balance != 500 || jane.Balance != 350 {
+		t.Errorf("balances john %d, jane %d; want 500, 350", john.Balance, jane.Balance)
+	}
+}
+
+//! many goroutines call Append on the same store with no lock of their own : the store's mutex alone must give every event its own seq and its own whole line
+func TestConcurrentAppend(t *testing.T) {
+	const writers = 50
+	path := filepath.Join(t.TempDir(), "john.log")
+	store, err := OpenEventStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	seqs := make([]int, writers)
+	errs := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			saved, err := store.Append(Event{Type: EventDeposit, Amount: i + 1})
+			seqs[i], errs[i] = saved.Seq, err
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]bool, writers)
+	for _, seq := range seqs {
+		if seq < 1 || seq > writers || seen[seq] {
+			t.Fatalf("returned seqs %v, want every seq from 1 to %d once", seqs, writers)
+		}
+		seen[seq] = true
+	}
+
+	//! Replay fails on a gap (*SequenceGapError) or a torn or interleaved line (*CorruptEventError)
+	replayed, err := Replay(path)
+	if err != nil {
+		t.Fatalf("Replay error = %v", err)
+	}
+	if replayed.LastSeq != writers || len(replayed.History) != writers {
+		t.Errorf("replayed last seq %d, %d events; want %d", replayed.LastSeq, len(replayed.History), writers)
+	}
+	if want := writers * (writers + 1) / 2; replayed.Balance != want {
+		t.Errorf("replayed balance %d, want %d : every amount exactly once", replayed.Balance, want)
+	}
+	for i, event := range replayed.History {
+		if event.Seq != i+1 {
+			t.Fatalf("line %d has seq %d, want %d", i+1, event.Seq, i+1)
+		}
+	}
+}
+
+//! the receiver's log is closed, so the transfer_in can't be saved : the sender gets a transfer_reversed, and both logs replay to the balances before the transfer
+func TestTransferReversedWhenReceiverFails(t *testing.T) {
+	directory := t.TempDir()
+	john, err := openAccount(directory, "john")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer john.store.Close()
+	jane, err := openAccount(directory, "jane")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := john.Deposit(1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := jane.store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = john.Transfer(jane, 300)
+	if err == nil || !strings.Contains(err.Error(), "reversed") {
+		t.Fatalf("Transfer error = %v, want the failed transfer reported as reversed", err)
+	}
+	if john.Balance != 1000 || jane.Balance != 0 {
+		t.Errorf("balances john %d, jane %d; want 1000, 0", john.Balance, jane.Balance)
+	}
+
+	var types []string
+	for _, event := range john.History {
+		types = append(types, event.Type)
+	}
+	if want := []string{EventDeposit, EventTransferOut, EventTransferReversed}; !reflect.DeepEqual(types, want) {
+		t.Errorf("john's events = %q, want %q", types, want)
+	}
+
+	for _, live := range []*Account{john, jane} {
+		replayed, err := Replay(filepath.Join(directory, live.Owner+".log"))
+		if err != nil {
+			t.Fatalf("Replay(%s) error = %v", live.Owner, err)
+		}
+		if replayed.Balance != live.Balance || replayed.LastSeq != live.LastSeq {
+			t.Errorf("%s replayed = balance %d, seq %d; live = balance %d, seq %d", live.Owner, replayed.Balance, replayed.LastSeq, live.Balance, live.LastSeq)
+		}
+	}
+}
+
+func TestTransferRejected(t *testing.T) {
+	john, err := openAccount(t.TempDir(), "john")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer john.store.Close()
+	if err := john.Deposit(100); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		to     *Account
+		amount int
+	}{
+		{"to itself", john, 10},
+		{"to no account", nil, 10},
+		{"more than the balance", &Account{Owner: "jane"}, 500},
+		{"zero", &Account{Owner: "jane"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := john.Transfer(tt.to, tt.amount); err == nil {
+				t.Fatal("Transfer error = nil, want the transfer rejected")
+			}
+			if john.Balance != 100 || john.LastSeq != 1 {
+				t.Errorf("john balance %d, last seq %d; want 100, 1 : a rejected transfer saves nothing", john.Balance, john.LastSeq)
+			}
+		})
+	}
+}
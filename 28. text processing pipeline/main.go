@@ -0,0 +1,223 @@
+//! A text processing pipeline -> the text goes through a list of small steps (stages) one after another. Each stage does only one job : trim, lowercase, remove punctuation ... The output of one stage is the input of the next.
+//! Every stage is just a function, so we can keep them in a map by name and build a pipeline from a list of names given on the command line. This is the higher order function idea from the functions section.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//! Transform is the type of every stage : takes a string, returns the changed string or an error
+type Transform func(string) (string, error)
+
+//! NamedTransform keeps the name together with the function, so that errors and debug output can tell WHICH stage it was
+type NamedTransform struct {
+	Name      string
+	Transform Transform
+}
+
+//! StageResult records what came out of each stage, for debugging
+type StageResult struct {
+	Stage  string
+	Output string
+}
+
+type Pipeline struct {
+	stages []NamedTransform
+}
+
+//! registry -> name of the stage -> the function
+var registry = map[string]Transform{}
+
+//! Register adds a stage by name. Registering the same name twice is a programming mistake, so it panics
+func Register(name string, transform Transform) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("transform %q is already registered", name))
+	}
+	registry[name] = transform
+}
+
+//! NewPipeline builds a pipeline from stage names. Unknown names are reported here, before any text is processed
+func NewPipeline(names []string) (*Pipeline, error) {
+	pipeline := &Pipeline{}
+	for _, name := range names {
+		transform, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown stage %q (available : %s)", name, strings.Join(availableStages(), ", "))
+		}
+		pipeline.stages = append(pipeline.stages, NamedTransform{Name: name, Transform: transform})
+	}
+	return pipeline, nil
+}
+
+//! Run passes the input through every stage in order. If one stage fails, the rest are not run and the error says which stage failed
+//! an empty pipeline returns the input unchanged
+func (p *Pipeline) Run(input string) (string, []StageResult, error) {
+	var results []StageResult
+	text := input
+
+	for _, stage := range p.stages {
+		output, err := stage.Transform(text)
+		if err != nil {
+			return "", results, fmt.Errorf("stage %q: %w", stage.Name, err)
+		}
+		results = append(results, StageResult{Stage: stage.Name, Output: output})
+		text = output
+	}
+
+	return text, results, nil
+}
+
+func availableStages() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names) //! map order is random, sorting makes the message the same every time
+	return names
+}
+
+//! the built-in stages
+
+func trim(s string) (string, error) {
+	return strings.TrimSpace(s), nil
+}
+
+func lower(s string) (string, error) {
+	return strings.ToLower(s), nil
+}
+
+func stripPunctuation(s string) (string, error) {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1 //! returning a negative value from the mapping function removes the character
+		}
+		return r
+	}, s), nil
+}
+
+func collapseWhitespace(s string) (string, error) {
+	return strings.Join(strings.Fields(s), " "), nil //! Fields splits on any amount of whitespace, Join puts exactly one space back
+}
+
+func requireText(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", fmt.Errorf("no text left to process")
+	}
+	return s, nil
+}
+
+//! isWordRune -> letters, digits and marks (the vowel signs of "বৃষ্টি" are marks). Everything else is a boundary between words
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+//! censor returns a Transform which hides every word of the list with '*'. This is a closure : the returned function remembers 'words'
+//! whole words only : "rain" is hidden, but "training" stays as it is. So "dark" and "darkness" are simply two different words
+func censor(words []string) Transform {
+	hidden := make(map[string]bool, len(words)) //! a new map, the caller's slice is never changed
+	for _, word := range words {
+		if word != "" {
+			hidden[word] = true
+		}
+	}
+
+	return func(s string) (string, error) {
+		var result strings.Builder
+		for s != "" {
+			//! the next run : a whole word, or the boundary characters up to the next word
+			first, _ := utf8.DecodeRuneInString(s)
+			inWord := isWordRune(first)
+			end := strings.IndexFunc(s, func(r rune) bool { return isWordRune(r) != inWord })
+			if end < 0 {
+				end = len(s)
+			}
+			run := s[:end]
+			if inWord && hidden[run] {
+				result.WriteString(strings.Repeat("*", utf8.RuneCountInString(run)))
+			} else {
+				result.WriteString(run)
+			}
+			s = s[end:]
+		}
+		return result.String(), nil
+	}
+}
+
+func init() {
+	Register("trim", trim)
+	Register("lower", lower)
+	Register("strip", stripPunctuation)
+	Register("collapse", collapseWhitespace)
+	Register("require", requireText)
+	Register("censor", censor([]string{"dark", "darkness", "rain"}))
+}
+
+//! wordFrequency -> the last step of a word counting program : how many times does each word appear
+func wordFrequency(text string) map[string]int {
+	counts := map[string]int{}
+	for _, word := range strings.Fields(text) {
+		counts[word]++
+	}
+	return counts
+}
+
+func main() {
+	stagesFlag := flag.String("stages", "trim,lower,strip,collapse", "comma separated list of stages")
+	debug := flag.Bool("debug", false, "print the output of every stage")
+	flag.Parse()
+
+	input := "   Hello, World!   The DARK night... and the   Rain, the RAIN!  Darkness?  hello. "
+
+	pipeline, err := NewPipeline(strings.Split(*stagesFlag, ","))
+	if err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+
+	output, results, err := pipeline.Run(input)
+	if err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("input  : %q\n", input)
+	fmt.Printf("output : %q\n", output)
+
+	if *debug {
+		for i, result := range results {
+			fmt.Printf("  %d. %-9s -> %q\n", i+1, result.Stage, result.Output)
+		}
+	}
+
+	counts := wordFrequency(output)
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	fmt.Println("word frequency :")
+	for _, word := range words {
+		fmt.Printf("  %-10s %d\n", word, counts[word])
+	}
+
+}
+
+/*
+	Try :
+
+	go run main.go
+	go run main.go -debug
+	go run main.go -stages trim,lower,strip,collapse,censor -debug
+	go run main.go -stages trim,lower,shout    -> unknown stage error
+*/
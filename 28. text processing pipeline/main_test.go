@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! stages only for the tests : they show the ORDER, because appending "a" then "b" is not the same as "b" then "a"
+var errBroken = errors.New("broken on purpose")
+
+var laterStageRuns int
+
+func init() {
+	Register("test-append-a", func(s string) (string, error) { return s + "a", nil })
+	Register("test-append-b", func(s string) (string, error) { return s + "b", nil })
+	Register("test-fail", func(s string) (string, error) { return "", errBroken })
+	Register("test-count", func(s string) (string, error) { laterStageRuns++; return s, nil })
+}
+
+func TestRunOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		stages     []string
+		input      string
+		want       string
+		wantStages []StageResult
+	}{
+		{"a then b", []string{"test-append-a", "test-append-b"}, ">", ">ab", []StageResult{{"test-append-a", ">a"}, {"test-append-b", ">ab"}}},
+		{"b then a", []string{"test-append-b", "test-append-a"}, ">", ">ba", []StageResult{{"test-append-b", ">b"}, {"test-append-a", ">ba"}}},
+		{"the same stage twice", []string{"test-append-a", "test-append-a"}, "", "aa", []StageResult{{"test-append-a", "a"}, {"test-append-a", "aa"}}},
+		{"strip before collapse", []string{"strip", "collapse"}, "a , b", "a b", []StageResult{{"strip", "a  b"}, {"collapse", "a b"}}},
+		{"collapse before strip leaves two spaces", []string{"collapse", "strip"}, "a , b", "a  b", []StageResult{{"collapse", "a , b"}, {"strip", "a  b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := NewPipeline(tt.stages)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, results, err := pipeline.Run(tt.input)
+			if err != nil || got != tt.want {
+				t.Errorf("Run(%q) = %q, %v; want %q, nil", tt.input, got, err, tt.want)
+			}
+			if !reflect.DeepEqual(results, tt.wantStages) {
+				t.Errorf("stage results = %q, want %q", results, tt.wantStages)
+			}
+		})
+	}
+}
+
+//! a failing stage stops the pipeline : the error names the stage and wraps its error, the stages before it are in the results, the stages after it never run
+func TestRunFailingStage(t *testing.T) {
+	laterStageRuns = 0
+	pipeline, err := NewPipeline([]string{"test-append-a", "test-fail", "test-count"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, results, err := pipeline.Run("x")
+	if err == nil || err.Error() != `stage "test-fail": broken on purpose` {
+		t.Errorf("Run error = %v, want %q", err, `stage "test-fail": broken on purpose`)
+	}
+	if !errors.Is(err, errBroken) {
+		t.Errorf("errors.Is(err, errBroken) = false, want true")
+	}
+	if got != "" {
+		t.Errorf("Run output = %q, want empty on failure", got)
+	}
+	if want := []StageResult{{"test-append-a", "xa"}}; !reflect.DeepEqual(results, want) {
+		t.Errorf("stage results = %q, want %q", results, want)
+	}
+	if laterStageRuns != 0 {
+		t.Errorf("the stage after the failure ran %d times, want 0", laterStageRuns)
+	}
+}
+
+func TestRequireStage(t *testing.T) {
+	pipeline, _ := NewPipeline([]string{"strip", "collapse", "require", "lower"})
+	_, results, err := pipeline.Run("!!! ... ???")
+	if err == nil || err.Error() != `stage "require": no text left to process` {
+		t.Errorf("Run error = %v, want the require stage to fail", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("%d stages finished before the failure, want 2", len(results))
+	}
+}
+
+func TestNewPipelineUnknownStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []string
+		wantErr string
+	}{
+		{"unknown name", []string{"trim", "uppercase"}, `unknown stage "uppercase"`},
+		{"names are case sensitive", []string{"Trim"}, `unknown stage "Trim"`},
+		{"an empty name from a trailing comma", strings.Split("trim,", ","), `unknown stage ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := NewPipeline(tt.stages)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("NewPipeline(%q) error = %v, want it to start with %q", tt.stages, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "censor, collapse, lower, require, strip") {
+				t.Errorf("error %q doesn't list the available stages", err)
+			}
+			if pipeline != nil {
+				t.Errorf("NewPipeline = %v, want nil with an error", pipeline)
+			}
+		})
+	}
+}
+
+func TestEmptyPipelineIsIdentity(t *testing.T) {
+	for _, stages := range [][]string{nil, {}} {
+		pipeline, err := NewPipeline(stages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, input := range []string{"", "  unchanged  ", "Hello, World!"} {
+			got, results, err := pipeline.Run(input)
+			if got != input || results != nil || err != nil {
+				t.Errorf("empty pipeline Run(%q) = %q, %v, %v; want %q, nil, nil", input, got, results, err, input)
+			}
+		}
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering \"trim\" twice didn't panic")
+		}
+	}()
+	Register("trim", trim)
+}
+
+func TestBuiltinStages(t *testing.T) {
+	tests := []struct {
+		stage string
+		input string
+		want  string
+	}{
+		{"trim", " \t hello \n", "hello"},
+		{"lower", "HeLLo ÄÖ", "hello äö"},
+		{"strip", `Hello, "World"! (yes) ...`, "Hello World yes "},
+		{"collapse", "  a \t\n b   c ", "a b c"},
+		{"require", " x ", " x "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stage, func(t *testing.T) {
+			got, err := registry[tt.stage](tt.input)
+			if err != nil || got != tt.want {
+				t.Errorf("%s(%q) = %q, %v; want %q, nil", tt.stage, tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCensor(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		input string
+		want  string
+	}{
+		{"one word", []string{"rain"}, "the rain falls", "the **** falls"},
+		{"overlapping words are different words", []string{"dark", "darkness"}, "darkness and dark", "******** and ****"},
+		{"only the whole word of the list", []string{"dark"}, "darkness and dark", "darkness and ****"},
+		{"inside another word", []string{"rain"}, "training", "training"},
+		{"at the start and the end of another word", []string{"rain"}, "rainy brain", "rainy brain"},
+		{"words next to each other", []string{"ab", "cd"}, "abcd ab cd", "abcd ** **"},
+		{"punctuation is a boundary", []string{"rain"}, "rain, (rain).", "****, (****)."},
+		{"digits are part of a word", []string{"rain"}, "rain2 rain", "rain2 ****"},
+		{"repeated letters", []string{"aa"}, "aa aaa", "** aaa"},
+		{"counted in runes, not bytes", []string{"বৃষ্টি"}, "বৃষ্টি!", "******!"},
+		{"case sensitive, lower comes first in the pipeline", []string{"rain"}, "Rain", "Rain"},
+		{"an empty word is ignored", []string{"", "x"}, "a x b", "a * b"},
+		{"no words", nil, "unchanged", "unchanged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := censor(tt.words)(tt.input)
+			if err != nil || got != tt.want {
+				t.Errorf("censor(%q)(%q) = %q, %v; want %q, nil", tt.words, tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCensorDoesNotChangeTheList(t *testing.T) {
+	words := []string{"a", "bbb", "cc"}
+	censor(words)
+	if want := []string{"a", "bbb", "cc"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("words = %q after censor, want %q", words, want)
+	}
+}
+
+func TestWordFrequency(t *testing.T) {
+	got := wordFrequency("the rain the  dark\tthe")
+	want := map[string]int{"the": 3, "rain": 1, "dark": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wordFrequency = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import "fmt"
+
+//! filterEvens lets only the even numbers pass
+func filterEvens(in <-chan int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		for n := range in {
+			if n%2 == 0 {
+				out <- n
+			}
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+//! sum is the last stage. It returns a channel with exactly ONE value : the total
+func sum(in <-chan int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		total := 0
+		for n := range in {
+			total = total + n
+		}
+		out <- total
+		close(out)
+	}()
+
+	return out
+}
+
+//! every stage takes a '<-chan int' and returns a '<-chan int', so the stages can be combined like building blocks
+//! generate -> filterEvens -> square -> sum
+func fourStagePipeline() {
+	numbers := generate(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	evens := filterEvens(numbers)
+	squares := square(evens) //! the same square function from main.go, reused
+	total := sum(squares)
+
+	fmt.Println("sum of the squares of the even numbers :", <-total) //! 4 + 16 + 36 + 64 + 100 = 220
+}
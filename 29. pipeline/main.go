@@ -0,0 +1,57 @@
+//! Pipeline -> a chain of stages connected by channels. Every stage is a goroutine : it receives values from the previous stage, does something with them, and sends the results to the next stage.
+//! generate -> square -> print
+package main
+
+import "fmt"
+
+//! stage 1 (generator) : turns the numbers into a channel. 'nums ...int' is a variadic parameter, just like in the variadic function lesson
+//! '<-chan int' -> a receive-only channel. The caller can only read from it, never send into it or close it
+func generate(nums ...int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		for _, n := range nums {
+			out <- n
+		}
+		close(out) //! no more values will come. The sender closes the channel, never the receiver
+	}()
+
+	return out //! returned immediately, the goroutine keeps sending in the background
+}
+
+//! stage 2 (transformer) : reads from 'in', sends the square of every value to 'out'
+func square(in <-chan int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		for n := range in { //! this loop ends when 'in' is closed by generate
+			out <- n * n
+		}
+		close(out) //! 'in' is finished, so 'out' is finished too. Closing it tells the next stage to stop
+	}()
+
+	return out
+}
+
+func main() {
+	//! stage 3 (consumer) : a simple range loop
+	for value := range square(generate(1, 2, 3, 4, 5)) {
+		fmt.Println(value)
+	}
+
+	/*
+		Why does 'for value := range ch' stop?
+
+		range over a channel receives values one by one. When the channel is closed AND all sent values have been received, the loop ends.
+
+		If square forgot to call close(out), main would wait forever for the next value :
+
+		fatal error: all goroutines are asleep - deadlock!
+
+		The close travels down the pipeline : generate closes -> square's loop ends and square closes -> main's loop ends.
+	*/
+
+	fmt.Println("--------------------------------")
+
+	fourStagePipeline()
+}
@@ -0,0 +1,102 @@
+//! Fan-out -> several workers read from the SAME channel, so the work is shared between them
+//! Fan-in  -> the outputs of several channels are merged back into ONE channel
+//!
+//!                  -> square worker 1 ->
+//!     generate     -> square worker 2 ->     merge -> main
+//!                  -> square worker 3 ->
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//! the generator from the pipeline lesson
+func generate(nums ...int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		for _, n := range nums {
+			out <- n
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+//! square is the pipeline lesson's square, with a worker id and a counter, so we can see how much work each worker did
+func square(worker int, in <-chan int, produced []int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		for n := range in { //! every worker ranges over the same 'in'. Each value is received by only ONE of them
+			time.Sleep(10 * time.Millisecond) //! pretending that the work takes some time
+			produced[worker]++                //! each worker only touches its own index, so there is no data race
+			out <- n * n
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+//! merge (fan-in) -> 'channels ...<-chan int' is a variadic parameter of channels, so it works with any number of inputs
+//! it only knows about channels of int, nothing about squares or workers, so it can be reused anywhere
+func merge(channels ...<-chan int) <-chan int {
+	out := make(chan int)
+	var wg sync.WaitGroup
+
+	//! one goroutine per input channel, copying its values into 'out'
+	for _, ch := range channels {
+		wg.Add(1)
+		go func(ch <-chan int) {
+			defer wg.Done()
+			for n := range ch {
+				out <- n
+			}
+		}(ch)
+	}
+
+	//! 'out' can only be closed after ALL inputs are finished. If we closed it when the first one finished, the others would panic with 'send on closed channel'
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+func main() {
+	numbers := generate(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+
+	produced := make([]int, 3)
+
+	//! fan-out : three workers read from the same 'numbers' channel
+	worker0 := square(0, numbers, produced)
+	worker1 := square(1, numbers, produced)
+	worker2 := square(2, numbers, produced)
+
+	//! fan-in : merge the three outputs into one
+	total := 0
+	for value := range merge(worker0, worker1, worker2) {
+		fmt.Print(value, " ") //! the order is NOT 1 4 9 16 ... anymore, the workers finish in any order
+		total = total + value
+	}
+	fmt.Println()
+	fmt.Println("total :", total) //! the order changes, but the total is always 650
+
+	fmt.Println("--------------------------------")
+
+	//! reading 'produced' is safe here : the merged channel is closed only after every worker has finished
+	for worker, count := range produced {
+		fmt.Printf("worker %d produced %d results\n", worker+1, count)
+	}
+
+	/*
+		Run it a few times : the work distribution changes from run to run (for example 4 4 4, or 5 4 3).
+
+		Whichever worker is free first receives the next value. A slow worker automatically gets less work.
+	*/
+}
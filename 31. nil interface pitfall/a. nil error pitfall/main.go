@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//! our own error type. Its Error method has a pointer receiver, so *MyError implements the error interface
+type MyError struct {
+	Message string
+}
+
+func (e *MyError) Error() string {
+	return e.Message
+}
+
+//! the BROKEN version. It looks correct : when nothing goes wrong, 'myErr' is nil and we return it
+func doBroken(fail bool) error {
+	var myErr *MyError //! a nil POINTER of type *MyError
+	if fail {
+		myErr = &MyError{Message: "something went wrong"}
+	}
+	return myErr //! the nil *MyError is put inside an 'error' interface here -> the interface is NOT nil
+}
+
+//! the FIXED version. When there is no error, return the literal nil
+func doFixed(fail bool) error {
+	if fail {
+		return &MyError{Message: "something went wrong"}
+	}
+	return nil //! a nil interface : no type, no value
+}
+
+//! printInterface shows both parts of an interface value
+func printInterface(label string, err error) {
+	if err == nil {
+		fmt.Printf("%-6s : type = <nil>, value = <nil>, err != nil -> false\n", label)
+		return
+	}
+	value := reflect.ValueOf(err)
+	fmt.Printf("%-6s : type = %v, value is nil pointer = %v, err != nil -> %v\n", label, reflect.TypeOf(err), value.IsNil(), err != nil)
+}
+
+func main() {
+	err := doBroken(false)
+	if err != nil {
+		fmt.Println("broken : got an error, but nothing went wrong!") //! this line IS printed
+	}
+
+	err = doFixed(false)
+	if err != nil {
+		fmt.Println("fixed  : got an error")
+	} else {
+		fmt.Println("fixed  : no error") //! this line is printed
+	}
+
+	fmt.Println("--------------------------------")
+
+	/*
+		Why? An interface value is a PAIR : (type, value)
+
+		var err error = nil            -> (nil,      nil)  -> err == nil is true
+		var p *MyError = nil
+		var err error = p              -> (*MyError, nil)  -> err == nil is FALSE
+
+		An interface is nil ONLY when both parts are nil. In the broken version, the type part is *MyError, so the interface is not nil, even though the pointer inside it is nil.
+	*/
+	printInterface("broken", doBroken(false))
+	printInterface("fixed", doFixed(false))
+
+	var nilPointer *MyError
+	var asInterface error = nilPointer
+	fmt.Println("nilPointer == nil  :", nilPointer == nil)  //! true -> comparing a pointer with nil
+	fmt.Println("asInterface == nil :", asInterface == nil) //! false -> comparing an interface with nil
+
+	fmt.Println("--------------------------------")
+
+	//! a real error still works the same in both versions
+	printInterface("broken", doBroken(true))
+	printInterface("fixed", doFixed(true))
+	fmt.Println(doFixed(true))
+
+	/*
+		Rule : if a function returns 'error', never return a variable of a concrete pointer type (like *MyError). Return the literal nil when there is no error.
+
+		Calling a method on the broken error would even crash :
+
+		doBroken(false).Error() -> panic: runtime error: invalid memory address or nil pointer dereference
+
+		because Error() reads e.Message of a nil pointer.
+	*/
+}
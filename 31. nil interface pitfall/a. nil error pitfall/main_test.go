@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+//! the same 'no failure' call : the broken version gives a non-nil error, the fixed one doesn't
+func TestNilError(t *testing.T) {
+	tests := []struct {
+		name        string
+		do          func(bool) error
+		fail        bool
+		wantNonNil  bool
+		wantType    reflect.Type
+		wantNilPtr  bool
+		wantMessage string
+	}{
+		{"broken, no failure", doBroken, false, true, reflect.TypeOf(&MyError{}), true, ""},
+		{"fixed, no failure", doFixed, false, false, nil, false, ""},
+		{"broken, failure", doBroken, true, true, reflect.TypeOf(&MyError{}), false, "something went wrong"},
+		{"fixed, failure", doFixed, true, true, reflect.TypeOf(&MyError{}), false, "something went wrong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.do(tt.fail)
+			if (err != nil) != tt.wantNonNil {
+				t.Fatalf("err != nil = %v, want %v", err != nil, tt.wantNonNil)
+			}
+			//! the two parts of the interface value : the type, and the value inside
+			if got := reflect.TypeOf(err); got != tt.wantType {
+				t.Errorf("type = %v, want %v", got, tt.wantType)
+			}
+			if err == nil {
+				return
+			}
+			if got := reflect.ValueOf(err).IsNil(); got != tt.wantNilPtr {
+				t.Errorf("value is nil pointer = %v, want %v", got, tt.wantNilPtr)
+			}
+			if !tt.wantNilPtr && err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestInterfaceHoldingNilPointer(t *testing.T) {
+	var nilPointer *MyError
+	var asInterface error = nilPointer
+	if nilPointer != nil {
+		t.Error("nilPointer == nil = false, want true")
+	}
+	if asInterface == nil {
+		t.Error("asInterface == nil = true, want false : the type part is *MyError")
+	}
+
+	//! errors.As still finds the *MyError, and it's a nil pointer
+	var myErr *MyError
+	if !errors.As(doBroken(false), &myErr) || myErr != nil {
+		t.Errorf("errors.As = %v, want a nil *MyError", myErr)
+	}
+}
+
+//! calling Error() on the broken 'no error' value reads e.Message of a nil pointer
+func TestBrokenErrorPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("doBroken(false).Error() didn't panic, want a nil pointer dereference")
+		}
+	}()
+	_ = doBroken(false).Error()
+}
@@ -0,0 +1,316 @@
+//! A small 'vet-style' checker. It reads Go source code as a tree (AST = Abstract Syntax Tree) with the standard go/ast and go/parser packages, and flags functions which return a concrete pointer error type (like *MyError) through an 'error' result. That is exactly the bug from the previous example.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//! Finding is one reported problem
+type Finding struct {
+	Position token.Position
+	Function string
+	Reason   string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s returns %s through 'error', a nil pointer here makes err != nil true", f.Position, f.Function, f.Reason)
+}
+
+//! guard -> the body of 'if name != nil { ... }'. Inside it, 'name' is known to be non-nil
+type guard struct {
+	name       string
+	start, end token.Pos
+}
+
+//! Check parses one file (src can be nil to read it from disk, or a string with the code) and returns the findings
+func Check(filename string, src any) ([]Finding, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	helpers := pointerHelpers(file)
+
+	var findings []Finding
+	for _, decl := range file.Decls {
+		function, ok := decl.(*ast.FuncDecl)
+		if !ok || function.Body == nil {
+			continue
+		}
+		errorIndex, namedResult := errorResult(function.Type)
+		if errorIndex < 0 {
+			continue //! the function doesn't return 'error'
+		}
+		findings = append(findings, checkFunction(fset, function, errorIndex, namedResult, helpers)...)
+	}
+	return findings, nil
+}
+
+//! errorResult finds which result is 'error' (-1 if none) and its name, if the results are named
+//! func f() (int, error)       -> 1, ""
+//! func f() (n int, err error) -> 1, "err"
+func errorResult(funcType *ast.FuncType) (int, string) {
+	if funcType.Results == nil {
+		return -1, ""
+	}
+	index := 0
+	for _, field := range funcType.Results.List {
+		isError := false
+		if ident, ok := field.Type.(*ast.Ident); ok && ident.Name == "error" {
+			isError = true
+		}
+		if len(field.Names) == 0 {
+			if isError {
+				return index, ""
+			}
+			index++
+			continue
+		}
+		for _, name := range field.Names {
+			if isError {
+				return index, name.Name
+			}
+			index++
+		}
+	}
+	return -1, ""
+}
+
+//! pointerHelpers -> functions of the file which return exactly one pointer, for example 'func lookup() *MyError'
+//! 'return lookup()' inside a function returning 'error' has the same problem as returning a pointer variable
+func pointerHelpers(file *ast.File) map[string]string {
+	helpers := map[string]string{}
+	for _, decl := range file.Decls {
+		function, ok := decl.(*ast.FuncDecl)
+		if !ok || function.Recv != nil || function.Type.Results == nil {
+			continue
+		}
+		results := function.Type.Results.List
+		if len(results) != 1 || len(results[0].Names) > 1 {
+			continue
+		}
+		if star, ok := results[0].Type.(*ast.StarExpr); ok {
+			helpers[function.Name.Name] = types.ExprString(star)
+		}
+	}
+	return helpers
+}
+
+func checkFunction(fset *token.FileSet, function *ast.FuncDecl, errorIndex int, namedResult string, helpers map[string]string) []Finding {
+	//! pointers -> variable name -> its pointer type, for every variable which MAY be nil
+	pointers := map[string]string{}
+	addPointerFields(pointers, function.Type.Params)
+	addPointerFields(pointers, function.Type.Results)
+
+	var guards []guard
+	var findings []Finding
+
+	report := func(pos token.Pos, reason string) {
+		findings = append(findings, Finding{Position: fset.Position(pos), Function: function.Name.Name, Reason: reason})
+	}
+
+	isGuarded := func(name string, pos token.Pos) bool {
+		for _, g := range guards {
+			if g.name == name && g.start <= pos && pos < g.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	//! ast.Inspect visits every node of the function body in source order
+	ast.Inspect(function.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false //! a 'return' inside a closure belongs to the closure, not to this function
+
+		case *ast.IfStmt:
+			if name := notNilCheck(n.Cond); name != "" {
+				guards = append(guards, guard{name: name, start: n.Body.Pos(), end: n.Body.End()})
+			}
+
+		case *ast.DeclStmt: //! var p *MyError
+			genDecl, ok := n.Decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				return true
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				star, ok := valueSpec.Type.(*ast.StarExpr)
+				if !ok {
+					continue
+				}
+				for i, name := range valueSpec.Names {
+					if i < len(valueSpec.Values) && isNonNil(valueSpec.Values[i]) {
+						continue
+					}
+					pointers[name.Name] = types.ExprString(star)
+				}
+			}
+
+		case *ast.AssignStmt: //! p := lookup(), err = p, p = &MyError{}
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch pointerType := pointerTypeOf(n.Rhs[i], pointers, helpers); {
+				case pointerType != "":
+					pointers[ident.Name] = pointerType
+				case isNonNil(n.Rhs[i]) && n.Tok == token.ASSIGN:
+					//! 'myErr = &MyError{}' may happen only in one branch (inside an if), so the variable can still be nil elsewhere. Keep it
+				default:
+					delete(pointers, ident.Name) //! a new non-nil pointer (p := &MyError{}) or something else (a real interface value)
+				}
+			}
+
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 { //! a bare 'return' with named results
+				if pointerType, ok := pointers[namedResult]; ok && namedResult != "" {
+					report(n.Pos(), fmt.Sprintf("named result '%s' holding a %s", namedResult, pointerType))
+				}
+				return true
+			}
+			if errorIndex >= len(n.Results) {
+				return true //! 'return f()' with a multi value call, we can't see inside
+			}
+			result := ast.Unparen(n.Results[errorIndex])
+			pointerType := pointerTypeOf(result, pointers, helpers)
+			if pointerType == "" {
+				return true
+			}
+			if ident, ok := result.(*ast.Ident); ok {
+				if isGuarded(ident.Name, n.Pos()) {
+					return true //! inside 'if p != nil { ... }', so p is provably not nil
+				}
+				report(n.Pos(), fmt.Sprintf("variable '%s' of type %s", ident.Name, pointerType))
+			} else {
+				report(n.Pos(), fmt.Sprintf("helper call '%s' of type %s", types.ExprString(result), pointerType))
+			}
+		}
+		return true
+	})
+
+	return findings
+}
+
+//! addPointerFields adds every named parameter / result of a pointer type, like 'func f(p *MyError)'
+func addPointerFields(pointers map[string]string, fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		for _, name := range field.Names {
+			pointers[name.Name] = types.ExprString(star)
+		}
+	}
+}
+
+//! pointerTypeOf returns the pointer type of an expression which may be a nil pointer, or "" if it is not one
+func pointerTypeOf(expr ast.Expr, pointers, helpers map[string]string) string {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return pointers[e.Name]
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok {
+			return helpers[ident.Name]
+		}
+	}
+	return ""
+}
+
+//! isNonNil -> &MyError{...} and new(MyError) can never be nil
+func isNonNil(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		return e.Op == token.AND
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		return ok && ident.Name == "new"
+	}
+	return false
+}
+
+//! notNilCheck returns 'p' for the conditions 'p != nil' and 'nil != p'
+func notNilCheck(cond ast.Expr) string {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || binary.Op != token.NEQ {
+		return ""
+	}
+	left, leftOk := ast.Unparen(binary.X).(*ast.Ident)
+	right, rightOk := ast.Unparen(binary.Y).(*ast.Ident)
+	switch {
+	case leftOk && rightOk && right.Name == "nil":
+		return left.Name
+	case leftOk && rightOk && left.Name == "nil":
+		return right.Name
+	}
+	return ""
+}
+
+//! checkRepo runs Check on every .go file under root
+func checkRepo(root string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		fileFindings, err := Check(path, nil)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	return findings, err
+}
+
+func main() {
+	//! the fixtures (small pieces of code which are flagged or not) are in main_test.go. Here we check the whole repo. By default the root is two folders up from this lesson
+	root := "../.."
+	if len(os.Args) > 1 {
+		root = os.Args[1]
+	}
+	findings, err := checkRepo(root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	fmt.Println("findings in", root, ":", len(findings))
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	//! a check which always exits with 0 can't stop a build. Like 'go vet', any finding makes the exit code 1
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+
+	/*
+		This checker only looks at the shape of the code, it doesn't know the real types (go/types could do that). So it has limits :
+
+		- it assumes that every pointer type can be an error type
+		- it doesn't follow values through other packages
+		- 'if p == nil { return nil }; return p' is still flagged (only 'if p != nil { return p }' is understood)
+
+		Real tools like 'staticcheck' and the 'nilness' analyzer use full type information for this.
+	*/
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		wantReasons []string
+	}{
+		//! flagged : a nil pointer can reach the 'error' result
+		{"direct return", `package p
+func do() error {
+	var myErr *MyError
+	return myErr
+}`, []string{"variable 'myErr' of type *MyError"}},
+		{"named result with a bare return", `package p
+func do() (n int, err error) {
+	var myErr *MyError
+	err = myErr
+	return
+}`, []string{"named result 'err' holding a *MyError"}},
+		{"named result of a pointer type", `package p
+func do() (n int, err error) {
+	var myErr *MyError
+	return 0, myErr
+}`, []string{"variable 'myErr' of type *MyError"}},
+		{"helper-wrapped return", `package p
+func lookup() *MyError { return nil }
+func do() error {
+	return lookup()
+}`, []string{"helper call 'lookup()' of type *MyError"}},
+		{"helper result in a variable", `package p
+func lookup() *MyError { return nil }
+func do() error {
+	myErr := lookup()
+	return myErr
+}`, []string{"variable 'myErr' of type *MyError"}},
+		{"pointer parameter", `package p
+func do(p *MyError) error {
+	return p
+}`, []string{"variable 'p' of type *MyError"}},
+		{"error as the second result, in parentheses", `package p
+func do() (int, error) {
+	var myErr *MyError
+	return 0, (myErr)
+}`, []string{"variable 'myErr' of type *MyError"}},
+		{"set only in one branch", `package p
+func do(fail bool) error {
+	var myErr *MyError
+	if fail {
+		myErr = &MyError{}
+	}
+	return myErr
+}`, []string{"variable 'myErr' of type *MyError"}},
+		{"guarded by == nil is still flagged (a known limit)", `package p
+func do(p *MyError) error {
+	if p == nil {
+		return nil
+	}
+	return p
+}`, []string{"variable 'p' of type *MyError"}},
+		{"two returns", `package p
+func do(fail bool) error {
+	var a, b *MyError
+	if fail {
+		return a
+	}
+	return b
+}`, []string{"variable 'a' of type *MyError", "variable 'b' of type *MyError"}},
+
+		//! not flagged : the value is provably non-nil, or it never reaches an 'error' result
+		{"literal", `package p
+func do() error {
+	return &MyError{Message: "failed"}
+}`, nil},
+		{"new", `package p
+func do() error {
+	return new(MyError)
+}`, nil},
+		{"var with a non-nil value", `package p
+func do() error {
+	var myErr *MyError = &MyError{}
+	return myErr
+}`, nil},
+		{"short declaration of a literal", `package p
+func do() error {
+	myErr := &MyError{}
+	return myErr
+}`, nil},
+		{"guarded by != nil", `package p
+func do() error {
+	myErr := lookup()
+	if myErr != nil {
+		return myErr
+	}
+	return nil
+}
+func lookup() *MyError { return nil }`, nil},
+		{"guarded by nil != p", `package p
+func do(p *MyError) error {
+	if nil != p {
+		return p
+	}
+	return nil
+}`, nil},
+		{"the fixed version", `package p
+func do(fail bool) error {
+	if fail {
+		return &MyError{}
+	}
+	return nil
+}`, nil},
+		{"the variable is an interface", `package p
+func do() error {
+	var err error
+	return err
+}`, nil},
+		{"return inside a closure belongs to the closure", `package p
+func do() error {
+	var myErr *MyError
+	f := func() *MyError { return myErr }
+	f()
+	return nil
+}`, nil},
+		{"no error result", `package p
+func do() *MyError {
+	var myErr *MyError
+	return myErr
+}`, nil},
+		{"a method is not a helper", `package p
+type T struct{}
+func (T) lookup() *MyError { return nil }
+func do(t T) error {
+	return t.lookup()
+}`, nil},
+		{"a multi value call can't be seen into", `package p
+func pair() (int, error) { return 0, nil }
+func do() (int, error) {
+	return pair()
+}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := Check("fixture.go", tt.code)
+			if err != nil {
+				t.Fatalf("Check error = %v", err)
+			}
+			var reasons []string
+			for _, finding := range findings {
+				reasons = append(reasons, finding.Reason)
+				if finding.Function != "do" {
+					t.Errorf("finding in function %q, want \"do\"", finding.Function)
+				}
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("reasons = %q, want %q", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestCheckPosition(t *testing.T) {
+	findings, err := Check("direct.go", "package p\n\nfunc do() error {\n\tvar myErr *MyError\n\treturn myErr\n}\n")
+	if err != nil || len(findings) != 1 {
+		t.Fatalf("Check = %v, %v; want one finding", findings, err)
+	}
+	want := "direct.go:5:2: do returns variable 'myErr' of type *MyError through 'error', a nil pointer here makes err != nil true"
+	if got := findings[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCheckInvalidSource(t *testing.T) {
+	if _, err := Check("broken.go", "package p\nfunc {"); err == nil {
+		t.Error("Check of invalid Go = nil error, want a parse error")
+	}
+}
+
+//! the broken example of the previous lesson is found, and only its broken function
+func TestCheckRepo(t *testing.T) {
+	findings, err := checkRepo("../a. nil error pitfall")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Function != "doBroken" || !strings.HasSuffix(findings[0].Position.Filename, "main.go") {
+		t.Errorf("findings = %v, want only doBroken in main.go", findings)
+	}
+}
@@ -0,0 +1,264 @@
+//! A maze generator and solver. The maze is a 2D slice ([][]Cell) : a slice of rows, every row is a slice of cells.
+//! generate -> recursive backtracking (a depth first walk which knocks down walls)
+//! solve    -> BFS (breadth first search, finds the SHORTEST path) and DFS (depth first search, finds A path)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+//! directions, used as indexes of Cell.Walls
+const (
+	North = iota
+	East
+	South
+	West
+)
+
+var dx = [4]int{0, 1, 0, -1} //! how x changes when we move North, East, South, West
+var dy = [4]int{-1, 0, 1, 0} //! y grows downwards, like rows on the screen
+var opposite = [4]int{South, West, North, East}
+
+type Cell struct {
+	Walls [4]bool //! true -> there is a wall on that side
+}
+
+type Point struct {
+	X, Y int
+}
+
+type Maze struct {
+	Width, Height int
+	Cells         [][]Cell //! Cells[y][x]
+}
+
+//! Generate builds a maze. The random source is passed in (injected), so the same seed always gives the same maze
+func Generate(width, height int, rng *rand.Rand) *Maze {
+	maze := &Maze{Width: width, Height: height}
+
+	//! a 2D slice is created row by row
+	maze.Cells = make([][]Cell, height)
+	for y := range maze.Cells {
+		maze.Cells[y] = make([]Cell, width)
+		for x := range maze.Cells[y] {
+			maze.Cells[y][x].Walls = [4]bool{true, true, true, true} //! start with every wall standing
+		}
+	}
+
+	visited := make([][]bool, height)
+	for y := range visited {
+		visited[y] = make([]bool, width)
+	}
+
+	var carve func(x, y int) //! declared first, so the function can call itself (recursion)
+	carve = func(x, y int) {
+		visited[y][x] = true
+		for _, direction := range rng.Perm(4) { //! visit the neighbours in a random order
+			nx, ny := x+dx[direction], y+dy[direction]
+			if !maze.inside(nx, ny) || visited[ny][nx] {
+				continue
+			}
+			//! knock down the wall on both sides
+			maze.Cells[y][x].Walls[direction] = false
+			maze.Cells[ny][nx].Walls[opposite[direction]] = false
+			carve(nx, ny)
+		}
+		//! no unvisited neighbour left -> return = 'backtrack' to the previous cell
+	}
+	carve(0, 0)
+
+	//! every cell was visited from a connected cell, so every cell is reachable from (0, 0)
+	return maze
+}
+
+//! AddLoops removes some extra walls. A maze from Generate has exactly ONE path between two cells, with loops there can be several
+func (m *Maze) AddLoops(count int, rng *rand.Rand) {
+	for i := 0; i < count*10 && count > 0; i++ {
+		x, y := rng.IntN(m.Width), rng.IntN(m.Height)
+		direction := rng.IntN(4)
+		nx, ny := x+dx[direction], y+dy[direction]
+		if !m.inside(nx, ny) || !m.Cells[y][x].Walls[direction] {
+			continue
+		}
+		m.Cells[y][x].Walls[direction] = false
+		m.Cells[ny][nx].Walls[opposite[direction]] = false
+		count--
+	}
+}
+
+func (m *Maze) inside(x, y int) bool {
+	return x >= 0 && x < m.Width && y >= 0 && y < m.Height
+}
+
+//! neighbours returns the cells we can walk to from p (no wall between)
+func (m *Maze) neighbours(p Point) []Point {
+	var result []Point
+	for direction := North; direction <= West; direction++ {
+		if !m.Cells[p.Y][p.X].Walls[direction] {
+			result = append(result, Point{p.X + dx[direction], p.Y + dy[direction]})
+		}
+	}
+	return result
+}
+
+//! SolveBFS explores the maze level by level with a queue. The first time it reaches 'end', the path is the shortest one
+func (m *Maze) SolveBFS(start, end Point) []Point {
+	previous := map[Point]Point{} //! where we came from, to rebuild the path at the end
+	visited := map[Point]bool{start: true}
+	queue := []Point{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:] //! dequeue from the front -> First In First Out
+		if current == end {
+			return buildPath(previous, start, end)
+		}
+		for _, next := range m.neighbours(current) {
+			if !visited[next] {
+				visited[next] = true
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil //! no path
+}
+
+//! SolveDFS goes as deep as possible first, with a stack. It finds A path, not always the shortest one
+func (m *Maze) SolveDFS(start, end Point) []Point {
+	previous := map[Point]Point{}
+	visited := map[Point]bool{start: true}
+	stack := []Point{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1] //! pop from the end -> Last In First Out
+		if current == end {
+			return buildPath(previous, start, end)
+		}
+		for _, next := range m.neighbours(current) {
+			if !visited[next] {
+				visited[next] = true
+				previous[next] = current
+				stack = append(stack, next)
+			}
+		}
+	}
+	return nil
+}
+
+func buildPath(previous map[Point]Point, start, end Point) []Point {
+	path := []Point{end}
+	for current := end; current != start; {
+		current = previous[current]
+		path = append(path, current)
+	}
+	//! the path was built from end to start, reverse it
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+//! Reachable counts the cells reachable from (0, 0) with a flood fill
+func (m *Maze) Reachable() int {
+	seen := map[Point]bool{{0, 0}: true}
+	stack := []Point{{0, 0}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range m.neighbours(current) {
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return len(seen)
+}
+
+//! Render draws the maze with ASCII characters. The cells of 'path' are marked with '*'
+func (m *Maze) Render(path []Point) string {
+	onPath := map[Point]bool{}
+	for _, p := range path {
+		onPath[p] = true
+	}
+
+	var builder strings.Builder
+	for y := 0; y < m.Height; y++ {
+		//! the top walls of this row
+		for x := 0; x < m.Width; x++ {
+			if m.Cells[y][x].Walls[North] {
+				builder.WriteString("+---")
+			} else {
+				builder.WriteString("+   ")
+			}
+		}
+		builder.WriteString("+\n")
+
+		//! the west walls and the cells themselves
+		for x := 0; x < m.Width; x++ {
+			if m.Cells[y][x].Walls[West] {
+				builder.WriteString("|")
+			} else {
+				builder.WriteString(" ")
+			}
+			if onPath[Point{x, y}] {
+				builder.WriteString(" * ")
+			} else {
+				builder.WriteString("   ")
+			}
+		}
+		builder.WriteString("|\n") //! the east wall of the maze
+	}
+	builder.WriteString(strings.Repeat("+---", m.Width) + "+\n") //! the bottom wall of the maze
+	return builder.String()
+}
+
+func main() {
+	width := flag.Int("w", 10, "width of the maze")
+	height := flag.Int("h", 6, "height of the maze")
+	seed := flag.Uint64("seed", 7, "random seed, the same seed gives the same maze")
+	loops := flag.Int("loops", 6, "extra walls to remove, so there is more than one path")
+	flag.Parse()
+
+	if *width < 1 || *height < 1 {
+		fmt.Println("error : width and height must be at least 1")
+		return
+	}
+
+	rng := rand.New(rand.NewPCG(*seed, *seed))
+	maze := Generate(*width, *height, rng)
+	maze.AddLoops(*loops, rng)
+
+	start, end := Point{0, 0}, Point{*width - 1, *height - 1}
+	bfsPath := maze.SolveBFS(start, end)
+	dfsPath := maze.SolveDFS(start, end)
+
+	fmt.Printf("maze %dx%d, seed %d, reachable cells %d of %d\n", *width, *height, *seed, maze.Reachable(), *width**height)
+	fmt.Println()
+	fmt.Println("BFS (shortest path), length", len(bfsPath))
+	fmt.Print(maze.Render(bfsPath))
+	fmt.Println()
+	fmt.Println("DFS, length", len(dfsPath))
+	fmt.Print(maze.Render(dfsPath))
+
+	/*
+		With '-loops 0' the maze has exactly one path between any two cells, so BFS and DFS always find the same path.
+
+		With loops, DFS may take a longer way, but it is never shorter than BFS :
+		BFS visits all cells at distance 1, then all cells at distance 2, ... so it reaches the end at the smallest distance first.
+	*/
+}
+
+/*
+	Try :
+
+	go run main.go
+	go run main.go -w 20 -h 10 -seed 42
+	go run main.go -loops 0
+	go run main.go -w 1 -h 1
+*/
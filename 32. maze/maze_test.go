@@ -0,0 +1,203 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//! go test *.go -update -> rewrites the golden files in testdata, after a change of the drawing which is on purpose
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func newMaze(width, height int, seed uint64, loops int) *Maze {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	maze := Generate(width, height, rng)
+	maze.AddLoops(loops, rng)
+	return maze
+}
+
+var sizes = []struct {
+	width, height int
+	seed          uint64
+	loops         int
+}{
+	{1, 1, 7, 0},
+	{1, 8, 7, 0},
+	{8, 1, 7, 0},
+	{10, 6, 7, 0},
+	{10, 6, 7, 6},
+	{20, 10, 42, 0},
+	{40, 40, 3, 50},
+}
+
+//! the flood fill must reach every cell : Generate visits every cell from a connected one
+func TestReachable(t *testing.T) {
+	for _, tt := range sizes {
+		t.Run(fmt.Sprintf("%dx%d seed %d loops %d", tt.width, tt.height, tt.seed, tt.loops), func(t *testing.T) {
+			maze := newMaze(tt.width, tt.height, tt.seed, tt.loops)
+			if got, want := maze.Reachable(), tt.width*tt.height; got != want {
+				t.Errorf("Reachable() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+//! a wall is stored twice (once in each cell), so both sides must agree, and the outer border must be closed
+func TestWallsAreConsistent(t *testing.T) {
+	for _, tt := range sizes {
+		t.Run(fmt.Sprintf("%dx%d seed %d loops %d", tt.width, tt.height, tt.seed, tt.loops), func(t *testing.T) {
+			maze := newMaze(tt.width, tt.height, tt.seed, tt.loops)
+			for y := range maze.Cells {
+				for x := range maze.Cells[y] {
+					for direction := North; direction <= West; direction++ {
+						nx, ny := x+dx[direction], y+dy[direction]
+						wall := maze.Cells[y][x].Walls[direction]
+						if !maze.inside(nx, ny) {
+							if !wall {
+								t.Errorf("cell (%d, %d) has no border wall in direction %d", x, y, direction)
+							}
+							continue
+						}
+						if other := maze.Cells[ny][nx].Walls[opposite[direction]]; wall != other {
+							t.Errorf("wall between (%d, %d) and (%d, %d) : %v on one side, %v on the other", x, y, nx, ny, wall, other)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSameSeedSameMaze(t *testing.T) {
+	first := newMaze(20, 10, 42, 6)
+	second := newMaze(20, 10, 42, 6)
+	if !reflect.DeepEqual(first.Cells, second.Cells) {
+		t.Error("two mazes with seed 42 are different, want the same maze")
+	}
+	if first.Render(nil) != second.Render(nil) {
+		t.Error("two mazes with seed 42 render differently")
+	}
+
+	other := newMaze(20, 10, 43, 6)
+	if reflect.DeepEqual(first.Cells, other.Cells) {
+		t.Error("seeds 42 and 43 gave the same 20x10 maze, want different mazes")
+	}
+}
+
+//! checkPath fails when the path doesn't go from start to end in steps of one cell without crossing a wall
+func checkPath(t *testing.T, maze *Maze, path []Point, start, end Point) {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("path %v doesn't go from %v to %v", path, start, end)
+	}
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		found := false
+		for _, next := range maze.neighbours(from) {
+			found = found || next == to
+		}
+		if !found {
+			t.Errorf("step %d : %v -> %v crosses a wall or isn't a single step", i, from, to)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	for _, tt := range sizes {
+		t.Run(fmt.Sprintf("%dx%d seed %d loops %d", tt.width, tt.height, tt.seed, tt.loops), func(t *testing.T) {
+			maze := newMaze(tt.width, tt.height, tt.seed, tt.loops)
+			start, end := Point{0, 0}, Point{tt.width - 1, tt.height - 1}
+
+			bfs := maze.SolveBFS(start, end)
+			dfs := maze.SolveDFS(start, end)
+			checkPath(t, maze, bfs, start, end)
+			checkPath(t, maze, dfs, start, end)
+
+			if len(bfs) > len(dfs) {
+				t.Errorf("BFS path length %d > DFS path length %d, BFS must find the shortest path", len(bfs), len(dfs))
+			}
+			//! without loops there is exactly one path, so both must find it
+			if tt.loops == 0 && !reflect.DeepEqual(bfs, dfs) {
+				t.Errorf("without loops BFS = %v, DFS = %v; want the same path", bfs, dfs)
+			}
+		})
+	}
+}
+
+//! a hand built 3x2 maze : only the top row is open, the bottom row is walled off
+func TestSolveNoPath(t *testing.T) {
+	closed := Cell{Walls: [4]bool{true, true, true, true}}
+	maze := &Maze{Width: 3, Height: 2, Cells: [][]Cell{
+		{{Walls: [4]bool{true, false, true, true}}, {Walls: [4]bool{true, false, true, false}}, {Walls: [4]bool{true, true, true, false}}},
+		{closed, closed, closed},
+	}}
+
+	tests := []struct {
+		name string
+		end  Point
+		want []Point
+	}{
+		{"along the open row", Point{2, 0}, []Point{{0, 0}, {1, 0}, {2, 0}}},
+		{"start is the end", Point{0, 0}, []Point{{0, 0}}},
+		{"walled off cell", Point{2, 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maze.SolveBFS(Point{0, 0}, tt.end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SolveBFS = %v, want %v", got, tt.want)
+			}
+			if got := maze.SolveDFS(Point{0, 0}, tt.end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SolveDFS = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := maze.Reachable(); got != 3 {
+		t.Errorf("Reachable() = %d, want 3", got)
+	}
+}
+
+//! the drawing is compared with testdata/*.golden byte for byte
+func TestRenderGolden(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		seed          uint64
+		loops         int
+		solve         bool
+	}{
+		{"1x1", 1, 1, 7, 0, false},
+		{"5x3_seed7", 5, 3, 7, 0, false},
+		{"5x3_seed7_path", 5, 3, 7, 0, true},
+		{"10x6_seed7_loops6_path", 10, 6, 7, 6, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maze := newMaze(tt.width, tt.height, tt.seed, tt.loops)
+			var path []Point
+			if tt.solve {
+				path = maze.SolveBFS(Point{0, 0}, Point{tt.width - 1, tt.height - 1})
+			}
+			got := maze.Render(path)
+
+			goldenPath := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(goldenPath, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(golden) {
+				t.Errorf("Render doesn't match %s (run 'go test *.go -update' if the change is on purpose)\n got:\n%s\nwant:\n%s", goldenPath, got, golden)
+			}
+		})
+	}
+}
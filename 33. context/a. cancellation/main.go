@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//! context -> carries a 'stop' signal (and deadlines, values) from the caller to every goroutine doing work for it
+//! by convention, ctx is the FIRST parameter of a function
+func worker(ctx context.Context, done chan<- string) {
+	count := 0
+	for {
+		select {
+		case <-ctx.Done(): //! this channel is closed when cancel() is called
+			done <- fmt.Sprintf("worker : cancelled after %d steps (%v)", count, ctx.Err())
+			return
+		default:
+			count++
+			fmt.Println("worker : working, step", count)
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+func main() {
+	//! context.Background() -> the empty root context. WithCancel returns a child context and a function to cancel it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() //! always call cancel, even if the work finishes by itself, so the context's resources are released
+
+	done := make(chan string)
+	go worker(ctx, done)
+
+	time.Sleep(350 * time.Millisecond)
+	fmt.Println("main   : calling cancel()")
+	cancel()
+
+	fmt.Println(<-done) //! wait until the worker really stopped
+
+	/*
+		Output :
+
+		worker : working, step 1
+		worker : working, step 2
+		worker : working, step 3
+		worker : working, step 4
+		main   : calling cancel()
+		worker : cancelled after 4 steps (context canceled)
+
+		The worker never stops by itself. It checks ctx.Done() in every loop, and returns as soon as main cancels.
+		Calling cancel() more than once is safe, that's why the deferred cancel() is not a problem.
+	*/
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//! slowOperation pretends to be a slow database query or HTTP call which takes 'duration'
+func slowOperation(ctx context.Context, duration time.Duration) (string, error) {
+	select {
+	case <-time.After(duration): //! the work finished
+		return "result", nil
+	case <-ctx.Done(): //! the deadline came first
+		return "", ctx.Err()
+	}
+}
+
+func run(timeout, duration time.Duration) {
+	//! WithTimeout -> the context is cancelled automatically after 'timeout'
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := slowOperation(ctx, duration)
+	elapsed := time.Since(start).Round(10 * time.Millisecond)
+
+	switch {
+	case err == nil:
+		fmt.Printf("timeout %v, work %v : completed with %q after %v\n", timeout, duration, result, elapsed)
+	case errors.Is(err, context.DeadlineExceeded): //! ctx.Err() tells WHY the context ended
+		fmt.Printf("timeout %v, work %v : cancelled, deadline exceeded after %v (%v)\n", timeout, duration, elapsed, err)
+	default:
+		fmt.Println("error :", err)
+	}
+}
+
+func main() {
+	run(500*time.Millisecond, 100*time.Millisecond) //! fast enough
+	run(200*time.Millisecond, 1*time.Second)        //! too slow, stopped after 200ms and NOT after 1s
+
+	/*
+		ctx.Err() returns :
+
+		nil                      -> the context is still active
+		context.Canceled         -> cancel() was called
+		context.DeadlineExceeded -> the timeout / deadline passed
+
+		context.WithDeadline(parent, time) is the same as WithTimeout, but with a point in time instead of a duration.
+	*/
+}
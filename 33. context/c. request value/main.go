@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! the key of a context value should be our OWN unexported type. A plain string key like "requestID" could clash with another package using the same string
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+func handleRequest(ctx context.Context, person Person) {
+	fmt.Println(requestID(ctx), ": handling request for", person.Name)
+	savePerson(ctx, person) //! ctx is passed down, the value travels with it
+}
+
+//! savePerson is two calls away from main, but still knows the request id without an extra parameter
+func savePerson(ctx context.Context, person Person) {
+	fmt.Println(requestID(ctx), `: saved Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+}
+
+func requestID(ctx context.Context) string {
+	id, ok := ctx.Value(requestIDKey).(string) //! Value returns 'any', so a type assertion is needed. comma-ok -> no panic if the value is missing
+	if !ok {
+		return "no-request-id"
+	}
+	return id
+}
+
+func main() {
+	ctx := context.WithValue(context.Background(), requestIDKey, "req-42")
+	handleRequest(ctx, Person{Name: "John", Age: 20, Email: "john@example.com"})
+
+	fmt.Println("--------------------------------")
+
+	handleRequest(context.Background(), Person{Name: "Jane", Age: 21, Email: "jane@example.com"}) //! no value in this context
+	fmt.Println("completed")
+
+	/*
+		Use context values only for data that belongs to ONE request (request id, logged in user, trace id).
+		Don't use them for normal function parameters, those should stay visible in the function signature.
+	*/
+}
@@ -0,0 +1,35 @@
+package main
+
+import "fmt"
+
+//! defer -> postpones a function call until the surrounding function returns
+func main() {
+	fmt.Println("start")
+
+	defer fmt.Println("deferred 1")
+	defer fmt.Println("deferred 2")
+	defer fmt.Println("deferred 3")
+
+	//! defer in a loop -> each iteration adds one more call
+	for i := 1; i <= 3; i++ {
+		defer fmt.Println("loop defer", i)
+	}
+
+	fmt.Println("end")
+
+	/*
+		Output :
+
+		start
+		end
+		loop defer 3
+		loop defer 2
+		loop defer 1
+		deferred 3
+		deferred 2
+		deferred 1
+
+		Deferred calls are kept on a stack : Last In First Out (LIFO). The last defer runs first, so the loop defers (deferred last) run before 'deferred 3'.
+		This is useful when things must be cleaned up in the reverse order they were created (open a file, then lock it -> unlock it, then close the file).
+	*/
+}
@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+func main() {
+	i := 0
+	defer fmt.Println("deferred value :", i) //! the argument 'i' is evaluated RIGHT NOW, when the defer line runs -> 0 is saved
+	i = 5
+	fmt.Println("current value  :", i)
+
+	//! a deferred closure doesn't take 'j' as an argument, it reads the variable when it RUNS, at the end of main
+	j := 0
+	defer func() {
+		fmt.Println("closure value  :", j)
+	}()
+	j = 5
+
+	/*
+		Output :
+
+		current value  : 5
+		closure value  : 5
+		deferred value : 0
+
+		defer fmt.Println(i) -> fmt.Println and its arguments are decided at the defer statement, only the CALL is postponed
+		defer func() { ... }() -> the closure captures the variable itself, so it sees the latest value
+	*/
+}
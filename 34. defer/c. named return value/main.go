@@ -0,0 +1,52 @@
+package main
+
+import "fmt"
+
+//! 'result' is a named return value. A deferred closure runs AFTER 'return 10' has set result = 10, but BEFORE the caller gets it, so it can still change it
+func double() (result int) {
+	defer func() {
+		result = result * 2
+	}()
+	return 10
+}
+
+//! without a named result, the deferred closure can't change what is returned
+func noEffect() int {
+	result := 10
+	defer func() {
+		result = result * 2 //! changes the local variable, but the return value was already copied
+	}()
+	return result
+}
+
+//! a real use : add information to an error on the way out
+func loadPerson(name string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("loadPerson %q : %w", name, err)
+		}
+	}()
+
+	if name == "" {
+		return fmt.Errorf("empty name")
+	}
+	return nil
+}
+
+func main() {
+	fmt.Println("double()   :", double())   //! 20
+	fmt.Println("noEffect() :", noEffect()) //! 10
+
+	fmt.Println("--------------------------------")
+
+	fmt.Println(loadPerson("John")) //! <nil>
+	fmt.Println(loadPerson(""))     //! loadPerson "" : empty name
+
+	/*
+		The order when a function returns :
+
+		1. the return values are set (result = 10)
+		2. the deferred calls run (result = 20)
+		3. the function really returns to the caller
+	*/
+}
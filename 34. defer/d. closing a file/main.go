@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err //! opening failed -> there is nothing to close, so the defer comes AFTER the error check
+	}
+	defer file.Close() //! written right next to Open, and runs on EVERY return below
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err //! the file is closed here too
+	}
+	return lines, nil //! and here
+}
+
+func main() {
+	lines, err := countLines("main.go")
+	if err != nil {
+		fmt.Println("error :", err)
+	} else {
+		fmt.Println("main.go has", lines, "lines")
+	}
+
+	_, err = countLines("missing.txt")
+	if err != nil {
+		fmt.Println("error :", err) //! open missing.txt: no such file or directory
+	}
+
+	/*
+		Without defer, we would have to call file.Close() before every return, and it's easy to forget one when a new return is added later.
+
+		Note : don't 'defer file.Close()' inside a loop over many files. Deferred calls only run when the FUNCTION returns, so all the files would stay open until then. Move the body of the loop into its own function instead (like countLines here).
+	*/
+}
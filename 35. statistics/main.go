@@ -0,0 +1,111 @@
+//! A small statistics toolkit (the stats package in stats/stats.go) used on the ages of 10 000 generated people
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"statistics/stats"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! generateRoster creates 'count' people with random ages. A fixed seed gives the same roster on every run
+func generateRoster(count int, seed uint64) []Person {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	people := make([]Person, count)
+	for i := range people {
+		age := int(math.Round(rng.NormFloat64()*12 + 35)) //! a bell curve around 35 years
+		age = max(18, min(age, 80))
+		people[i] = Person{
+			Name:  fmt.Sprintf("Person %d", i+1),
+			Age:   age,
+			Email: fmt.Sprintf("person%d@example.com", i+1),
+		}
+	}
+	return people
+}
+
+func main() {
+	people := generateRoster(10000, 42)
+
+	ages := make([]float64, len(people))
+	for i, person := range people {
+		ages[i] = float64(person.Age)
+	}
+
+	mean, _ := stats.Mean(ages)
+	median, _ := stats.Median(ages)
+	mode, _ := stats.Mode(ages)
+	stdDev, _ := stats.StdDev(ages)
+	p10, _ := stats.Percentile(ages, 10)
+	p90, _ := stats.Percentile(ages, 90)
+
+	fmt.Println("people :", len(people))
+	fmt.Printf("mean   : %.2f\n", mean)
+	fmt.Printf("median : %.2f\n", median)
+	fmt.Println("mode   :", mode) //! 18, not 35 : generateRoster clamps every age below 18 to 18, so they all pile up there
+	fmt.Printf("stddev : %.2f\n", stdDev)
+	fmt.Printf("p10    : %.2f\n", p10)
+	fmt.Printf("p90    : %.2f\n", p90)
+
+	fmt.Println("--------------------------------")
+
+	//! the histogram, drawn as a horizontal bar chart. '[' means included, ')' means not included
+	histogram := stats.Histogram(ages, 8)
+	largest := 0
+	total := 0
+	for _, bucket := range histogram {
+		largest = max(largest, bucket.Count)
+		total = total + bucket.Count
+	}
+	for i, bucket := range histogram {
+		closing := ")"
+		if i == len(histogram)-1 {
+			closing = "]"
+		}
+		bar := strings.Repeat("#", bucket.Count*40/largest)
+		fmt.Printf("[%5.1f, %5.1f%s %5d %s\n", bucket.Low, bucket.High, closing, bucket.Count, bar)
+	}
+	fmt.Println("sum of the counts :", total) //! always equal to the number of values
+
+	fmt.Println("--------------------------------")
+
+	//! known datasets
+	oneToHundred := make([]float64, 100)
+	for i := range oneToHundred {
+		oneToHundred[i] = float64(i + 1)
+	}
+	q1, _ := stats.Percentile(oneToHundred, 25)
+	q2, _ := stats.Percentile(oneToHundred, 50)
+	q3, _ := stats.Percentile(oneToHundred, 75)
+	fmt.Println("quartiles of 1..100 :", q1, q2, q3) //! 25.75 50.5 75.25
+
+	evenMedian, _ := stats.Median([]float64{4, 1, 3, 2})
+	fmt.Println("median of [4 1 3 2] :", evenMedian) //! 2.5
+
+	modes, _ := stats.Mode([]float64{1, 1, 2, 2, 3})
+	fmt.Println("mode of [1 1 2 2 3] :", modes) //! two values share the highest count
+
+	single, _ := stats.StdDev([]float64{7})
+	singleMedian, _ := stats.Median([]float64{7})
+	fmt.Println("single element      : median", singleMedian, "stddev", single)
+
+	fmt.Println("--------------------------------")
+
+	//! invalid input
+	_, err := stats.Mean(nil)
+	fmt.Println("empty   :", err)
+	_, err = stats.Mean([]float64{1, math.NaN(), 3})
+	fmt.Println("NaN     :", err)
+	_, err = stats.StdDev([]float64{1, math.Inf(1)})
+	fmt.Println("Inf     :", err)
+	_, err = stats.Percentile(oneToHundred, 101)
+	fmt.Println("p = 101 :", err)
+}
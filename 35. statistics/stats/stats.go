@@ -0,0 +1,159 @@
+//! Package stats -> a small statistics toolkit : mean, median, mode, percentiles, standard deviation and histograms
+//! every function validates its input first and returns ErrEmpty or ErrNotFinite instead of a silently wrong result
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+//! errors which the caller can check with errors.Is
+var (
+	ErrEmpty     = errors.New("stats: empty input")
+	ErrNotFinite = errors.New("stats: input contains NaN or Inf")
+)
+
+//! validate is called first by every function, so NaN / Inf never silently produce a wrong result (any calculation with NaN gives NaN)
+func validate(xs []float64) error {
+	if len(xs) == 0 {
+		return ErrEmpty
+	}
+	for i, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return fmt.Errorf("%w (index %d : %v)", ErrNotFinite, i, x)
+		}
+	}
+	return nil
+}
+
+//! sorted returns a sorted COPY. Sorting xs itself would change the caller's slice, because a slice shares its underlying array
+func sorted(xs []float64) []float64 {
+	result := make([]float64, len(xs))
+	copy(result, xs)
+	sort.Float64s(result)
+	return result
+}
+
+func Mean(xs []float64) (float64, error) {
+	if err := validate(xs); err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum = sum + x
+	}
+	return sum / float64(len(xs)), nil
+}
+
+//! Mode -> the most frequent value. Several values can share the highest count (for example [1 1 2 2 3]), so all of them are returned, sorted
+func Mode(xs []float64) ([]float64, error) {
+	if err := validate(xs); err != nil {
+		return nil, err
+	}
+	counts := map[float64]int{}
+	highest := 0
+	for _, x := range xs {
+		counts[x]++
+		highest = max(highest, counts[x])
+	}
+	var modes []float64
+	for x, count := range counts {
+		if count == highest {
+			modes = append(modes, x)
+		}
+	}
+	sort.Float64s(modes) //! map order is random, the result must not be
+	return modes, nil
+}
+
+//! Median -> the middle value. With an even count there are TWO middle values, the median is their average
+//! [1 2 3]   -> 2
+//! [1 2 3 4] -> (2 + 3) / 2 = 2.5
+func Median(xs []float64) (float64, error) {
+	if err := validate(xs); err != nil {
+		return 0, err
+	}
+	s := sorted(xs)
+	middle := len(s) / 2
+	if len(s)%2 == 0 {
+		return (s[middle-1] + s[middle]) / 2, nil
+	}
+	return s[middle], nil
+}
+
+//! Percentile -> the value below which p percent of the data falls. p = 50 is the median, p = 25 / 75 are the quartiles
+//! linear interpolation : the position is p/100 * (n-1). If it falls between two values, we take the point between them
+//! for 1..100 : p = 25 -> position 24.75 -> between 25 and 26 -> 25.75
+func Percentile(xs []float64, p float64) (float64, error) {
+	if err := validate(xs); err != nil {
+		return 0, err
+	}
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, fmt.Errorf("stats: percentile %v is outside [0, 100]", p)
+	}
+	s := sorted(xs)
+	position := p / 100 * float64(len(s)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	fraction := position - float64(lower)
+	return s[lower] + (s[upper]-s[lower])*fraction, nil
+}
+
+//! StdDev -> the population standard deviation : how far the values are from the mean, on average
+//! it is calculated in ONE pass with Welford's method. The simple formula sqrt(mean(x*x) - mean(x)*mean(x)) loses precision badly when the values are large and close together
+func StdDev(xs []float64) (float64, error) {
+	if err := validate(xs); err != nil {
+		return 0, err
+	}
+	mean, m2 := 0.0, 0.0
+	for i, x := range xs {
+		delta := x - mean
+		mean = mean + delta/float64(i+1)
+		m2 = m2 + delta*(x-mean)
+	}
+	return math.Sqrt(m2 / float64(len(xs))), nil
+}
+
+//! Bucket counts the values in [Low, High). Only the LAST bucket also includes High, so the maximum is not lost
+type Bucket struct {
+	Low, High float64
+	Count     int
+}
+
+//! Histogram splits the range [min, max] into equal buckets. It returns nil for invalid input (empty, NaN / Inf, buckets < 1)
+//! every value goes into exactly one bucket, so the counts always add up to len(xs)
+func Histogram(xs []float64, buckets int) []Bucket {
+	if buckets < 1 || validate(xs) != nil {
+		return nil
+	}
+	s := sorted(xs)
+	low, high := s[0], s[len(s)-1]
+	if low == high {
+		return []Bucket{{Low: low, High: high, Count: len(xs)}} //! all values are equal, one bucket is enough
+	}
+
+	width := (high - low) / float64(buckets)
+	result := make([]Bucket, buckets)
+	for i := range result {
+		result[i].Low = low + float64(i)*width
+		result[i].High = low + float64(i+1)*width
+	}
+	result[buckets-1].High = high //! avoid a tiny rounding error at the end
+
+	for _, x := range xs {
+		index := int((x - low) / width)
+		if index >= buckets {
+			index = buckets - 1 //! x == max belongs to the last bucket
+		}
+		//! the division can be off by one because of floating point rounding, the bucket boundaries decide
+		if x < result[index].Low {
+			index--
+		} else if x >= result[index].High && index < buckets-1 {
+			index++
+		}
+		result[index].Count++
+	}
+	return result
+}
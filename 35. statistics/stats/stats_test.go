@@ -0,0 +1,262 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+//! oneToHundred -> 1, 2, ..., 100, a dataset whose results are known by heart
+func oneToHundred() []float64 {
+	xs := make([]float64, 100)
+	for i := range xs {
+		xs[i] = float64(i + 1)
+	}
+	return xs
+}
+
+//! the invalid inputs every function must reject
+var invalid = []struct {
+	name    string
+	xs      []float64
+	wantErr error
+}{
+	{"nil", nil, ErrEmpty},
+	{"empty", []float64{}, ErrEmpty},
+	{"NaN", []float64{1, math.NaN(), 3}, ErrNotFinite},
+	{"+Inf", []float64{1, math.Inf(1)}, ErrNotFinite},
+	{"-Inf", []float64{math.Inf(-1)}, ErrNotFinite},
+}
+
+func TestInvalidInput(t *testing.T) {
+	functions := []struct {
+		name string
+		call func(xs []float64) error
+	}{
+		{"Mean", func(xs []float64) error { _, err := Mean(xs); return err }},
+		{"Median", func(xs []float64) error { _, err := Median(xs); return err }},
+		{"Mode", func(xs []float64) error { _, err := Mode(xs); return err }},
+		{"StdDev", func(xs []float64) error { _, err := StdDev(xs); return err }},
+		{"Percentile", func(xs []float64) error { _, err := Percentile(xs, 50); return err }},
+	}
+	for _, function := range functions {
+		for _, tt := range invalid {
+			t.Run(function.name+"/"+tt.name, func(t *testing.T) {
+				if err := function.call(tt.xs); !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s(%v) error = %v, want %v", function.name, tt.xs, err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestMean(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"single element", []float64{7}, 7},
+		{"1..100", oneToHundred(), 50.5},
+		{"negative values", []float64{-3, -1, 1, 3}, 0},
+		{"fractions", []float64{0.5, 1.5, 2.5}, 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Mean(tt.xs)
+			if err != nil || got != tt.want {
+				t.Errorf("Mean(%v) = %v, %v; want %v, nil", tt.xs, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"single element", []float64{7}, 7},
+		{"odd count", []float64{3, 1, 2}, 2},
+		{"even count : average of the two middle values", []float64{4, 1, 3, 2}, 2.5},
+		{"1..100", oneToHundred(), 50.5},
+		{"duplicates", []float64{5, 5, 5, 1}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Median(tt.xs)
+			if err != nil || got != tt.want {
+				t.Errorf("Median(%v) = %v, %v; want %v, nil", tt.xs, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedianDoesNotSortTheInput(t *testing.T) {
+	xs := []float64{4, 1, 3, 2}
+	Median(xs)
+	if want := []float64{4, 1, 3, 2}; !slices.Equal(xs, want) {
+		t.Errorf("after Median the input is %v, want %v", xs, want)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want []float64
+	}{
+		{"single element", []float64{7}, []float64{7}},
+		{"one mode", []float64{1, 2, 2, 3}, []float64{2}},
+		{"two modes, sorted", []float64{3, 3, 1, 1, 2}, []float64{1, 3}},
+		{"all different : every value", []float64{3, 1, 2}, []float64{1, 2, 3}},
+		{"negative zero equals zero", []float64{0, math.Copysign(0, -1), 1}, []float64{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Mode(tt.xs)
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("Mode(%v) = %v, %v; want %v, nil", tt.xs, got, err, tt.want)
+			}
+		})
+	}
+}
+
+//! twoPassStdDev is the textbook way : first the mean, then the squared distances. The reference for StdDev
+func twoPassStdDev(xs []float64) float64 {
+	mean, _ := Mean(xs)
+	sum := 0.0
+	for _, x := range xs {
+		sum = sum + (x-mean)*(x-mean)
+	}
+	return math.Sqrt(sum / float64(len(xs)))
+}
+
+func TestStdDev(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	ages := make([]float64, 10000)
+	for i := range ages {
+		ages[i] = math.Round(rng.NormFloat64()*12 + 35)
+	}
+	//! large values close together : the shortcut sqrt(mean(x*x) - mean(x)*mean(x)) loses precision here, Welford doesn't
+	large := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"single element", []float64{7}, 0},
+		{"all equal", []float64{3, 3, 3}, 0},
+		{"known dataset", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 2},
+		{"1..100", oneToHundred(), twoPassStdDev(oneToHundred())},
+		{"10 000 ages", ages, twoPassStdDev(ages)},
+		{"large values close together", large, twoPassStdDev(large)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StdDev(tt.xs)
+			if err != nil || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("StdDev = %v, %v; want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs      []float64
+		p       float64
+		want    float64
+		wantErr bool
+	}{
+		{"Q1 of 1..100", oneToHundred(), 25, 25.75, false},
+		{"Q2 of 1..100 is the median", oneToHundred(), 50, 50.5, false},
+		{"Q3 of 1..100", oneToHundred(), 75, 75.25, false},
+		{"p = 0 is the minimum", oneToHundred(), 0, 1, false},
+		{"p = 100 is the maximum", oneToHundred(), 100, 100, false},
+		{"single element", []float64{7}, 90, 7, false},
+		{"unsorted input", []float64{30, 10, 20}, 50, 20, false},
+		{"p < 0", oneToHundred(), -1, 0, true},
+		{"p > 100", oneToHundred(), 101, 0, true},
+		{"p is NaN", oneToHundred(), math.NaN(), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Percentile(tt.xs, tt.p)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Percentile(p = %v) = %v, nil; want an error", tt.p, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Percentile(p = %v) = %v, %v; want %v, nil", tt.p, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs      []float64
+		buckets int
+		want    []Bucket
+	}{
+		{"boundary values go up : [0, 1) [1, 2) [2, 3]", []float64{0, 1, 2, 3}, 3, []Bucket{{0, 1, 1}, {1, 2, 1}, {2, 3, 2}}},
+		{"the maximum is in the last bucket", []float64{0, 10}, 2, []Bucket{{0, 5, 1}, {5, 10, 1}}},
+		{"all values equal : one bucket", []float64{4, 4, 4}, 5, []Bucket{{4, 4, 3}}},
+		{"empty", nil, 3, nil},
+		{"NaN", []float64{1, math.NaN()}, 3, nil},
+		{"zero buckets", []float64{1, 2}, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Histogram(tt.xs, tt.buckets); !slices.Equal(got, tt.want) {
+				t.Errorf("Histogram(%v, %d) = %v, want %v", tt.xs, tt.buckets, got, tt.want)
+			}
+		})
+	}
+}
+
+//! with awkward widths (like 62 / 8 = 7.75) every value must still land in exactly one half-open bucket
+func TestHistogramCountsSumToN(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, buckets := range []int{1, 3, 7, 8, 13} {
+		xs := make([]float64, 1000)
+		for i := range xs {
+			xs[i] = float64(18 + rng.IntN(63))
+		}
+
+		histogram := Histogram(xs, buckets)
+		total := 0
+		for i, bucket := range histogram {
+			total = total + bucket.Count
+			if i > 0 && bucket.Low != histogram[i-1].High {
+				t.Errorf("%d buckets : bucket %d starts at %v, the previous one ends at %v", buckets, i, bucket.Low, histogram[i-1].High)
+			}
+		}
+		if total != len(xs) {
+			t.Errorf("%d buckets : counts add up to %d, want %d", buckets, total, len(xs))
+		}
+
+		//! count again by hand with the half-open rule, and compare
+		for i, bucket := range histogram {
+			last := i == len(histogram)-1
+			want := 0
+			for _, x := range xs {
+				if x >= bucket.Low && (x < bucket.High || last && x == bucket.High) {
+					want++
+				}
+			}
+			if bucket.Count != want {
+				t.Errorf("%d buckets : bucket %d [%v, %v) has count %d, want %d", buckets, i, bucket.Low, bucket.High, bucket.Count, want)
+			}
+		}
+	}
+}
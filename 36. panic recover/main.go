@@ -0,0 +1,104 @@
+//! panic -> stops the normal flow of the program. The deferred functions still run, then the program crashes with a message and a stack trace
+//! recover -> catches a panic, but ONLY when it is called inside a deferred function
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//! safeDivide turns the divide-by-zero panic into a normal error
+func safeDivide(a, b int) (result int, err error) {
+	defer func() {
+		if r := recover(); r != nil { //! r is the value passed to panic (here a runtime error)
+			err = fmt.Errorf("safeDivide(%d, %d) : %v", a, b, r) //! a deferred closure can change a named result
+		}
+	}()
+
+	result = a / b //! panics when b is 0 : runtime error: integer divide by zero
+	return result, nil
+}
+
+//! getElement has no recover at all. A panic here goes UP to the caller, and to the caller's caller ... until someone recovers it
+func getElement(arr [5]int, index int) int {
+	return arr[index] //! panics when index is not between 0 and 4
+}
+
+func sumFirst(arr [5]int, count int) int {
+	sum := 0
+	for i := 0; i < count; i++ {
+		sum = sum + getElement(arr, i)
+	}
+	return sum
+}
+
+//! run is the 'higher level'. One recover here protects every function called below it
+func run(arr [5]int, count int) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("recovered in run :", r)
+		}
+	}()
+
+	fmt.Println("sum of the first", count, "elements :", sumFirst(arr, count)) //! not printed when sumFirst panics
+}
+
+//! recover called directly, NOT inside a deferred function, does nothing
+func notDeferred() {
+	if r := recover(); r != nil { //! always nil here : there is no panic happening while this line runs
+		fmt.Println("never printed")
+	}
+	fmt.Println("notDeferred : recover() returned nil")
+}
+
+func main() {
+	result, err := safeDivide(10, 2)
+	fmt.Println("10 / 2 :", result, err) //! 5 <nil>
+
+	result, err = safeDivide(10, 0)
+	fmt.Println("10 / 0 :", result, err) //! 0 safeDivide(10, 0) : runtime error: integer divide by zero
+
+	fmt.Println("--------------------------------")
+
+	arr := [5]int{1, 2, 3, 4, 5}
+	run(arr, 3) //! 6
+	run(arr, 7) //! recovered in run : runtime error: index out of range [5] with length 5
+	fmt.Println("the program is still running")
+
+	fmt.Println("--------------------------------")
+
+	notDeferred()
+
+	//! a panic with our own value. panic accepts any value, an error is the most useful one
+	func() {
+		defer func() {
+			r := recover()
+			if err, ok := r.(error); ok && errors.Is(err, errInvalidAge) {
+				fmt.Println("recovered our own panic :", err)
+			}
+		}()
+		mustValidAge(-3)
+	}()
+
+	/*
+		When to panic and when to return an error?
+
+		Return an error -> for things that CAN go wrong in normal use : a missing file, wrong user input, a network problem. The caller decides what to do.
+
+		Panic -> only for programmer mistakes that should never happen, or when the program can't continue at all (for example broken configuration at startup).
+		Functions called 'Must...' (like regexp.MustCompile) panic on purpose, for values fixed in the code.
+
+		recover is for the borders of a program : a web server recovers so one bad request doesn't stop the whole server. Don't use panic / recover as a try / catch for normal errors.
+
+		Note : a panic in ANOTHER goroutine can't be recovered here. Every goroutine needs its own deferred recover.
+	*/
+}
+
+var errInvalidAge = errors.New("invalid age")
+
+func mustValidAge(age int) int {
+	if age < 0 {
+		panic(fmt.Errorf("%w : %d", errInvalidAge, age))
+	}
+	return age
+}
@@ -0,0 +1,69 @@
+//! An interactive wizard which builds a Person step by step. Commands at every step : back, preview, abort
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+//! appendToRoster reads the JSON roster (a list of people), adds the person and writes it back
+func appendToRoster(path string, person Person) error {
+	var people []Person
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &people); err != nil {
+			return fmt.Errorf("reading %s : %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) { //! a missing roster is fine, it is created
+		return err
+	}
+
+	people = append(people, person)
+	data, err = json.MarshalIndent(people, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func main() {
+	roster := flag.String("roster", filepath.Join(os.TempDir(), "people.json"), "the JSON roster file")
+	flag.Parse()
+
+	wizard := NewWizard()
+	fmt.Println("Commands : back, preview, abort")
+	fmt.Println(wizard.Start().Text)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		prompt, err := wizard.Next(scanner.Text())
+		if err != nil {
+			fmt.Println("error :", err)
+		}
+		fmt.Println(prompt.Text)
+		if prompt.Done {
+			break
+		}
+	}
+
+	if !wizard.Saved {
+		return //! aborted, answered 'no', or the input ended (Ctrl+D)
+	}
+
+	if err := appendToRoster(*roster, wizard.Person()); err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+	fmt.Println("added to", *roster)
+}
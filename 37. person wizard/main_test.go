@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppendToRoster(t *testing.T) {
+	john := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	jane := Person{Name: "Jane", Age: 21, Email: "jane@example.com"}
+	tests := []struct {
+		name     string
+		existing string //! "" -> no file yet
+		want     []Person
+		wantErr  string
+	}{
+		{"the roster is created", "", []Person{jane}, ""},
+		{"appended to the existing people", `[{"name":"John","age":20,"email":"john@example.com"}]`, []Person{john, jane}, ""},
+		{"empty list", `[]`, []Person{jane}, ""},
+		{"broken roster is not overwritten", `[{"name":`, nil, "unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "people.json")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := appendToRoster(path, jane)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("appendToRoster error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				if data, _ := os.ReadFile(path); string(data) != tt.existing {
+					t.Errorf("roster = %q after the error, want it unchanged %q", data, tt.existing)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("appendToRoster error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var people []Person
+			if err := json.Unmarshal(got, &people); err != nil || !reflect.DeepEqual(people, tt.want) {
+				t.Errorf("roster = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+//! the wizard is a 'state machine' : it is always in one state (asking for a field, or asking to confirm), and every input moves it to the next state
+//! it doesn't read the keyboard or print anything itself. It only gets a string and returns the next prompt, so a whole session can be driven from code
+
+type Prompt struct {
+	Text string
+	Done bool //! true -> the wizard is finished (saved or aborted), no more input is expected
+}
+
+//! field describes one step : its name, the question, and how to check the answer
+type field struct {
+	name     string
+	question string
+	validate func(string) error
+}
+
+var fields = []field{
+	{"Name", "Name :", validateName},
+	{"Age", "Age :", validateAge},
+	{"Email", "Email :", validateEmail},
+}
+
+var ErrFirstField = errors.New("already at the first field")
+
+type Wizard struct {
+	current  int               //! index of the field being asked. len(fields) means 'asking to confirm'
+	values   map[string]string //! the answers entered so far
+	undo     []int             //! undo stack : the indexes of the answered fields, the last one on top
+	Saved    bool
+	Aborted  bool
+	finished bool
+}
+
+func NewWizard() *Wizard {
+	return &Wizard{values: map[string]string{}}
+}
+
+//! Start returns the first prompt
+func (w *Wizard) Start() Prompt {
+	return w.prompt()
+}
+
+func (w *Wizard) prompt() Prompt {
+	if w.current == len(fields) {
+		return Prompt{Text: "Save this person? (yes / no)"}
+	}
+	return Prompt{Text: fields[w.current].question}
+}
+
+//! Next takes one line of input. On an error, the returned prompt asks the same question again
+func (w *Wizard) Next(input string) (Prompt, error) {
+	if w.finished {
+		return Prompt{Done: true}, errors.New("the wizard is already finished")
+	}
+	input = strings.TrimSpace(input)
+
+	//! commands work at every step
+	switch input {
+	case "back":
+		if len(w.undo) == 0 {
+			return w.prompt(), ErrFirstField
+		}
+		previous := w.undo[len(w.undo)-1]
+		w.undo = w.undo[:len(w.undo)-1] //! pop
+		delete(w.values, fields[previous].name)
+		w.current = previous
+		return w.prompt(), nil
+	case "preview":
+		return Prompt{Text: w.Preview() + "\n" + w.prompt().Text}, nil
+	case "abort":
+		w.Aborted, w.finished = true, true
+		return Prompt{Text: "Aborted, nothing was saved.", Done: true}, nil
+	}
+
+	//! the confirm step
+	if w.current == len(fields) {
+		switch strings.ToLower(input) {
+		case "yes", "y":
+			w.Saved, w.finished = true, true
+			return Prompt{Text: "Saved.", Done: true}, nil
+		case "no", "n":
+			w.Aborted, w.finished = true, true
+			return Prompt{Text: "Not saved.", Done: true}, nil
+		}
+		return w.prompt(), fmt.Errorf("please answer yes or no")
+	}
+
+	//! a field
+	current := fields[w.current]
+	if err := current.validate(input); err != nil {
+		return w.prompt(), fmt.Errorf("%s : %w", current.name, err)
+	}
+	w.values[current.name] = input
+	w.undo = append(w.undo, w.current) //! push
+	w.current++
+	return w.prompt(), nil
+}
+
+//! Person builds the struct from the values entered so far. Missing fields stay zero values
+func (w *Wizard) Person() Person {
+	age, _ := strconv.Atoi(w.values["Age"]) //! already validated, can't fail
+	return Person{Name: w.values["Name"], Age: age, Email: w.values["Email"]}
+}
+
+//! Preview pretty prints the partially built Person. '-' marks a field that is not entered yet
+func (w *Wizard) Preview() string {
+	var builder strings.Builder
+	builder.WriteString("Person {\n")
+	for _, f := range fields {
+		value, ok := w.values[f.name]
+		if !ok {
+			value = "-"
+		}
+		fmt.Fprintf(&builder, "  %-6s %s\n", f.name+":", value)
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+//! validation for every field
+
+func validateName(s string) error {
+	if s == "" {
+		return errors.New("can't be empty")
+	}
+	if len([]rune(s)) > 50 {
+		return errors.New("is longer than 50 characters")
+	}
+	return nil
+}
+
+func validateAge(s string) error {
+	age, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", s)
+	}
+	if age < 0 || age > 150 {
+		return fmt.Errorf("%d is not between 0 and 150", age)
+	}
+	return nil
+}
+
+func validateEmail(s string) error {
+	address, err := mail.ParseAddress(s)
+	if err != nil || address.Address != s { //! ParseAddress also accepts 'John <john@example.com>', we want only the address
+		return fmt.Errorf("%q is not a valid email address", s)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//! step -> one line of input, and what the wizard must answer
+type step struct {
+	input    string
+	want     string //! the prompt text
+	wantErr  string //! "" -> no error
+	wantDone bool
+}
+
+//! previewOf -> what 'preview' answers : the pretty printed person, then the current question again
+func previewOf(name, age, email, question string) string {
+	return "Person {\n  Name:  " + name + "\n  Age:   " + age + "\n  Email: " + email + "\n}\n" + question
+}
+
+func TestSessions(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       []step
+		wantSaved   bool
+		wantAborted bool
+		wantPerson  Person
+	}{
+		{"happy path", []step{
+			{"John", "Age :", "", false},
+			{"20", "Email :", "", false},
+			{"john@example.com", "Save this person? (yes / no)", "", false},
+			{"yes", "Saved.", "", true},
+		}, true, false, Person{"John", 20, "john@example.com"}},
+
+		{"invalid entries ask the same question again", []step{
+			{"", "Name :", "Name : can't be empty", false},
+			{"John", "Age :", "", false},
+			{"abc", "Age :", `Age : "abc" is not a number`, false},
+			{"200", "Age :", "Age : 200 is not between 0 and 150", false},
+			{"20", "Email :", "", false},
+			{"John <john@example.com>", "Email :", `Email : "John <john@example.com>" is not a valid email address`, false},
+			{"john@example.com", "Save this person? (yes / no)", "", false},
+			{"maybe", "Save this person? (yes / no)", "please answer yes or no", false},
+			{"Y", "Saved.", "", true},
+		}, true, false, Person{"John", 20, "john@example.com"}},
+
+		{"back clears the value and asks again", []step{
+			{"John", "Age :", "", false},
+			{"20", "Email :", "", false},
+			{"back", "Age :", "", false},
+			{"back", "Name :", "", false},
+			{"Jane", "Age :", "", false},
+			{"21", "Email :", "", false},
+			{"jane@example.com", "Save this person? (yes / no)", "", false},
+			{"back", "Email :", "", false},
+			{"jane@example.org", "Save this person? (yes / no)", "", false},
+			{"yes", "Saved.", "", true},
+		}, true, false, Person{"Jane", 21, "jane@example.org"}},
+
+		{"repeated backs past the first field", []step{
+			{"back", "Name :", "already at the first field", false},
+			{"John", "Age :", "", false},
+			{"back", "Name :", "", false},
+			{"back", "Name :", "already at the first field", false},
+			{"back", "Name :", "already at the first field", false},
+			{"Jane", "Age :", "", false},
+		}, false, false, Person{Name: "Jane"}},
+
+		{"preview at each stage", []step{
+			{"preview", previewOf("-", "-", "-", "Name :"), "", false},
+			{"John", "Age :", "", false},
+			{"preview", previewOf("John", "-", "-", "Age :"), "", false},
+			{"20", "Email :", "", false},
+			{"preview", previewOf("John", "20", "-", "Email :"), "", false},
+			{"john@example.com", "Save this person? (yes / no)", "", false},
+			{"preview", previewOf("John", "20", "john@example.com", "Save this person? (yes / no)"), "", false},
+			{"back", "Email :", "", false},
+			{"preview", previewOf("John", "20", "-", "Email :"), "", false},
+		}, false, false, Person{Name: "John", Age: 20}},
+
+		{"abort without save", []step{
+			{"John", "Age :", "", false},
+			{"abort", "Aborted, nothing was saved.", "", true},
+		}, false, true, Person{Name: "John"}},
+
+		{"abort at the confirm step", []step{
+			{"John", "Age :", "", false},
+			{"20", "Email :", "", false},
+			{"john@example.com", "Save this person? (yes / no)", "", false},
+			{"abort", "Aborted, nothing was saved.", "", true},
+		}, false, true, Person{"John", 20, "john@example.com"}},
+
+		{"answer no", []step{
+			{"John", "Age :", "", false},
+			{"20", "Email :", "", false},
+			{"john@example.com", "Save this person? (yes / no)", "", false},
+			{"no", "Not saved.", "", true},
+		}, false, true, Person{"John", 20, "john@example.com"}},
+
+		{"input is trimmed", []step{
+			{"  John  ", "Age :", "", false},
+			{" 20\t", "Email :", "", false},
+			{" back ", "Age :", "", false},
+		}, false, false, Person{Name: "John"}},
+
+		{"input after the end", []step{
+			{"abort", "Aborted, nothing was saved.", "", true},
+			{"John", "", "the wizard is already finished", true},
+		}, false, true, Person{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wizard := NewWizard()
+			if got := wizard.Start(); got.Text != "Name :" || got.Done {
+				t.Fatalf("Start = %+v, want the Name question", got)
+			}
+			for i, s := range tt.steps {
+				prompt, err := wizard.Next(s.input)
+				gotErr := ""
+				if err != nil {
+					gotErr = err.Error()
+				}
+				if prompt.Text != s.want || gotErr != s.wantErr || prompt.Done != s.wantDone {
+					t.Fatalf("step %d Next(%q) = %q done %v, error %q; want %q done %v, error %q", i+1, s.input, prompt.Text, prompt.Done, gotErr, s.want, s.wantDone, s.wantErr)
+				}
+			}
+			if wizard.Saved != tt.wantSaved || wizard.Aborted != tt.wantAborted {
+				t.Errorf("Saved, Aborted = %v, %v; want %v, %v", wizard.Saved, wizard.Aborted, tt.wantSaved, tt.wantAborted)
+			}
+			if got := wizard.Person(); got != tt.wantPerson {
+				t.Errorf("Person = %+v, want %+v", got, tt.wantPerson)
+			}
+		})
+	}
+}
+
+func TestBackAtFirstFieldIsErrFirstField(t *testing.T) {
+	_, err := NewWizard().Next("back")
+	if !errors.Is(err, ErrFirstField) {
+		t.Errorf("Next(back) error = %v, want ErrFirstField", err)
+	}
+}
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		input    string
+		wantOK   bool
+	}{
+		{"name", validateName, "John", true},
+		{"name in bengali", validateName, "ফয়জুল", true},
+		{"empty name", validateName, "", false},
+		{"name of 50 characters", validateName, strings.Repeat("a", 50), true},
+		{"name of 51 characters", validateName, strings.Repeat("a", 51), false},
+		{"50 bengali letters are 50 characters, not 150 bytes", validateName, strings.Repeat("ক", 50), true},
+		{"age", validateAge, "20", true},
+		{"age 0", validateAge, "0", true},
+		{"age 150", validateAge, "150", true},
+		{"age -1", validateAge, "-1", false},
+		{"age 151", validateAge, "151", false},
+		{"age with a decimal point", validateAge, "20.5", false},
+		{"age in words", validateAge, "twenty", false},
+		{"email", validateEmail, "john@example.com", true},
+		{"email without @", validateEmail, "john.example.com", false},
+		{"email with a display name", validateEmail, "John <john@example.com>", false},
+		{"empty email", validateEmail, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validate(tt.input); (err == nil) != tt.wantOK {
+				t.Errorf("validate(%q) = %v, want ok = %v", tt.input, err, tt.wantOK)
+			}
+		})
+	}
+}
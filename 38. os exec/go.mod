@@ -0,0 +1,3 @@
+module osexec
+
+go 1.22
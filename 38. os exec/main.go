@@ -0,0 +1,247 @@
+//! os/exec -> runs other programs from Go. Here we run 'go vet' and 'go test -json' on a lesson directory, read their output line by line while they run, and turn the test output into a summary table.
+//! on Linux and macOS a timeout kills the whole process group (procgroup_unix.go), elsewhere only the command itself (procgroup_other.go)
+//! the 'go test -json' parser is the testjson package, which the exercise grader lesson imports too
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"osexec/testjson"
+)
+
+type Summary struct {
+	Passed, Failed, Skipped int
+	Elapsed                 float64
+}
+
+//! CommandResult -> what happened to the child process
+type CommandResult struct {
+	ExitCode int
+	TimedOut bool
+	Duration time.Duration
+}
+
+//! runCommand starts 'name args...' in dir and calls onLine for every line of its combined output (stdout and stderr) WHILE it runs
+//! a non-zero exit code is NOT returned as an error : go vet and go test exit with 1 when they find problems, but their output is still useful
+func runCommand(ctx context.Context, dir string, onLine func(string), name string, args ...string) (CommandResult, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return CommandResult{}, fmt.Errorf("%s not found in PATH, is it installed? (%w)", name, err)
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = dir
+
+	killProcessGroup(cmd)
+	cmd.WaitDelay = 2 * time.Second //! don't wait forever for the output pipe after the kill
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer //! the same writer for both -> combined output, in the order it was written
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return CommandResult{}, err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		writer.Close() //! ends the scanner loop below
+		waitErr <- err
+	}()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) //! test output lines can be long
+	for scanner.Scan() {
+		onLine(scanner.Text())
+	}
+	io.Copy(io.Discard, reader) //! if the scanner stopped early, keep draining so the child never blocks on a full pipe
+
+	err = <-waitErr
+	result := CommandResult{ExitCode: cmd.ProcessState.ExitCode(), Duration: time.Since(start)}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		return result, fmt.Errorf("%s timed out after %v", name, result.Duration.Round(time.Millisecond))
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return result, err //! a real failure (for example the pipe broke), not just a non-zero exit
+	}
+	return result, nil
+}
+
+func Summarize(results []testjson.Result) Summary {
+	var summary Summary
+	for _, result := range results {
+		switch result.Status {
+		case "pass":
+			summary.Passed++
+		case "fail":
+			summary.Failed++
+		case "skip":
+			summary.Skipped++
+		}
+		if !strings.Contains(result.Name, "/") { //! a subtest's time is already included in its parent's time, don't count it twice
+			summary.Elapsed = summary.Elapsed + result.Elapsed
+		}
+	}
+	return summary
+}
+
+func printTable(results []testjson.Result) {
+	fmt.Printf("  %-28s %-6s %8s\n", "TEST", "RESULT", "TIME")
+	for _, result := range results {
+		fmt.Printf("  %-28s %-6s %7.2fs\n", result.Name, strings.ToUpper(result.Status), result.Elapsed)
+		if result.Status == "fail" {
+			for _, line := range result.Output {
+				if strings.Contains(line, "_test.go:") || strings.HasPrefix(line, "panic:") {
+					fmt.Println("      ", strings.TrimSpace(line)) //! only the interesting lines : the error location and the panic message
+				}
+			}
+		}
+	}
+	summary := Summarize(results)
+	fmt.Printf("  passed %d, failed %d, skipped %d, total time %.2fs\n", summary.Passed, summary.Failed, summary.Skipped, summary.Elapsed)
+}
+
+//! goFiles lists the .go files of a lesson. The lessons have no go.mod, so the files are passed to go vet / go test by name
+func goFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .go files in %s", dir)
+	}
+	for i, file := range files {
+		files[i] = filepath.Base(file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func check(dir string, timeout time.Duration) error {
+	files, err := goFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fmt.Println("$ go vet", strings.Join(files, " "))
+	vet, err := runCommand(ctx, dir, func(line string) { fmt.Println("  |", line) }, "go", append([]string{"vet"}, files...)...)
+	if err != nil {
+		return err
+	}
+	fmt.Println("  go vet exit code :", vet.ExitCode)
+
+	fmt.Println("$ go test -json", strings.Join(files, " "))
+	collector := testjson.NewCollector()
+	test, err := runCommand(ctx, dir, func(line string) {
+		if text := collector.Add(line); text != "" {
+			fmt.Println("  |", text)
+		}
+	}, "go", append([]string{"test", "-json"}, files...)...)
+	if err != nil {
+		return err
+	}
+	fmt.Println("  go test exit code :", test.ExitCode, "(1 means a test failed, the output is still parsed)")
+	printTable(collector.Results())
+	return nil
+}
+
+//! writeSampleLesson creates a small lesson with passing, failing, skipped and panicking tests, so there is something to check
+func writeSampleLesson() (string, error) {
+	dir, err := os.MkdirTemp("", "sample-lesson")
+	if err != nil {
+		return "", err
+	}
+	files := map[string]string{
+		"main.go": "package main\n\nimport \"fmt\"\n\nfunc add(a, b int) int { return a + b }\n\nfunc main() { fmt.Println(add(1, 2)) }\n",
+		"main_test.go": `package main
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if add(2, 3) != 5 {
+		t.Fatal("2 + 3 should be 5")
+	}
+}
+
+func TestAddWrong(t *testing.T) {
+	if got := add(2, 2); got != 5 {
+		t.Errorf("add(2, 2) = %d, want 5", got)
+	}
+}
+
+func TestSkipped(t *testing.T) {
+	t.Skip("not ready yet")
+}
+
+func TestPanics(t *testing.T) {
+	var numbers []int
+	_ = numbers[3]
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func main() {
+	dir := flag.String("dir", "", "the lesson directory to check (default : a generated sample lesson)")
+	timeout := flag.Duration("timeout", time.Minute, "stop go vet / go test after this time")
+	flag.Parse()
+
+	if *dir == "" {
+		sample, err := writeSampleLesson()
+		if err != nil {
+			fmt.Println("error :", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(sample)
+		*dir = sample
+	}
+
+	if err := check(*dir, *timeout); err != nil {
+		fmt.Println("error :", err)
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! a slow command with a short timeout : the shell and BOTH sleep processes are killed, because they are in the same process group
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := runCommand(ctx, ".", func(line string) { fmt.Println("  |", line) }, "sh", "-c", "echo started; sleep 30 & sleep 30; wait")
+	fmt.Println("slow command :", err) //! after ~300ms, not 30s
+
+	_, err = runCommand(context.Background(), ".", func(string) {}, "no-such-program")
+	fmt.Println("missing      :", err)
+}
+
+/*
+	Try :
+
+	go run .
+	go run . -dir "../41. bloom filter"
+	go run . -timeout 1ms
+*/
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"osexec/testjson"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []testjson.Result
+		want    Summary
+	}{
+		{"no tests", nil, Summary{}},
+		{"every status", []testjson.Result{
+			{Name: "TestAdd", Status: "pass", Elapsed: 0.25},
+			{Name: "TestAddWrong", Status: "fail", Elapsed: 0.5},
+			{Name: "TestSkipped", Status: "skip"},
+			{Name: "TestPanics", Status: "fail", Elapsed: 0.125},
+		}, Summary{Passed: 1, Failed: 2, Skipped: 1, Elapsed: 0.875}},
+		//! the parent's time already contains the time of its subtests
+		{"subtests are counted, their time is not", []testjson.Result{
+			{Name: "TestTable", Status: "fail", Elapsed: 0.75},
+			{Name: "TestTable/empty", Status: "pass", Elapsed: 0.25},
+			{Name: "TestTable/long", Status: "fail", Elapsed: 0.5},
+		}, Summary{Passed: 1, Failed: 2, Elapsed: 0.75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.results); got != tt.want {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+//! the summary of the recorded run of the sample lesson, the stream the testjson package is tested with
+func TestSummarizeRecordedRun(t *testing.T) {
+	file, err := os.Open("testjson/testdata/tests.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	collector := testjson.NewCollector()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		collector.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Summarize(collector.Results())
+	if want := (Summary{Passed: 1, Failed: 2, Skipped: 1}); got != want {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
+
+//! TestHelperProcess is not a real test : runCommand starts the test binary itself as a stub command (like the tests of os/exec do)
+//! OSEXEC_STUB says what the stub does. Without it, the test returns at once
+func TestHelperProcess(t *testing.T) {
+	switch os.Getenv("OSEXEC_STUB") {
+	case "":
+		return
+	case "output":
+		fmt.Println("one")
+		fmt.Fprintln(os.Stderr, "two")
+		fmt.Println("three")
+		os.Exit(3)
+	case "slow":
+		time.Sleep(30 * time.Second)
+		os.Exit(0)
+	}
+}
+
+//! stub -> the name and the arguments which start the test binary as the stub 'kind'
+func stub(t *testing.T, kind string) (string, []string) {
+	t.Setenv("OSEXEC_STUB", kind) //! the child gets the environment of the test
+	return os.Args[0], []string{"-test.run=^TestHelperProcess$"}
+}
+
+//! stdout and stderr in the order they were written, and a non-zero exit code is not an error
+func TestRunCommandOutput(t *testing.T) {
+	name, args := stub(t, "output")
+	var lines []string
+	result, err := runCommand(context.Background(), ".", func(line string) { lines = append(lines, line) }, name, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ExitCode != 3 || result.TimedOut {
+		t.Errorf("result = %+v, want exit code 3, not timed out", result)
+	}
+	if want := []string{"one", "two", "three"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+//! a stub which sleeps 30s is killed after the timeout, and runCommand returns right away
+func TestRunCommandTimeout(t *testing.T) {
+	name, args := stub(t, "slow")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := runCommand(ctx, ".", func(string) {}, name, args...)
+	if err == nil || !strings.Contains(err.Error(), "timed out after") {
+		t.Errorf("err = %v, want a timeout error", err)
+	}
+	if !result.TimedOut {
+		t.Errorf("result = %+v, want TimedOut", result)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("runCommand returned after %v, want soon after the 200ms timeout", elapsed)
+	}
+}
+
+func TestRunCommandMissingProgram(t *testing.T) {
+	_, err := runCommand(context.Background(), ".", func(string) {}, "no-such-program")
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("err = %v, want it to wrap exec.ErrNotFound", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "no-such-program not found in PATH") {
+		t.Errorf("err = %v, want it to name the program", err)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os/exec"
+
+//! killProcessGroup -> there are no process groups like on unix, so on a timeout only the command itself is killed (the default of exec.CommandContext). WaitDelay in runCommand still makes sure we don't wait forever for its output
+func killProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+//! killProcessGroup -> the child gets its own process group. 'go test' starts more processes (the compiled test binary), and on a timeout we want to stop ALL of them, not only 'go'
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) //! a negative pid means 'the whole group'
+	}
+}
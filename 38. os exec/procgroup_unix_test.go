@@ -0,0 +1,41 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//! the shell starts a background sleep and prints its pid. After the timeout that grandchild must be dead too, not only the shell
+func TestTimeoutKillsTheProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var pid int
+	_, err := runCommand(ctx, ".", func(line string) { pid, _ = strconv.Atoi(line) }, "sh", "-c", "sleep 30 & echo $!; wait")
+	if err == nil {
+		t.Fatal("no timeout error")
+	}
+	if pid == 0 {
+		t.Fatal("the shell didn't print the pid of the background sleep")
+	}
+
+	//! signal 0 only checks if the process exists. The killed sleep is adopted and reaped by init, so give that a moment
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := syscall.Kill(pid, 0)
+		if errors.Is(err, syscall.ESRCH) {
+			return
+		}
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL) //! don't leave it behind after a failed test
+			t.Fatalf("the background sleep (pid %d) is still running : signal 0 = %v", pid, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,86 @@
+//! Package testjson reads the output of 'go test -json' (the test2json format) and keeps one result per test
+//! it's shared by the os/exec lesson (38. os exec) and the exercise grader lesson (94. exercise grader), which imports it with a replace in its go.mod
+package testjson
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+//! Event is one line of 'go test -json' (the test2json format)
+type Event struct {
+	Action  string //! start, run, output, pass, fail, skip, pause, cont, and build-output / build-fail for compiler errors
+	Package string
+	Test    string  //! empty for events about the whole package
+	Elapsed float64 //! seconds, only on pass / fail / skip
+	Output  string
+}
+
+//! Result is the final state of one test
+type Result struct {
+	Name    string
+	Status  string //! pass, fail or skip
+	Elapsed float64
+	Output  []string //! the output lines of the test, useful to show WHY it failed
+}
+
+//! Collector gathers the events of one 'go test -json' run
+type Collector struct {
+	tests   map[string]*Result
+	order   []string
+	Package []string //! output which doesn't belong to a test : compiler errors, "FAIL ... [build failed]", lines which are not JSON
+}
+
+func NewCollector() *Collector {
+	return &Collector{tests: map[string]*Result{}}
+}
+
+//! Add handles one output line. It returns the text to show the user for this line ("" if nothing)
+func (c *Collector) Add(line string) string {
+	var event Event
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		c.Package = append(c.Package, line) //! not JSON : for example a message of the go command itself
+		return line
+	}
+
+	if event.Test == "" {
+		//! since Go 1.24 the compiler errors are JSON too : "build-output" events, before the package's "FAIL ... [build failed]" output event
+		if event.Action == "output" || event.Action == "build-output" {
+			output := strings.TrimRight(event.Output, "\n")
+			c.Package = append(c.Package, output)
+			return output
+		}
+		return ""
+	}
+
+	test, ok := c.tests[event.Test]
+	if !ok {
+		test = &Result{Name: event.Test}
+		c.tests[event.Test] = test
+		c.order = append(c.order, event.Test)
+	}
+
+	switch event.Action {
+	case "output":
+		output := strings.TrimRight(event.Output, "\n")
+		test.Output = append(test.Output, output)
+		return output
+	case "pass", "fail", "skip":
+		test.Status = event.Action
+		test.Elapsed = event.Elapsed
+	}
+	return ""
+}
+
+//! Results returns the tests in the order they started. A test without a final status (the run was killed) is reported as a failure
+func (c *Collector) Results() []Result {
+	results := make([]Result, 0, len(c.order))
+	for _, name := range c.order {
+		test := *c.tests[name]
+		if test.Status == "" {
+			test.Status = "fail"
+		}
+		results = append(results, test)
+	}
+	return results
+}
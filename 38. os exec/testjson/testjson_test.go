@@ -0,0 +1,102 @@
+package testjson
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+//! collect feeds a recorded 'go test -json' stream from testdata into a new collector
+func collect(t *testing.T, path string) *Collector {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	collector := NewCollector()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		collector.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return collector
+}
+
+func TestCollectorResults(t *testing.T) {
+	collector := collect(t, "testdata/tests.jsonl")
+
+	var got []string
+	for _, result := range collector.Results() {
+		got = append(got, result.Name+" "+result.Status)
+	}
+	want := []string{"TestAdd pass", "TestAddWrong fail", "TestSkipped skip", "TestPanics fail"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("results = %q, want %q", got, want)
+	}
+
+	wrong := collector.Results()[1]
+	if len(wrong.Output) < 2 || wrong.Output[1] != "    main_test.go:13: add(2, 2) = 4, want 5" {
+		t.Errorf("TestAddWrong output = %q, want the error line", wrong.Output)
+	}
+
+	//! a panic ends the test binary : the panic message and the stack trace are output lines of the panicking test
+	panics := collector.Results()[3]
+	if !slices.Contains(panics.Output, "panic: runtime error: index out of range [3] with length 0 [recovered, repanicked]") {
+		t.Errorf("TestPanics output = %q, want the panic message", panics.Output)
+	}
+}
+
+//! a lesson which doesn't compile : since Go 1.24 the compiler errors come as "build-output" events, and there are no test events at all
+func TestCollectorBuildFailure(t *testing.T) {
+	collector := collect(t, "testdata/build-failed.jsonl")
+
+	if results := collector.Results(); len(results) != 0 {
+		t.Errorf("results = %v, want none", results)
+	}
+	want := []string{
+		"# command-line-arguments [command-line-arguments.test]",
+		"./main.go:3:37: undefined: c",
+		"FAIL\tcommand-line-arguments [build failed]",
+	}
+	if !reflect.DeepEqual(collector.Package, want) {
+		t.Errorf("Package = %q, want %q", collector.Package, want)
+	}
+}
+
+func TestCollectorAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"build-output is shown", `{"Action":"build-output","Output":"./main.go:3:37: undefined: c\n"}`, "./main.go:3:37: undefined: c"},
+		{"build-fail has no text", `{"Action":"build-fail"}`, ""},
+		{"package output is shown", `{"Action":"output","Package":"p","Output":"ok  \tp\t0.1s\n"}`, "ok  \tp\t0.1s"},
+		{"test output is shown", `{"Action":"output","Package":"p","Test":"TestA","Output":"=== RUN   TestA\n"}`, "=== RUN   TestA"},
+		{"a status has no text", `{"Action":"pass","Package":"p","Test":"TestA"}`, ""},
+		{"plain text is kept", "go: no such tool", "go: no such tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCollector().Add(tt.line); got != tt.want {
+				t.Errorf("Add(%s) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+//! a run which was killed : the test started but never reported pass or fail
+func TestCollectorUnfinishedIsFailure(t *testing.T) {
+	collector := NewCollector()
+	collector.Add(`{"Action":"run","Test":"TestSlow"}`)
+	results := collector.Results()
+	if len(results) != 1 || results[0].Name != "TestSlow" || results[0].Status != "fail" {
+		t.Errorf("results = %+v, want TestSlow fail", results)
+	}
+}
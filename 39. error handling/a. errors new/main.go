@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//! the calculator in '05. functions' accepts any input. calculateSum can never fail, but division CAN : dividing by zero has no answer
+//! 'error' is a built-in interface type. By convention, it is the LAST return value of a function
+func divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero") //! errors.New creates an error with a fixed message
+	}
+	return a / b, nil //! nil -> no error
+}
+
+func printOutput(a, b, result int) {
+	fmt.Println("The result of", a, "/", b, "is", result)
+}
+
+func main() {
+	result, err := divide(10, 2)
+	if err != nil {
+		fmt.Println("Error :", err)
+	} else {
+		printOutput(10, 2, result) //! The result of 10 / 2 is 5
+	}
+
+	result, err = divide(10, 0)
+	if err != nil {
+		fmt.Println("Error :", err) //! Error : division by zero
+	} else {
+		printOutput(10, 0, result)
+	}
+
+	/*
+		Go has no exceptions. A function which can fail returns an error value, and the caller checks it.
+
+		When err is not nil, the other return values should be ignored (here 'result' is 0, but it means nothing).
+
+		Without the check, 10 / 0 would crash the program :
+
+		panic: runtime error: integer divide by zero
+	*/
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrDivisionByZero = errors.New("division by zero") //! a 'sentinel' error : one fixed value, which callers can compare with
+
+//! fmt.Errorf works like fmt.Sprintf, but creates an error. The message can contain the actual values, which helps a lot when debugging
+func divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("cannot divide %d by %d : %w", a, b, ErrDivisionByZero) //! %w wraps the original error inside the new one
+	}
+	return a / b, nil
+}
+
+func checkAge(name string, age int) error {
+	if age < 0 {
+		return fmt.Errorf("invalid age %d for %s : age can't be negative", age, name) //! no %w -> nothing to wrap, just a formatted message
+	}
+	return nil
+}
+
+func main() {
+	_, err := divide(10, 0)
+	fmt.Println("Error :", err) //! Error : cannot divide 10 by 0 : division by zero
+
+	//! errors.Is looks inside the wrapped errors, so the caller can still find out WHAT went wrong
+	fmt.Println("is division by zero :", errors.Is(err, ErrDivisionByZero)) //! true
+
+	fmt.Println("--------------------------------")
+
+	if err := checkAge("John", -5); err != nil {
+		fmt.Println("Error :", err) //! Error : invalid age -5 for John : age can't be negative
+	}
+
+	/*
+		errors.New("...")          -> a fixed message
+		fmt.Errorf("... %d", x)    -> a message with values
+		fmt.Errorf("... %w", err)  -> a message with values, and the original error kept inside (wrapping)
+	*/
+}
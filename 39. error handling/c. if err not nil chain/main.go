@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//! three levels : main -> runCalculator -> parseNumbers -> strconv.Atoi
+//! every level checks the error, adds what IT was doing, and returns it to its caller. The normal code stays on the left, the error handling is indented
+
+//! level 3
+func parseNumbers(input1, input2 string) (int, int, error) {
+	number1, err := strconv.Atoi(input1) //! Atoi = 'ASCII to integer'. It returns an error when the text is not a number
+	if err != nil {
+		return 0, 0, fmt.Errorf("first number : %w", err)
+	}
+
+	number2, err := strconv.Atoi(input2)
+	if err != nil {
+		return 0, 0, fmt.Errorf("second number : %w", err)
+	}
+
+	return number1, number2, nil
+}
+
+//! level 2
+func runCalculator(input1, input2 string) (int, error) {
+	number1, number2, err := parseNumbers(input1, input2)
+	if err != nil {
+		return 0, fmt.Errorf("calculator : %w", err)
+	}
+
+	if number2 == 0 {
+		return 0, fmt.Errorf("calculator : division by zero")
+	}
+
+	return number1 / number2, nil
+}
+
+//! level 1 : main is the top level. It doesn't return the error, it decides what to do : print it
+func main() {
+	inputs := [][2]string{
+		{"10", "2"},
+		{"ten", "2"},
+		{"10", "two"},
+		{"10", "0"},
+	}
+
+	for _, input := range inputs {
+		result, err := runCalculator(input[0], input[1])
+		if err != nil {
+			fmt.Printf("%-6s / %-6s -> Error : %v\n", input[0], input[1], err)
+			continue
+		}
+		fmt.Printf("%-6s / %-6s -> %d\n", input[0], input[1], result)
+	}
+
+	/*
+		Output :
+
+		10     / 2      -> 5
+		ten    / 2      -> Error : calculator : first number : strconv.Atoi: parsing "ten": invalid syntax
+		10     / two    -> Error : calculator : second number : strconv.Atoi: parsing "two": invalid syntax
+		10     / 0      -> Error : calculator : division by zero
+
+		Read the message from left to right : it goes from the top level down to the real cause.
+	*/
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//! mustAtoi -> the same conversion as strconv.Atoi, but instead of returning an error it PANICS. 'must' in the name warns the caller
+func mustAtoi(s string) int {
+	number, err := strconv.Atoi(s)
+	if err != nil {
+		panic(err)
+	}
+	return number
+}
+
+func main() {
+	//! with an error : the program decides what to do and continues
+	_, err := strconv.Atoi("ten")
+	if err != nil {
+		fmt.Println("Error :", err)
+	}
+	fmt.Println("still running")
+
+	fmt.Println("--------------------------------")
+
+	fmt.Println(mustAtoi("10")) //! 10, shorter code when the input is known to be correct
+
+	fmt.Println(mustAtoi("ten")) //! the program stops here
+	fmt.Println("never printed")
+
+	/*
+		Output :
+
+		Error : strconv.Atoi: parsing "ten": invalid syntax
+		still running
+		--------------------------------
+		10
+		panic: strconv.Atoi: parsing "ten": invalid syntax
+
+		goroutine 1 [running]:
+		main.mustAtoi(...)
+		...
+		exit status 2
+
+		The same failure, but the caller had no chance to handle it.
+
+		Use 'must' functions only for values written in the code itself (like regexp.MustCompile(`[0-9]+`)), where a failure is a programmer mistake. For user input, always return an error.
+	*/
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! our own error type. It is a normal struct, so it can carry more information than a message : WHICH field is wrong and WHY
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+//! a receiver function named Error() string. Any type with this method implements the built-in 'error' interface, there is no 'implements' keyword
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s : %s", e.Field, e.Reason)
+}
+
+//! validatePerson returns 'error', not ValidationError. Callers who only want the message don't need to know about our type
+func validatePerson(p Person) error {
+	if p.Name == "" {
+		return ValidationError{Field: "Name", Reason: "can't be empty"}
+	}
+	if p.Age < 0 {
+		return ValidationError{Field: "Age", Reason: fmt.Sprintf("%d is negative", p.Age)}
+	}
+	if !strings.Contains(p.Email, "@") {
+		return ValidationError{Field: "Email", Reason: fmt.Sprintf("%q has no '@'", p.Email)}
+	}
+	return nil
+}
+
+func main() {
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "", Age: 21, Email: "jane@example.com"},
+		{Name: "Alice", Age: -3, Email: "alice@example.com"},
+		{Name: "Bob", Age: 30, Email: "bob.example.com"},
+	}
+
+	for _, person := range people {
+		err := validatePerson(person)
+		if err == nil {
+			fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email, `-> valid`)
+			continue
+		}
+
+		fmt.Println("Error :", err) //! fmt calls our Error() method
+
+		//! type assertion : 'is the value inside this error interface a ValidationError?' comma-ok -> no panic if it isn't
+		if validationErr, ok := err.(ValidationError); ok {
+			switch validationErr.Field {
+			case "Name":
+				fmt.Println("  -> please enter a name")
+			case "Age":
+				fmt.Println("  -> please enter an age of 0 or more")
+			case "Email":
+				fmt.Println("  -> please check the email address")
+			}
+		}
+	}
+
+	/*
+		A plain errors.New("invalid email") only has a message. To find out which field was wrong, we would have to search inside the text, which breaks as soon as someone changes the message.
+
+		With a custom type, the caller reads the Field directly.
+
+		If the error might be wrapped (fmt.Errorf with %w), use errors.As instead of a type assertion :
+
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) { ... }
+	*/
+}
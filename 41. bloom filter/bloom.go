@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+)
+
+//! BloomFilter -> a very small structure which answers 'have I seen this before?'
+//! "no"    -> definitely NOT seen (never wrong)
+//! "maybe" -> PROBABLY seen. Sometimes it says maybe for something never added : a 'false positive'
+//! It doesn't store the items, only a row of bits, so it uses much less memory than a set
+type BloomFilter struct {
+	bits      []uint64 //! the bit array, 64 bits in every uint64
+	size      uint64   //! m : number of bits
+	hashCount uint64   //! k : number of bit positions for each item
+	count     uint64   //! how many items were added
+}
+
+//! New calculates the size from the standard formulas :
+//! m = -n * ln(p) / (ln 2)^2   (bits)
+//! k = m / n * ln 2            (hash functions)
+func New(expectedItems int, falsePositiveRate float64) (*BloomFilter, error) {
+	if expectedItems <= 0 {
+		return nil, fmt.Errorf("bloom: expected items must be positive, got %d", expectedItems)
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) { //! also catches NaN
+		return nil, fmt.Errorf("bloom: false positive rate must be between 0 and 1, got %v", falsePositiveRate)
+	}
+
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	size := uint64(m)
+	return &BloomFilter{
+		bits:      make([]uint64, (size+63)/64),
+		size:      size,
+		hashCount: uint64(k),
+	}, nil
+}
+
+//! positions uses 'double hashing' : two hashes h1 and h2 give k positions h1 + i*h2. Much faster than k different hash functions, and just as good
+func (b *BloomFilter) positions(s string) []uint64 {
+	h1 := fnv.New64()
+	h1.Write([]byte(s))
+	h2 := fnv.New64a() //! FNV-1a, a variant of FNV-1 with the steps in the other order, gives a different hash
+	h2.Write([]byte(s))
+
+	a, c := h1.Sum64(), h2.Sum64()|1 //! an odd step, so the positions don't repeat too early
+	result := make([]uint64, b.hashCount)
+	for i := range result {
+		result[i] = (a + uint64(i)*c) % b.size
+	}
+	return result
+}
+
+func (b *BloomFilter) Add(s string) {
+	for _, position := range b.positions(s) {
+		b.bits[position/64] |= 1 << (position % 64) //! set the bit : word number position/64, bit number position%64
+	}
+	b.count++
+}
+
+//! MayContain -> false means definitely not added. true means probably added
+func (b *BloomFilter) MayContain(s string) bool {
+	for _, position := range b.positions(s) {
+		if b.bits[position/64]&(1<<(position%64)) == 0 {
+			return false //! one bit is 0 -> this item was never added
+		}
+	}
+	return true
+}
+
+//! EstimatedFalsePositiveRate -> (1 - e^(-k*n/m))^k for the items added so far. It grows when more items are added than expected
+func (b *BloomFilter) EstimatedFalsePositiveRate() float64 {
+	k, n, m := float64(b.hashCount), float64(b.count), float64(b.size)
+	return math.Pow(1-math.Exp(-k*n/m), k)
+}
+
+//! SizeInBytes -> the memory used by the bit array
+func (b *BloomFilter) SizeInBytes() int {
+	return len(b.bits) * 8
+}
+
+//! the file format : size, hashCount, count, then the bit array. All numbers are little endian uint64
+
+func (b *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := []uint64{b.size, b.hashCount, b.count}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.LittleEndian, b.bits); err != nil {
+		return 0, err
+	}
+	return int64(8 * (len(header) + len(b.bits))), nil
+}
+
+//! limits for ReadFrom : the header comes from a file, and a corrupt or hostile file must not make us allocate gigabytes
+const (
+	maxHashCount = 64      //! New never picks more than ~30, even for a false positive rate of 1e-9
+	readChunk    = 1 << 13 //! the bits are read 8192 words (64 KiB) at a time
+)
+
+func ReadFrom(r io.Reader) (*BloomFilter, error) {
+	header := make([]uint64, 3)
+	if err := binary.Read(r, binary.LittleEndian, header); err != nil {
+		return nil, fmt.Errorf("bloom: reading header : %w", err)
+	}
+	b := &BloomFilter{size: header[0], hashCount: header[1], count: header[2]}
+	if b.size == 0 || b.hashCount == 0 || b.hashCount > maxHashCount {
+		return nil, errors.New("bloom: invalid header")
+	}
+
+	//! the header says how many words follow, but it can lie. Allocating (size+63)/64 words right away would trust it :
+	//! a header with size = 2^63 would ask for 128 GiB. So the bits are read chunk by chunk, and the slice only grows with data that really arrived
+	words := (b.size + 63) / 64
+	chunk := make([]uint64, min(words, readChunk))
+	b.bits = make([]uint64, 0, len(chunk))
+	for remaining := words; remaining > 0; {
+		n := min(remaining, uint64(len(chunk)))
+		if err := binary.Read(r, binary.LittleEndian, chunk[:n]); err != nil {
+			return nil, fmt.Errorf("bloom: reading bits : %w", err)
+		}
+		b.bits = append(b.bits, chunk[:n]...)
+		remaining -= n
+	}
+	return b, nil
+}
+
+func (b *BloomFilter) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := b.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close() //! Close can fail too (the data may be written only now), so its error is returned
+}
+
+func Load(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadFrom(file)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func filterWith(t *testing.T, items []string) *BloomFilter {
+	t.Helper()
+	b, err := New(1000, 0.01)
+	if err != nil {
+		t.Fatalf("New error : %v", err)
+	}
+	for _, item := range items {
+		b.Add(item)
+	}
+	return b
+}
+
+//! header builds the 24 byte file header by hand, so the tests can write headers WriteTo never would
+func header(size, hashCount, count uint64) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint64{size, hashCount, count})
+	return buf.Bytes()
+}
+
+func TestNewInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   int
+		rate    float64
+		wantErr string
+	}{
+		{"zero items", 0, 0.01, "bloom: expected items must be positive"},
+		{"negative items", -5, 0.01, "bloom: expected items must be positive"},
+		{"zero rate", 1000, 0, "bloom: false positive rate must be between 0 and 1"},
+		{"rate of one", 1000, 1, "bloom: false positive rate must be between 0 and 1"},
+		{"negative rate", 1000, -0.5, "bloom: false positive rate must be between 0 and 1"},
+		{"NaN rate", 1000, math.NaN(), "bloom: false positive rate must be between 0 and 1"}, //! NaN > 0 and NaN < 1 are both false
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(tt.items, tt.rate)
+			if err == nil {
+				t.Fatalf("New(%d, %v) = %+v, nil; want error %q", tt.items, tt.rate, b, tt.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("New(%d, %v) error = %q, want prefix %q", tt.items, tt.rate, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteToReadFromRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+	}{
+		{"empty", nil},
+		{"one item", []string{"Jane Khan 4821"}},
+		{"many items", []string{"John Smith 1", "Alice Lee 2", "Bob Garcia 3", "Eve Ahmed 4", "Grace Lopez 5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := filterWith(t, tt.items)
+
+			var buf bytes.Buffer
+			n, err := original.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("WriteTo error : %v", err)
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("WriteTo = %d bytes, wrote %d", n, buf.Len())
+			}
+
+			loaded, err := ReadFrom(&buf)
+			if err != nil {
+				t.Fatalf("ReadFrom error : %v", err)
+			}
+			if loaded.size != original.size || loaded.hashCount != original.hashCount || loaded.count != original.count {
+				t.Errorf("header = {%d %d %d}, want {%d %d %d}", loaded.size, loaded.hashCount, loaded.count, original.size, original.hashCount, original.count)
+			}
+			if !slices.Equal(loaded.bits, original.bits) {
+				t.Errorf("bits differ after the round trip")
+			}
+			for _, item := range tt.items {
+				if !loaded.MayContain(item) {
+					t.Errorf("loaded MayContain(%q) = false, want true", item)
+				}
+			}
+			if buf.Len() != 0 {
+				t.Errorf("ReadFrom left %d bytes unread", buf.Len())
+			}
+		})
+	}
+}
+
+//! Save and Load through a real file in a temporary directory, which the test removes afterwards
+func TestSaveLoadRoundTrip(t *testing.T) {
+	items := []string{"John Smith 1", "Alice Lee 2", "Bob Garcia 3"}
+	original := filterWith(t, items)
+	path := filepath.Join(t.TempDir(), "people.bloom")
+
+	if err := original.Save(path); err != nil {
+		t.Fatalf("Save error : %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error : %v", err)
+	}
+	if loaded.size != original.size || loaded.hashCount != original.hashCount || loaded.count != original.count || !slices.Equal(loaded.bits, original.bits) {
+		t.Errorf("loaded filter differs from the saved one")
+	}
+	for _, item := range items {
+		if !loaded.MayContain(item) {
+			t.Errorf("loaded MayContain(%q) = false, want true", item)
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.bloom")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+	if err := original.Save(filepath.Join(t.TempDir(), "no such dir", "people.bloom")); err == nil {
+		t.Error("Save into a missing directory = nil, want an error")
+	}
+}
+
+func TestReadFromCorrupt(t *testing.T) {
+	var valid bytes.Buffer
+	filterWith(t, []string{"Peggy Brown 78442"}).WriteTo(&valid)
+	full := valid.Bytes()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"empty", nil, "bloom: reading header"},
+		{"short header", full[:10], "bloom: reading header"},
+		{"zero size", header(0, 7, 0), "bloom: invalid header"},
+		{"zero hash count", header(64, 0, 0), "bloom: invalid header"},
+		{"huge hash count", append(header(64, 1<<62, 0), make([]byte, 8)...), "bloom: invalid header"},
+		{"bits truncated", full[:len(full)-8], "bloom: reading bits"},
+		{"header only", full[:24], "bloom: reading bits"},
+		{"huge size without bits", header(1<<63, 7, 0), "bloom: reading bits"},
+		{"huge size with a few bits", append(header(1<<63, 7, 0), make([]byte, 1000)...), "bloom: reading bits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ReadFrom(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatalf("ReadFrom = %+v, nil; want error %q", b, tt.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("ReadFrom error = %q, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadFromTruncatedWrapsEOF(t *testing.T) {
+	_, err := ReadFrom(bytes.NewReader(header(1<<20, 7, 0)))
+	if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFrom error = %v, want it to wrap io.EOF or io.ErrUnexpectedEOF", err)
+	}
+}
+
+//! a header that claims 2^63 bits (128 GiB) must not allocate more than the few bytes that really follow it
+func TestReadFromHugeHeaderDoesNotAllocate(t *testing.T) {
+	data := append(header(1<<63, 7, 0), make([]byte, 1000)...)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	ReadFrom(bytes.NewReader(data))
+	runtime.ReadMemStats(&after)
+
+	const limit = 1 << 20
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > limit {
+		t.Errorf("ReadFrom allocated %d bytes for a 1024 byte stream, want at most %d", allocated, limit)
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		items int
+		rate  float64
+	}{
+		{1000, 0.1},
+		{10000, 0.01},
+		{10000, 0.001},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d p=%v", tt.items, tt.rate), func(t *testing.T) {
+			b, err := New(tt.items, tt.rate)
+			if err != nil {
+				t.Fatalf("New error : %v", err)
+			}
+			for i := range tt.items {
+				b.Add(fmt.Sprintf("added %d", i))
+			}
+
+			for i := range tt.items {
+				if item := fmt.Sprintf("added %d", i); !b.MayContain(item) {
+					t.Fatalf("MayContain(%q) = false for an added item : a false negative", item)
+				}
+			}
+
+			//! the hashes are fixed (FNV), so the measured rate is the same on every run
+			const probes = 100000
+			falsePositives := 0
+			for i := range probes {
+				if b.MayContain(fmt.Sprintf("never added %d", i)) {
+					falsePositives++
+				}
+			}
+			measured := float64(falsePositives) / probes
+			if measured > 2*tt.rate {
+				t.Errorf("measured false positive rate = %.4f, want at most %.4f (twice the target %v)", measured, 2*tt.rate, tt.rate)
+			}
+			if estimated := b.EstimatedFalsePositiveRate(); estimated > 1.5*tt.rate {
+				t.Errorf("EstimatedFalsePositiveRate = %.4f, want about %v", estimated, tt.rate)
+			}
+		})
+	}
+}
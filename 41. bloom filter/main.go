@@ -0,0 +1,116 @@
+//! A bloom filter in front of an 'expensive' set : most names are new, and the filter answers 'definitely not seen' without touching the set at all
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+var firstNames = []string{"John", "Jane", "Alice", "Bob", "Carol", "Dave", "Eve", "Frank", "Grace", "Heidi", "Ivan", "Judy", "Mallory", "Niaj", "Olivia", "Peggy", "Rupert", "Sybil", "Trent", "Victor"}
+var lastNames = []string{"Smith", "Khan", "Rahman", "Garcia", "Miller", "Lee", "Brown", "Wilson", "Ahmed", "Lopez"}
+
+//! generateNames creates 'count' names like 'Jane Khan 4821'. Because the number is random, some names appear more than once
+func generateNames(count int, rng *rand.Rand) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s %s %d", firstNames[rng.IntN(len(firstNames))], lastNames[rng.IntN(len(lastNames))], rng.IntN(count))
+	}
+	return names
+}
+
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func main() {
+	rng := rand.New(rand.NewPCG(1, 2))
+	names := generateNames(100000, rng)
+
+	filter, err := New(100000, 0.01) //! 100 000 items, 1% false positives
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Printf("filter : %d bits, %d hash positions per item\n", filter.size, filter.hashCount)
+
+	before := heapInUse()
+	seen := map[string]struct{}{} //! the 'expensive' set : it stores every name
+	setLookups := 0
+	duplicates := 0
+
+	for _, name := range names {
+		if filter.MayContain(name) {
+			setLookups++ //! only now we ask the set, which knows for sure
+			if _, ok := seen[name]; ok {
+				duplicates++
+				continue
+			}
+		}
+		filter.Add(name)
+		seen[name] = struct{}{}
+	}
+	setMemory := heapInUse() - before
+
+	fmt.Println("names            :", len(names))
+	fmt.Println("unique           :", len(seen))
+	fmt.Println("duplicates       :", duplicates)
+	fmt.Println("set lookups      :", setLookups, "(the filter answered the rest alone)")
+	fmt.Printf("estimated FP     : %.4f\n", filter.EstimatedFalsePositiveRate())
+	fmt.Printf("memory filter    : %7d KB\n", filter.SizeInBytes()/1024)
+	fmt.Printf("memory map set   : %7d KB (about)\n", setMemory/1024)
+
+	fmt.Println("--------------------------------")
+
+	//! no false negatives : every added name must be found
+	falseNegatives := 0
+	for name := range seen {
+		if !filter.MayContain(name) {
+			falseNegatives++
+		}
+	}
+	fmt.Println("false negatives  :", falseNegatives) //! always 0
+
+	//! measured false positive rate : names which were NEVER added (a different format, so they can't be in the set)
+	falsePositives := 0
+	const tests = 100000
+	for i := 0; i < tests; i++ {
+		if filter.MayContain(fmt.Sprintf("never added %d", i)) {
+			falsePositives++
+		}
+	}
+	fmt.Printf("measured FP      : %.4f (target 0.01)\n", float64(falsePositives)/tests)
+
+	fmt.Println("--------------------------------")
+
+	//! save to a file and load it back
+	path := filepath.Join(os.TempDir(), "names.bloom")
+	defer os.Remove(path)
+	if err := filter.Save(path); err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	same := loaded.size == filter.size && loaded.hashCount == filter.hashCount && loaded.count == filter.count
+	for i := range filter.bits {
+		same = same && loaded.bits[i] == filter.bits[i]
+	}
+	fmt.Println("saved and loaded :", same, "| loaded filter contains", names[0], ":", loaded.MayContain(names[0]))
+
+	fmt.Println("--------------------------------")
+
+	//! invalid parameters
+	_, err = New(0, 0.01)
+	fmt.Println(err)
+	_, err = New(1000, 1.5)
+	fmt.Println(err)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//! a sentinel error : one fixed value which callers can check for
+var ErrNotFound = errors.New("not found")
+
+//! a custom error type, like os.PathError. It keeps the operation, the path and the real cause
+type ConfigError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+//! Unwrap returns the error inside. errors.Is and errors.As call it to walk down the chain
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+//! low level
+func loadConfig(path string) error {
+	return &ConfigError{Op: "open", Path: path, Err: ErrNotFound}
+}
+
+//! mid level : adds what it was doing, and keeps the original error with %w
+func setupApp(path string) error {
+	if err := loadConfig(path); err != nil {
+		return fmt.Errorf("setup failed: %w", err)
+	}
+	return nil
+}
+
+//! the same mid level, but with %v : the message looks the same, but the original error is turned into plain text and LOST
+func setupAppWithV(path string) error {
+	if err := loadConfig(path); err != nil {
+		return fmt.Errorf("setup failed: %v", err)
+	}
+	return nil
+}
+
+func main() {
+	err := setupApp("config.json")
+
+	fmt.Println("message :", err) //! setup failed: open config.json: not found
+
+	//! the chain : *fmt.wrapError -> *ConfigError -> ErrNotFound
+	fmt.Println("chain   :")
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		fmt.Printf("  %T : %v\n", current, current)
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! errors.Is -> is ErrNotFound anywhere in the chain? (== would only compare the outer error)
+	fmt.Println("err == ErrNotFound          :", err == ErrNotFound)          //! false
+	fmt.Println("errors.Is(err, ErrNotFound) :", errors.Is(err, ErrNotFound)) //! true
+
+	//! errors.As -> is there a *ConfigError anywhere in the chain? If yes, put it into configErr
+	var configErr *ConfigError
+	if errors.As(err, &configErr) {
+		fmt.Printf("errors.As found ConfigError : Op = %s, Path = %s\n", configErr.Op, configErr.Path)
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! negative case : %v instead of %w
+	errV := setupAppWithV("config.json")
+	fmt.Printf("message (made with %%v)       : %v\n", errV)                    //! exactly the same text
+	fmt.Println("errors.Is(errV, ErrNotFound) :", errors.Is(errV, ErrNotFound)) //! false !
+	fmt.Println("errors.As(errV, &configErr)  :", errors.As(errV, &configErr))  //! false !
+	fmt.Println("errors.Unwrap(errV)          :", errors.Unwrap(errV))          //! <nil> -> there is no chain
+
+	/*
+		%w -> wraps : the new error remembers the original one, errors.Is / errors.As / errors.Unwrap can find it
+		%v -> formats : only the TEXT of the original error is copied into the new message
+
+		Use %w when callers may need to check the cause. Use %v when the cause is an internal detail you don't want callers to depend on.
+	*/
+}
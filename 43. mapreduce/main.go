@@ -0,0 +1,48 @@
+//! Counts the words in the comments of every lesson in this repository, with a channel based MapReduce (mapreduce.go)
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+//! lessonFiles finds every .go file under root. testdata directories are skipped, like the go tool does
+func lessonFiles(root string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == "testdata" {
+			return filepath.SkipDir
+		}
+		if !entry.IsDir() && strings.HasSuffix(path, ".go") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+func main() {
+	paths, err := lessonFiles("..")
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+
+	counts, err := WordCountFiles(paths, 4)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+
+	fmt.Println("files :", len(paths), "| different words :", len(counts))
+	fmt.Println("top 15 words in the comments of all lessons :")
+	for _, wc := range counts[:min(15, len(counts))] {
+		fmt.Printf("  %-10s %5d\n", wc.Word, wc.Count)
+	}
+
+}
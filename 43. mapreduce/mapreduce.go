@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+//! WordCountFiles is a small MapReduce :
+//!
+//!   map    -> one goroutine per file counts the words of that file, and splits the counts into one part (shard) per reducer
+//!   reduce -> 'reducers' goroutines, each one adds up the counts of ITS words only (a word always goes to the same reducer)
+//!   merge  -> the results of all reducers are put together and sorted, so the output is the same on every run
+//!
+//! if a file can't be read, the other mappers stop early and the error is returned
+func WordCountFiles(paths []string, reducers int) ([]WordCount, error) {
+	if reducers < 1 {
+		return nil, fmt.Errorf("wordcount: reducers must be at least 1, got %d", reducers)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//! one input channel per reducer
+	shards := make([]chan map[string]int, reducers)
+	for i := range shards {
+		shards[i] = make(chan map[string]int, len(paths))
+	}
+
+	//! reduce
+	results := make(chan map[string]int, reducers)
+	var reduceWg sync.WaitGroup
+	for _, shard := range shards {
+		reduceWg.Add(1)
+		go func(in <-chan map[string]int) {
+			defer reduceWg.Done()
+			total := map[string]int{}
+			for fragment := range in {
+				for word, count := range fragment {
+					total[word] = total[word] + count
+				}
+			}
+			results <- total
+		}(shard)
+	}
+
+	//! map
+	var mapWg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for _, path := range paths {
+		mapWg.Add(1)
+		go func(path string) {
+			defer mapWg.Done()
+			if ctx.Err() != nil {
+				return //! another mapper already failed, don't even start
+			}
+			counts, err := countFile(path)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("wordcount: %s: %w", path, err)
+					cancel()
+				})
+				return
+			}
+			for i, fragment := range split(counts, reducers) {
+				if len(fragment) > 0 {
+					shards[i] <- fragment
+				}
+			}
+		}(path)
+	}
+
+	//! when every mapper is finished, the reducers get no more input
+	mapWg.Wait()
+	for _, shard := range shards {
+		close(shard)
+	}
+	reduceWg.Wait()
+	close(results)
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	//! merge
+	var merged []WordCount
+	for total := range results {
+		for word, count := range total {
+			merged = append(merged, WordCount{Word: word, Count: count})
+		}
+	}
+	sortWordCounts(merged)
+	return merged, nil
+}
+
+//! split puts every word into the shard of its reducer. The hash of the word decides, so the same word from different files always meets in the same reducer
+func split(counts map[string]int, reducers int) []map[string]int {
+	fragments := make([]map[string]int, reducers)
+	for i := range fragments {
+		fragments[i] = map[string]int{}
+	}
+	for word, count := range counts {
+		hash := fnv.New32a()
+		hash.Write([]byte(word))
+		fragments[hash.Sum32()%uint32(reducers)][word] = count
+	}
+	return fragments
+}
+
+//! sortWordCounts -> the most frequent first. Equal counts are sorted by the word, otherwise the order would change between runs
+func sortWordCounts(counts []WordCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Word < counts[j].Word
+	})
+}
+
+//! countFile reads one file. For a .go file only the comments are counted, for any other file the whole text
+func countFile(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if filepath.Ext(path) == ".go" {
+		text, err = goComments(path, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return countWords(text), nil
+}
+
+func goComments(path string, data []byte) (string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, data, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			builder.WriteString(comment.Text)
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String(), nil
+}
+
+//! countWords -> lowercase words made of letters only. 'don't' becomes 'don' and 't', good enough for this example
+func countWords(text string) map[string]int {
+	counts := map[string]int{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		counts[word]++
+	}
+	return counts
+}
+
+//! WordCountSequential is the simple single goroutine version. It is used to check the result of WordCountFiles
+func WordCountSequential(paths []string) ([]WordCount, error) {
+	total := map[string]int{}
+	for _, path := range paths {
+		counts, err := countFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("wordcount: %s: %w", path, err)
+		}
+		for word, count := range counts {
+			total[word] = total[word] + count
+		}
+	}
+	result := make([]WordCount, 0, len(total))
+	for word, count := range total {
+		result = append(result, WordCount{Word: word, Count: count})
+	}
+	sortWordCounts(result)
+	return result, nil
+}
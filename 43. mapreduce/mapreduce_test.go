@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var fixtures = []string{"testdata/fox.txt", "testdata/dog.txt", "testdata/empty.txt", "testdata/comments.go"}
+
+func TestWordCountFiles(t *testing.T) {
+	got, err := WordCountFiles([]string{"testdata/fox.txt", "testdata/dog.txt"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []WordCount{
+		{"the", 4}, {"dog", 3}, {"café", 2}, {"fox", 2},
+		{"brown", 1}, {"eats", 1}, {"end", 1}, {"lazy", 1}, {"no", 1}, {"quick", 1}, {"sleeps", 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WordCountFiles =\n%v\nwant\n%v", got, want)
+	}
+}
+
+//! for a .go file only the comments are counted, not the code or the strings
+func TestWordCountGoComments(t *testing.T) {
+	got, err := WordCountFiles([]string{"testdata/comments.go"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]int{}
+	for _, wc := range got {
+		counts[wc.Word] = wc.Count
+	}
+	for _, word := range []string{"package", "fixture", "fox", "block", "counted", "identifiers"} {
+		if counts[word] == 0 {
+			t.Errorf("%q not counted, it is in a comment", word)
+		}
+	}
+	for _, word := range []string{"var", "string", "either", "notcounted"} {
+		if counts[word] != 0 {
+			t.Errorf("%q counted %d times, it is not in a comment", word, counts[word])
+		}
+	}
+}
+
+//! the parallel version must give exactly the result of the simple single goroutine version, with any number of reducers
+func TestSameAsSequential(t *testing.T) {
+	lessons, err := lessonFiles("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := map[string][]string{
+		"fixtures":        fixtures,
+		"all the lessons": lessons,
+	}
+	for name, paths := range inputs {
+		reference, err := WordCountSequential(paths)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, reducers := range []int{1, 2, 3, 8, 64} {
+			t.Run(fmt.Sprintf("%s, %d reducers", name, reducers), func(t *testing.T) {
+				got, err := WordCountFiles(paths, reducers)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, reference) {
+					t.Errorf("WordCountFiles with %d reducers differs from WordCountSequential (%d vs %d words)", reducers, len(got), len(reference))
+				}
+			})
+		}
+	}
+}
+
+//! the merge sorts by count and then by word, so the order never depends on which reducer finished first
+func TestDeterministicOrder(t *testing.T) {
+	first, err := WordCountFiles(fixtures, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for run := 0; run < 20; run++ {
+		got, _ := WordCountFiles(fixtures, 8)
+		if !reflect.DeepEqual(got, first) {
+			t.Fatalf("run %d gave a different order", run)
+		}
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{"no files", nil},
+		{"an empty file", []string{"testdata/empty.txt"}},
+		{"two empty files", []string{"testdata/empty.txt", "testdata/empty.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WordCountFiles(tt.paths, 2)
+			if err != nil || len(got) != 0 {
+				t.Errorf("WordCountFiles = %v, %v; want no words, nil", got, err)
+			}
+		})
+	}
+}
+
+//! one bad file stops the whole job, and the error says which file and wraps the cause
+func TestErrors(t *testing.T) {
+	//! the broken Go file is written here and not kept in testdata, so the tools which walk the whole repo don't trip over it
+	broken := filepath.Join(t.TempDir(), "broken.go")
+	if err := os.WriteFile(broken, []byte("// a Go file which doesn't parse\npackage broken\n\nfunc {\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		paths     []string
+		reducers  int
+		wantStart string
+		wantIs    error
+	}{
+		{"missing file", append([]string{"testdata/missing.txt"}, fixtures...), 4, "wordcount: testdata/missing.txt: open testdata/missing.txt:", fs.ErrNotExist},
+		{"missing file last", append(append([]string{}, fixtures...), "testdata/missing.txt"), 4, "wordcount: testdata/missing.txt:", fs.ErrNotExist},
+		{"a directory", []string{"testdata"}, 2, "wordcount: testdata: read testdata:", nil},
+		{"a Go file which doesn't parse", []string{"testdata/fox.txt", broken}, 2, "wordcount: " + broken + ": " + broken + ":4:6:", nil},
+		{"no reducer", fixtures, 0, "wordcount: reducers must be at least 1, got 0", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WordCountFiles(tt.paths, tt.reducers)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantStart) {
+				t.Fatalf("WordCountFiles error = %v, want it to start with %q", err, tt.wantStart)
+			}
+			if got != nil {
+				t.Errorf("WordCountFiles = %v, want nil with an error", got)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+		})
+	}
+}
+
+//! split sends every word to exactly one shard, and always to the same one
+func TestSplit(t *testing.T) {
+	counts := map[string]int{"a": 1, "b": 2, "c": 3, "the": 4, "নমস্কার": 5}
+	for _, reducers := range []int{1, 2, 3, 8} {
+		fragments := split(counts, reducers)
+		again := split(counts, reducers)
+		if len(fragments) != reducers {
+			t.Fatalf("split into %d fragments, want %d", len(fragments), reducers)
+		}
+		seen := map[string]int{}
+		for i, fragment := range fragments {
+			for word, count := range fragment {
+				seen[word] += count
+				if _, ok := again[i][word]; !ok {
+					t.Errorf("%d reducers : %q went to shard %d once, but not the second time", reducers, word, i)
+				}
+			}
+		}
+		if !reflect.DeepEqual(seen, counts) {
+			t.Errorf("%d reducers : the shards hold %v, want %v", reducers, seen, counts)
+		}
+	}
+}
+
+//! benchInput -> files with many different words, so the reduce step has real work to do
+func benchInput(b *testing.B, files, wordsPerFile int) []string {
+	b.Helper()
+	dir := b.TempDir()
+	var paths []string
+	for f := 0; f < files; f++ {
+		var text strings.Builder
+		for w := 0; w < wordsPerFile; w++ {
+			fmt.Fprintf(&text, "word%s ", strings.Repeat(string(rune('a'+w%26)), 1+w%7)+string(rune('a'+(w*31+f)%26))+string(rune('a'+(w/26)%26)))
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%d.txt", f))
+		if err := os.WriteFile(path, []byte(text.String()), 0o644); err != nil {
+			b.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func BenchmarkWordCountFiles(b *testing.B) {
+	paths := benchInput(b, 32, 20_000)
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			WordCountSequential(paths)
+		}
+	})
+	for _, reducers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d reducers", reducers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				WordCountFiles(paths, reducers)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package fixture has words in comments only.
+package fixture
+
+/* The fox is in a block comment. */
+
+// counted is a word in a comment, the identifiers below are not counted
+var notCounted = "the string isn't counted either"
@@ -0,0 +1,41 @@
+package main
+
+import "fmt"
+
+//! in the functions lessons, 'add' is written again and again, always only for int :
+//!
+//! func add(number1 int, number2 int) int { ... }
+//!
+//! for float64 we would need a second copy (addFloat), for int64 a third one ... The code is the same, only the type changes.
+
+//! Generics -> the TYPE becomes a parameter too. [T int | float64] is the type parameter list : T can be int or float64
+//! this is 'printNumbers(numbers ...int)' from the variadic function lesson, but for more than one type
+func Sum[T int | float64](values ...T) T {
+	var total T //! the zero value of T : 0 for int, 0.0 for float64
+	for _, value := range values {
+		total = total + value //! + is allowed because BOTH int and float64 support it
+	}
+	return total
+}
+
+func main() {
+	fmt.Println(Sum(1, 2, 3, 4, 5))    //! 15   -> T is int, Go infers it from the arguments
+	fmt.Println(Sum(1.5, 2.5, 3.25))   //! 7.25 -> T is float64
+	fmt.Println(Sum[float64](1, 2, 3)) //! 6    -> T written explicitly : the untyped constants 1, 2, 3 become float64
+	fmt.Println(Sum[int]())            //! 0    -> no values, the zero value. T can't be inferred here, so it must be written
+
+	numbers := []int{10, 20, 30}
+	fmt.Println(Sum(numbers...)) //! 60 -> a slice can be passed with '...', like any variadic function
+
+	/*
+		Sum(1, 2.5) works : 1 and 2.5 are untyped constants, so Go picks float64 for T. But with typed variables :
+
+		count := 3
+		price := 2.5
+		Sum(count, price) -> doesn't compile : in call to Sum, type float64 of price does not match inferred type int for T
+
+		Every value must have the SAME type T. Go doesn't convert between int and float64 automatically, with or without generics.
+
+		Sum("a", "b") doesn't compile either : string does not satisfy int | float64
+	*/
+}
@@ -0,0 +1,46 @@
+package main
+
+import "fmt"
+
+//! a constraint is an interface which lists the allowed types. Writing 'int | float64' in every function gets long, so we give it a name
+//! ~int means 'int AND every type whose underlying type is int' (like 'type Age int' below)
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+//! Max needs '>' so it can only accept types which support it. 'any' would not compile here : not every type can be compared with >
+func Max[T Number](values ...T) T {
+	if len(values) == 0 {
+		var zero T
+		return zero
+	}
+	largest := values[0]
+	for _, value := range values[1:] {
+		if value > largest {
+			largest = value
+		}
+	}
+	return largest
+}
+
+type Age int //! a new type with the underlying type int
+
+func main() {
+	fmt.Println(Max(3, 9, 2))          //! 9
+	fmt.Println(Max(2.5, -1.0, 2.75))  //! 2.75
+	fmt.Println(Max[int64](7, 42, 13)) //! 42
+
+	ages := []Age{20, 21, 35}
+	fmt.Println(Max(ages...)) //! 35 -> works because of the ~ in ~int
+
+	/*
+		Without the ~, Max(ages...) fails :
+
+		Age does not satisfy Number (possibly missing ~ for int in Number)
+
+		The standard library has ready made constraints :
+
+		cmp.Ordered -> every type which supports < <= > >= (numbers AND strings)
+		comparable  -> every type which supports == and != (built-in, used for map keys)
+	*/
+}
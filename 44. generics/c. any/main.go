@@ -0,0 +1,53 @@
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! 'any' is the widest constraint : every type is allowed. So inside the function we can only do things that work for EVERY type, like passing the value to fmt
+func PrintSlice[T any](items []T) {
+	for i, item := range items {
+		fmt.Printf("  %d : %v\n", i, item)
+	}
+}
+
+//! a generic function can also RETURN the type T. Map turns a []T into a []U with a function
+func Map[T, U any](items []T, transform func(T) U) []U {
+	result := make([]U, 0, len(items))
+	for _, item := range items {
+		result = append(result, transform(item))
+	}
+	return result
+}
+
+func main() {
+	fmt.Println("ints :")
+	PrintSlice([]int{1, 2, 3})
+
+	fmt.Println("float64s :")
+	PrintSlice([]float64{1.5, 2.5})
+
+	fmt.Println("people :")
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 21, Email: "jane@example.com"},
+	}
+	PrintSlice(people)
+
+	fmt.Println("names :")
+	names := Map(people, func(p Person) string { return p.Name }) //! T = Person, U = string
+	PrintSlice(names)
+
+	/*
+		Why not 'func PrintSlice(items []any)'?
+
+		A []int can't be passed as []any, the memory layout is different. We would have to copy every element into a new []any first.
+		With generics, PrintSlice([]int{...}) just works, and the compiler still checks the types.
+
+		With the empty interface, the type is checked at RUN time (type assertions). With generics, it is checked at COMPILE time.
+	*/
+}
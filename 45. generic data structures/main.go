@@ -0,0 +1,58 @@
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func main() {
+	//! a stack of int
+	var numbers Stack[int] //! the type parameter is written once here, every method then works with int
+	numbers.Push(1)
+	numbers.Push(2)
+	numbers.Push(3)
+	top, _ := numbers.Peek()
+	fmt.Println("stack length :", numbers.Len(), "top :", top) //! 3 3
+
+	for numbers.Len() > 0 {
+		value, _ := numbers.Pop()
+		fmt.Println("popped :", value) //! 3, 2, 1 -> LIFO
+	}
+
+	value, ok := numbers.Pop()                     //! empty : no panic
+	fmt.Println("pop on empty stack :", value, ok) //! 0 false
+
+	fmt.Println("--------------------------------")
+
+	//! a queue of Person
+	var line Queue[Person]
+	line.Enqueue(Person{Name: "John", Age: 20, Email: "john@example.com"})
+	line.Enqueue(Person{Name: "Jane", Age: 21, Email: "jane@example.com"})
+	line.Enqueue(Person{Name: "Alice", Age: 22, Email: "alice@example.com"})
+
+	for {
+		person, ok := line.Dequeue()
+		if !ok {
+			break //! the queue is empty
+		}
+		fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email) //! John, Jane, Alice -> FIFO
+	}
+
+	person, ok := line.Dequeue()
+	fmt.Printf("dequeue on empty queue : %+v %v\n", person, ok) //! {Name: Age:0 Email:} false
+
+	fmt.Println("--------------------------------")
+
+	//! a stack of Person works with exactly the same code
+	var history Stack[Person]
+	history.Push(Person{Name: "John", Age: 20, Email: "john@example.com"})
+	last, _ := history.Peek()
+	fmt.Println("last person :", last.Name)
+}
+
+/*
+	Now run the tests with : go test -v *.go
+*/
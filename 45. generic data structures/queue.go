@@ -0,0 +1,38 @@
+package main
+
+//! Queue -> First In First Out (FIFO), like people waiting in a line : the first who came is the first who is served
+type Queue[T any] struct {
+	items []T
+}
+
+//! Enqueue adds to the end
+func (q *Queue[T]) Enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+//! Dequeue removes from the front. On an empty queue it returns the zero value and false
+func (q *Queue[T]) Dequeue() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+	item := q.items[0]
+	q.items[0] = zero     //! clear the slot for the garbage collector
+	q.items = q.items[1:] //! reslice : the slice now starts one element later in the SAME underlying array
+	if len(q.items) == 0 {
+		q.items = nil //! nothing left : drop the old array, so the space in front of the slice is not kept forever
+	}
+	return item, true
+}
+
+func (q *Queue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
+
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		enqueue      []string
+		dequeues     int
+		wantDequeues []string
+		wantLen      int
+	}{
+		{name: "empty", enqueue: nil, dequeues: 0, wantDequeues: nil, wantLen: 0},
+		{name: "first in first out", enqueue: []string{"John", "Jane", "Alice"}, dequeues: 3, wantDequeues: []string{"John", "Jane", "Alice"}, wantLen: 0},
+		{name: "partial dequeue", enqueue: []string{"John", "Jane"}, dequeues: 1, wantDequeues: []string{"John"}, wantLen: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q Queue[string]
+			for _, value := range tt.enqueue {
+				q.Enqueue(value)
+			}
+			for i := 0; i < tt.dequeues; i++ {
+				got, ok := q.Dequeue()
+				if !ok || got != tt.wantDequeues[i] {
+					t.Errorf("dequeue %d = %q, %v; want %q, true", i+1, got, ok, tt.wantDequeues[i])
+				}
+			}
+			if q.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", q.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestQueueEmpty(t *testing.T) {
+	var q Queue[int]
+	if got, ok := q.Dequeue(); ok || got != 0 {
+		t.Errorf("Dequeue on an empty queue = %d, %v; want 0, false", got, ok)
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("Peek on an empty queue returned ok = true")
+	}
+}
+
+//! mixing Enqueue and Dequeue : the order must stay FIFO even after the slice was resliced from the front
+func TestQueueInterleaved(t *testing.T) {
+	var q Queue[int]
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Dequeue()
+	q.Enqueue(3)
+
+	for _, want := range []int{2, 3} {
+		if got, ok := q.Dequeue(); !ok || got != want {
+			t.Errorf("Dequeue() = %d, %v; want %d, true", got, ok, want)
+		}
+	}
+}
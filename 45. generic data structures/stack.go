@@ -0,0 +1,38 @@
+package main
+
+//! Stack -> Last In First Out (LIFO), like a pile of plates : the last plate put on top is the first one taken
+//! the zero value is ready to use : var s Stack[int]
+type Stack[T any] struct {
+	items []T
+}
+
+//! Push adds to the end of the slice. append grows the underlying array when the capacity is full
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+//! Pop removes the last item. On an empty stack it returns the zero value and false, instead of panicking with 'index out of range'
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	last := len(s.items) - 1
+	item := s.items[last]
+	s.items[last] = zero     //! clear the slot, so a popped pointer / struct doesn't stay alive in the underlying array
+	s.items = s.items[:last] //! reslice : the length shrinks by one, the capacity stays, so the next Push doesn't allocate
+	return item, true
+}
+
+//! Peek returns the last item without removing it
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
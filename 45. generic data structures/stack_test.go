@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+//! a test file ends with _test.go. 'go test' finds every function called TestXxx(t *testing.T) and runs it
+//! table-driven test : the cases are rows of a table (a slice of structs), and one loop runs all of them
+func TestStack(t *testing.T) {
+	tests := []struct {
+		name     string
+		push     []int
+		pops     int
+		wantPops []int
+		wantLen  int
+	}{
+		{name: "empty", push: nil, pops: 0, wantPops: nil, wantLen: 0},
+		{name: "last in first out", push: []int{1, 2, 3}, pops: 3, wantPops: []int{3, 2, 1}, wantLen: 0},
+		{name: "partial pop", push: []int{1, 2, 3}, pops: 1, wantPops: []int{3}, wantLen: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { //! a subtest per row, shown as TestStack/empty, TestStack/last_in_first_out ...
+			var s Stack[int]
+			for _, value := range tt.push {
+				s.Push(value)
+			}
+			for i := 0; i < tt.pops; i++ {
+				got, ok := s.Pop()
+				if !ok || got != tt.wantPops[i] {
+					t.Errorf("pop %d = %d, %v; want %d, true", i+1, got, ok, tt.wantPops[i])
+				}
+			}
+			if s.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", s.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestStackEmpty(t *testing.T) {
+	var s Stack[Person]
+
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop on an empty stack returned ok = true")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Error("Peek on an empty stack returned ok = true")
+	}
+
+	s.Push(Person{Name: "John"})
+	s.Pop()
+	if got, ok := s.Pop(); ok || got != (Person{}) {
+		t.Errorf("Pop after emptying = %+v, %v; want zero Person, false", got, ok)
+	}
+}
+
+func TestStackPeekDoesNotRemove(t *testing.T) {
+	var s Stack[string]
+	s.Push("a")
+	s.Push("b")
+
+	if got, _ := s.Peek(); got != "b" {
+		t.Errorf("Peek() = %q, want %q", got, "b")
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() after Peek = %d, want 2", s.Len())
+	}
+}
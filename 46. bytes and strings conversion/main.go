@@ -0,0 +1,131 @@
+//! A string and a []byte can hold the same data, but they are different :
+//! string -> read-only. Nobody can ever change its bytes
+//! []byte -> can be changed
+//! Because of this, string(b) and []byte(s) normally COPY all the bytes into new memory (an allocation). Otherwise, changing the []byte would also change the "read-only" string.
+//! In this section we measure these copies, and look at the places where the compiler can safely skip them.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+var sinkString string //! storing results in package variables makes them 'escape', so the compiler can't optimize the conversion away in our measurement
+var sinkBytes []byte
+var sinkInt int
+
+//! KeyFromBytes looks up a []byte key in a map with string keys
+//! m[string(b)] -> the compiler sees that the temporary string is only used for the lookup and never kept, so it does NOT copy the bytes : 0 allocations
+func KeyFromBytes(m map[string]int, b []byte) int {
+	return m[string(b)]
+}
+
+//! keyFromBytesSlow does the same in two steps. Now 'key' is a real variable which could be kept, so the bytes are copied
+func keyFromBytesSlow(m map[string]int, b []byte) int {
+	key := string(b)
+	sinkString = key
+	return m[key]
+}
+
+//! a small CSV writer : fields arrive as []byte (for example read from a file), and are written separated by commas
+
+//! writeRowConvert converts every field to a string first -> one allocation per field
+func writeRowConvert(builder *strings.Builder, fields [][]byte) {
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		value := string(field)
+		sinkString = value
+		builder.WriteString(value)
+	}
+	builder.WriteByte('\n')
+}
+
+//! writeRowDirect writes the bytes as they are. strings.Builder has Write([]byte), so no conversion is needed at all
+func writeRowDirect(builder *strings.Builder, fields [][]byte) {
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.Write(field)
+	}
+	builder.WriteByte('\n')
+}
+
+func main() {
+	data := []byte("john@example.com")
+	ages := map[string]int{"john@example.com": 20, "jane@example.com": 21}
+
+	//! 1. the plain conversions copy
+	fmt.Println("allocations per call :")
+	fmt.Println("  string(b)                  :", testing.AllocsPerRun(1000, func() { sinkString = string(data) }))
+	fmt.Println("  []byte(s)                  :", testing.AllocsPerRun(1000, func() { sinkBytes = []byte("jane@example.com") }))
+
+	//! 2. cases where the compiler knows the copy is not needed
+	fmt.Println("  m[string(b)] (KeyFromBytes):", testing.AllocsPerRun(1000, func() { sinkInt = KeyFromBytes(ages, data) }))
+	fmt.Println("  key := string(b); m[key]   :", testing.AllocsPerRun(1000, func() { sinkInt = keyFromBytesSlow(ages, data) }))
+	fmt.Println("  string(b) == \"...\"         :", testing.AllocsPerRun(1000, func() {
+		if string(data) == "john@example.com" { //! a comparison doesn't keep the string either
+			sinkInt++
+		}
+	}))
+	fmt.Println("  for range []byte(s)        :", testing.AllocsPerRun(1000, func() {
+		for _, c := range []byte("jane@example.com") { //! reading only, the slice is never kept
+			sinkInt = sinkInt + int(c)
+		}
+	}))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. the CSV writer in a hot loop
+	fields := [][]byte{[]byte("John"), []byte("20"), []byte("john@example.com")}
+	var builder strings.Builder
+	builder.Grow(1 << 20) //! reserve the space first, so that only the field conversions are measured
+
+	convert := testing.AllocsPerRun(1000, func() { writeRowConvert(&builder, fields) })
+	builder.Reset()
+	builder.Grow(1 << 20)
+	direct := testing.AllocsPerRun(1000, func() { writeRowDirect(&builder, fields) })
+	fmt.Println("CSV row, string(field) :", convert, "allocations") //! 3, one per field
+	fmt.Println("CSV row, Write(field)  :", direct, "allocations")  //! 0
+
+	builder.Reset()
+	writeRowDirect(&builder, fields)
+	fmt.Print("row : ", builder.String())
+
+	fmt.Println("--------------------------------")
+
+	//! 4. why the copy exists : changing the []byte after the conversion
+	name := []byte("John")
+	copied := string(name) //! a copy
+	name[0] = 'B'
+	fmt.Println("after name[0] = 'B' :", string(name), "| copied string :", copied) //! Bohn | John -> the string is safe
+
+	//! unsafe.String builds a string which SHARES the bytes, without copying. It's shown here only to see WHY that is dangerous
+	name = []byte("John")
+	shared := unsafe.String(unsafe.SliceData(name), len(name))
+	name[0] = 'B'
+	fmt.Println("after name[0] = 'B' :", string(name), "| shared string :", shared) //! Bohn | Bohn -> the "read-only" string changed!
+
+	/*
+		A string whose bytes change breaks everything which trusts strings to stay the same :
+		a map key stored with "John" is now at the wrong place in the map, a cached hash is wrong, two goroutines can see different values ...
+
+		Rule : don't use unsafe to skip the copy. Instead :
+		- let the compiler do it (m[string(b)], string(b) == "...", for range []byte(s))
+		- work with []byte all the way (builder.Write, bytes package functions) and don't convert at all
+	*/
+
+	fmt.Println("--------------------------------")
+
+	//! 5. strings.Clone -> the opposite problem. A substring shares the memory of the big string
+	big := strings.Repeat("x", 1<<20) + "john@example.com" //! 1 MB
+	email := big[len(big)-16:]                             //! no copy : points into 'big', and keeps ALL of it alive
+	cloned := strings.Clone(email)                         //! a copy of only the 16 bytes, 'big' can be freed
+	fmt.Println("substring shares memory with big :", unsafe.StringData(email) == (*byte)(unsafe.Add(unsafe.Pointer(unsafe.StringData(big)), len(big)-16)))
+	fmt.Println("clone shares memory with big     :", unsafe.StringData(cloned) == unsafe.StringData(email))
+	fmt.Println("values equal                     :", email == cloned)
+}
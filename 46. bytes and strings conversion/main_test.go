@@ -0,0 +1,211 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestKeyFromBytes(t *testing.T) {
+	ages := map[string]int{"john@example.com": 20, "jane@example.com": 21, "": 99}
+	tests := []struct {
+		name string
+		key  []byte
+		want int
+	}{
+		{"found", []byte("john@example.com"), 20},
+		{"another key", []byte("jane@example.com"), 21},
+		{"missing", []byte("bob@example.com"), 0},
+		{"a prefix is not the key", []byte("john"), 0},
+		{"nil is the empty key", nil, 99},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyFromBytes(ages, tt.key); got != tt.want {
+				t.Errorf("KeyFromBytes(%q) = %d, want %d", tt.key, got, tt.want)
+			}
+			if got := keyFromBytesSlow(ages, tt.key); got != tt.want {
+				t.Errorf("keyFromBytesSlow(%q) = %d, want %d", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+//! the allocation counts from the table in the README. The compiler skips the copy only when the converted value is never kept
+func TestConversionAllocations(t *testing.T) {
+	data := []byte("john@example.com")
+	ages := map[string]int{"john@example.com": 20}
+	tests := []struct {
+		name string
+		fn   func()
+		want float64
+	}{
+		{"string(b)", func() { sinkString = string(data) }, 1},
+		{"[]byte(s)", func() { sinkBytes = []byte("jane@example.com") }, 1},
+		{"m[string(b)]", func() { sinkInt = KeyFromBytes(ages, data) }, 0},
+		{"key := string(b); m[key]", func() { sinkInt = keyFromBytesSlow(ages, data) }, 1},
+		{"string(b) == \"...\"", func() {
+			if string(data) == "john@example.com" {
+				sinkInt++
+			}
+		}, 0},
+		{"for range []byte(s)", func() {
+			for _, c := range []byte("jane@example.com") {
+				sinkInt = sinkInt + int(c)
+			}
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testing.AllocsPerRun(1000, tt.fn); got != tt.want {
+				t.Errorf("allocations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVWriters(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields [][]byte
+		want   string
+	}{
+		{"three fields", [][]byte{[]byte("John"), []byte("20"), []byte("john@example.com")}, "John,20,john@example.com\n"},
+		{"one field", [][]byte{[]byte("John")}, "John\n"},
+		{"empty fields", [][]byte{nil, []byte(""), []byte("x")}, ",,x\n"},
+		{"no fields", nil, "\n"},
+		{"unicode", [][]byte{[]byte("নমস্কার"), []byte("café")}, "নমস্কার,café\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var convert, direct strings.Builder
+			writeRowConvert(&convert, tt.fields)
+			writeRowDirect(&direct, tt.fields)
+			if convert.String() != tt.want {
+				t.Errorf("writeRowConvert = %q, want %q", convert.String(), tt.want)
+			}
+			if direct.String() != tt.want {
+				t.Errorf("writeRowDirect = %q, want %q", direct.String(), tt.want)
+			}
+		})
+	}
+}
+
+//! one allocation per field when converting, none when writing the bytes. The builder has room reserved, so only the conversions are counted
+func TestCSVAllocations(t *testing.T) {
+	fields := [][]byte{[]byte("John"), []byte("20"), []byte("john@example.com")}
+	tests := []struct {
+		name  string
+		write func(*strings.Builder, [][]byte)
+		want  float64
+	}{
+		{"string(field)", writeRowConvert, 3},
+		{"Write(field)", writeRowDirect, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder strings.Builder
+			builder.Grow(1 << 20)
+			if got := testing.AllocsPerRun(1000, func() { tt.write(&builder, fields) }); got != tt.want {
+				t.Errorf("allocations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//! the hazard, on purpose : a string converted with a copy keeps its value, a string sharing the bytes through unsafe changes with them
+func TestMutationAfterConversion(t *testing.T) {
+	name := []byte("John")
+	copied := string(name)
+	shared := unsafe.String(unsafe.SliceData(name), len(name))
+	name[0] = 'B'
+
+	if copied != "John" {
+		t.Errorf("copied = %q after changing the bytes, want %q", copied, "John")
+	}
+	if shared != "Bohn" {
+		t.Errorf("shared = %q after changing the bytes, want %q (it shares the memory)", shared, "Bohn")
+	}
+
+	//! a map key which shares the bytes : after the change the entry can't be found by the name it was stored with
+	key := []byte("John")
+	ages := map[string]int{unsafe.String(unsafe.SliceData(key), len(key)): 20}
+	key[0] = 'B'
+	if _, ok := ages["John"]; ok {
+		t.Error(`ages["John"] found, want it lost : the stored key now reads "Bohn"`)
+	}
+	for stored := range ages {
+		if stored != "Bohn" {
+			t.Errorf("stored key = %q, want %q", stored, "Bohn")
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	big := strings.Repeat("x", 1<<20) + "john@example.com"
+	email := big[len(big)-16:]
+	cloned := strings.Clone(email)
+
+	if email != "john@example.com" || cloned != email {
+		t.Fatalf("email = %q, cloned = %q, want both %q", email, cloned, "john@example.com")
+	}
+	if unsafe.StringData(email) != (*byte)(unsafe.Add(unsafe.Pointer(unsafe.StringData(big)), len(big)-16)) {
+		t.Error("the substring doesn't share the memory of big, want it to")
+	}
+	if unsafe.StringData(cloned) == unsafe.StringData(email) {
+		t.Error("the clone shares the memory of the substring, want a copy")
+	}
+}
+
+func BenchmarkConversion(b *testing.B) {
+	data := []byte("john@example.com")
+	ages := map[string]int{"john@example.com": 20, "jane@example.com": 21}
+	b.Run("string(b)", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkString = string(data)
+		}
+	})
+	b.Run("[]byte(s)", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkBytes = []byte("jane@example.com")
+		}
+	})
+	b.Run("KeyFromBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkInt = KeyFromBytes(ages, data)
+		}
+	})
+	b.Run("keyFromBytesSlow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkInt = keyFromBytesSlow(ages, data)
+		}
+	})
+}
+
+func BenchmarkCSVRow(b *testing.B) {
+	fields := [][]byte{[]byte("John"), []byte("20"), []byte("john@example.com")}
+	writers := []struct {
+		name  string
+		write func(*strings.Builder, [][]byte)
+	}{
+		{"string(field)", writeRowConvert},
+		{"Write(field)", writeRowDirect},
+	}
+	for _, w := range writers {
+		b.Run(w.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var builder strings.Builder
+			for i := 0; i < b.N; i++ {
+				if builder.Len() > 1<<16 { //! reuse one big buffer, so only the conversions are counted
+					builder.Reset()
+					builder.Grow(1 << 17)
+				}
+				w.write(&builder, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+module randomwalk
+
+go 1.22
+
+require statistics v0.0.0
+
+replace statistics => "../35. statistics"
@@ -0,0 +1,246 @@
+//! A random walk simulation : N walkers move on a 2D grid, one random step at a time. Every walker is a goroutine.
+//! The walkers DON'T write to the grid themselves. They send their positions over a channel, and ONE aggregator goroutine counts the visits. Only one goroutine ever touches the counts, so there is no data race and no mutex is needed.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"statistics/stats" //! the statistics package of 35. statistics, through the replace in go.mod
+)
+
+type Point struct {
+	X, Y int
+}
+
+type Grid struct {
+	Width, Height int
+}
+
+//! Strategy decides the next position. Strategies are plain functions, so a new one can be plugged in without changing the walker
+type Strategy func(current Point, rng *rand.Rand, grid Grid) Point
+
+var moves = []Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} //! north, east, south, west
+
+//! uniform -> the 4 directions are equally likely. Leaving the grid on one side enters it on the other side (wrap around)
+func uniform(current Point, rng *rand.Rand, grid Grid) Point {
+	move := moves[rng.IntN(len(moves))]
+	return Point{
+		X: (current.X + move.X + grid.Width) % grid.Width,
+		Y: (current.Y + move.Y + grid.Height) % grid.Height,
+	}
+}
+
+//! biased -> east is chosen more often (a 'wind' from the west)
+func biased(current Point, rng *rand.Rand, grid Grid) Point {
+	if rng.Float64() < 0.4 {
+		return Point{X: min(current.X+1, grid.Width-1), Y: current.Y}
+	}
+	return bounded(current, rng, grid)
+}
+
+//! bounded -> a step outside the grid is clamped back to the edge, the walker stays inside
+func bounded(current Point, rng *rand.Rand, grid Grid) Point {
+	move := moves[rng.IntN(len(moves))]
+	return Point{
+		X: max(0, min(current.X+move.X, grid.Width-1)),
+		Y: max(0, min(current.Y+move.Y, grid.Height-1)),
+	}
+}
+
+var strategies = map[string]Strategy{
+	"uniform": uniform,
+	"biased":  biased,
+	"bounded": bounded,
+}
+
+type Step struct {
+	Walker   int
+	Position Point
+}
+
+type Result struct {
+	Visits [][]int //! Visits[y][x]
+	Final  []Point //! the last position of every walker
+	Total  int
+}
+
+//! walk is one walker. Its random source is seeded from (seed, walker id), so every walker has its own reproducible sequence no matter how the goroutines are scheduled
+func walk(id, steps int, seed uint64, start Point, grid Grid, strategy Strategy, out chan<- Step, wg *sync.WaitGroup) {
+	defer wg.Done()
+	rng := rand.New(rand.NewPCG(seed, uint64(id)))
+	position := start
+	for i := 0; i < steps; i++ {
+		position = strategy(position, rng, grid)
+		out <- Step{Walker: id, Position: position}
+	}
+}
+
+//! checkSize -> 0 walkers or 0 steps is an empty simulation. A negative count or a grid without cells is an error, not a panic in make or an index out of range
+func checkSize(walkers, steps int, grid Grid) error {
+	switch {
+	case walkers < 0:
+		return fmt.Errorf("walkers must be 0 or more, got %d", walkers)
+	case steps < 0:
+		return fmt.Errorf("steps must be 0 or more, got %d", steps)
+	case grid.Width < 1 || grid.Height < 1:
+		return fmt.Errorf("the grid must be at least 1x1, got %dx%d", grid.Width, grid.Height)
+	}
+	return nil
+}
+
+//! Simulate starts the walkers and the aggregator and waits until everything is finished
+func Simulate(walkers, steps int, seed uint64, grid Grid, strategy Strategy) (Result, error) {
+	if err := checkSize(walkers, steps, grid); err != nil {
+		return Result{}, err
+	}
+	if strategy == nil {
+		return Result{}, errors.New("no strategy")
+	}
+	start := Point{grid.Width / 2, grid.Height / 2}
+	out := make(chan Step, 64)
+	done := make(chan Result)
+
+	//! the aggregator : the ONLY goroutine which writes to visits and final
+	go func() {
+		visits := make([][]int, grid.Height)
+		for y := range visits {
+			visits[y] = make([]int, grid.Width)
+		}
+		final := make([]Point, walkers)
+		total := 0
+		for step := range out { //! ends when 'out' is closed
+			visits[step.Position.Y][step.Position.X]++
+			final[step.Walker] = step.Position
+			total++
+		}
+		done <- Result{Visits: visits, Final: final, Total: total}
+	}()
+
+	var wg sync.WaitGroup
+	for id := 0; id < walkers; id++ {
+		wg.Add(1)
+		go walk(id, steps, seed, start, grid, strategy, out, &wg)
+	}
+
+	wg.Wait()  //! every walker finished
+	close(out) //! -> the aggregator's loop ends
+	return <-done, nil
+}
+
+//! heatMap draws the visit counts with characters from light to dark
+func heatMap(visits [][]int) string {
+	const shades = " .:-=+*#%@"
+	largest := 0
+	for _, row := range visits {
+		for _, count := range row {
+			largest = max(largest, count)
+		}
+	}
+
+	var builder strings.Builder
+	for _, row := range visits {
+		for _, count := range row {
+			index := 0
+			if count > 0 {
+				index = 1 + count*(len(shades)-2)/largest //! any visit is at least '.', the most visited cell is '@'
+			}
+			builder.WriteByte(shades[index])
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+//! distanceStats -> how far the walkers ended up from the start : mean, median and max (the 100th percentile)
+func distanceStats(final []Point, start Point) (mean, median, farthest float64, err error) {
+	distances := make([]float64, len(final))
+	for i, p := range final {
+		distances[i] = math.Hypot(float64(p.X-start.X), float64(p.Y-start.Y))
+	}
+	if mean, err = stats.Mean(distances); err != nil {
+		return 0, 0, 0, err
+	}
+	if median, err = stats.Median(distances); err != nil {
+		return 0, 0, 0, err
+	}
+	if farthest, err = stats.Percentile(distances, 100); err != nil {
+		return 0, 0, 0, err
+	}
+	return mean, median, farthest, nil
+}
+
+func main() {
+	walkers := flag.Int("walkers", 20, "number of walkers (goroutines), 1 or more")
+	steps := flag.Int("steps", 500, "steps per walker")
+	width := flag.Int("width", 60, "grid width")
+	height := flag.Int("height", 15, "grid height")
+	seed := flag.Uint64("seed", 1, "random seed, the same seed gives the same result")
+	strategyName := flag.String("strategy", "bounded", "uniform, biased or bounded")
+	flag.Parse()
+
+	strategy, ok := strategies[*strategyName]
+	if !ok {
+		names := make([]string, 0, len(strategies))
+		for name := range strategies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("unknown strategy %q, choose one of : %s\n", *strategyName, strings.Join(names, ", "))
+		os.Exit(1)
+	}
+
+	grid := Grid{Width: *width, Height: *height}
+	if *walkers < 1 {
+		fmt.Printf("walkers must be 1 or more, got %d : the distance summary needs a walker\n", *walkers)
+		os.Exit(1)
+	}
+	result, err := Simulate(*walkers, *steps, *seed, grid, strategy)
+	if err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("strategy %s, %d walkers x %d steps, seed %d\n", *strategyName, *walkers, *steps, *seed)
+	fmt.Print(heatMap(result.Visits))
+
+	fmt.Println("--------------------------------")
+
+	//! summary statistics
+	start := Point{grid.Width / 2, grid.Height / 2}
+	visited, busiest := 0, 0
+	for _, row := range result.Visits {
+		for _, count := range row {
+			if count > 0 {
+				visited++
+			}
+			busiest = max(busiest, count)
+		}
+	}
+	mean, median, farthest, err := distanceStats(result.Final, start)
+	if err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("total visits       : %d (walkers x steps = %d)\n", result.Total, *walkers**steps) //! conservation : every step is counted exactly once
+	fmt.Printf("cells visited      : %d of %d\n", visited, grid.Width*grid.Height)
+	fmt.Printf("busiest cell       : %d visits\n", busiest)
+	fmt.Printf("distance from start: mean %.1f, median %.1f, max %.1f\n", mean, median, farthest)
+}
+
+/*
+	Try :
+
+	go run .
+	go run . -strategy biased
+	go run . -strategy uniform -walkers 100 -steps 2000 -seed 7
+	go run . -width 20 -height 20
+*/
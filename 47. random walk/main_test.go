@@ -0,0 +1,261 @@
+package main
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"statistics/stats"
+)
+
+var testGrid = Grid{Width: 20, Height: 10}
+
+func simulate(t *testing.T, walkers, steps int, seed uint64, grid Grid, strategy Strategy) Result {
+	t.Helper()
+	result, err := Simulate(walkers, steps, seed, grid, strategy)
+	if err != nil {
+		t.Fatalf("Simulate error = %v", err)
+	}
+	return result
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			first := simulate(t, 8, 300, 42, testGrid, strategy)
+			for run := 0; run < 5; run++ {
+				again := simulate(t, 8, 300, 42, testGrid, strategy)
+				if !reflect.DeepEqual(again.Visits, first.Visits) || !reflect.DeepEqual(again.Final, first.Final) {
+					t.Fatalf("run %d with the same seed gave a different result", run)
+				}
+			}
+			other := simulate(t, 8, 300, 43, testGrid, strategy)
+			if reflect.DeepEqual(other.Visits, first.Visits) {
+				t.Error("seeds 42 and 43 gave the same visits, want different walks")
+			}
+		})
+	}
+}
+
+//! every step is counted exactly once : total visits = walkers x steps
+func TestConservation(t *testing.T) {
+	tests := []struct {
+		name           string
+		walkers, steps int
+	}{
+		{"one walker", 1, 100},
+		{"many walkers", 50, 200},
+		{"more walkers than the channel buffer", 100, 1},
+		{"no steps", 10, 0},
+		{"no walkers", 0, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := simulate(t, tt.walkers, tt.steps, 1, testGrid, uniform)
+			sum := 0
+			for _, row := range result.Visits {
+				for _, count := range row {
+					sum = sum + count
+				}
+			}
+			want := tt.walkers * tt.steps
+			if result.Total != want || sum != want {
+				t.Errorf("Total = %d, sum of visits = %d, want both %d", result.Total, sum, want)
+			}
+			if len(result.Final) != tt.walkers {
+				t.Errorf("len(Final) = %d, want %d", len(result.Final), tt.walkers)
+			}
+		})
+	}
+}
+
+//! a bad size is an error before any goroutine starts, not a panic
+func TestSimulateInvalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		walkers, steps int
+		grid           Grid
+		strategy       Strategy
+	}{
+		{"negative walkers", -1, 10, testGrid, uniform},
+		{"negative steps", 5, -1, testGrid, uniform},
+		{"no width", 5, 10, Grid{Width: 0, Height: 10}, uniform},
+		{"negative height", 5, 10, Grid{Width: 20, Height: -3}, bounded},
+		{"no strategy", 5, 10, testGrid, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Simulate(tt.walkers, tt.steps, 1, tt.grid, tt.strategy); err == nil {
+				t.Error("Simulate error = nil, want an error")
+			}
+		})
+	}
+}
+
+//! a 1x1 grid is the smallest one : every step stays on the only cell
+func TestOneCellGrid(t *testing.T) {
+	for name, strategy := range strategies {
+		result := simulate(t, 3, 10, 1, Grid{Width: 1, Height: 1}, strategy)
+		if result.Visits[0][0] != 30 {
+			t.Errorf("%s : visits = %v, want 30 on the only cell", name, result.Visits)
+		}
+	}
+}
+
+func TestDistanceStats(t *testing.T) {
+	start := Point{0, 0}
+	mean, median, farthest, err := distanceStats([]Point{{3, 4}, {0, 1}, {0, 0}, {6, 8}}, start) //! distances 5, 1, 0, 10
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mean != 4 || median != 3 || farthest != 10 {
+		t.Errorf("mean %v, median %v, max %v; want 4, 3, 10", mean, median, farthest)
+	}
+	if _, _, _, err := distanceStats(nil, start); !errors.Is(err, stats.ErrEmpty) {
+		t.Errorf("no walkers : error = %v, want stats.ErrEmpty", err)
+	}
+}
+
+//! from every edge and corner, many random steps never leave the grid, and a step is at most one cell
+func TestBoundedClamps(t *testing.T) {
+	tests := []struct {
+		name  string
+		start Point
+	}{
+		{"top left", Point{0, 0}},
+		{"top right", Point{testGrid.Width - 1, 0}},
+		{"bottom left", Point{0, testGrid.Height - 1}},
+		{"bottom right", Point{testGrid.Width - 1, testGrid.Height - 1}},
+		{"left edge", Point{0, 5}},
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stayed := 0
+			for i := 0; i < 1000; i++ {
+				next := bounded(tt.start, rng, testGrid)
+				if next.X < 0 || next.X >= testGrid.Width || next.Y < 0 || next.Y >= testGrid.Height {
+					t.Fatalf("bounded(%v) = %v, outside the %dx%d grid", tt.start, next, testGrid.Width, testGrid.Height)
+				}
+				if distance := abs(next.X-tt.start.X) + abs(next.Y-tt.start.Y); distance > 1 {
+					t.Fatalf("bounded(%v) = %v, a step of %d cells", tt.start, next, distance)
+				}
+				if next == tt.start {
+					stayed++
+				}
+			}
+			if stayed == 0 {
+				t.Errorf("a step off the grid never clamped back to %v", tt.start)
+			}
+		})
+	}
+}
+
+func TestUniformWrapsAround(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	corner := Point{0, 0}
+	seen := map[Point]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[uniform(corner, rng, testGrid)] = true
+	}
+	want := map[Point]bool{{0, testGrid.Height - 1}: true, {1, 0}: true, {0, 1}: true, {testGrid.Width - 1, 0}: true}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("uniform from %v reached %v, want %v", corner, seen, want)
+	}
+}
+
+//! with a wind from the west, the walkers end up east of where they started
+func TestBiasedDriftsEast(t *testing.T) {
+	result := simulate(t, 50, 200, 1, testGrid, biased)
+	start := Point{testGrid.Width / 2, testGrid.Height / 2}
+	sum := 0
+	for _, p := range result.Final {
+		sum = sum + p.X - start.X
+	}
+	if mean := float64(sum) / float64(len(result.Final)); mean < 3 {
+		t.Errorf("mean X distance = %.1f, want at least 3 to the east", mean)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	want := map[string]Strategy{"uniform": uniform, "biased": biased, "bounded": bounded}
+	if len(strategies) != len(want) {
+		t.Errorf("%d strategies, want %d", len(strategies), len(want))
+	}
+	for name, strategy := range want {
+		got, ok := strategies[name]
+		if !ok || reflect.ValueOf(got).Pointer() != reflect.ValueOf(strategy).Pointer() {
+			t.Errorf("strategies[%q] is not the %s function", name, name)
+		}
+	}
+}
+
+//! any function with the Strategy signature can be plugged in, Simulate calls exactly that one
+func TestPluggedInStrategy(t *testing.T) {
+	calls := make(chan struct{}, 1000)
+	east := func(current Point, rng *rand.Rand, grid Grid) Point {
+		calls <- struct{}{}
+		return Point{X: min(current.X+1, grid.Width-1), Y: current.Y}
+	}
+	result := simulate(t, 3, 100, 1, testGrid, east)
+
+	if len(calls) != 300 {
+		t.Errorf("the strategy was called %d times, want 300", len(calls))
+	}
+	edge := Point{testGrid.Width - 1, testGrid.Height / 2}
+	for i, p := range result.Final {
+		if p != edge {
+			t.Errorf("walker %d ended at %v, want %v", i, p, edge)
+		}
+	}
+	//! from x = 10 the 9th step reaches x = 19, so 92 of the 100 steps of every walker are at the edge
+	if got := result.Visits[edge.Y][edge.X]; got != 3*92 {
+		t.Errorf("visits at the edge = %d, want %d", got, 3*92)
+	}
+}
+
+//! after Simulate returns, the walkers and the aggregator have all exited
+func TestNoGoroutineLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		simulate(t, 20, 100, uint64(i), testGrid, bounded)
+	}
+	//! a goroutine which already sent its last value may need a moment to finish completely
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines before / after = %d / %d, want no more after", before, after)
+	}
+}
+
+func TestHeatMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		visits [][]int
+		want   string
+	}{
+		{"no visits", [][]int{{0, 0}}, "  \n"},
+		{"one visit is the darkest", [][]int{{0, 1}, {0, 0}}, " @\n  \n"},
+		{"any visit is at least '.'", [][]int{{1, 100}}, ".@\n"},
+		{"in between", [][]int{{8, 4, 0}}, "@+ \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := heatMap(tt.visits); got != tt.want {
+				t.Errorf("heatMap(%v) = %q, want %q", tt.visits, got, tt.want)
+			}
+		})
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,102 @@
+package main
+
+import "fmt"
+
+//! const -> a value which can never change. It is fixed when the program is compiled
+const appName = "learn-GoLang"
+
+//! Weekday is our own type with int as the underlying type. Now a day can't be mixed up with any other int by accident
+type Weekday int
+
+//! iota -> a counter inside a const block. It starts at 0 and grows by 1 on every line
+//! only the first line needs '= iota', the next lines repeat the same expression automatically
+const (
+	Sunday    Weekday = iota //! 0
+	Monday                   //! 1
+	Tuesday                  //! 2
+	Wednesday                //! 3
+	Thursday                 //! 4
+	Friday                   //! 5
+	Saturday                 //! 6
+)
+
+var weekdayNames = [...]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+//! String() string -> fmt calls this method automatically when it prints a Weekday (the fmt.Stringer interface)
+func (d Weekday) String() string {
+	if d < Sunday || d > Saturday {
+		return fmt.Sprintf("Weekday(%d)", int(d)) //! int(d) -> otherwise Sprintf would call String() again, forever
+	}
+	return weekdayNames[d]
+}
+
+func (d Weekday) IsWeekend() bool {
+	return d == Saturday || d == Sunday
+}
+
+//! skipping values with '_' : the blank identifier throws the value away, but iota still counts
+type Priority int
+
+const (
+	_      Priority = iota //! 0 is skipped, so the zero value of Priority means 'not set'
+	Low                    //! 1
+	Medium                 //! 2
+	High                   //! 3
+)
+
+//! iota inside an expression : every line repeats '1 << (10 * (iota + 1))' with the next iota
+//! << is a 'left shift' : 1 << 10 moves the 1 ten bits to the left = 2 to the power of 10
+const (
+	KB = 1 << (10 * (iota + 1)) //! iota 0 -> 1 << 10 = 1024
+	MB                          //! iota 1 -> 1 << 20
+	GB                          //! iota 2 -> 1 << 30
+)
+
+//! bit flags : every constant is a different bit, so they can be combined with | and checked with &
+type Permission int
+
+const (
+	Read    Permission = 1 << iota //! 1  (binary 001)
+	Write                          //! 2  (binary 010)
+	Execute                        //! 4  (binary 100)
+)
+
+func main() {
+	fmt.Println("app :", appName)
+
+	fmt.Println("--------------------------------")
+
+	//! the numeric and the string form of every day
+	for day := Sunday; day <= Saturday; day++ {
+		fmt.Printf("%d %-9s weekend : %v\n", day, day, day.IsWeekend()) //! %d -> the number, %s -> String()
+	}
+	fmt.Println(Weekday(9)) //! Weekday(9)
+
+	fmt.Println("--------------------------------")
+
+	//! the day switch from '04. switch-case', rewritten with the enum. A typo like "Mondya" is now a compile error, not a silent 'it is not a day'
+	day := Monday
+	switch day {
+	case Saturday, Sunday:
+		fmt.Println("it is", day, ": weekend")
+	case Monday, Tuesday, Wednesday, Thursday, Friday:
+		fmt.Println("it is", day, ": a working day") //! no 'break' needed, Go stops after the matching case
+	default:
+		fmt.Println("it is not a day")
+	}
+
+	fmt.Println("--------------------------------")
+
+	fmt.Println("Low, Medium, High :", Low, Medium, High) //! 1 2 3
+	var notSet Priority
+	fmt.Println("zero Priority     :", notSet) //! 0 -> not a valid priority, so we can tell that it was never set
+
+	fmt.Println("KB :", KB) //! 1024
+	fmt.Println("MB :", MB) //! 1048576
+	fmt.Println("GB :", GB) //! 1073741824
+
+	permissions := Read | Write //! 1 | 2 = 3 (binary 011)
+	fmt.Printf("permissions : %d (binary %03b)\n", permissions, permissions)
+	fmt.Println("can read    :", permissions&Read != 0)    //! true
+	fmt.Println("can execute :", permissions&Execute != 0) //! false
+}
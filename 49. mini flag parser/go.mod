@@ -0,0 +1,3 @@
+module miniflagparser
+
+go 1.22
@@ -0,0 +1,65 @@
+//! How does the 'flag' package work inside? We write a small copy of it (miniflag/miniflag.go). miniflag_test.go checks that it gives the same results as the real one
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"miniflagparser/miniflag"
+)
+
+//! the flags of our "program"
+type options struct {
+	name    *string
+	age     *int
+	verbose *bool
+}
+
+func newPersonFlags() (*miniflag.FlagSet, options) {
+	set := miniflag.NewFlagSet("person")
+	o := options{
+		name:    set.String("name", "John", "name of the person"),
+		age:     set.Int("age", 30, "age of the person"),
+		verbose: set.Bool("verbose", false, "print more details"),
+	}
+	return set, o
+}
+
+func main() {
+	//! the program's own arguments, parsed with miniflag
+	set, o := newPersonFlags()
+	err := set.Parse(os.Args[1:])
+	if errors.Is(err, miniflag.ErrHelp) {
+		fmt.Print(set.Usage())
+		return
+	}
+	if err != nil {
+		fmt.Println(err)
+		fmt.Print(set.Usage())
+		os.Exit(2)
+	}
+
+	fmt.Println("name    :", *o.name)
+	fmt.Println("age     :", *o.age)
+	fmt.Println("verbose :", *o.verbose)
+	fmt.Printf("args    : %q\n", set.Args())
+}
+
+/*
+	What we learned about the flag package :
+	1. String / Int / Bool create a variable, store a Value for it in a map by name, and return a pointer to it
+	2. Parse walks the arguments : it finds the flag by name and calls Set(text) on its Value
+	3. a bool flag doesn't take the next argument, so "-verbose file.txt" means verbose + one positional arg
+	4. Parse stops at "--" or at the first argument which isn't a flag. The rest is in Args()
+*/
+
+/*
+	Try :
+
+	go run .
+	go run . -name Jane -age=25 -verbose file.txt
+	go run . -name Jane -- -age 99
+	go run . -age abc
+	go run . -h
+*/
@@ -0,0 +1,192 @@
+//! Package miniflag -> a small copy of the standard 'flag' package, to see how it works inside
+//! the lesson's main.go parses its own arguments with it, and '62. json to csv' can be built with it instead of 'flag' (go build -tags miniflag)
+package miniflag
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//! every flag knows how to parse its own text into its own type. This is the same idea as flag.Value in the standard library
+type value interface {
+	Set(text string) error
+	String() string
+}
+
+//! stringValue is a *string, with methods. Registering returns the same pointer, so Parse writes directly into the caller's variable
+type stringValue struct{ p *string }
+
+func (v stringValue) Set(text string) error { *v.p = text; return nil }
+func (v stringValue) String() string        { return *v.p }
+
+type intValue struct{ p *int }
+
+func (v intValue) Set(text string) error {
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid number", text)
+	}
+	*v.p = n
+	return nil
+}
+func (v intValue) String() string { return strconv.Itoa(*v.p) }
+
+type boolValue struct{ p *bool }
+
+func (v boolValue) Set(text string) error {
+	b, err := strconv.ParseBool(text) //! accepts 1, t, true, TRUE, 0, f, false ...
+	if err != nil {
+		return fmt.Errorf("%q is not a valid boolean", text)
+	}
+	*v.p = b
+	return nil
+}
+func (v boolValue) String() string { return strconv.FormatBool(*v.p) }
+
+type definition struct {
+	name         string
+	usage        string
+	defaultValue string
+	value        value
+	isBool       bool
+}
+
+type FlagSet struct {
+	name        string
+	definitions map[string]*definition
+	args        []string //! positional arguments, everything which is not a flag
+}
+
+var ErrHelp = errors.New("miniflag: help requested")
+
+func NewFlagSet(name string) *FlagSet {
+	return &FlagSet{name: name, definitions: map[string]*definition{}}
+}
+
+//! register panics on a duplicate name, like the standard flag package. It's a programmer mistake, not a user mistake
+func (f *FlagSet) register(name, usage string, v value, isBool bool) {
+	if _, exists := f.definitions[name]; exists {
+		panic(fmt.Sprintf("miniflag: flag redefined: %s", name))
+	}
+	f.definitions[name] = &definition{name: name, usage: usage, defaultValue: v.String(), value: v, isBool: isBool}
+}
+
+func (f *FlagSet) String(name, defaultValue, usage string) *string {
+	p := new(string)
+	*p = defaultValue
+	f.register(name, usage, stringValue{p}, false)
+	return p
+}
+
+func (f *FlagSet) Int(name string, defaultValue int, usage string) *int {
+	p := new(int)
+	*p = defaultValue
+	f.register(name, usage, intValue{p}, false)
+	return p
+}
+
+func (f *FlagSet) Bool(name string, defaultValue bool, usage string) *bool {
+	p := new(bool)
+	*p = defaultValue
+	f.register(name, usage, boolValue{p}, true)
+	return p
+}
+
+//! Parse understands :
+//!   -name value   -name=value   --name value   --name=value
+//!   -verbose                    (bool flags don't need a value)
+//!   --                          (everything after it is positional, even if it starts with '-')
+//! parsing stops at the first argument which is not a flag, like the standard package
+func (f *FlagSet) Parse(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			f.args = append(f.args, args[i+1:]...)
+			return nil
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			f.args = append(f.args, args[i:]...) //! the first positional argument : the rest are positional too
+			return nil
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		text, hasValue := "", false
+		if before, after, found := strings.Cut(name, "="); found {
+			name, text, hasValue = before, after, true
+		}
+
+		if name == "h" || name == "help" {
+			if _, defined := f.definitions[name]; !defined {
+				return ErrHelp
+			}
+		}
+
+		definition, ok := f.definitions[name]
+		if !ok {
+			return fmt.Errorf("flag provided but not defined: -%s", name)
+		}
+
+		if !hasValue {
+			if definition.isBool {
+				text = "true" //! -verbose means -verbose=true
+			} else {
+				if i+1 >= len(args) {
+					return fmt.Errorf("flag needs an argument: -%s", name)
+				}
+				i++
+				text = args[i]
+			}
+		}
+
+		if err := definition.value.Set(text); err != nil {
+			return fmt.Errorf("invalid value for flag -%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+//! Args returns the positional arguments
+func (f *FlagSet) Args() []string {
+	return f.args
+}
+
+//! Usage builds the help text from the registered flags, sorted by name
+func (f *FlagSet) Usage() string {
+	names := make([]string, 0, len(f.definitions))
+	for name := range f.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Usage of %s:\n", f.name)
+	for _, name := range names {
+		d := f.definitions[name]
+		switch d.value.(type) {
+		case intValue:
+			fmt.Fprintf(&builder, "  -%s int\n", name)
+		case stringValue:
+			fmt.Fprintf(&builder, "  -%s string\n", name)
+		default:
+			fmt.Fprintf(&builder, "  -%s\n", name)
+		}
+		fmt.Fprintf(&builder, "    \t%s", d.usage)
+		//! like the standard package : zero values are not shown, strings are quoted
+		switch d.value.(type) {
+		case stringValue:
+			if d.defaultValue != "" {
+				fmt.Fprintf(&builder, " (default %q)", d.defaultValue)
+			}
+		default:
+			if d.defaultValue != "0" && d.defaultValue != "false" {
+				fmt.Fprintf(&builder, " (default %s)", d.defaultValue)
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
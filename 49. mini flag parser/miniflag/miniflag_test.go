@@ -0,0 +1,277 @@
+package miniflag
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+//! go test ./... -update -> rewrites the golden file, after a change of the usage text which is on purpose
+var update = flag.Bool("update", false, "rewrite "+usageGoldenPath)
+
+const usageGoldenPath = "testdata/usage.golden"
+
+//! the flags of the "person" program of main.go. The tests register the same three flags in the standard flag package too
+type options struct {
+	name    *string
+	age     *int
+	verbose *bool
+}
+
+func newPersonFlags() (*FlagSet, options) {
+	set := NewFlagSet("person")
+	o := options{
+		name:    set.String("name", "John", "name of the person"),
+		age:     set.Int("age", 30, "age of the person"),
+		verbose: set.Bool("verbose", false, "print more details"),
+	}
+	return set, o
+}
+
+func parseMini(args []string) (options, []string, error) {
+	set, o := newPersonFlags()
+	err := set.Parse(args)
+	return o, set.Args(), err
+}
+
+//! parseStd registers the same three flags in the standard flag package
+func parseStd(args []string) (options, []string, error) {
+	set := flag.NewFlagSet("person", flag.ContinueOnError)
+	set.SetOutput(io.Discard) //! the standard package prints errors by itself, we only want the returned error
+	o := options{
+		name:    set.String("name", "John", "name of the person"),
+		age:     set.Int("age", 30, "age of the person"),
+		verbose: set.Bool("verbose", false, "print more details"),
+	}
+	err := set.Parse(args)
+	return o, set.Args(), err
+}
+
+func describe(o options, positional []string, err error) string {
+	if err != nil {
+		return "error"
+	}
+	return fmt.Sprintf("name=%s age=%d verbose=%t args=%q", *o.name, *o.age, *o.verbose, positional)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"-name value", []string{"-name", "Jane", "-age", "25"}, `name=Jane age=25 verbose=false args=[]`},
+		{"-name=value", []string{"-name=Jane", "-age=25"}, `name=Jane age=25 verbose=false args=[]`},
+		{"two dashes", []string{"--name", "Jane", "--age=25"}, `name=Jane age=25 verbose=false args=[]`},
+		{"bool shorthand", []string{"-verbose"}, `name=John age=30 verbose=true args=[]`},
+		{"bool with =", []string{"-verbose=false"}, `name=John age=30 verbose=false args=[]`},
+		{"bool with = and 1", []string{"-verbose=1"}, `name=John age=30 verbose=true args=[]`},
+		{"a bool doesn't take the next argument", []string{"-verbose", "file.txt"}, `name=John age=30 verbose=true args=["file.txt"]`},
+		{"an empty value with =", []string{"-name="}, `name= age=30 verbose=false args=[]`},
+		{"a value which starts with '-'", []string{"-name", "-Jane-"}, `name=-Jane- age=30 verbose=false args=[]`},
+		{"negative number", []string{"-age", "-5"}, `name=John age=-5 verbose=false args=[]`},
+		{"the last one wins", []string{"-age", "1", "-age", "2"}, `name=John age=2 verbose=false args=[]`},
+		{"positional args", []string{"-age", "40", "file1.txt", "file2.txt"}, `name=John age=40 verbose=false args=["file1.txt" "file2.txt"]`},
+		{"parsing stops at the first positional arg", []string{"file.txt", "-age", "99"}, `name=John age=30 verbose=false args=["file.txt" "-age" "99"]`},
+		{"a single '-' is positional", []string{"-", "-age", "99"}, `name=John age=30 verbose=false args=["-" "-age" "99"]`},
+		{"defaults", []string{}, `name=John age=30 verbose=false args=[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describe(parseMini(tt.args)); got != tt.want {
+				t.Errorf("parse %q = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+//! after '--' everything is positional, even '--' itself and arguments which look like flags
+func TestTerminator(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantName string
+	}{
+		{"flags after --", []string{"-name", "Jane", "--", "-age", "99"}, []string{"-age", "99"}, "Jane"},
+		{"-- first", []string{"--", "-name", "Jane"}, []string{"-name", "Jane"}, "John"},
+		{"-- last", []string{"-name", "Jane", "--"}, []string{}, "Jane"},
+		{"a second --", []string{"--", "a", "--", "b"}, []string{"a", "--", "b"}, "John"},
+		{"-- as a flag value", []string{"-name", "--", "x"}, []string{"x"}, "--"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, positional, err := parseMini(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprintf("%q", positional) != fmt.Sprintf("%q", tt.wantArgs) {
+				t.Errorf("Args() = %q, want %q", positional, tt.wantArgs)
+			}
+			if *o.name != tt.wantName {
+				t.Errorf("name = %q, want %q", *o.name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown flag", []string{"-colour", "red"}, "flag provided but not defined: -colour"},
+		{"unknown flag with =", []string{"--colour=red"}, "flag provided but not defined: -colour"},
+		{"missing value", []string{"-name"}, "flag needs an argument: -name"},
+		{"missing value after other flags", []string{"-verbose", "-age"}, "flag needs an argument: -age"},
+		{"not a number", []string{"-age", "abc"}, `invalid value for flag -age: "abc" is not a valid number`},
+		{"not a number with =", []string{"-age=4.5"}, `invalid value for flag -age: "4.5" is not a valid number`},
+		{"not a boolean", []string{"-verbose=maybe"}, `invalid value for flag -verbose: "maybe" is not a valid boolean`},
+		{"an error after good flags", []string{"-name", "Jane", "-age", "x"}, `invalid value for flag -age: "x" is not a valid number`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseMini(tt.args)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("parse %q error = %v, want %q", tt.args, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	for _, args := range [][]string{{"-h"}, {"-help"}, {"--help"}, {"-age", "1", "-h"}} {
+		if _, _, err := parseMini(args); !errors.Is(err, ErrHelp) {
+			t.Errorf("parse %q error = %v, want ErrHelp", args, err)
+		}
+	}
+
+	//! a program which defines -h itself gets its own flag, not help
+	set := NewFlagSet("person")
+	host := set.String("h", "", "host")
+	if err := set.Parse([]string{"-h", "example.com"}); err != nil || *host != "example.com" {
+		t.Errorf("-h defined : host = %q, error = %v; want example.com, nil", *host, err)
+	}
+}
+
+//! registering the same name twice is a programmer mistake -> panic, whatever the types are
+func TestRegisterTwicePanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		second func(set *FlagSet)
+	}{
+		{"string then string", func(set *FlagSet) { set.String("name", "", "") }},
+		{"string then int", func(set *FlagSet) { set.Int("name", 0, "") }},
+		{"string then bool", func(set *FlagSet) { set.Bool("name", false, "") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != "miniflag: flag redefined: name" {
+					t.Errorf("panic = %v, want %q", got, "miniflag: flag redefined: name")
+				}
+			}()
+			set := NewFlagSet("person")
+			set.String("name", "", "")
+			tt.second(set)
+		})
+	}
+}
+
+//! miniflag must give the same values, args and errors as the standard package
+func TestSameAsFlag(t *testing.T) {
+	cases := [][]string{
+		{"-name", "Jane", "-age", "25"},
+		{"-name=Jane", "-age=25"},
+		{"--name", "Jane", "--age=25"},
+		{"-verbose"},
+		{"-verbose=false"},
+		{"-verbose", "file.txt"},
+		{"-age", "40", "file1.txt", "file2.txt"},
+		{"-name", "Jane", "--", "-age", "99"},
+		{"file.txt", "-age", "99"},
+		{"-", "-age", "99"},
+		{},
+		{"-colour", "red"},
+		{"-name"},
+		{"-age", "abc"},
+		{"-verbose=maybe"},
+	}
+	for _, args := range cases {
+		mini := describe(parseMini(args))
+		std := describe(parseStd(args))
+		if mini != std {
+			t.Errorf("parse %q : miniflag %s, flag %s", args, mini, std)
+		}
+	}
+	_, _, miniErr := parseMini([]string{"-h"})
+	_, _, stdErr := parseStd([]string{"-h"})
+	if !errors.Is(miniErr, ErrHelp) || !errors.Is(stdErr, flag.ErrHelp) {
+		t.Errorf("-h : miniflag %v, flag %v; want ErrHelp from both", miniErr, stdErr)
+	}
+}
+
+//! the usage text is compared with the golden file, byte for byte
+func TestUsageGolden(t *testing.T) {
+	set, _ := newPersonFlags()
+	got := set.Usage()
+	if *update {
+		if err := os.WriteFile(usageGoldenPath, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(usageGoldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(golden) {
+		t.Errorf("Usage() doesn't match %s (run 'go test ./... -update' if the change is on purpose)\n got:\n%s", usageGoldenPath, got)
+	}
+}
+
+//! ... and with what the standard package prints for the same flags, also with zero and empty defaults which are not shown
+func TestUsageSameAsFlag(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		number     int
+		enabled    bool
+		wantInText string
+	}{
+		{"person defaults", "John", 30, false, `(default "John")`},
+		{"zero defaults", "", 0, false, ""},
+		{"a true bool", "", 0, true, "(default true)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mini := NewFlagSet("person")
+			mini.String("name", tt.text, "name of the person")
+			mini.Int("age", tt.number, "age of the person")
+			mini.Bool("verbose", tt.enabled, "print more details")
+
+			std := flag.NewFlagSet("person", flag.ContinueOnError)
+			std.String("name", tt.text, "name of the person")
+			std.Int("age", tt.number, "age of the person")
+			std.Bool("verbose", tt.enabled, "print more details")
+			var want bytes.Buffer
+			std.SetOutput(&want)
+			fmt.Fprintf(&want, "Usage of %s:\n", std.Name())
+			std.PrintDefaults()
+
+			if got := mini.Usage(); got != want.String() {
+				t.Errorf("Usage() =\n%s\nwant (from flag)\n%s", got, want.String())
+			}
+			if tt.wantInText == "" && bytes.Contains(want.Bytes(), []byte("(default")) {
+				t.Errorf("the usage text shows a default, want none for zero values :\n%s", want.String())
+			}
+			if !bytes.Contains(want.Bytes(), []byte(tt.wantInText)) {
+				t.Errorf("the usage text doesn't contain %q", tt.wantInText)
+			}
+		})
+	}
+}
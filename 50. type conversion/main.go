@@ -0,0 +1,114 @@
+//! Type conversion -> Go never converts types automatically. int + float64 is a compile error, we have to write the conversion ourselves : float64(x), int(y) ...
+//! strconv (string conversion) -> converts between strings and numbers / booleans. Parsing can fail, so those functions return an error too
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//! parseNumber -> the helper our calculator from '05. functions/c. function best practice' was missing
+//! there, fmt.Scanln(&number1) reads straight into an int. If the user types "abc", number1 silently stays 0 and the sum is wrong
+//! here we read the input as a string and convert it ourselves, so we can tell the user what went wrong
+func parseNumber(input string) (int, error) {
+	number, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a whole number", input)
+	}
+	return number, nil
+}
+
+func calculateSum(number1 int, number2 int) int {
+	return number1 + number2
+}
+
+func main() {
+	//! 1. int <-> float64
+	age := 30
+	height := 5.9
+
+	// total := age + height //! compile error : mismatched types int and float64
+	total := float64(age) + height
+	fmt.Println("float64(age) + height :", total) //! 35.9
+
+	fmt.Println("int(5.9)              :", int(height))  //! 5 -> the decimal part is cut off (truncated), NOT rounded
+	fmt.Println("int(-5.9)             :", int(-height)) //! -5 -> truncated towards zero
+	fmt.Println("7 / 2                 :", 7/2)          //! 3 -> int division
+	fmt.Println("float64(7) / 2        :", float64(7)/2) //! 3.5
+
+	fmt.Println("--------------------------------")
+
+	//! 2. string <-> int
+	number, err := strconv.Atoi("42") //! Atoi -> ASCII to integer
+	fmt.Println(`strconv.Atoi("42")  :`, number, err)
+
+	number, err = strconv.Atoi("abc")
+	fmt.Println(`strconv.Atoi("abc") :`, number, err) //! 0 strconv.Atoi: parsing "abc": invalid syntax
+
+	text := strconv.Itoa(42) //! Itoa -> integer to ASCII
+	fmt.Println(`strconv.Itoa(42)    :`, text+"!")
+
+	fmt.Println(`string(rune(65))    :`, string(rune(65))) //! "A" -> string(number) gives the CHARACTER with that code, not "65". Use strconv.Itoa for "65"
+
+	fmt.Println("--------------------------------")
+
+	//! 3. ParseFloat and ParseBool
+	price, err := strconv.ParseFloat("19.99", 64) //! 64 -> the result must fit into a float64
+	fmt.Println(`strconv.ParseFloat("19.99", 64) :`, price, err)
+
+	_, err = strconv.ParseFloat("19,99", 64)
+	fmt.Println(`strconv.ParseFloat("19,99", 64) :`, err) //! a comma is not a decimal point
+
+	isAdmin, err := strconv.ParseBool("true") //! accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False
+	fmt.Println(`strconv.ParseBool("true")       :`, isAdmin, err)
+
+	_, err = strconv.ParseBool("yes")
+	fmt.Println(`strconv.ParseBool("yes")        :`, err)
+
+	fmt.Println("--------------------------------")
+
+	//! 4. FormatInt -> an int64 as text in any base from 2 to 36
+	fmt.Println("255 in base 2  :", strconv.FormatInt(255, 2))  //! 11111111
+	fmt.Println("255 in base 8  :", strconv.FormatInt(255, 8))  //! 377
+	fmt.Println("255 in base 10 :", strconv.FormatInt(255, 10)) //! 255
+	fmt.Println("255 in base 16 :", strconv.FormatInt(255, 16)) //! ff
+
+	fromBinary, _ := strconv.ParseInt("11111111", 2, 64) //! and back : text in base 2 -> int64
+	fmt.Println(`ParseInt("11111111", 2, 64) :`, fromBinary)
+
+	fmt.Println("--------------------------------")
+
+	//! 5. a subtle case : converting to a SMALLER integer type doesn't fail, it overflows (wraps around)
+	var big int64 = 300
+	small := int8(big)                       //! int8 holds only -128 ... 127
+	fmt.Println("int8(int64(300)) :", small) //! 44 -> only the lowest 8 bits are kept : 300 - 256 = 44
+
+	var negative int64 = -1
+	fmt.Println("uint8(int64(-1)) :", uint8(negative)) //! 255
+
+	//! strconv checks the range for us, ParseInt with bitSize 8 returns an error instead of a wrong number
+	_, err = strconv.ParseInt("300", 10, 8)
+	fmt.Println(`ParseInt("300", 10, 8) :`, err) //! value out of range
+
+	fmt.Println("--------------------------------")
+
+	//! 6. the calculator from '05. functions/c' with parseNumber. These are the texts a user could type
+	inputs := [][2]string{
+		{"10", "32"},
+		{"10", "abc"},
+		{"3.5", "2"},
+	}
+	for _, input := range inputs {
+		number1, err := parseNumber(input[0])
+		if err != nil {
+			fmt.Println("error :", err)
+			continue
+		}
+		number2, err := parseNumber(input[1])
+		if err != nil {
+			fmt.Println("error :", err)
+			continue
+		}
+		fmt.Println("The sum of", number1, "and", number2, "is", calculateSum(number1, number2))
+	}
+}
@@ -0,0 +1,3 @@
+module shutdownorder
+
+go 1.22
@@ -0,0 +1,100 @@
+//! Package lifecycle closes the resources of a program in the reverse of the order they were opened, each with a share of the deadline
+//! the shutdown order lesson (51. shutdown order) and the HTTP server lesson (81. http server) use it
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//! ErrCloserTimeout -> the closer didn't finish inside its share of the deadline, so Shutdown stopped waiting for it
+var ErrCloserTimeout = errors.New("closer timed out")
+
+//! ShutdownResult -> what happened when one resource was closed
+type ShutdownResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+	TimedOut bool
+}
+
+type closerEntry struct {
+	name   string
+	closer func(ctx context.Context) error
+}
+
+//! Lifecycle keeps the resources of a program in the order they were opened, and closes them in REVERSE order
+//! reverse order (LIFO), because a resource opened later usually depends on the ones opened before it : the HTTP server uses the repository, the repository writes into the event log
+type Lifecycle struct {
+	mutex   sync.Mutex
+	closers []closerEntry
+	done    bool
+	now     func() time.Time //! time.Now by default. Replaced by a fake clock to check the durations
+}
+
+func New() *Lifecycle {
+	return &Lifecycle{now: time.Now}
+}
+
+//! Register adds a resource. Register it right after it was opened successfully
+func (l *Lifecycle) Register(name string, closer func(ctx context.Context) error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.closers = append(l.closers, closerEntry{name: name, closer: closer})
+}
+
+//! Shutdown closes every resource, last registered first, and returns one result per resource
+//! if ctx has a deadline, every closer gets an equal share of the time that is LEFT. A hung closer is abandoned when its share is used up, so it can't eat the time of the others
+//! calling Shutdown a second time does nothing and returns nil
+func (l *Lifecycle) Shutdown(ctx context.Context) []ShutdownResult {
+	l.mutex.Lock()
+	if l.done {
+		l.mutex.Unlock()
+		return nil
+	}
+	l.done = true
+	closers := l.closers
+	l.mutex.Unlock()
+
+	results := make([]ShutdownResult, 0, len(closers))
+	for i := len(closers) - 1; i >= 0; i-- {
+		left := i + 1 //! how many closers still share the remaining time, including this one
+		results = append(results, l.close(ctx, closers[i], left))
+	}
+	return results
+}
+
+func (l *Lifecycle) close(ctx context.Context, entry closerEntry, left int) ShutdownResult {
+	closerCtx, cancel := ctx, context.CancelFunc(func() {})
+	if deadline, ok := ctx.Deadline(); ok {
+		share := time.Until(deadline) / time.Duration(left)
+		closerCtx, cancel = context.WithTimeout(ctx, share)
+	}
+	defer cancel()
+
+	start := l.now()
+	finished := make(chan error, 1) //! buffered, so an abandoned closer can still send and its goroutine can end
+	go func() {
+		defer func() {
+			//! a panicking closer must not stop the other closers, so the panic becomes an error
+			if r := recover(); r != nil {
+				finished <- fmt.Errorf("closer panicked: %v", r)
+			}
+		}()
+		finished <- entry.closer(closerCtx)
+	}()
+
+	result := ShutdownResult{Name: entry.name}
+	select {
+	case err := <-finished:
+		result.Err = err
+	case <-closerCtx.Done():
+		result.Err = fmt.Errorf("%w: %v", ErrCloserTimeout, closerCtx.Err())
+		result.TimedOut = true
+	}
+	result.Duration = l.now().Sub(start)
+	return result
+}
@@ -0,0 +1,295 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+//! fakeClock -> time only moves when we call Advance, so the durations are exact
+type fakeClock struct {
+	mutex   sync.Mutex
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.current = c.current.Add(d)
+}
+
+//! recorder remembers which closers ran. An abandoned closer may still write after Shutdown returned, so it has a mutex
+type recorder struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (r *recorder) closer(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mutex.Lock()
+		r.names = append(r.names, name)
+		r.mutex.Unlock()
+		return err
+	}
+}
+
+func (r *recorder) ran() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+func resultNames(results []ShutdownResult) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	return names
+}
+
+func TestShutdownLIFO(t *testing.T) {
+	tests := []struct {
+		name       string
+		registered []string
+		want       []string
+	}{
+		{"nothing registered", nil, nil},
+		{"one", []string{"log"}, []string{"log"}},
+		{"three", []string{"log", "repository", "server"}, []string{"server", "repository", "log"}},
+		{"five", []string{"a", "b", "c", "d", "e"}, []string{"e", "d", "c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := New()
+			var calls recorder
+			for _, name := range tt.registered {
+				lifecycle.Register(name, calls.closer(name, nil))
+			}
+			results := lifecycle.Shutdown(context.Background())
+
+			if got := calls.ran(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closers ran in the order %q, want %q", got, tt.want)
+			}
+			if got := resultNames(results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results in the order %q, want %q", got, tt.want)
+			}
+			for _, result := range results {
+				if result.Err != nil || result.TimedOut {
+					t.Errorf("%s : err = %v, timed out = %t; want nil, false", result.Name, result.Err, result.TimedOut)
+				}
+			}
+		})
+	}
+}
+
+//! an error is recorded in the result of its closer, and the other closers still run
+func TestShutdownErrors(t *testing.T) {
+	closed := errors.New("already closed")
+	lifecycle := New()
+	var calls recorder
+	lifecycle.Register("first", calls.closer("first", nil))
+	lifecycle.Register("fails", calls.closer("fails", closed))
+	lifecycle.Register("last", calls.closer("last", nil))
+
+	results := lifecycle.Shutdown(context.Background())
+	if got := calls.ran(); !reflect.DeepEqual(got, []string{"last", "fails", "first"}) {
+		t.Errorf("closers ran %q, want all three", got)
+	}
+	wantErrs := []error{nil, closed, nil}
+	for i, result := range results {
+		if result.Err != wantErrs[i] {
+			t.Errorf("%s : err = %v, want %v", result.Name, result.Err, wantErrs[i])
+		}
+	}
+}
+
+//! a panicking closer becomes an error, and the closers after it still run
+func TestPanickingCloserIsolated(t *testing.T) {
+	tests := []struct {
+		name    string
+		panicOf any
+		wantErr string
+	}{
+		{"a string", "nil map write", "closer panicked: nil map write"},
+		{"an error", errors.New("boom"), "closer panicked: boom"},
+		{"a number", 42, "closer panicked: 42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := New()
+			var calls recorder
+			lifecycle.Register("first", calls.closer("first", nil))
+			lifecycle.Register("panics", func(ctx context.Context) error { panic(tt.panicOf) })
+			lifecycle.Register("last", calls.closer("last", nil))
+
+			results := lifecycle.Shutdown(context.Background())
+			if got := calls.ran(); !reflect.DeepEqual(got, []string{"last", "first"}) {
+				t.Errorf("closers ran %q, want [last first]", got)
+			}
+			if err := results[1].Err; err == nil || err.Error() != tt.wantErr || results[1].TimedOut {
+				t.Errorf("panics : err = %v, timed out = %t; want %q, false", err, results[1].TimedOut, tt.wantErr)
+			}
+		})
+	}
+}
+
+//! a hung closer is abandoned when its share of the deadline is used up, and the closers after it still get their time
+func TestHungCloserAbandoned(t *testing.T) {
+	lifecycle := New()
+	var calls recorder
+	release := make(chan struct{})
+	defer close(release) //! let the abandoned goroutine end after the test
+	lifecycle.Register("first", calls.closer("first", nil))
+	lifecycle.Register("hung", func(ctx context.Context) error {
+		<-release //! ignores ctx completely
+		return nil
+	})
+	lifecycle.Register("last", calls.closer("last", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	results := lifecycle.Shutdown(ctx)
+	elapsed := time.Since(start)
+
+	hung := results[1]
+	if !hung.TimedOut || !errors.Is(hung.Err, ErrCloserTimeout) {
+		t.Errorf("hung : err = %v, timed out = %t; want ErrCloserTimeout, true", hung.Err, hung.TimedOut)
+	}
+	//! "last" returned at once, so 2 closers share about 300ms : the hung one gets about 150ms
+	if hung.Duration < 100*time.Millisecond || hung.Duration > 250*time.Millisecond {
+		t.Errorf("hung closer ran %v, want about 150ms", hung.Duration)
+	}
+	if got := calls.ran(); !reflect.DeepEqual(got, []string{"last", "first"}) {
+		t.Errorf("closers ran %q, want [last first]", got)
+	}
+	if elapsed >= 300*time.Millisecond {
+		t.Errorf("Shutdown took %v, want less than the 300ms deadline", elapsed)
+	}
+}
+
+//! every closer gets an equal share of the time that is LEFT : the deadlines of the closer contexts, for 4 quick closers
+func TestShareOfRemainingTime(t *testing.T) {
+	lifecycle := New()
+	var mutex sync.Mutex
+	var budgets []time.Duration
+	for i := 0; i < 4; i++ {
+		lifecycle.Register(fmt.Sprint(i), func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Error("the closer context has no deadline")
+			}
+			mutex.Lock()
+			budgets = append(budgets, time.Until(deadline))
+			mutex.Unlock()
+			return nil
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+	lifecycle.Shutdown(ctx)
+
+	//! the closers return at once, so the time left stays about 4s : 4s/4, 4s/3, 4s/2, 4s/1
+	want := []time.Duration{time.Second, 4 * time.Second / 3, 2 * time.Second, 4 * time.Second}
+	for i, budget := range budgets {
+		if budget > want[i] || budget < want[i]-100*time.Millisecond {
+			t.Errorf("closer %d got %v, want about %v", i, budget, want[i])
+		}
+	}
+}
+
+//! without a deadline there is no share : the closer context has no deadline, and a slow closer is not abandoned
+func TestNoDeadline(t *testing.T) {
+	lifecycle := New()
+	lifecycle.Register("slow", func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("the closer context has a deadline, want none")
+		}
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	results := lifecycle.Shutdown(context.Background())
+	if results[0].Err != nil || results[0].TimedOut {
+		t.Errorf("slow : err = %v, timed out = %t; want nil, false", results[0].Err, results[0].TimedOut)
+	}
+}
+
+func TestShutdownTwiceIsNoOp(t *testing.T) {
+	lifecycle := New()
+	var calls recorder
+	lifecycle.Register("log", calls.closer("log", nil))
+	lifecycle.Register("server", calls.closer("server", nil))
+
+	if first := lifecycle.Shutdown(context.Background()); len(first) != 2 {
+		t.Fatalf("first Shutdown = %d results, want 2", len(first))
+	}
+	if second := lifecycle.Shutdown(context.Background()); second != nil {
+		t.Errorf("second Shutdown = %v, want nil", second)
+	}
+	if got := calls.ran(); len(got) != 2 {
+		t.Errorf("closers ran %q, want each one once", got)
+	}
+}
+
+//! two goroutines call Shutdown at the same time : exactly one of them closes, every closer runs once. Run with -race
+func TestConcurrentShutdown(t *testing.T) {
+	lifecycle := New()
+	var calls recorder
+	for _, name := range []string{"a", "b", "c"} {
+		lifecycle.Register(name, calls.closer(name, nil))
+	}
+
+	var wg sync.WaitGroup
+	counts := make(chan int, 2)
+	for g := 0; g < 2; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts <- len(lifecycle.Shutdown(context.Background()))
+		}()
+	}
+	wg.Wait()
+	close(counts)
+
+	total := 0
+	for count := range counts {
+		total = total + count
+	}
+	if total != 3 || len(calls.ran()) != 3 {
+		t.Errorf("%d results and %d closer calls, want 3 and 3", total, len(calls.ran()))
+	}
+}
+
+//! durations with a fake clock : every closer moves the clock forward by a known amount
+func TestDurationsWithFakeClock(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	lifecycle := New()
+	lifecycle.now = clock.Now
+
+	steps := []time.Duration{50 * time.Millisecond, 120 * time.Millisecond, 0, 5 * time.Millisecond}
+	for i, step := range steps {
+		lifecycle.Register(fmt.Sprint(i), func(ctx context.Context) error {
+			clock.Advance(step)
+			return nil
+		})
+	}
+
+	results := lifecycle.Shutdown(context.Background())
+	for i, result := range results {
+		want := steps[len(steps)-1-i] //! the results are in reverse order
+		if result.Duration != want {
+			t.Errorf("closer %s : duration = %v, want %v", result.Name, result.Duration, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+//! Shutdown order -> a program opens its resources one after another : the event log file, then the repository which writes into it, then the HTTP server which uses the repository.
+//! When the program stops, they must be closed in the REVERSE order : first stop the server (no new requests), then flush the repository, then sync and close the log. Closing the log first would lose the data the repository still has in memory.
+//! Lifecycle is the lifecycle package, which the HTTP server lesson (81. http server) uses for its graceful shutdown. This main is a small stand-in for it
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"shutdownorder/lifecycle"
+)
+
+//! repository keeps new persons in memory and writes them into the event log only on Flush
+type repository struct {
+	mutex   sync.Mutex
+	pending []string
+	log     *os.File
+}
+
+func (r *repository) Add(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending = append(r.pending, name)
+}
+
+func (r *repository) Flush(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, name := range r.pending {
+		if _, err := fmt.Fprintln(r.log, name); err != nil {
+			return err
+		}
+	}
+	r.pending = nil
+	return nil
+}
+
+func printResults(results []lifecycle.ShutdownResult) {
+	for i, result := range results {
+		fmt.Printf("  %d. %-16s err = %v, timed out = %t\n", i+1, result.Name, result.Err, result.TimedOut)
+	}
+}
+
+func main() {
+	directory, err := os.MkdirTemp("", "shutdown-order") //! a temporary directory, so the example doesn't leave files in the repo
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	defer os.RemoveAll(directory)
+
+	resources := lifecycle.New()
+
+	//! 1. the event log
+	logPath := filepath.Join(directory, "persons.log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	resources.Register("event log sync", func(ctx context.Context) error {
+		if err := logFile.Sync(); err != nil {
+			return err
+		}
+		return logFile.Close()
+	})
+
+	//! 2. the repository, writes into the event log
+	repo := &repository{log: logFile}
+	resources.Register("repository flush", repo.Flush)
+
+	//! 3. the HTTP server, uses the repository
+	listener, err := net.Listen("tcp", "127.0.0.1:0") //! port 0 -> any free port
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo.Add(r.URL.Query().Get("name"))
+		fmt.Fprintln(w, "added")
+	})}
+	go server.Serve(listener)
+	resources.Register("http server", server.Shutdown) //! http.Server.Shutdown already has the right signature : func(ctx) error
+
+	for _, name := range []string{"John", "Jane"} {
+		response, err := http.Get("http://" + listener.Addr().String() + "/?name=" + name)
+		if err == nil {
+			response.Body.Close()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	results := resources.Shutdown(ctx)
+	fmt.Println("shutdown (reverse of the registration order) :")
+	printResults(results)
+
+	saved, _ := os.ReadFile(logPath)
+	fmt.Printf("event log after shutdown : %q\n", saved) //! the repository was flushed BEFORE the log was closed
+}
@@ -0,0 +1,64 @@
+//! A string in Go is a read-only slice of BYTES, written in UTF-8. English letters take 1 byte, but 'é' takes 2 bytes, Bengali letters take 3 bytes and an emoji takes 4 bytes
+//! rune -> one Unicode character (code point). It's just another name for int32
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+//! reverse -> works on runes, so multi-byte characters stay in one piece
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+//! reverseBytes -> the wrong way : it swaps single bytes, so the bytes of 'é' end up in the wrong order and the text is broken
+func reverseBytes(s string) string {
+	bytes := []byte(s)
+	for i, j := 0, len(bytes)-1; i < j; i, j = i+1, j-1 {
+		bytes[i], bytes[j] = bytes[j], bytes[i]
+	}
+	return string(bytes)
+}
+
+func main() {
+	word := "héllo"
+
+	//! 1. len counts BYTES, not characters
+	fmt.Println("len(\"héllo\")                    :", len(word))                    //! 6 -> 'é' is 2 bytes
+	fmt.Println("utf8.RuneCountInString(\"héllo\") :", utf8.RuneCountInString(word)) //! 5 characters
+	fmt.Println("len([]rune(\"héllo\"))            :", len([]rune(word)))            //! 5, the same, but it makes a new slice
+
+	fmt.Println("--------------------------------")
+
+	//! 2. indexing gives a BYTE, ranging gives RUNES
+	fmt.Println("word[1] :", word[1]) //! 195 -> only the first byte of 'é'
+
+	for i, r := range word { //! i -> the BYTE position where the rune starts, r -> the rune
+		fmt.Printf("  byte %d : %c (%d bytes)\n", i, r, utf8.RuneLen(r))
+	}
+	//! byte 2 is missing : it's the second half of 'é'
+
+	fmt.Println("--------------------------------")
+
+	//! 3. Bengali and emoji make the difference obvious
+	for _, text := range []string{"hello", "বাংলা", "Go 🚀"} {
+		fmt.Printf("%-8q bytes = %2d, runes = %d, []rune = %U\n", text, len(text), utf8.RuneCountInString(text), []rune(text))
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 4. reverse : by runes works, by bytes breaks
+	for _, text := range []string{"hello", "héllo", "Go 🚀"} {
+		broken := reverseBytes(text)
+		fmt.Printf("%-7q reverse = %-9q reverseBytes = %-22q valid UTF-8 : %t\n", text, reverse(text), broken, utf8.ValidString(broken))
+	}
+
+	//! even rune by rune is not perfect : in Bengali, a vowel sign (like 'া') is a separate rune which belongs to the letter BEFORE it
+	//! reversing the runes moves the sign to the wrong letter. The text is valid UTF-8, but it doesn't read correctly any more
+	fmt.Printf("reverse(\"বাংলা\") = %q\n", reverse("বাংলা"))
+}
@@ -0,0 +1,52 @@
+package main
+
+import "container/list"
+
+//! lru -> a cache with a maximum size. When it's full, the Least Recently Used entry is removed
+//! the list keeps the order of use (front = used last), the map finds the list element of a key in O(1)
+type lru[K comparable, V any] struct {
+	maxEntries int
+	order      *list.List
+	elements   map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](maxEntries int) *lru[K, V] {
+	return &lru[K, V]{maxEntries: maxEntries, order: list.New(), elements: map[K]*list.Element{}}
+}
+
+func (c *lru[K, V]) Get(key K) (V, bool) {
+	element, ok := c.elements[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element) //! just used -> it's the most recent now
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+//! Put adds or updates a key. It returns true when an old entry had to be removed to make room
+func (c *lru[K, V]) Put(key K, value V) (evicted bool) {
+	if element, ok := c.elements[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return false
+	}
+
+	c.elements[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.elements, oldest.Value.(*lruEntry[K, V]).key)
+		return true
+	}
+	return false
+}
+
+func (c *lru[K, V]) Len() int {
+	return c.order.Len()
+}
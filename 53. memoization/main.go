@@ -0,0 +1,101 @@
+//! Memoization -> remember the result of a function for every input, so the next call with the same input doesn't have to calculate it again
+//! Memoize is a higher order function : it takes a function and returns a new function with the same signature, but with a cache in front of it
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! a slow "database" lookup
+var people = []Person{
+	{Name: "John", Age: 30, Email: "john@example.com"},
+	{Name: "Jane", Age: 25, Email: "jane@example.com"},
+	{Name: "Alice", Age: 28, Email: "alice@example.com"},
+}
+
+func lookupPersonByEmail(email string) Person {
+	time.Sleep(50 * time.Millisecond) //! pretend this is a network call
+	for _, person := range people {
+		if person.Email == email {
+			return person
+		}
+	}
+	return Person{}
+}
+
+func fibonacci(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fibonacci(n-1) + fibonacci(n-2)
+}
+
+//! memoizedFibonacci -> the recursive calls go through the memoized function too, so every n is calculated only once
+func memoizedFibonacci() (func(int) int, *CacheStats) {
+	var fib func(int) int
+	fib, stats := Memoize(func(n int) int {
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	}, 100)
+	return fib, stats
+}
+
+func main() {
+	//! 1. fibonacci : how many times does the real function run?
+	rawCalls := 0
+	var countingFibonacci func(int) int
+	countingFibonacci = func(n int) int {
+		rawCalls++
+		if n < 2 {
+			return n
+		}
+		return countingFibonacci(n-1) + countingFibonacci(n-2)
+	}
+	fmt.Println("raw fibonacci(30)      :", countingFibonacci(30), "| calls :", rawCalls) //! 2,692,537 calls
+
+	fib, stats := memoizedFibonacci()
+	fmt.Println("memoized fibonacci(30) :", fib(30), "|", stats) //! 31 misses -> each n from 0 to 30 calculated ONCE
+	fmt.Println("memoized fibonacci(80) :", fib(80), "|", stats) //! only 50 new numbers calculated
+
+	fmt.Println("--------------------------------")
+
+	//! 2. the slow lookup
+	lookup, lookupStats := Memoize(lookupPersonByEmail, 2)
+
+	start := time.Now()
+	person := lookup("john@example.com")
+	fmt.Println("first lookup  :", person.Name, time.Since(start).Round(10*time.Millisecond)) //! 50ms
+
+	start = time.Now()
+	person = lookup("john@example.com")
+	fmt.Println("second lookup :", person.Name, time.Since(start).Round(10*time.Millisecond)) //! 0s, from the cache
+
+	//! the cache holds only 2 entries. jane and alice push john out (he is the least recently used)
+	lookup("jane@example.com")
+	lookup("alice@example.com")
+	lookup("john@example.com") //! a miss again
+	fmt.Println("stats         :", lookupStats)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. two arguments : the key is a comparable struct
+	calls := 0
+	greet, greetStats := Memoize2(func(name string, times int) string {
+		calls++
+		return strings.Repeat("Hello "+name+"! ", times)
+	}, 10)
+	greet("John", 2)
+	greet("John", 2)
+	greet("John", 3) //! a different key
+	fmt.Printf("greet(\"John\", 2) : %q | real calls : %d | %v\n", greet("John", 2), calls, greetStats)
+}
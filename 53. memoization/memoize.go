@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+//! CacheStats -> counters of a memoized function. atomic, so they can be read while other goroutines use the function
+type CacheStats struct {
+	Hits      atomic.Int64
+	Misses    atomic.Int64
+	Evictions atomic.Int64
+}
+
+func (s *CacheStats) String() string {
+	return fmt.Sprintf("hits = %d, misses = %d, evictions = %d", s.Hits.Load(), s.Misses.Load(), s.Evictions.Load())
+}
+
+//! Memoize wraps fn with a cache : the first call with a key runs fn, the next calls with the same key return the saved result
+//! the cache keeps at most maxEntries results, the least recently used one is removed first
+//! this is a closure : the returned function remembers cache, mutex and stats
+func Memoize[K comparable, V any](fn func(K) V, maxEntries int) (func(K) V, *CacheStats) {
+	cache := newLRU[K, V](maxEntries)
+	var mutex sync.Mutex
+	stats := &CacheStats{}
+
+	memoized := func(key K) V {
+		mutex.Lock()
+		value, ok := cache.Get(key)
+		mutex.Unlock()
+		if ok {
+			stats.Hits.Add(1)
+			return value
+		}
+
+		//! fn runs WITHOUT the lock. A recursive function like fibonacci calls the memoized function again, holding the lock would be a deadlock
+		//! the price : two goroutines asking for the same new key at the same time may both run fn
+		stats.Misses.Add(1)
+		value = fn(key)
+
+		mutex.Lock()
+		if cache.Put(key, value) {
+			stats.Evictions.Add(1)
+		}
+		mutex.Unlock()
+		return value
+	}
+	return memoized, stats
+}
+
+//! pair -> two arguments in one comparable struct, so it can be a map key
+type pair[A, B comparable] struct {
+	first  A
+	second B
+}
+
+//! Memoize2 -> the same for functions with two arguments. It packs them into a pair and reuses Memoize
+func Memoize2[A, B comparable, V any](fn func(A, B) V, maxEntries int) (func(A, B) V, *CacheStats) {
+	memoized, stats := Memoize(func(key pair[A, B]) V {
+		return fn(key.first, key.second)
+	}, maxEntries)
+
+	return func(a A, b B) V {
+		return memoized(pair[A, B]{a, b})
+	}, stats
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//! counting wraps a square function and counts the real calls per key
+func counting() (func(int) int, map[int]int) {
+	calls := map[int]int{}
+	return func(n int) int {
+		calls[n]++
+		return n * n
+	}, calls
+}
+
+//! every distinct key runs the function exactly once, as long as nothing is evicted
+func TestMemoizeOncePerKey(t *testing.T) {
+	square, calls := counting()
+	memoized, stats := Memoize(square, 10)
+
+	for round := 0; round < 3; round++ {
+		for n := 0; n < 10; n++ {
+			if got := memoized(n); got != n*n {
+				t.Fatalf("memoized(%d) = %d, want %d", n, got, n*n)
+			}
+		}
+	}
+	for n := 0; n < 10; n++ {
+		if calls[n] != 1 {
+			t.Errorf("fn(%d) ran %d times, want 1", n, calls[n])
+		}
+	}
+	if stats.Misses.Load() != 10 || stats.Hits.Load() != 20 || stats.Evictions.Load() != 0 {
+		t.Errorf("stats = %v, want hits = 20, misses = 10, evictions = 0", stats)
+	}
+}
+
+func TestMemoizeStats(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxEntries    int
+		keys          []int
+		wantHits      int64
+		wantMisses    int64
+		wantEvictions int64
+	}{
+		{"no calls", 5, nil, 0, 0, 0},
+		{"one key twice", 5, []int{1, 1}, 1, 1, 0},
+		{"20 keys, bound 5", 5, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, 0, 20, 15},
+		{"a hit keeps a key : 1 is used again before 3 arrives", 2, []int{1, 2, 1, 3, 1}, 2, 3, 1},
+		{"no hit : 1 is the oldest when 3 arrives", 2, []int{1, 2, 3, 1}, 0, 4, 2},
+		{"bound 1", 1, []int{1, 1, 2, 2, 1}, 2, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			square, _ := counting()
+			memoized, stats := Memoize(square, tt.maxEntries)
+			for _, key := range tt.keys {
+				memoized(key)
+			}
+			if stats.Hits.Load() != tt.wantHits || stats.Misses.Load() != tt.wantMisses || stats.Evictions.Load() != tt.wantEvictions {
+				t.Errorf("stats = %v; want hits = %d, misses = %d, evictions = %d", stats, tt.wantHits, tt.wantMisses, tt.wantEvictions)
+			}
+		})
+	}
+}
+
+//! after an eviction, the function runs again for the evicted key, and only for it
+func TestMemoizeEvictsLeastRecentlyUsed(t *testing.T) {
+	square, calls := counting()
+	memoized, _ := Memoize(square, 3)
+
+	for _, key := range []int{1, 2, 3} {
+		memoized(key)
+	}
+	memoized(1) //! 1 is now the most recent, 2 is the oldest
+	memoized(4) //! evicts 2
+
+	memoized(1)
+	memoized(3)
+	memoized(4)
+	memoized(2) //! evicted, so it runs again
+
+	want := map[int]int{1: 1, 2: 2, 3: 1, 4: 1}
+	for key, count := range want {
+		if calls[key] != count {
+			t.Errorf("fn(%d) ran %d times, want %d", key, calls[key], count)
+		}
+	}
+}
+
+func TestMemoize2(t *testing.T) {
+	calls := 0
+	greet, stats := Memoize2(func(name string, times int) string {
+		calls++
+		return strings.Repeat("Hello "+name+"! ", times)
+	}, 10)
+
+	tests := []struct {
+		name      string
+		times     int
+		want      string
+		wantCalls int
+	}{
+		{"John", 2, "Hello John! Hello John! ", 1},
+		{"John", 2, "Hello John! Hello John! ", 1}, //! the same pair -> from the cache
+		{"John", 3, "Hello John! Hello John! Hello John! ", 2},
+		{"Jane", 2, "Hello Jane! Hello Jane! ", 3}, //! only the first argument differs -> a different key
+	}
+	for _, tt := range tests {
+		if got := greet(tt.name, tt.times); got != tt.want || calls != tt.wantCalls {
+			t.Errorf("greet(%q, %d) = %q after %d calls; want %q after %d calls", tt.name, tt.times, got, calls, tt.want, tt.wantCalls)
+		}
+	}
+	if stats.Hits.Load() != 1 || stats.Misses.Load() != 3 {
+		t.Errorf("stats = %v, want hits = 1, misses = 3", stats)
+	}
+}
+
+func TestMemoizedFibonacci(t *testing.T) {
+	fib, stats := memoizedFibonacci()
+	tests := []struct {
+		n          int
+		want       int
+		wantMisses int64
+	}{
+		{0, 0, 1},
+		{1, 1, 2},
+		{30, 832040, 31}, //! every n from 0 to 30 calculated once
+		{30, 832040, 31},
+		{80, 23416728348467685, 81},
+	}
+	for _, tt := range tests {
+		if got := fib(tt.n); got != tt.want || stats.Misses.Load() != tt.wantMisses {
+			t.Errorf("fib(%d) = %d with %d misses; want %d with %d misses", tt.n, got, stats.Misses.Load(), tt.want, tt.wantMisses)
+		}
+	}
+	if got := fibonacci(30); got != 832040 {
+		t.Errorf("fibonacci(30) = %d, want 832040", got)
+	}
+}
+
+//! many goroutines at the same time. The mutex protects the cache : run with 'go test -race *.go'
+func TestMemoizeConcurrent(t *testing.T) {
+	var mutex sync.Mutex
+	calls := map[int]int{}
+	double, stats := Memoize(func(n int) int {
+		mutex.Lock()
+		calls[n]++
+		mutex.Unlock()
+		return n * 2
+	}, 50)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 20; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if got := double(n % 10); got != n%10*2 {
+					t.Errorf("double(%d) = %d, want %d", n%10, got, n%10*2)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if total := stats.Hits.Load() + stats.Misses.Load(); total != 2000 {
+		t.Errorf("hits + misses = %d, want 2000", total)
+	}
+	//! fn runs without the lock, so two goroutines may both miss the same new key, but never more often than there are goroutines
+	for key, count := range calls {
+		if count < 1 || count > 20 {
+			t.Errorf("fn(%d) ran %d times, want between 1 and 20", key, count)
+		}
+	}
+	if stats.Evictions.Load() != 0 {
+		t.Errorf("evictions = %d, want 0 (10 keys, bound 50)", stats.Evictions.Load())
+	}
+}
+
+func TestLRU(t *testing.T) {
+	cache := newLRU[string, int](2)
+	if evicted := cache.Put("a", 1); evicted {
+		t.Error("Put(a) evicted, want no eviction")
+	}
+	cache.Put("b", 2)
+	if evicted := cache.Put("a", 10); evicted {
+		t.Error("updating a evicted, want no eviction")
+	}
+	if evicted := cache.Put("c", 3); !evicted { //! b is the least recently used now
+		t.Error("Put(c) didn't evict, want an eviction")
+	}
+
+	tests := []struct {
+		key    string
+		want   int
+		wantOK bool
+	}{
+		{"a", 10, true},
+		{"b", 0, false},
+		{"c", 3, true},
+	}
+	for _, tt := range tests {
+		if got, ok := cache.Get(tt.key); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Get(%q) = %d, %v; want %d, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func BenchmarkFibonacci(b *testing.B) {
+	for _, n := range []int{10, 25} {
+		b.Run(fmt.Sprintf("raw/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fibonacci(n)
+			}
+		})
+		b.Run(fmt.Sprintf("memoized/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fib, _ := memoizedFibonacci() //! a new cache every time, so we measure the calculation and not only one cache hit
+				fib(n)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+//! The 'strings' package has the everyday helpers for working with text. Here we use them on one realistic job : turning a line like "John, 20, john@example.com" into a Person
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func (person Person) printDetails() {
+	fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+}
+
+//! parsePerson -> "John, 20, john@example.com" -> Person. A malformed line returns an error
+func parsePerson(line string) (Person, error) {
+	fields := strings.Split(line, ",") //! ["John", " 20", " john@example.com"]
+	if len(fields) != 3 {
+		return Person{}, fmt.Errorf("expected 3 fields (name, age, email), got %d in %q", len(fields), line)
+	}
+
+	name := strings.TrimSpace(fields[0]) //! removes the spaces around the value
+	if name == "" {
+		return Person{}, fmt.Errorf("name is empty in %q", line)
+	}
+
+	age, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return Person{}, fmt.Errorf("age is not a number in %q", line)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(fields[2])) //! "John@Example.COM" and "john@example.com" are the same address
+	if !strings.Contains(email, "@") {
+		return Person{}, fmt.Errorf("email %q has no '@'", email)
+	}
+
+	return Person{Name: name, Age: age, Email: email}, nil
+}
+
+func main() {
+	//! 1. the helpers one by one
+	line := "  John, 20, John@Example.COM  "
+
+	fmt.Printf("TrimSpace : %q\n", strings.TrimSpace(line))
+	fmt.Printf("Split     : %q\n", strings.Split("John, 20, john@example.com", ","))
+	fmt.Printf("Join      : %q\n", strings.Join([]string{"John", "20", "john@example.com"}, " | "))
+	fmt.Printf("ToUpper   : %q\n", strings.ToUpper("john"))
+	fmt.Printf("ToLower   : %q\n", strings.ToLower("John@Example.COM"))
+	fmt.Printf("Replace   : %q\n", strings.Replace("john@example.com", "example.com", "mail.com", 1)) //! 1 -> replace only the first match, -1 -> replace all
+	fmt.Printf("Fields    : %q\n", strings.Fields("  John    Doe \t 20 "))                            //! splits on ANY amount of whitespace, no empty parts
+	fmt.Println("Contains  :", strings.Contains("john@example.com", "@"))
+	fmt.Println("HasPrefix :", strings.HasPrefix("john@example.com", "john"))
+	fmt.Println("HasSuffix :", strings.HasSuffix("john@example.com", ".com"))
+
+	//! Split vs Fields
+	fmt.Printf("Split(\"a  b\", \" \") : %q\n", strings.Split("a  b", " ")) //! ["a" "" "b"] -> an empty string between the two spaces
+	fmt.Printf("Fields(\"a  b\")     : %q\n", strings.Fields("a  b"))       //! ["a" "b"]
+
+	fmt.Println("--------------------------------")
+
+	//! 2. parsing persons
+	lines := []string{
+		"John, 20, john@example.com",
+		"  Jane ,21,  Jane@Example.COM ",
+		"Alice, 28",                         //! too few fields
+		"Bob, twenty, bob@example.com",      //! age is not a number
+		"Carol, 30, carol.example.com",      //! no '@'
+		"Dave, 40, dave@example.com, extra", //! too many fields
+	}
+
+	for _, line := range lines {
+		person, err := parsePerson(line)
+		if err != nil {
+			fmt.Println("error :", err)
+			continue
+		}
+		person.printDetails()
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//! INI file :
+//!
+//!   ; a comment          # also a comment
+//!   name = my app        <- before any section : goes into the section ""
+//!   [server]             <- a section
+//!   port = 8080          <- key = value
+//!   motd = "hi; there"   <- a quoted value can have ; # = and escapes like \n \"
+//!
+//! the result is a map of sections, every section is a map of keys -> values
+
+//! LineError -> a line which can't be parsed. The line number makes it easy to find in the file
+type LineError struct {
+	Line int
+	Text string
+	Msg  string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Msg, e.Text)
+}
+
+//! DuplicateKeyError -> only in strict mode : every key which was set more than once, with all its line numbers
+type DuplicateKeyError struct {
+	Duplicates []string //! "[server] port (lines 3, 9)"
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return "duplicate keys: " + strings.Join(e.Duplicates, "; ")
+}
+
+//! ParseINI -> when a key appears twice in a section, the last value wins
+func ParseINI(r io.Reader) (map[string]map[string]string, error) {
+	return parseINI(r, false)
+}
+
+//! ParseINIStrict -> the same, but duplicate keys are an error
+func ParseINIStrict(r io.Reader) (map[string]map[string]string, error) {
+	return parseINI(r, true)
+}
+
+func parseINI(r io.Reader, strict bool) (map[string]map[string]string, error) {
+	data := map[string]map[string]string{}
+	section := ""
+	seen := map[string][]int{} //! "[section] key" -> line numbers
+	var order []string         //! the order duplicates were found, so the error message is always the same
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := strings.TrimSuffix(scanner.Text(), "\r") //! files written on Windows end lines with "\r\n"
+		line := strings.TrimSpace(raw)
+
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, &LineError{Line: lineNumber, Text: raw, Msg: "section without closing ']'"}
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, &LineError{Line: lineNumber, Text: raw, Msg: "empty section name"}
+			}
+			if data[section] == nil {
+				data[section] = map[string]string{} //! an empty section is kept too
+			}
+			continue
+		}
+
+		//! Cut at the FIRST '=' only, so the value itself may contain '=' : url = http://x/?a=1
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, &LineError{Line: lineNumber, Text: raw, Msg: "expected key = value"}
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, &LineError{Line: lineNumber, Text: raw, Msg: "empty key"}
+		}
+
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, &LineError{Line: lineNumber, Text: raw, Msg: err.Error()}
+		}
+
+		id := "[" + section + "] " + key
+		seen[id] = append(seen[id], lineNumber)
+		if len(seen[id]) == 2 {
+			order = append(order, id)
+		}
+
+		if data[section] == nil {
+			data[section] = map[string]string{}
+		}
+		data[section][key] = value //! last wins
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if strict && len(order) > 0 {
+		duplicates := make([]string, len(order))
+		for i, id := range order {
+			lines := make([]string, len(seen[id]))
+			for j, n := range seen[id] {
+				lines[j] = strconv.Itoa(n)
+			}
+			duplicates[i] = fmt.Sprintf("%s (lines %s)", id, strings.Join(lines, ", "))
+		}
+		return nil, &DuplicateKeyError{Duplicates: duplicates}
+	}
+	return data, nil
+}
+
+//! parseValue -> a value in double quotes is unquoted with the Go rules (\n, \t, \", \\ ...). Anything else is taken as it is
+func parseValue(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, nil
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("bad quoted value")
+	}
+	return unquoted, nil
+}
+
+//! WriteINI -> sections and keys are sorted, so the same data always gives exactly the same file (easy to diff and to test)
+func WriteINI(w io.Writer, data map[string]map[string]string) error {
+	sections := make([]string, 0, len(data))
+	for section := range data {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections) //! "" (the keys without a section) sorts first, so they are written before any [header]
+
+	buffered := bufio.NewWriter(w)
+	for i, section := range sections {
+		if i > 0 {
+			buffered.WriteString("\n")
+		}
+		if section != "" {
+			fmt.Fprintf(buffered, "[%s]\n", section)
+		}
+
+		keys := make([]string, 0, len(data[section]))
+		for key := range data[section] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(buffered, "%s = %s\n", key, formatValue(data[section][key]))
+		}
+	}
+	return buffered.Flush()
+}
+
+//! formatValue -> quotes a value only when reading it back without quotes would give something different
+func formatValue(value string) string {
+	if value == "" || value != strings.TrimSpace(value) || strings.ContainsAny(value, "\"\\\n\r\t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sections = map[string]map[string]string
+
+func TestParseINI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  sections
+	}{
+		{"empty file", "", sections{}},
+		{"only comments", "; one\n# two\n   ; indented\n", sections{}},
+		{"keys before any section", "name = my app\n", sections{"": {"name": "my app"}}},
+		{"whitespace trimmed", "  [ server ]  \n\t port\t=\t8080  \n", sections{"server": {"port": "8080"}}},
+		{"'=' in the value", "[s]\nurl = http://x/?a=1&b=2\n", sections{"s": {"url": "http://x/?a=1&b=2"}}},
+		{"empty value", "[s]\nkey =\n", sections{"s": {"key": ""}}},
+		{"nested-looking section is just a name", "[database.primary]\nuser = admin\n", sections{"database.primary": {"user": "admin"}}},
+		{"nested-looking key is just a key", "[s]\na.b.c = 1\n", sections{"s": {"a.b.c": "1"}}},
+		{"empty section is kept", "[empty]\n", sections{"empty": {}}},
+		{"last wins", "[s]\nport = 1\nport = 2\n", sections{"s": {"port": "2"}}},
+		{"the same section twice is merged", "[s]\na = 1\n[t]\nb = 2\n[s]\nc = 3\n", sections{"s": {"a": "1", "c": "3"}, "t": {"b": "2"}}},
+		{"a comment character inside an unquoted value is kept", "[s]\ncolor = #ff0000\n", sections{"s": {"color": "#ff0000"}}},
+		{"CRLF line endings", "[s]\r\na = 1\r\n; comment\r\nb = \"x y\"\r\n", sections{"s": {"a": "1", "b": "x y"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseINI(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseINI error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseINI = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseINIQuoting(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"; and # inside quotes", `"p@ss; #word"`, "p@ss; #word"},
+		{"escaped quote and new line", `"Hello\n\t\"World\""`, "Hello\n\t\"World\""},
+		{"escaped backslash", `"C:\\temp"`, `C:\temp`},
+		{"spaces kept inside quotes", `"  padded  "`, "  padded  "},
+		{"empty quoted value", `""`, ""},
+		{"unicode escape", `"caf\u00e9"`, "café"},
+		{"a quote not at the start is kept as it is", `say "hi"`, `say "hi"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseINI(strings.NewReader("[s]\nkey = " + tt.value + "\n"))
+			if err != nil {
+				t.Fatalf("ParseINI error = %v", err)
+			}
+			if got["s"]["key"] != tt.want {
+				t.Errorf("value %s = %q, want %q", tt.value, got["s"]["key"], tt.want)
+			}
+		})
+	}
+}
+
+func TestParseINIMalformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+		wantMsg  string
+	}{
+		{"no '='", "[server]\nport 8080", 2, "expected key = value"},
+		{"section without ']'", "[server\nport = 8080", 1, "section without closing ']'"},
+		{"empty section name", "; comment\n[ ]\n", 2, "empty section name"},
+		{"empty key", "[server]\n = 8080", 2, "empty key"},
+		{"quote not closed", "[server]\nmotd = \"not closed", 2, "bad quoted value"},
+		{"text after the closing quote", "[s]\nkey = \"a\" b\n", 2, "bad quoted value"},
+		{"line numbers count comments and blank lines", "; a\n\n# b\n[s]\n\noops\n", 6, "expected key = value"},
+		{"line numbers with CRLF", "[s]\r\na = 1\r\noops\r\n", 3, "expected key = value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseINI(strings.NewReader(tt.input))
+			var lineErr *LineError
+			if !errors.As(err, &lineErr) {
+				t.Fatalf("ParseINI error = %v, want a *LineError", err)
+			}
+			if lineErr.Line != tt.wantLine || lineErr.Msg != tt.wantMsg {
+				t.Errorf("LineError = line %d %q, want line %d %q", lineErr.Line, lineErr.Msg, tt.wantLine, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseINIStrict(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantDuplicates []string
+	}{
+		{"no duplicates", "[s]\na = 1\n[t]\na = 2\n", nil},
+		{"one duplicate", "[s]\nport = 1\nport = 2\n", []string{"[s] port (lines 2, 3)"}},
+		{"three times", "[s]\nport = 1\nport = 2\nport = 3\n", []string{"[s] port (lines 2, 3, 4)"}},
+		{"across a reopened section", "[s]\na = 1\n[t]\nb = 1\n[s]\na = 2\n", []string{"[s] a (lines 2, 6)"}},
+		{"in the order they were found", "[s]\nb = 1\na = 1\na = 2\nb = 2\n", []string{"[s] a (lines 3, 4)", "[s] b (lines 2, 5)"}},
+		{"keys without a section", "name = a\nname = b\n[s]\nx = 1\nx = 2\n", []string{"[] name (lines 1, 2)", "[s] x (lines 4, 5)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ParseINIStrict(strings.NewReader(tt.input))
+			if tt.wantDuplicates == nil {
+				if err != nil {
+					t.Fatalf("ParseINIStrict error = %v, want nil", err)
+				}
+				if data == nil {
+					t.Error("ParseINIStrict data = nil, want the parsed sections")
+				}
+				return
+			}
+			var duplicateErr *DuplicateKeyError
+			if !errors.As(err, &duplicateErr) {
+				t.Fatalf("ParseINIStrict error = %v, want a *DuplicateKeyError", err)
+			}
+			if !reflect.DeepEqual(duplicateErr.Duplicates, tt.wantDuplicates) {
+				t.Errorf("Duplicates = %q, want %q", duplicateErr.Duplicates, tt.wantDuplicates)
+			}
+			if data != nil {
+				t.Errorf("ParseINIStrict data = %v, want nil with an error", data)
+			}
+		})
+	}
+}
+
+func TestWriteINI(t *testing.T) {
+	tests := []struct {
+		name string
+		data sections
+		want string
+	}{
+		{"nothing", sections{}, ""},
+		{"sorted sections and keys, no section first", sections{"z": {"b": "2", "a": "1"}, "": {"name": "app"}, "m": {"k": "v"}}, "name = app\n\n[m]\nk = v\n\n[z]\na = 1\nb = 2\n"},
+		{"empty section", sections{"empty": {}}, "[empty]\n"},
+		{"values which need quotes", sections{"s": {"empty": "", "padded": " x ", "newline": "a\nb", "quote": `say "hi"`, "backslash": `C:\temp`}},
+			"[s]\nbackslash = \"C:\\\\temp\"\nempty = \"\"\nnewline = \"a\\nb\"\npadded = \" x \"\nquote = \"say \\\"hi\\\"\"\n"},
+		{"'=' and ; need no quotes after the first '='", sections{"s": {"url": "http://x/?a=1", "semi": "a;b"}}, "[s]\nsemi = a;b\nurl = http://x/?a=1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := WriteINI(&buffer, tt.data); err != nil {
+				t.Fatal(err)
+			}
+			if buffer.String() != tt.want {
+				t.Errorf("WriteINI =\n%s\nwant\n%s", buffer.String(), tt.want)
+			}
+		})
+	}
+}
+
+//! parse(write(data)) == data, and writing it again gives the same bytes
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data sections
+	}{
+		{"simple", sections{"server": {"host": "localhost", "port": "8080"}}},
+		{"no section and empty section", sections{"": {"name": "app"}, "empty": {}}},
+		{"awkward values", sections{"s": {"motd": "Hello\n\t\"World\"", "password": "p@ss; #word", "padded": "  x  ", "empty": "", "url": "http://x/?a=1", "quote": `"starts with a quote`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first bytes.Buffer
+			if err := WriteINI(&first, tt.data); err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := ParseINIStrict(bytes.NewReader(first.Bytes()))
+			if err != nil {
+				t.Fatalf("parsing the written file : %v\n%s", err, first.String())
+			}
+			if !reflect.DeepEqual(parsed, tt.data) {
+				t.Errorf("parse(write(data)) = %v, want %v", parsed, tt.data)
+			}
+
+			var second bytes.Buffer
+			WriteINI(&second, parsed)
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Errorf("writing twice gave different bytes :\n%s\n---\n%s", first.String(), second.String())
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	directory := t.TempDir()
+	want := sections{"server": {"host": "localhost", "port": "8080"}}
+	files := map[string]string{
+		"config.ini":  "[server]\nhost = localhost\nport = 8080\n",
+		"CONFIG.INI":  "[server]\nhost = localhost\nport = 8080\n",
+		"config.json": `{"server":{"host":"localhost","port":"8080"}}`,
+		"bad.json":    `{"server":`,
+		"config.yaml": "server:\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		file    string
+		wantErr string
+	}{
+		{"config.ini", ""},
+		{"CONFIG.INI", ""}, //! the extension is compared in lower case
+		{"config.json", ""},
+		{"bad.json", "bad.json: unexpected EOF"},
+		{"config.yaml", `unknown config format ".yaml"`},
+		{"missing.ini", "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, err := LoadConfig(filepath.Join(directory, tt.file))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadConfig error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadConfig = %v, %v; want %v, nil", got, err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//! Parsing and writing INI config files with only the standard library. INI is older and simpler than JSON : sections, key = value lines and comments
+//! LoadConfig picks the parser from the file extension, so the same program can read config.ini or config.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+//! LoadConfig -> ".ini" uses ParseINI, ".json" uses encoding/json. Both give the same shape : section -> key -> value
+func LoadConfig(path string) (map[string]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ini":
+		return ParseINI(file)
+	case ".json":
+		var data map[string]map[string]string
+		if err := json.NewDecoder(file).Decode(&data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown config format %q (use .ini or .json)", filepath.Ext(path))
+	}
+}
+
+const sample = `; application config
+# both ; and # start a comment
+name = person api
+
+[server]
+host = localhost
+port = 8080
+port = 9090
+url = http://localhost:9090/?debug=true&lang=en
+
+[database.primary]
+user = admin
+password = "p@ss; #word"
+motd = "Hello\n\t\"World\""
+
+[empty]
+`
+
+func main() {
+	//! 1. parse
+	data, err := ParseINI(strings.NewReader(sample))
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Printf("name              : %q\n", data[""]["name"])
+	fmt.Printf("server port       : %q (last wins)\n", data["server"]["port"])
+	fmt.Printf("server url        : %q ('=' in the value)\n", data["server"]["url"])
+	fmt.Printf("database.primary  : %q (just a section name with a dot, not nested)\n", data["database.primary"])
+	fmt.Printf("empty section     : %v\n", data["empty"])
+
+	fmt.Println("--------------------------------")
+
+	//! 2. write : sorted and deterministic
+	var written bytes.Buffer
+	WriteINI(&written, data)
+	fmt.Print(written.String())
+
+	//! round trip : parse(write(data)) == data, and writing again gives the same bytes
+	again, _ := ParseINI(bytes.NewReader(written.Bytes()))
+	var writtenAgain bytes.Buffer
+	WriteINI(&writtenAgain, again)
+	fmt.Println("round trip same data  :", reflect.DeepEqual(data, again))
+	fmt.Println("round trip same bytes :", bytes.Equal(written.Bytes(), writtenAgain.Bytes()))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. CRLF (Windows) line endings give the same result
+	crlf, _ := ParseINI(strings.NewReader(strings.ReplaceAll(sample, "\n", "\r\n")))
+	fmt.Println("CRLF same data :", reflect.DeepEqual(data, crlf))
+
+	//! 4. strict mode : duplicates are an error with all their line numbers
+	_, err = ParseINIStrict(strings.NewReader(sample + "[server]\nhost = example.com\n"))
+	var duplicateErr *DuplicateKeyError
+	fmt.Println("strict         :", err, "| is DuplicateKeyError :", errors.As(err, &duplicateErr))
+
+	//! 5. malformed lines
+	for _, bad := range []string{
+		"[server]\nport 8080",
+		"[server\nport = 8080",
+		"[server]\n = 8080",
+		"[server]\nmotd = \"not closed",
+	} {
+		_, err := ParseINI(strings.NewReader(bad))
+		fmt.Println("malformed      :", err)
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 6. the loader picks the backend by extension
+	directory, err := os.MkdirTemp("", "ini-config") //! a temporary directory, so the example doesn't leave files in the repo
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	defer os.RemoveAll(directory)
+
+	jsonBytes, _ := json.Marshal(data)
+	os.WriteFile(filepath.Join(directory, "config.ini"), written.Bytes(), 0o644)
+	os.WriteFile(filepath.Join(directory, "config.json"), jsonBytes, 0o644)
+	os.WriteFile(filepath.Join(directory, "config.yaml"), nil, 0o644)
+
+	fromINI, _ := LoadConfig(filepath.Join(directory, "config.ini"))
+	fromJSON, _ := LoadConfig(filepath.Join(directory, "config.json"))
+	fmt.Println("config.ini == config.json :", reflect.DeepEqual(fromINI, fromJSON))
+	_, err = LoadConfig(filepath.Join(directory, "config.yaml"))
+	fmt.Println("config.yaml               :", err)
+}
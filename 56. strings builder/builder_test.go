@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+//! a benchmark is a function called BenchmarkXxx(b *testing.B). 'go test -bench .' runs it with a bigger and bigger b.N until the timing is stable
+//! b.ReportAllocs() adds two columns : bytes allocated per operation (B/op) and number of allocations per operation (allocs/op)
+
+var result string //! saving the result in a package variable stops the compiler from removing the call as unused
+
+func BenchmarkConcat(b *testing.B) {
+	pieces := makePieces(10_000)
+	b.ReportAllocs()
+	b.ResetTimer() //! don't count the time spent in makePieces
+	for i := 0; i < b.N; i++ {
+		result = concatNaive(pieces)
+	}
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	pieces := makePieces(10_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result = concatBuilder(pieces)
+	}
+}
+
+func BenchmarkBuilderGrow(b *testing.B) {
+	pieces := makePieces(10_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result = concatBuilderGrow(pieces)
+	}
+}
@@ -0,0 +1,91 @@
+//! Strings in Go can't be changed (they are immutable). So 's += x' never adds to the old string : it makes a NEW string, and copies the old text and x into it
+//! in a loop this gets slow : the 1st round copies 1 piece, the 2nd round 2 pieces, ... the n-th round n pieces -> 1 + 2 + ... + n = about n*n/2 copies -> O(n²)
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func concatNaive(pieces []string) string {
+	result := ""
+	for _, piece := range pieces {
+		result += piece //! a new string every round, the whole text so far is copied again
+	}
+	return result
+}
+
+//! strings.Builder keeps a []byte inside and appends to it. When the slice is full, append makes a bigger array (about double, like in '15. slice/b. slice appending') and copies only then
+//! doubling means the copies happen rarely : for n pieces, the total copied is less than 2n -> O(n)
+func concatBuilder(pieces []string) string {
+	var builder strings.Builder
+	for _, piece := range pieces {
+		builder.WriteString(piece)
+	}
+	return builder.String() //! no copy here : String() uses the bytes the builder already has
+}
+
+//! if we know the final size, Grow makes the array big enough at the start, so it never has to grow again
+func concatBuilderGrow(pieces []string) string {
+	size := 0
+	for _, piece := range pieces {
+		size += len(piece)
+	}
+	var builder strings.Builder
+	builder.Grow(size)
+	for _, piece := range pieces {
+		builder.WriteString(piece)
+	}
+	return builder.String()
+}
+
+func makePieces(n int) []string {
+	pieces := make([]string, n)
+	for i := range pieces {
+		pieces[i] = fmt.Sprintf("piece-%d,", i)
+	}
+	return pieces
+}
+
+func main() {
+	pieces := makePieces(10_000)
+
+	start := time.Now()
+	naive := concatNaive(pieces)
+	naiveTime := time.Since(start)
+
+	start = time.Now()
+	built := concatBuilder(pieces)
+	builderTime := time.Since(start)
+
+	start = time.Now()
+	grown := concatBuilderGrow(pieces)
+	growTime := time.Since(start)
+
+	fmt.Println("length          :", len(naive), "bytes, same result :", naive == built && built == grown)
+	fmt.Println("s += piece      :", naiveTime)
+	fmt.Println("strings.Builder :", builderTime)
+	fmt.Println("Builder + Grow  :", growTime)
+
+	fmt.Println("--------------------------------")
+
+	//! watching the builder grow : the capacity jumps, it doesn't grow by one piece at a time
+	var builder strings.Builder
+	lastCap := 0
+	for i := 0; i < 200; i++ {
+		builder.WriteString("abcdefgh")
+		if builder.Cap() != lastCap {
+			fmt.Printf("len %5d -> cap %5d\n", builder.Len(), builder.Cap())
+			lastCap = builder.Cap()
+		}
+	}
+	//! small arrays double (8, 16, 32 ... 512). Bigger ones grow by less than double, and the size is rounded up to the memory allocator's size classes (896, 1408 ...)
+	//! 200 writes, but only 10 new arrays
+}
+
+/*
+	Try the benchmarks (the repo's first benchmark example) :
+
+	go test -bench . -benchmem main.go builder_test.go
+*/
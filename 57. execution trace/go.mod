@@ -0,0 +1,3 @@
+module executiontrace
+
+go 1.22
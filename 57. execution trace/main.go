@@ -0,0 +1,99 @@
+//! Execution trace -> instead of printing and reading the output with our eyes, every interesting step calls tracer.Mark("label"). Afterwards, the program itself checks the order
+//! this is useful for goroutines, init order, pipeline stages, middleware chains ... anywhere the order matters but is hard to see
+package main
+
+import (
+	"fmt"
+
+	"executiontrace/trace"
+)
+
+//! printReporter -> prints the failure instead of failing a test. In a _test.go file we would pass 't' instead
+type printReporter struct{}
+
+func (printReporter) Helper() {}
+func (printReporter) Errorf(format string, args ...any) {
+	fmt.Printf("  FAIL : "+format+"\n", args...)
+}
+
+//! 1. init order : package variables -> init() -> main()
+var tracer = startTracer()
+
+func startTracer() *trace.T {
+	t := trace.New()
+	t.Mark("package variable")
+	return t
+}
+
+func init() {
+	tracer.Mark("init")
+}
+
+//! 3. middleware : every layer does something before and after the next one
+type handler func()
+
+func logging(t *trace.T, next handler) handler {
+	return func() {
+		t.Mark("logging start")
+		next()
+		t.Mark("logging end")
+	}
+}
+
+func auth(t *trace.T, next handler) handler {
+	return func() {
+		t.Mark("auth start")
+		next()
+		t.Mark("auth end")
+	}
+}
+
+func main() {
+	tracer.Mark("main")
+	fmt.Println("init order sequence :", tracer.Sequence())
+	fmt.Println("init order ok       :", tracer.AssertOrder(printReporter{}, "package variable", "init", "main"))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. pipeline stages in different goroutines
+	pipelineTracer := trace.New()
+	numbers := make(chan int)
+	squares := make(chan int)
+
+	go func() {
+		for i := 1; i <= 3; i++ {
+			pipelineTracer.Mark(fmt.Sprint("generate ", i))
+			numbers <- i
+		}
+		close(numbers)
+	}()
+	go func() {
+		for n := range numbers {
+			pipelineTracer.Mark(fmt.Sprint("square ", n))
+			squares <- n * n
+		}
+		close(squares)
+	}()
+	for square := range squares {
+		pipelineTracer.Mark(fmt.Sprint("print ", square))
+	}
+
+	for _, event := range pipelineTracer.Events() {
+		fmt.Printf("  goroutine %-3d %s\n", event.Goroutine, event.Label)
+	}
+	//! the goroutines run at the same time, so "generate 2" may come before or after "square 1". But the channels guarantee this chain for every value :
+	fmt.Println("value 2 flows in order :", pipelineTracer.AssertOrder(printReporter{}, "generate 2", "square 2", "print 4"))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. middleware chain
+	chainTracer := trace.New()
+	chain := logging(chainTracer, auth(chainTracer, func() { chainTracer.Mark("handler") }))
+	chain()
+	fmt.Println("middleware sequence :", chainTracer.Sequence())
+	fmt.Println("middleware ok       :", chainTracer.AssertOrder(printReporter{}, "logging start", "auth start", "handler", "auth end", "logging end"))
+
+	//! a failing assertion : the message says what matched and what is missing
+	fmt.Println("wrong order expected :")
+	chainTracer.AssertOrder(printReporter{}, "auth start", "logging start", "handler")
+}
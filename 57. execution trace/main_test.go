@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"executiontrace/trace"
+)
+
+//! the package variable and init() run before any test, so the global tracer already has them
+func TestInitOrder(t *testing.T) {
+	tracer.AssertOrder(t, "package variable", "init")
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	chainTracer := trace.New()
+	chain := logging(chainTracer, auth(chainTracer, func() { chainTracer.Mark("handler") }))
+	chain()
+	chainTracer.AssertOrder(t, "logging start", "auth start", "handler", "auth end", "logging end")
+}
+
+//! the channels guarantee generate -> square -> print for every value, whatever the goroutines do in between
+func TestPipelineOrder(t *testing.T) {
+	pipelineTracer := trace.New()
+	numbers := make(chan int)
+	squares := make(chan int)
+
+	go func() {
+		for i := 1; i <= 3; i++ {
+			pipelineTracer.Mark(fmt.Sprint("generate ", i))
+			numbers <- i
+		}
+		close(numbers)
+	}()
+	go func() {
+		for n := range numbers {
+			pipelineTracer.Mark(fmt.Sprint("square ", n))
+			squares <- n * n
+		}
+		close(squares)
+	}()
+	for square := range squares {
+		pipelineTracer.Mark(fmt.Sprint("print ", square))
+	}
+
+	for i := 1; i <= 3; i++ {
+		pipelineTracer.AssertOrder(t, fmt.Sprint("generate ", i), fmt.Sprint("square ", i), fmt.Sprint("print ", i*i))
+	}
+	pipelineTracer.AssertOrder(t, "generate 1", "generate 2", "generate 3")
+	pipelineTracer.AssertOrder(t, "print 1", "print 4", "print 9")
+}
@@ -0,0 +1,104 @@
+//! Package trace records labelled events from any number of goroutines and checks their order in tests
+//! any lesson can import it : trace.New(), then Mark in the code and AssertOrder(t, ...) in the test
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//! Event -> one Mark call : what happened, when, and in which goroutine
+type Event struct {
+	Label     string
+	Time      time.Time
+	Goroutine int
+}
+
+//! T records labels from any number of goroutines. It's safe for concurrent use
+type T struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+func New() *T {
+	return &T{}
+}
+
+//! Mark records a label. The mutex decides the global order : whoever gets the lock first is recorded first
+func (t *T) Mark(label string) {
+	goroutine, _ := currentGoroutineID()
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.events = append(t.events, Event{Label: label, Time: time.Now(), Goroutine: goroutine}) //! time.Now is taken inside the lock, so the times never go backwards
+}
+
+//! Events returns a copy, so the caller can't change the recorded events
+func (t *T) Events() []Event {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]Event(nil), t.events...)
+}
+
+func (t *T) Sequence() []string {
+	events := t.Events()
+	labels := make([]string, len(events))
+	for i, event := range events {
+		labels[i] = event.Label
+	}
+	return labels
+}
+
+//! Reporter -> the two methods of *testing.T we need. *testing.T has them, so a test can pass 't' directly, and a main can pass a Reporter which only prints
+type Reporter interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+//! AssertOrder checks that the labels were marked in this order. Other labels may be in between : with goroutines, we usually only care about the order of SOME events
+//! on failure, the message shows what matched, what is missing, and the whole sequence
+func (t *T) AssertOrder(r Reporter, labels ...string) bool {
+	r.Helper()
+	sequence := t.Sequence()
+
+	matched := 0
+	for _, label := range sequence {
+		if matched < len(labels) && label == labels[matched] {
+			matched++
+		}
+	}
+	if matched == len(labels) {
+		return true
+	}
+
+	after := "the start"
+	if matched > 0 {
+		after = strconv.Quote(labels[matched-1])
+	}
+	r.Errorf("order mismatch\n  want : %q\n  ok   : %q\n  missing %q after %s\n  got  : %q", labels, labels[:matched], labels[matched], after, sequence)
+	return false
+}
+
+//! currentGoroutineID -> Go doesn't give goroutine IDs on purpose (so nobody builds goroutine-local storage). For tracing, we read it from the first line of the stack trace
+func currentGoroutineID() (int, error) {
+	buffer := make([]byte, 64) //! the first line is enough : "goroutine 18 [running]:"
+	n := runtime.Stack(buffer, false)
+	return parseGoroutineID(buffer[:n])
+}
+
+func parseGoroutineID(stack []byte) (int, error) {
+	line, _, _ := bytes.Cut(stack, []byte("\n"))
+	fields := strings.Fields(string(line))
+	if len(fields) < 2 || fields[0] != "goroutine" {
+		return 0, fmt.Errorf("unexpected stack header %q", line)
+	}
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("bad goroutine id in %q", line)
+	}
+	return id, nil
+}
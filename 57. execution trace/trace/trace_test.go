@@ -0,0 +1,208 @@
+package trace
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//! recorder -> a Reporter which keeps the failure message, so we can check what AssertOrder says
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Helper() {}
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func tracerWith(labels ...string) *T {
+	tracer := New()
+	for _, label := range labels {
+		tracer.Mark(label)
+	}
+	return tracer
+}
+
+func TestSequence(t *testing.T) {
+	if got := New().Sequence(); len(got) != 0 {
+		t.Errorf("Sequence of a new tracer = %q, want empty", got)
+	}
+	tracer := tracerWith("a", "b", "a")
+	if got := strings.Join(tracer.Sequence(), " "); got != "a b a" {
+		t.Errorf("Sequence = %q, want %q", got, "a b a")
+	}
+}
+
+func TestEventsIsACopy(t *testing.T) {
+	tracer := tracerWith("a")
+	events := tracer.Events()
+	events[0].Label = "changed"
+	if got := tracer.Sequence()[0]; got != "a" {
+		t.Errorf("Sequence()[0] = %q after changing the copy, want %q", got, "a")
+	}
+}
+
+func TestAssertOrder(t *testing.T) {
+	tracer := tracerWith("logging start", "auth start", "handler", "auth end", "logging end")
+	tests := []struct {
+		name   string
+		labels []string
+		want   bool
+	}{
+		{"nothing to check", nil, true},
+		{"the whole sequence", []string{"logging start", "auth start", "handler", "auth end", "logging end"}, true},
+		{"others may be between", []string{"logging start", "handler", "logging end"}, true},
+		{"one label", []string{"handler"}, true},
+		{"wrong order", []string{"auth start", "logging start"}, false},
+		{"unknown label", []string{"handler", "cache"}, false},
+		{"one label too many", []string{"handler", "handler"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if got := tracer.AssertOrder(r, tt.labels...); got != tt.want {
+				t.Errorf("AssertOrder(%q) = %v, want %v", tt.labels, got, tt.want)
+			}
+			if tt.want != (len(r.messages) == 0) {
+				t.Errorf("AssertOrder(%q) reported %q", tt.labels, r.messages)
+			}
+		})
+	}
+}
+
+//! the failure message is the diff : what was wanted, what matched, what is missing after what, and what was really recorded
+func TestAssertOrderMessage(t *testing.T) {
+	tracer := tracerWith("logging start", "auth start", "handler")
+	tests := []struct {
+		name   string
+		labels []string
+		want   string
+	}{
+		{
+			"missing in the middle",
+			[]string{"auth start", "logging start", "handler"},
+			"order mismatch\n" +
+				`  want : ["auth start" "logging start" "handler"]` + "\n" +
+				`  ok   : ["auth start"]` + "\n" +
+				`  missing "logging start" after "auth start"` + "\n" +
+				`  got  : ["logging start" "auth start" "handler"]`,
+		},
+		{
+			"missing the first one",
+			[]string{"cache"},
+			"order mismatch\n" +
+				`  want : ["cache"]` + "\n" +
+				`  ok   : []` + "\n" +
+				`  missing "cache" after the start` + "\n" +
+				`  got  : ["logging start" "auth start" "handler"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			tracer.AssertOrder(r, tt.labels...)
+			if len(r.messages) != 1 || r.messages[0] != tt.want {
+				t.Errorf("message =\n%q\nwant\n%q", r.messages, tt.want)
+			}
+		})
+	}
+}
+
+//! many goroutines mark at the same time. The global order is random, but each goroutine's own marks keep their order, and the timestamps never go back
+func TestConcurrentMark(t *testing.T) {
+	const goroutines, marks = 10, 100
+	tracer := New()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < marks; i++ {
+				tracer.Mark(fmt.Sprintf("%d-%d", g, i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	events := tracer.Events()
+	if len(events) != goroutines*marks {
+		t.Fatalf("%d events, want %d", len(events), goroutines*marks)
+	}
+
+	next := map[int]int{}  //! g (from the label) -> the next number we expect from it
+	owner := map[int]int{} //! g (from the label) -> the goroutine id which marked it
+	for i, event := range events {
+		var g, n int
+		if _, err := fmt.Sscanf(event.Label, "%d-%d", &g, &n); err != nil {
+			t.Fatalf("label %q : %v", event.Label, err)
+		}
+		if id, ok := owner[g]; ok && id != event.Goroutine {
+			t.Errorf("%q marked by goroutine %d, earlier marks of %d by goroutine %d", event.Label, event.Goroutine, g, id)
+		}
+		owner[g] = event.Goroutine
+		if n != next[g] {
+			t.Errorf("event %d = %q, want %d-%d next", i, event.Label, g, next[g])
+		}
+		next[g] = n + 1
+		if i > 0 && event.Time.Before(events[i-1].Time) {
+			t.Errorf("event %d (%s) is before event %d (%s)", i, event.Time, i-1, events[i-1].Time)
+		}
+	}
+
+	ids := map[int]bool{}
+	for _, id := range owner {
+		ids[id] = true
+	}
+	if len(ids) != goroutines {
+		t.Errorf("%d different goroutine ids, want %d", len(ids), goroutines)
+	}
+}
+
+func TestCurrentGoroutineID(t *testing.T) {
+	id, err := currentGoroutineID()
+	if err != nil || id <= 0 {
+		t.Fatalf("currentGoroutineID = %d, %v; want a positive id", id, err)
+	}
+	again, _ := currentGoroutineID()
+	if again != id {
+		t.Errorf("the same goroutine gave ids %d and %d", id, again)
+	}
+
+	other := make(chan int)
+	go func() {
+		id, _ := currentGoroutineID()
+		other <- id
+	}()
+	if otherID := <-other; otherID == id {
+		t.Errorf("another goroutine has the same id %d", id)
+	}
+}
+
+//! stack headers captured from real programs, and broken ones
+func TestParseGoroutineID(t *testing.T) {
+	tests := []struct {
+		name    string
+		stack   string
+		want    int
+		wantErr bool
+	}{
+		{"main goroutine", "goroutine 1 [running]:\nmain.main()\n\t/tmp/main.go:10 +0x1d", 1, false},
+		{"blocked goroutine", "goroutine 18 [chan send]:\nmain.main.func1()", 18, false},
+		{"locked to thread", "goroutine 7 [running, locked to thread]:\n", 7, false},
+		{"only the first line", "goroutine 123456 [running]:", 123456, false},
+		{"not a stack trace", "not a stack trace", 0, true},
+		{"no id", "goroutine x [running]:", 0, true},
+		{"empty", "", 0, true},
+		{"header on the second line", "\ngoroutine 1 [running]:", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGoroutineID([]byte(tt.stack))
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("parseGoroutineID(%q) = %d, %v; want %d, error %v", tt.stack, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
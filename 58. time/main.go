@@ -0,0 +1,106 @@
+//! The 'time' package -> the current time, formatting and parsing dates, and durations (how long something takes)
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//! Person stores the birth date instead of the age. An age stored in a struct is wrong one year later, a birth date is always right
+type Person struct {
+	Name      string
+	BirthDate time.Time
+	Email     string
+}
+
+//! age -> full years from birthDate until now
+func age(birthDate time.Time) int {
+	return ageAt(birthDate, time.Now())
+}
+
+//! ageAt -> the same, but 'today' is a parameter, so the result can be checked with fixed dates
+func ageAt(birthDate, today time.Time) int {
+	years := today.Year() - birthDate.Year()
+	//! the birthday of this year hasn't come yet -> one year less
+	if today.Month() < birthDate.Month() || (today.Month() == birthDate.Month() && today.Day() < birthDate.Day()) {
+		years--
+	}
+	return years
+}
+
+func (person Person) printDetails() {
+	fmt.Println(`Person Name :`, person.Name, `Person Age :`, age(person.BirthDate), `Person Email :`, person.Email)
+}
+
+func slowWork() {
+	time.Sleep(120 * time.Millisecond) //! a fake workload
+}
+
+func main() {
+	//! 1. now
+	now := time.Now()
+	fmt.Println("now :", now.Format("2006-01-02 15:04:05"))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. formatting : Go doesn't use YYYY-MM-DD. The layout is the REFERENCE time Mon Jan 2 15:04:05 MST 2006, written the way you want it
+	//! an easy way to remember : 01/02 03:04:05PM '06 -0700 -> 1 2 3 4 5 6 7
+	moment := time.Date(2024, time.March, 9, 14, 5, 30, 0, time.UTC)
+	fmt.Println(`"2006-01-02 15:04:05"   :`, moment.Format("2006-01-02 15:04:05"))
+	fmt.Println(`"02/01/2006"            :`, moment.Format("02/01/2006"))
+	fmt.Println(`"Jan 2, 2006 at 3:04pm" :`, moment.Format("Jan 2, 2006 at 3:04pm"))
+	fmt.Println(`"Monday"                :`, moment.Format("Monday"))
+	fmt.Println(`time.RFC3339            :`, moment.Format(time.RFC3339))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. parsing : the same layout, the other way round
+	parsed, err := time.Parse("2006-01-02", "1995-08-17")
+	fmt.Println("parse 1995-08-17      :", parsed, err)
+
+	_, err = time.Parse("2006-01-02", "17/08/1995")
+	fmt.Println("parse 17/08/1995      :", err) //! the text doesn't match the layout
+
+	//! the gotcha : a layout with the wrong numbers. "2006-02-01" means year-DAY-month, so 1995-08-05 is read as 8 May
+	//! 'go vet' warns when it sees this exact constant ("2006-02-01 should be 2006-01-02"), but a layout in a variable, from a config file for example, slips through
+	wrongLayout := "2006-02-01"
+	wrong, err := time.Parse(wrongLayout, "1995-08-05")
+	fmt.Println("wrong layout (no err) :", wrong.Format("January 2, 2006"), err) //! May 8, 1995 <nil> -> no error, just a wrong date
+
+	//! another one : "YYYY-MM-DD" is not a layout at all. Its letters are copied as text, so the parse fails
+	_, err = time.Parse("YYYY-MM-DD", "1995-08-17")
+	fmt.Println(`layout "YYYY-MM-DD"   :`, err)
+
+	fmt.Println("--------------------------------")
+
+	//! 4. durations : time.Duration is an int64 of nanoseconds, with constants to make it readable
+	timeout := 2*time.Minute + 30*time.Second
+	fmt.Println("2*time.Minute + 30*time.Second :", timeout)           //! 2m30s
+	fmt.Println("in seconds                     :", timeout.Seconds()) //! 150
+	fmt.Println("timeout / 3                    :", timeout/3)         //! 50s
+
+	meeting := moment.Add(90 * time.Minute)
+	fmt.Println("moment + 90 minutes            :", meeting.Format("15:04"))
+	fmt.Println("meeting.Sub(moment)            :", meeting.Sub(moment))
+	fmt.Println("moment.AddDate(0, 1, 0)        :", moment.AddDate(0, 1, 0).Format("2006-01-02")) //! one month later. For days, months and years use AddDate, not Add
+	fmt.Println("meeting.After(moment)          :", meeting.After(moment))
+
+	fmt.Println("--------------------------------")
+
+	//! 5. time.Since -> how long something took
+	start := time.Now()
+	slowWork()
+	elapsed := time.Since(start) //! the same as time.Now().Sub(start)
+	fmt.Println("slowWork took about :", elapsed.Round(10*time.Millisecond))
+
+	fmt.Println("--------------------------------")
+
+	//! 6. Person with a BirthDate
+	birthDate, _ := time.Parse("2006-01-02", "2004-06-15")
+	person := Person{Name: "John", BirthDate: birthDate, Email: "john@example.com"}
+	person.printDetails() //! the age is calculated every time, so it's never out of date
+
+	//! with fixed 'today' dates, to see the birthday rule
+	fmt.Println("age on 2024-06-14 :", ageAt(birthDate, time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC))) //! 19, one day before the birthday
+	fmt.Println("age on 2024-06-15 :", ageAt(birthDate, time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC))) //! 20
+}
@@ -0,0 +1,171 @@
+//! Map key types and speed -> a map can use any comparable type as a key : string, int, or a struct of comparable fields. But they don't cost the same
+//! we build three indexes over the same people, time insert and lookup for each, and check how much it costs to glue a string key together vs using a struct key
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Person struct {
+	ID    int
+	Name  string
+	Age   int
+	Email string
+}
+
+//! personKey -> a composite key. A struct can be a map key when all its fields are comparable
+type personKey struct {
+	Name string
+	Age  int
+}
+
+func generatePeople(n int) []Person {
+	people := make([]Person, n)
+	for i := range people {
+		people[i] = Person{
+			ID:    i,
+			Name:  "name" + strconv.Itoa(i/100), //! 100 people share a name, but each of them has a different age -> {Name, Age} is unique
+			Age:   i % 100,
+			Email: "user" + strconv.Itoa(i) + "@example.com",
+		}
+	}
+	return people
+}
+
+//! the index values are *Person, so all three indexes point to the same people and don't copy them
+func buildByEmail(people []Person) map[string]*Person {
+	index := make(map[string]*Person, len(people)) //! size hint : the map doesn't have to grow while we insert
+	for i := range people {
+		index[people[i].Email] = &people[i]
+	}
+	return index
+}
+
+func buildByID(people []Person) map[int]*Person {
+	index := make(map[int]*Person, len(people))
+	for i := range people {
+		index[people[i].ID] = &people[i]
+	}
+	return index
+}
+
+func buildByNameAge(people []Person) map[personKey]*Person {
+	index := make(map[personKey]*Person, len(people))
+	for i := range people {
+		index[personKey{Name: people[i].Name, Age: people[i].Age}] = &people[i]
+	}
+	return index
+}
+
+//! the "old" way of a composite key : glue the fields into one string. Every key is a NEW string -> an allocation
+func concatKey(name string, age int) string {
+	return name + "|" + strconv.Itoa(age)
+}
+
+func timeBuild(build func()) time.Duration {
+	start := time.Now()
+	build()
+	return time.Since(start)
+}
+
+func main() {
+	n := flag.Int("n", 1_000_000, "number of people")
+	flag.Parse()
+
+	people := generatePeople(*n)
+
+	var byEmail map[string]*Person
+	var byID map[int]*Person
+	var byNameAge map[personKey]*Person
+	emailBuild := timeBuild(func() { byEmail = buildByEmail(people) })
+	idBuild := timeBuild(func() { byID = buildByID(people) })
+	structBuild := timeBuild(func() { byNameAge = buildByNameAge(people) })
+
+	//! lookups : testing.Benchmark runs the function with a bigger and bigger b.N, like 'go test -bench' does
+	var found *Person //! keeps the compiler from removing the lookups
+	emailLookup := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found = byEmail[people[i%len(people)].Email]
+		}
+	})
+	idLookup := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found = byID[people[i%len(people)].ID]
+		}
+	})
+	structLookup := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			found = byNameAge[personKey{Name: p.Name, Age: p.Age}]
+		}
+	})
+	_ = found
+
+	fmt.Printf("%d people\n", len(people))
+	fmt.Println("| key type          | insert (ns/person) | lookup (ns/op) |")
+	fmt.Println("| ----------------- | ------------------ | -------------- |")
+	fmt.Printf("| string (email)    | %18.1f | %14d |\n", float64(emailBuild.Nanoseconds())/float64(len(people)), emailLookup.NsPerOp())
+	fmt.Printf("| int (ID)          | %18.1f | %14d |\n", float64(idBuild.Nanoseconds())/float64(len(people)), idLookup.NsPerOp())
+	fmt.Printf("| struct {Name,Age} | %18.1f | %14d |\n", float64(structBuild.Nanoseconds())/float64(len(people)), structLookup.NsPerOp())
+	//! int is the fastest : hashing and comparing 8 bytes. Strings have to hash and compare every byte, a struct key hashes every field
+
+	fmt.Println("--------------------------------")
+
+	//! building a composite key : string concatenation vs struct
+	var keyString string
+	var keyStruct personKey
+	concat := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			keyString = concatKey(p.Name, p.Age)
+		}
+	})
+	direct := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			keyStruct = personKey{Name: p.Name, Age: p.Age} //! no new memory : the struct only holds the existing string and the int
+		}
+	})
+	_, _ = keyString, keyStruct
+	fmt.Printf("concat key \"name|age\" : %4d ns/op %3d allocs/op\n", concat.NsPerOp(), concat.AllocsPerOp())
+	fmt.Printf("struct key            : %4d ns/op %3d allocs/op\n", direct.NsPerOp(), direct.AllocsPerOp())
+
+	fmt.Println("--------------------------------")
+
+	//! struct keys : == compares EVERY field. The order we write the fields in a literal doesn't matter
+	fmt.Println(`personKey{Name: "John", Age: 30} == personKey{Age: 30, Name: "John"} :`, personKey{Name: "John", Age: 30} == personKey{Age: 30, Name: "John"})
+	fmt.Println(`personKey{Name: "John", Age: 30} == personKey{Name: "John", Age: 31} :`, personKey{Name: "John", Age: 30} == personKey{Name: "John", Age: 31})
+	_, ok := byNameAge[personKey{Name: "name0", Age: 0}]
+	fmt.Println(`lookup with a new but equal struct value finds it                    :`, ok)
+
+	fmt.Println("--------------------------------")
+
+	//! slices can't be map keys, because slices can't be compared with ==
+	//
+	//   tags := map[[]string]int{}   // compile error : invalid map key type []string
+	//
+	//! workaround 1 : an ARRAY has a fixed size and can be compared, so it can be a key
+	byCoordinates := map[[2]int]string{}
+	byCoordinates[[2]int{3, 4}] = "treasure"
+	fmt.Println("array key [2]int{3, 4}   :", byCoordinates[[2]int{3, 4}])
+
+	//! workaround 2 : turn the slice into a string. Use a separator which can't appear inside the values
+	tags := []string{"go", "maps"}
+	byTags := map[string]int{}
+	byTags[strings.Join(tags, "\x00")] = 42
+	fmt.Println("string key from a slice  :", byTags[strings.Join([]string{"go", "maps"}, "\x00")])
+}
+
+/*
+	Try :
+
+	go run main.go
+	go run main.go -n 100000
+*/
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+//! every person is found by all three indexes, and all three give the same pointer -> the same person
+func TestIndexesAgree(t *testing.T) {
+	people := generatePeople(10_000)
+	byEmail, byID, byNameAge := buildByEmail(people), buildByID(people), buildByNameAge(people)
+
+	for _, index := range []int{len(byEmail), len(byID), len(byNameAge)} {
+		if index != len(people) {
+			t.Fatalf("index sizes = %d, %d, %d; want %d each (a duplicate key?)", len(byEmail), len(byID), len(byNameAge), len(people))
+		}
+	}
+	for i := range people {
+		p := &people[i]
+		a, b, c := byEmail[p.Email], byID[p.ID], byNameAge[personKey{Name: p.Name, Age: p.Age}]
+		if a != p || b != p || c != p {
+			t.Fatalf("person %d : byEmail = %p, byID = %p, byNameAge = %p; want %p", i, a, b, c, p)
+		}
+	}
+}
+
+func TestMissingKeys(t *testing.T) {
+	people := generatePeople(100)
+	byEmail, byID, byNameAge := buildByEmail(people), buildByID(people), buildByNameAge(people)
+
+	if p, ok := byEmail["nobody@example.com"]; ok {
+		t.Errorf("byEmail[nobody] = %v, want nothing", p)
+	}
+	if p, ok := byID[100]; ok {
+		t.Errorf("byID[100] = %v, want nothing", p)
+	}
+	if p, ok := byNameAge[personKey{Name: "name0", Age: 100}]; ok {
+		t.Errorf("byNameAge[{name0 100}] = %v, want nothing", p)
+	}
+}
+
+func TestPersonKeyEquality(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b personKey
+		want bool
+	}{
+		{"same fields", personKey{Name: "John", Age: 30}, personKey{Name: "John", Age: 30}, true},
+		{"field order in the literal doesn't matter", personKey{Name: "John", Age: 30}, personKey{Age: 30, Name: "John"}, true},
+		{"positional literal", personKey{"John", 30}, personKey{Age: 30, Name: "John"}, true},
+		{"different age", personKey{Name: "John", Age: 30}, personKey{Name: "John", Age: 31}, false},
+		{"different name", personKey{Name: "John", Age: 30}, personKey{Name: "Jane", Age: 30}, false},
+		{"zero values", personKey{}, personKey{Name: "", Age: 0}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a == tt.b; got != tt.want {
+				t.Errorf("%v == %v = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			//! the map agrees with == : an equal key finds the entry, an unequal one doesn't
+			index := map[personKey]int{tt.a: 1}
+			if _, found := index[tt.b]; found != tt.want {
+				t.Errorf("map[%v] lookup with %v found = %v, want %v", tt.a, tt.b, found, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcatKey(t *testing.T) {
+	if got := concatKey("John", 30); got != "John|30" {
+		t.Errorf("concatKey(John, 30) = %q, want %q", got, "John|30")
+	}
+}
+
+//! slices can't be map keys :
+//
+//	tags := map[[]string]int{} // compile error : invalid map key type []string
+//
+//! so we test the two workarounds from main
+func TestSliceKeyWorkarounds(t *testing.T) {
+	byCoordinates := map[[2]int]string{{3, 4}: "treasure"}
+	coordinates := []int{3, 4}
+	if got := byCoordinates[[2]int(coordinates)]; got != "treasure" { //! a slice converts to an array of the same length
+		t.Errorf("array key [3 4] = %q, want %q", got, "treasure")
+	}
+
+	tests := []struct {
+		name      string
+		a, b      []string
+		separator string
+		wantSame  bool
+	}{
+		{"equal slices give the same key", []string{"go", "maps"}, []string{"go", "maps"}, "\x00", true},
+		{"order matters", []string{"go", "maps"}, []string{"maps", "go"}, "\x00", false},
+		{"no separator : different slices collide", []string{"ab", "c"}, []string{"a", "bc"}, "", true},
+		{"a separator inside a value collides", []string{"a|b"}, []string{"a", "b"}, "|", true},
+		{"\\x00 doesn't appear in the values", []string{"a|b"}, []string{"a", "b"}, "\x00", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			same := strings.Join(tt.a, tt.separator) == strings.Join(tt.b, tt.separator)
+			if same != tt.wantSame {
+				t.Errorf("key(%q) == key(%q) = %v, want %v", tt.a, tt.b, same, tt.wantSame)
+			}
+		})
+	}
+}
+
+//! the benchmarks share 1M people, generated once and only when a benchmark runs
+var (
+	benchOnce   sync.Once
+	benchPeople []Person
+)
+
+func peopleForBenchmark(b *testing.B) []Person {
+	b.Helper()
+	benchOnce.Do(func() { benchPeople = generatePeople(1_000_000) })
+	return benchPeople
+}
+
+//! one op builds a whole index over 1M people
+func BenchmarkInsert(b *testing.B) {
+	people := peopleForBenchmark(b)
+	b.Run("string", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buildByEmail(people)
+		}
+	})
+	b.Run("int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buildByID(people)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buildByNameAge(people)
+		}
+	})
+}
+
+var found *Person //! keeps the compiler from removing the lookups
+
+func BenchmarkLookup(b *testing.B) {
+	people := peopleForBenchmark(b)
+	byEmail, byID, byNameAge := buildByEmail(people), buildByID(people), buildByNameAge(people)
+	b.Run("string", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found = byEmail[people[i%len(people)].Email]
+		}
+	})
+	b.Run("int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found = byID[people[i%len(people)].ID]
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			found = byNameAge[personKey{Name: p.Name, Age: p.Age}]
+		}
+	})
+}
+
+var (
+	keyString string
+	keyStruct personKey
+)
+
+//! building a composite key : concatenation allocates a new string every time, the struct doesn't
+func BenchmarkCompositeKey(b *testing.B) {
+	people := peopleForBenchmark(b)
+	b.Run("concat", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			keyString = concatKey(p.Name, p.Age)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p := &people[i%len(people)]
+			keyStruct = personKey{Name: p.Name, Age: p.Age}
+		}
+	})
+}
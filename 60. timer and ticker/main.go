@@ -0,0 +1,118 @@
+//! timer -> fires ONCE after a duration. ticker -> fires AGAIN and AGAIN, every duration. Both send the current time on a channel
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+var start = time.Now()
+
+//! stamp -> the time since the program started, so the cadence is easy to see in the output
+func stamp() string {
+	return fmt.Sprintf("[+%5v]", time.Since(start).Round(10*time.Millisecond))
+}
+
+//! countdown -> prints 3 ... 2 ... 1 ... go, one number per second, with a ticker
+func countdown(seconds int) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop() //! always stop a ticker when you're done with it
+
+	for remaining := seconds; remaining > 0; remaining-- {
+		fmt.Println(stamp(), remaining, "...")
+		<-ticker.C //! wait for the next tick
+	}
+	fmt.Println(stamp(), "go!")
+}
+
+func main() {
+	//! 1. a timer fires once
+	timer := time.NewTimer(300 * time.Millisecond)
+	fmt.Println(stamp(), "timer started")
+	<-timer.C
+	fmt.Println(stamp(), "timer fired")
+
+	//! Stop -> cancels a timer which hasn't fired yet. It returns true when it stopped it in time
+	timer = time.NewTimer(time.Second)
+	stopped := timer.Stop()
+	fmt.Println(stamp(), "second timer stopped before firing :", stopped)
+
+	fmt.Println("--------------------------------")
+
+	//! 2. time.After inside a select -> a timeout : wait for a result, but not forever
+	result := make(chan string)
+	go func() {
+		time.Sleep(500 * time.Millisecond) //! a slow job
+		result <- "done"
+	}()
+
+	select {
+	case r := <-result:
+		fmt.Println(stamp(), "result :", r)
+	case <-time.After(200 * time.Millisecond):
+		fmt.Println(stamp(), "timeout : the job took longer than 200ms")
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 3. a ticker : a tick every 200ms, stopped after one second
+	ticker := time.NewTicker(200 * time.Millisecond)
+	done := time.After(time.Second + 50*time.Millisecond)
+	tickerStart := time.Now()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Println(stamp(), "tick, since the ticker started :", time.Since(tickerStart).Round(10*time.Millisecond))
+		case <-done:
+			ticker.Stop()
+			fmt.Println(stamp(), "ticker stopped")
+			break loop //! a plain 'break' would only leave the select, the label leaves the for loop
+		}
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 4. countdown
+	countdown(3)
+
+	fmt.Println("--------------------------------")
+
+	//! 5. the time.Tick pitfall
+	//! time.Tick(d) is a short way to get a ticker channel, but it gives no *Ticker, so there is no Stop
+	//!
+	//!   for {
+	//!       select {
+	//!       case <-jobs:           ...
+	//!       case <-time.Tick(time.Second): ...   // a NEW ticker in every round of the loop !
+	//!       }
+	//!   }
+	//!
+	//! before Go 1.23, a ticker that was never stopped was NEVER freed : this loop leaked one ticker per round, forever. In a long-running server that is a memory leak
+	//! since Go 1.23 the garbage collector frees tickers nobody references any more, but creating a new one in every round is still wasteful, and a ticker you keep a reference to keeps firing until Stop
+	//! the rule stays the same : create the ticker ONCE with time.NewTicker, and defer ticker.Stop()
+	jobs := make(chan int)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			jobs <- i
+			time.Sleep(50 * time.Millisecond)
+		}
+		close(jobs)
+	}()
+
+	heartbeat := time.NewTicker(120 * time.Millisecond) //! once, outside the loop
+	defer heartbeat.Stop()
+	for {
+		select {
+		case job, ok := <-jobs:
+			if !ok {
+				fmt.Println(stamp(), "all jobs done")
+				return
+			}
+			fmt.Println(stamp(), "job", job)
+		case <-heartbeat.C:
+			fmt.Println(stamp(), "heartbeat")
+		}
+	}
+}
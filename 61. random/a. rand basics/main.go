@@ -0,0 +1,48 @@
+//! math/rand -> pseudo random numbers. "Pseudo" because they come from a formula : the same starting value (seed) always gives the same numbers
+//! (for passwords, tokens or keys use crypto/rand instead. math/rand numbers can be predicted)
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+func main() {
+	//! 1. the package functions use a global generator. Since Go 1.20 it's seeded automatically with a random seed, so every run gives different numbers
+	fmt.Println("rand.Intn(100)  :", rand.Intn(100)) //! 0 ... 99
+	fmt.Println("rand.Float64()  :", rand.Float64()) //! 0.0 ... 1.0 (1.0 not included)
+	fmt.Println("1 + rand.Intn(6):", 1+rand.Intn(6)) //! a dice : 1 ... 6
+
+	//! a number between low and high (both included) : low + rand.Intn(high-low+1)
+	low, high := 10, 20
+	fmt.Println("10 ... 20       :", low+rand.Intn(high-low+1))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. seeding : our own generator with a FIXED seed gives the same numbers on every run. Useful for tests and for repeating a bug
+	//! (the old rand.Seed(42) for the global generator is deprecated, create your own generator instead)
+	first := rand.New(rand.NewSource(42))
+	second := rand.New(rand.NewSource(42))
+	for i := 0; i < 3; i++ {
+		fmt.Println("seed 42 :", first.Intn(100), second.Intn(100)) //! both columns are the same, and the same on every run
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 3. shuffling a slice. rand.Shuffle calls swap(i, j) for the elements it wants to exchange
+	names := []string{"John", "Jane", "Alice", "Bob", "Carol"}
+	rand.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+	fmt.Println("shuffled        :", names) //! a different order every run
+
+	deck := []int{1, 2, 3, 4, 5}
+	seeded := rand.New(rand.NewSource(7))
+	seeded.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	fmt.Println("shuffled seed 7 :", deck) //! the same order every run
+
+	//! rand.Perm(n) -> the numbers 0 ... n-1 in random order
+	fmt.Println("rand.Perm(5)    :", rand.Perm(5))
+}
+
+//! Go 1.22 added math/rand/v2 with nicer names (rand.IntN, rand.N) and better generators. The ideas are the same
@@ -0,0 +1,62 @@
+//! A number guessing game : the program picks a number from 1 to 100, the player guesses, and the program says "higher" or "lower" until the guess is correct
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+)
+
+//! readGuess -> reads one line with fmt.Scanln (like in the functions lesson) and turns it into a number with strconv.Atoi
+//! reading into a string first lets us check the input ourselves. With fmt.Scanln(&number) a typo like "5o" would just fail quietly
+func readGuess() (int, error) {
+	var input string
+	fmt.Print("Your guess (1-100): ")
+	if _, err := fmt.Scanln(&input); err != nil {
+		if err == io.EOF {
+			return 0, err //! no more input (Ctrl+D), the game ends
+		}
+		return 0, fmt.Errorf("please type a number")
+	}
+
+	guess, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", input)
+	}
+	if guess < 1 || guess > 100 {
+		return 0, fmt.Errorf("%d is not between 1 and 100", guess)
+	}
+	return guess, nil
+}
+
+func main() {
+	secret := 1 + rand.Intn(100) //! 1 ... 100
+	guesses := 0
+
+	fmt.Println("I'm thinking of a number between 1 and 100.")
+	for {
+		guess, err := readGuess()
+		if err == io.EOF {
+			fmt.Println("\nBye! The number was", secret)
+			return
+		}
+		if err != nil {
+			fmt.Println("Invalid input :", err, "-> try again") //! a bad input doesn't count as a guess
+			continue
+		}
+
+		guesses++
+		switch {
+		case guess < secret:
+			fmt.Println("Higher!")
+		case guess > secret:
+			fmt.Println("Lower!")
+		default:
+			fmt.Printf("Correct! The number was %d. You needed %d guesses.\n", secret, guesses)
+			return
+		}
+	}
+}
+
+//! with the halving strategy (always guess the middle of what is left : 50, 25 or 75, ...) you never need more than 7 guesses, because 2^7 = 128 > 100
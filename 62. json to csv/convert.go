@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//! Options -> Fields are the columns, in order. "address.city" reads the key 'city' inside the object 'address'
+//! when Fields is empty, the header is taken from the first record. Warnings (like unknown fields) go to Warn, if it's not nil
+type Options struct {
+	Fields []string
+	Warn   io.Writer
+}
+
+type Stats struct {
+	Records       int
+	UnknownFields []string //! fields found in later records which are not in the header
+}
+
+//! Convert reads a JSON array of objects, or JSON lines (one object per line), and writes CSV
+//! it streams : only ONE record is in memory at a time, so a 10 GB file works the same as a 10 KB file
+func Convert(r io.Reader, w io.Writer, opts Options) (Stats, error) {
+	var stats Stats
+	reader := bufio.NewReader(r)
+
+	isArray, err := startsWithArray(reader)
+	if err != nil {
+		return stats, err
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber() //! keep numbers as their original text : 1000000 stays "1000000" and not "1e+06"
+	if isArray {
+		if _, err := decoder.Token(); err != nil { //! read the '['
+			return stats, err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	fields := opts.Fields
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field] = true
+	}
+	reported := map[string]bool{}
+	row := make([]string, 0, len(fields)) //! reused for every record, the streaming loop doesn't allocate a new row each time
+
+	for {
+		if isArray && !decoder.More() { //! More -> is there another element before the ']' ?
+			break
+		}
+
+		var record map[string]any
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("record %d: %w", stats.Records+1, err)
+		}
+
+		flat := flatten(record)
+		if fields == nil {
+			fields = sortedKeys(flat) //! a Go map forgets the order of the JSON keys, so the header is sorted
+			for _, field := range fields {
+				known[field] = true
+			}
+			if err := writer.Write(fields); err != nil {
+				return stats, err
+			}
+		} else if stats.Records == 0 {
+			if err := writer.Write(fields); err != nil {
+				return stats, err
+			}
+		}
+
+		if opts.Fields == nil {
+			for _, key := range sortedKeys(flat) {
+				if !known[key] && !reported[key] {
+					reported[key] = true
+					stats.UnknownFields = append(stats.UnknownFields, key)
+					if opts.Warn != nil {
+						fmt.Fprintf(opts.Warn, "warning: record %d has field %q which is not in the header, it is skipped\n", stats.Records+1, key)
+					}
+				}
+			}
+		}
+
+		row = row[:0]
+		for _, field := range fields {
+			row = append(row, cell(flat[field])) //! a missing field is nil -> an empty cell
+		}
+		if err := writer.Write(row); err != nil {
+			return stats, err
+		}
+		stats.Records++
+	}
+
+	if isArray {
+		if _, err := decoder.Token(); err != nil { //! read the ']'
+			return stats, err
+		}
+	}
+
+	writer.Flush()
+	return stats, writer.Error()
+}
+
+//! startsWithArray -> looks at the first character which is not a space, WITHOUT consuming it. '[' means a JSON array, anything else means JSON lines
+func startsWithArray(reader *bufio.Reader) (bool, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			return false, nil //! empty input
+		}
+		if err != nil {
+			return false, err
+		}
+		if b == ' ' || b == '\n' || b == '\r' || b == '\t' {
+			continue
+		}
+		return b == '[', reader.UnreadByte()
+	}
+}
+
+//! flatten -> {"name": "John", "address": {"city": "Dhaka"}} -> {"name": "John", "address.city": "Dhaka"}
+//! only ONE level is flattened. Deeper objects and arrays stay as JSON text in one cell
+func flatten(record map[string]any) map[string]any {
+	flat := make(map[string]any, len(record))
+	for key, value := range record {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			flat[key] = value
+			continue
+		}
+		for nestedKey, nestedValue := range nested {
+			flat[key+"."+nestedKey] = nestedValue
+		}
+	}
+	return flat
+}
+
+//! cell -> how one JSON value is written in a CSV cell
+func cell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String() //! exactly as it was written in the JSON
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default: //! objects and arrays
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//! parseFields -> "name, age,address.city" -> ["name", "age", "address.city"]. Empty -> nil, which means "take the header from the first record"
+func parseFields(text string) []string {
+	var fields []string
+	for _, field := range strings.Split(text, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
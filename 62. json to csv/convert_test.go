@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		fields []string
+		want   string
+	}{
+		{"nested field", `[{"name":"John","address":{"city":"Dhaka","zip":"1207"}}]`, []string{"name", "address.city"}, "name,address.city\nJohn,Dhaka\n"},
+		{"columns in the order of -fields", `{"a":1,"b":2,"c":3}`, []string{"c", "a", "b"}, "c,a,b\n3,1,2\n"},
+		{"missing fields are empty cells", "{\"name\":\"John\",\"age\":30}\n{\"name\":\"Jane\",\"address\":{\"city\":\"Dhaka\"}}\n", []string{"name", "age", "address.city"}, "name,age,address.city\nJohn,30,\nJane,,Dhaka\n"},
+		{"null is an empty cell", `{"name":null}`, []string{"name"}, "name\n\n"},
+		{"heterogeneous records", "{\"a\":1}\n{\"b\":2}\n{\"a\":3,\"b\":4,\"c\":5}\n", []string{"a", "b"}, "a,b\n1,\n,2\n3,4\n"},
+		{"a path into a value which isn't an object", `{"address":"Dhaka"}`, []string{"address", "address.city"}, "address,address.city\nDhaka,\n"},
+		{"only one level is flattened", `{"geo":{"point":{"lat":1,"lng":2}}}`, []string{"geo.point", "geo.point.lat"}, "geo.point,geo.point.lat\n\"{\"\"lat\"\":1,\"\"lng\"\":2}\",\n"},
+		{"header from the first record, sorted", `{"name":"John","age":30,"address":{"zip":"1207","city":"Dhaka"}}`, nil, "address.city,address.zip,age,name\nDhaka,1207,30,John\n"},
+		{"array across many lines", "[\n  {\"a\": 1},\n  {\"a\": 2}\n]\n", []string{"a"}, "a\n1\n2\n"},
+		{"JSON lines with blank lines", "\n{\"a\": 1}\n\n{\"a\": 2}\n\n", []string{"a"}, "a\n1\n2\n"},
+		{"JSON lines without new lines", `{"a":1}{"a":2}`, []string{"a"}, "a\n1\n2\n"},
+		{"an array after spaces", " \t\r\n[{\"a\":1}]", []string{"a"}, "a\n1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			if _, err := Convert(strings.NewReader(tt.input), &output, Options{Fields: tt.fields}); err != nil {
+				t.Fatalf("Convert error = %v", err)
+			}
+			if output.String() != tt.want {
+				t.Errorf("Convert =\n%s\nwant\n%s", output.String(), tt.want)
+			}
+		})
+	}
+}
+
+//! numbers keep the text they had in the JSON, strings are written as they are, and everything else becomes JSON text
+func TestConvertCells(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"int", `1000000`, "1000000"},
+		{"float keeps its zeros", `2.50`, "2.50"},
+		{"exponent", `1e3`, "1e3"},
+		{"bigger than int64", `12345678901234567890`, "12345678901234567890"},
+		{"negative", `-0.5`, "-0.5"},
+		{"number as a string", `"42"`, "42"},
+		{"true", `true`, "true"},
+		{"false", `false`, "false"},
+		{"empty string", `""`, ""},
+		{"array", `["admin","dev"]`, `"[""admin"",""dev""]"`},
+		{"empty array", `[]`, "[]"},
+		{"unicode", `"Dhaka ঢ"`, "Dhaka ঢ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			if _, err := Convert(strings.NewReader(`{"v":`+tt.value+`}`), &output, Options{Fields: []string{"v"}}); err != nil {
+				t.Fatalf("Convert error = %v", err)
+			}
+			if got := strings.TrimPrefix(output.String(), "v\n"); got != tt.want+"\n" {
+				t.Errorf("cell of %s = %q, want %q", tt.value, got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+//! encoding/csv quotes a cell when it has a comma, a quote, a new line or starts with a space, and doubles the quotes inside
+func TestConvertEscaping(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", `"John"`, "John"},
+		{"comma", `"Alice, Jr."`, `"Alice, Jr."`},
+		{"quotes", `"say \"hi\""`, `"say ""hi"""`},
+		{"new line", `"two\nlines"`, "\"two\nlines\""},
+		{"leading space", `" padded"`, `" padded"`},
+		{"space inside", `"+880 1711"`, "+880 1711"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			if _, err := Convert(strings.NewReader(`{"v":`+tt.value+`}`), &output, Options{Fields: []string{"v"}}); err != nil {
+				t.Fatalf("Convert error = %v", err)
+			}
+			if got := strings.TrimPrefix(output.String(), "v\n"); got != tt.want+"\n" {
+				t.Errorf("cell of %s = %q, want %q", tt.value, got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestConvertStats(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		fields      []string
+		wantRecords int
+		wantUnknown []string
+		wantWarn    string
+	}{
+		{"empty input", "", nil, 0, nil, ""},
+		{"only spaces", " \n\t ", nil, 0, nil, ""},
+		{"empty array", "  []  ", nil, 0, nil, ""},
+		{"no new fields", "{\"a\":1}\n{\"a\":2}\n", nil, 2, nil, ""},
+		{"later unknown fields, reported once", "{\"a\":1}\n{\"a\":2,\"c\":3,\"b\":4}\n{\"b\":5}\n", nil, 3, []string{"b", "c"},
+			"warning: record 2 has field \"b\" which is not in the header, it is skipped\nwarning: record 2 has field \"c\" which is not in the header, it is skipped\n"},
+		{"nested unknown field", "{\"a\":1}\n{\"address\":{\"city\":\"Dhaka\"}}\n", nil, 2, []string{"address.city"},
+			"warning: record 2 has field \"address.city\" which is not in the header, it is skipped\n"},
+		{"with -fields, other fields are not reported", "{\"a\":1,\"b\":2}\n", []string{"a"}, 1, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output, warnings bytes.Buffer
+			stats, err := Convert(strings.NewReader(tt.input), &output, Options{Fields: tt.fields, Warn: &warnings})
+			if err != nil {
+				t.Fatalf("Convert error = %v", err)
+			}
+			if stats.Records != tt.wantRecords || !reflect.DeepEqual(stats.UnknownFields, tt.wantUnknown) {
+				t.Errorf("Stats = %+v, want Records %d, UnknownFields %q", stats, tt.wantRecords, tt.wantUnknown)
+			}
+			if warnings.String() != tt.wantWarn {
+				t.Errorf("warnings = %q, want %q", warnings.String(), tt.wantWarn)
+			}
+			if tt.wantRecords == 0 && output.Len() != 0 {
+				t.Errorf("output = %q, want nothing without records", output.String())
+			}
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantRecords int
+		wantErr     string
+	}{
+		{"broken second record", "{\"name\":\"John\"}\n{\"name\":", 1, "record 2: unexpected EOF"},
+		{"array not closed", `[{"a":1}`, 1, "unexpected end of JSON input"},
+		{"an element which isn't an object", `[{"a":1},2]`, 1, "record 2: json: cannot unmarshal number"},
+		{"a JSON line which isn't an object", "{\"a\":1}\n\"text\"\n", 1, "record 2: json: cannot unmarshal string"},
+		{"not JSON", "name,age\n", 0, "record 1: invalid character"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := Convert(strings.NewReader(tt.input), &bytes.Buffer{}, Options{Fields: []string{"a"}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Convert error = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if stats.Records != tt.wantRecords {
+				t.Errorf("Records = %d, want %d", stats.Records, tt.wantRecords)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"name", []string{"name"}},
+		{"name, age ,address.city", []string{"name", "age", "address.city"}},
+		{"name,,age", []string{"name", "age"}},
+	}
+	for _, tt := range tests {
+		if got := parseFields(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFields(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+//! largeInput -> n JSON lines
+func largeInput(n int) []byte {
+	var buffer bytes.Buffer
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buffer, `{"name":"person%d","age":%d,"address":{"city":"Dhaka","zip":"%04d"}}`+"\n", i, i%100, i%10000)
+	}
+	return buffer.Bytes()
+}
+
+//! streaming : the memory used PER RECORD stays the same when the input gets 10 or 100 times bigger. Compare B/op divided by the records
+//! the output goes to io.Discard, so only the memory of Convert itself is counted
+func BenchmarkConvert(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		input := largeInput(n)
+		b.Run(fmt.Sprintf("%d records", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Convert(bytes.NewReader(input), io.Discard, Options{Fields: []string{"name", "age", "address.city"}}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+//go:build miniflag
+
+package main
+
+import (
+	"errors"
+
+	"miniflagparser/miniflag" //! the flag parser of '49. mini flag parser', through the replace in go.mod
+)
+
+//! parseArgs with miniflag. It's built only with "go build -tags miniflag", flag_std.go is the default
+func parseArgs(args []string) (arguments, error) {
+	set := miniflag.NewFlagSet(commandName)
+	fields := set.String("fields", "", fieldsUsage)
+	err := set.Parse(args)
+
+	parsed := arguments{fields: *fields, paths: set.Args(), usage: set.Usage()}
+	if errors.Is(err, miniflag.ErrHelp) {
+		return parsed, errHelp
+	}
+	return parsed, err
+}
@@ -0,0 +1,29 @@
+//go:build !miniflag
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+)
+
+//! parseArgs with the standard flag package. "go build -tags miniflag" builds flag_miniflag.go instead of this file
+func parseArgs(args []string) (arguments, error) {
+	set := flag.NewFlagSet(commandName, flag.ContinueOnError)
+	set.SetOutput(io.Discard) //! the standard package prints errors by itself, main prints them like the miniflag version does
+	fields := set.String("fields", "", fieldsUsage)
+	err := set.Parse(args)
+
+	var usage strings.Builder
+	usage.WriteString("Usage of " + commandName + ":\n")
+	set.SetOutput(&usage)
+	set.PrintDefaults()
+
+	parsed := arguments{fields: *fields, paths: set.Args(), usage: usage.String()}
+	if errors.Is(err, flag.ErrHelp) {
+		return parsed, errHelp
+	}
+	return parsed, err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+//! run -> one run of a built binary in the lesson directory : what it printed and its exit code
+type run struct {
+	stdout, stderr string
+	exitCode       int
+}
+
+func runBinary(t *testing.T, binary string, args ...string) run {
+	t.Helper()
+	cmd := exec.Command(binary, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("run %s : %v", binary, err)
+	}
+	return run{stdout: stdout.String(), stderr: stderr.String(), exitCode: cmd.ProcessState.ExitCode()}
+}
+
+//! both builds, the default one with flag_std.go and "-tags miniflag" with flag_miniflag.go, must compile and behave the same for the same arguments
+func TestFlagVariants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the command twice")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("no go command :", err)
+	}
+
+	dir := t.TempDir()
+	binaries := map[string]string{}
+	for variant, tags := range map[string]string{"flag": "", "miniflag": "miniflag"} {
+		binary := filepath.Join(dir, variant)
+		build := exec.Command("go", "build", "-tags="+tags, "-o", binary, ".")
+		if output, err := build.CombinedOutput(); err != nil {
+			t.Fatalf("go build -tags=%q : %v\n%s", tags, err, output)
+		}
+		binaries[variant] = binary
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+	}{
+		{"fields and a file", []string{"-fields", "name,address.city", "people.jsonl"}, 0},
+		{"fields with =", []string{"--fields=name,age", "people.json"}, 0},
+		{"no fields", []string{"people.json"}, 0},
+		{"help", []string{"-h"}, 0},
+		{"unknown flag", []string{"-colour", "red", "people.json"}, 2},
+		{"missing value", []string{"-fields"}, 2},
+		{"missing file", []string{"-fields", "name", "missing.json"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			std := runBinary(t, binaries["flag"], tt.args...)
+			mini := runBinary(t, binaries["miniflag"], tt.args...)
+			if std.exitCode != tt.exitCode {
+				t.Errorf("flag build : exit code %d, want %d\nstderr : %s", std.exitCode, tt.exitCode, std.stderr)
+			}
+			if tt.exitCode == 0 && std.stdout == "" {
+				t.Error("flag build printed nothing")
+			}
+			if mini != std {
+				t.Errorf("miniflag build differs from the flag build\nminiflag : %+v\nflag     : %+v", mini, std)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+module jsontocsv
+
+go 1.22
+
+require miniflagparser v0.0.0
+
+replace miniflagparser => "../49. mini flag parser"
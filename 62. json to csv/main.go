@@ -0,0 +1,105 @@
+//! json to csv -> a small command line tool : it reads JSON records and writes them as CSV, which can be opened in Excel or Google Sheets
+//! the records are converted one by one while reading (streaming), so the file can be bigger than the memory
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	commandName = "json to csv"
+	fieldsUsage = "comma separated columns, for example name,age,address.city (default : the keys of the first record)"
+)
+
+//! arguments -> what parseArgs found on the command line. There are two parseArgs : flag_std.go (the standard flag package) and flag_miniflag.go (built with -tags miniflag)
+type arguments struct {
+	fields string
+	paths  []string
+	usage  string //! the help text of the flags
+}
+
+var errHelp = errors.New("help requested")
+
+func convertFile(path string, opts Options) error {
+	input := os.Stdin //! "-" means : read from the standard input
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	_, err := Convert(input, os.Stdout, opts)
+	return err
+}
+
+func demo(title, input string, opts Options) {
+	var output, warnings bytes.Buffer
+	opts.Warn = &warnings
+	stats, err := Convert(strings.NewReader(input), &output, opts)
+	fmt.Printf("%s (records %d, error %v)\n", title, stats.Records, err)
+	fmt.Print(output.String())
+	fmt.Print(warnings.String())
+	fmt.Println("--------------------------------")
+}
+
+func main() {
+	args, err := parseArgs(os.Args[1:])
+	if errors.Is(err, errHelp) {
+		fmt.Print(args.usage)
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprint(os.Stderr, args.usage)
+		os.Exit(2)
+	}
+
+	opts := Options{Fields: parseFields(args.fields), Warn: os.Stderr}
+	if len(args.paths) > 0 {
+		for _, path := range args.paths {
+			if err := convertFile(path, opts); err != nil {
+				fmt.Fprintln(os.Stderr, "error :", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	//! no file given -> show the different cases
+	demo("nested fields", `[{"name":"John","age":30,"address":{"city":"Dhaka","zip":"1207"}}]`,
+		Options{Fields: []string{"name", "age", "address.city"}})
+
+	demo("missing fields -> empty cells", `{"name":"John","age":30}
+{"name":"Jane","address":{"city":"Dhaka"}}`,
+		Options{Fields: []string{"name", "age", "address.city"}})
+
+	demo("numbers, bools, arrays, deeper objects", `[{"int":1000000,"float":2.50,"big":12345678901234567890,"ok":true,"tags":["a","b"],"geo":{"point":{"lat":1}},"text":"a, \"quoted\" value"}]`,
+		Options{})
+
+	demo("header from the first record, later unknown fields are reported", `{"name":"John","age":30}
+{"name":"Jane","age":25,"phone":"+880 1711","email":"jane@example.com"}`,
+		Options{})
+
+	demo("empty input", "", Options{})
+	demo("empty array", "  []  ", Options{})
+	demo("broken record", `{"name":"John"}
+{"name":`, Options{})
+}
+
+/*
+	Try :
+
+	go run .
+	go run . people.json
+	go run . -fields name,age,address.city people.json
+	go run . people.jsonl
+	cat people.jsonl | go run . -fields name,address.city -
+	go run -tags miniflag . -fields name,age people.json    -> the same, parsed with miniflag of '49. mini flag parser'
+	go run . -h
+*/
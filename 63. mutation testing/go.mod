@@ -0,0 +1,3 @@
+module mutationtesting
+
+go 1.22
@@ -0,0 +1,110 @@
+//! Mutation testing -> how do we know our tests are GOOD? We put small bugs into the code on purpose (mutants) and run the tests against each one
+//! if the tests fail, the mutant is "killed" : the tests noticed the bug. If they still pass, the mutant "survived" : a real bug like that would go unnoticed too
+//! the code under test is a real package of another lesson : every mutant of every file is tested with the package's own _test.go files
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mutationtesting/mutator"
+	"mutationtesting/runner"
+)
+
+//! sourceFiles -> the .go files of the package, without the _test.go files : those are the tests the mutants run against
+func sourceFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, path := range paths {
+		if !strings.HasSuffix(path, "_test.go") {
+			sources = append(sources, path)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no Go source files in %s", dir)
+	}
+	return sources, nil
+}
+
+//! mutateFile -> every mutant of one file against the package's tests. It returns how many were killed and how many were made
+func mutateFile(testRunner runner.Runner, path string, maxMutants int) (killed, total int, err error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	mutants, skipped, err := mutator.Enumerate(source, mutator.Rules, maxMutants)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s : %w", path, err)
+	}
+	fmt.Printf("%s : %d mutants, %d skipped\n", filepath.Base(path), len(mutants), skipped)
+
+	var survivors []mutator.Mutant
+	for _, mutant := range mutants {
+		result, err := testRunner.Run(mutant, path)
+		if err != nil {
+			return 0, 0, err
+		}
+		if result.Outcome == runner.Survived {
+			survivors = append(survivors, mutant)
+		} else {
+			killed++
+		}
+		fmt.Printf("  #%-2d %-22s line %-3d %-38s %s\n", mutant.ID, mutant.Rule, mutant.Line, mutant.Description, result.Outcome)
+	}
+	for _, mutant := range survivors {
+		fmt.Printf("  survivor #%d : line %d, %s\n", mutant.ID, mutant.Line, strings.TrimSpace(sourceLine(mutant.Source, mutant.Line)))
+	}
+	return killed, len(mutants), nil
+}
+
+func sourceLine(source []byte, line int) string {
+	lines := strings.Split(string(source), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+func main() {
+	dir := flag.String("dir", "../108. table test runner/calc", "the package to mutate : every .go file is mutated, the _test.go files are the tests")
+	maxMutants := flag.Int("max", 50, "at most this many mutants per file")
+	timeout := flag.Duration("timeout", 30*time.Second, "the time limit of one 'go test' run")
+	flag.Parse()
+
+	sources, err := sourceFiles(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	//! the rules and the runner's classification are checked in mutator/mutator_test.go and runner/runner_test.go. Here is the real thing : every mutant against the package's tests
+	if _, err := exec.LookPath("go"); err != nil {
+		fmt.Fprintln(os.Stderr, "error : go is not in PATH, every mutant is tested with 'go test'")
+		os.Exit(1)
+	}
+
+	testRunner := runner.Runner{Timeout: *timeout, Test: runner.GoTest}
+	killed, total := 0, 0
+	for _, path := range sources {
+		k, n, err := mutateFile(testRunner, path, *maxMutants)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		killed += k
+		total += n
+		fmt.Println("--------------------------------")
+	}
+	if total == 0 {
+		fmt.Println("no mutants : none of the rules matches the code")
+		return
+	}
+	fmt.Printf("mutation score of %s : %d / %d killed (%.0f%%)\n", *dir, killed, total, 100*float64(killed)/float64(total))
+}
@@ -0,0 +1,222 @@
+//! Package mutator -> makes mutants : copies of a Go source file with exactly one small bug in it, found and changed with go/ast
+package mutator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+)
+
+//! Rule -> one kind of small bug. Find returns the nodes the rule can change, Apply changes ONE of them and describes what it did
+type Rule struct {
+	Name  string
+	Find  func(file *ast.File) []ast.Node
+	Apply func(node ast.Node) string
+}
+
+//! Mutant -> the source code with exactly one small bug in it
+type Mutant struct {
+	ID          int
+	Rule        string
+	Line        int
+	Description string
+	Source      []byte
+}
+
+var (
+	swapped = map[token.Token]token.Token{
+		token.ADD: token.SUB, token.SUB: token.ADD,
+		token.ADD_ASSIGN: token.SUB_ASSIGN, token.SUB_ASSIGN: token.ADD_ASSIGN,
+	}
+	inverted = map[token.Token]token.Token{
+		token.GTR: token.LEQ, token.LEQ: token.GTR,
+		token.LSS: token.GEQ, token.GEQ: token.LSS,
+		token.EQL: token.NEQ, token.NEQ: token.EQL,
+	}
+)
+
+//! swap + and - : a + b -> a - b, total += x -> total -= x
+var SwapPlusMinus = Rule{
+	Name: "swap + and -",
+	Find: func(file *ast.File) []ast.Node {
+		return collect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.BinaryExpr:
+				_, ok := swapped[n.Op]
+				return ok && !isString(n) //! "a" + "b" can't become "a" - "b", it wouldn't compile
+			case *ast.AssignStmt:
+				_, ok := swapped[n.Tok]
+				return ok
+			}
+			return false
+		})
+	},
+	Apply: func(node ast.Node) string {
+		switch n := node.(type) {
+		case *ast.BinaryExpr:
+			before := n.Op
+			n.Op = swapped[n.Op]
+			return fmt.Sprintf("%s -> %s", before, n.Op)
+		case *ast.AssignStmt:
+			before := n.Tok
+			n.Tok = swapped[n.Tok]
+			return fmt.Sprintf("%s -> %s", before, n.Tok)
+		}
+		return ""
+	},
+}
+
+//! invert a comparison : a > b -> a <= b, b == 0 -> b != 0 ... (comparisons in a for loop condition are left to the off-by-one rule)
+var InvertComparison = Rule{
+	Name: "invert comparison",
+	Find: func(file *ast.File) []ast.Node {
+		loopConditions := map[ast.Node]bool{}
+		for _, node := range collect(file, func(node ast.Node) bool { _, ok := node.(*ast.ForStmt); return ok }) {
+			if cond := node.(*ast.ForStmt).Cond; cond != nil {
+				ast.Inspect(cond, func(n ast.Node) bool { loopConditions[n] = true; return true })
+			}
+		}
+		return collect(file, func(node ast.Node) bool {
+			n, ok := node.(*ast.BinaryExpr)
+			if !ok || loopConditions[n] || isErrCheck(n) {
+				return false
+			}
+			_, ok = inverted[n.Op]
+			return ok
+		})
+	},
+	Apply: func(node ast.Node) string {
+		n := node.(*ast.BinaryExpr)
+		before := n.Op
+		n.Op = inverted[n.Op]
+		return fmt.Sprintf("%s -> %s", before, n.Op)
+	},
+}
+
+//! off-by-one in a loop bound : i < n -> i <= n (and the other way round)
+var OffByOneLoop = Rule{
+	Name: "off-by-one loop bound",
+	Find: func(file *ast.File) []ast.Node {
+		var nodes []ast.Node
+		for _, node := range collect(file, func(node ast.Node) bool { _, ok := node.(*ast.ForStmt); return ok }) {
+			cond := node.(*ast.ForStmt).Cond
+			if cond == nil {
+				continue
+			}
+			ast.Inspect(cond, func(n ast.Node) bool {
+				if b, ok := n.(*ast.BinaryExpr); ok && (b.Op == token.LSS || b.Op == token.LEQ) {
+					nodes = append(nodes, b)
+				}
+				return true
+			})
+		}
+		return nodes
+	},
+	Apply: func(node ast.Node) string {
+		n := node.(*ast.BinaryExpr)
+		before := n.Op
+		if n.Op == token.LSS {
+			n.Op = token.LEQ
+		} else {
+			n.Op = token.LSS
+		}
+		return fmt.Sprintf("%s -> %s", before, n.Op)
+	},
+}
+
+//! drop an error check : if err != nil { ... } never runs any more
+//! the condition becomes 'err != nil && false' and not just 'false', because then 'err' would be unused and the mutant wouldn't even compile
+var DropErrorCheck = Rule{
+	Name: "drop error check",
+	Find: func(file *ast.File) []ast.Node {
+		return collect(file, func(node ast.Node) bool {
+			n, ok := node.(*ast.IfStmt)
+			if !ok {
+				return false
+			}
+			cond, ok := n.Cond.(*ast.BinaryExpr)
+			return ok && isErrCheck(cond)
+		})
+	},
+	Apply: func(node ast.Node) string {
+		n := node.(*ast.IfStmt)
+		n.Cond = &ast.BinaryExpr{X: n.Cond, Op: token.LAND, Y: ast.NewIdent("false")}
+		return "err != nil -> err != nil && false"
+	},
+}
+
+//! Rules -> every rule of the package
+var Rules = []Rule{SwapPlusMinus, InvertComparison, OffByOneLoop, DropErrorCheck}
+
+//! Enumerate makes every mutant of the source : for every rule, one mutant per place the rule can change
+//! every mutant starts from a FRESH parse, so a mutant has exactly one change. At most maxMutants are returned, skipped tells how many were left out
+func Enumerate(source []byte, rules []Rule, maxMutants int) (mutants []Mutant, skipped int, err error) {
+	for _, rule := range rules {
+		_, file, err := parse(source)
+		if err != nil {
+			return nil, 0, err
+		}
+		count := len(rule.Find(file))
+
+		for k := 0; k < count; k++ {
+			if len(mutants) == maxMutants {
+				skipped++
+				continue
+			}
+			fset, file, _ := parse(source)
+			node := rule.Find(file)[k]
+			description := rule.Apply(node)
+
+			var buffer bytes.Buffer
+			if err := format.Node(&buffer, fset, file); err != nil {
+				return nil, 0, err
+			}
+			mutants = append(mutants, Mutant{
+				ID:          len(mutants) + 1,
+				Rule:        rule.Name,
+				Line:        fset.Position(node.Pos()).Line,
+				Description: description,
+				Source:      buffer.Bytes(),
+			})
+		}
+	}
+	return mutants, skipped, nil
+}
+
+func parse(source []byte) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "source.go", source, parser.ParseComments)
+	return fset, file, err
+}
+
+//! collect -> every node for which match is true, in source order (ast.Inspect always walks in the same order, so the k-th node is the same in every fresh parse)
+func collect(file *ast.File, match func(ast.Node) bool) []ast.Node {
+	var nodes []ast.Node
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node != nil && match(node) {
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+	return nodes
+}
+
+//! isErrCheck -> err != nil
+func isErrCheck(n *ast.BinaryExpr) bool {
+	x, xOK := n.X.(*ast.Ident)
+	y, yOK := n.Y.(*ast.Ident)
+	return n.Op == token.NEQ && xOK && yOK && x.Name == "err" && y.Name == "nil"
+}
+
+//! isString -> a rough check without type information : is one side a string literal?
+func isString(n *ast.BinaryExpr) bool {
+	for _, side := range []ast.Expr{n.X, n.Y} {
+		if literal, ok := side.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			return true
+		}
+	}
+	return false
+}
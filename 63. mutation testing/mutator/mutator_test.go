@@ -0,0 +1,168 @@
+package mutator
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//! go test ./mutator -update -> rewrites the golden mutants in testdata, after a change of a rule which is on purpose
+var update = flag.Bool("update", false, "rewrite the golden mutants in testdata")
+
+//! fixture has exactly one place for every rule, plus a second one for swap + and -
+const fixture = `package p
+
+func f(a, b int, err error) int {
+	if err != nil {
+		return 0
+	}
+	for i := 0; i < b; i++ {
+		a += i
+	}
+	if a > b {
+		return a - b
+	}
+	return a
+}
+`
+
+func TestEnumerate(t *testing.T) {
+	mutants, skipped, err := Enumerate([]byte(fixture), Rules, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 0 {
+		t.Errorf("skipped = %d, want 0", skipped)
+	}
+
+	want := []struct {
+		rule        string
+		line        int
+		description string
+	}{
+		{"swap + and -", 8, "+= -> -="},
+		{"swap + and -", 11, "- -> +"},
+		{"invert comparison", 10, "> -> <="},
+		{"off-by-one loop bound", 7, "< -> <="},
+		{"drop error check", 4, "err != nil -> err != nil && false"},
+	}
+	if len(mutants) != len(want) {
+		t.Fatalf("Enumerate made %d mutants, want %d", len(mutants), len(want))
+	}
+	for i, w := range want {
+		m := mutants[i]
+		if m.ID != i+1 || m.Rule != w.rule || m.Line != w.line || m.Description != w.description {
+			t.Errorf("mutant %d = #%d %q line %d %q; want #%d %q line %d %q", i, m.ID, m.Rule, m.Line, m.Description, i+1, w.rule, w.line, w.description)
+		}
+	}
+}
+
+//! every mutant is compared with testdata/<ID>.golden byte for byte : the whole mutated source, as go/format prints it
+func TestGoldenMutants(t *testing.T) {
+	mutants, _, err := Enumerate([]byte(fixture), Rules, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mutant := range mutants {
+		t.Run(fmt.Sprintf("%d %s", mutant.ID, mutant.Rule), func(t *testing.T) {
+			goldenPath := filepath.Join("testdata", fmt.Sprintf("%d.golden", mutant.ID))
+			if *update {
+				if err := os.WriteFile(goldenPath, mutant.Source, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(mutant.Source) != string(golden) {
+				t.Errorf("mutant doesn't match %s (run 'go test ./mutator -update' if the change is on purpose)\n got:\n%s", goldenPath, mutant.Source)
+			}
+		})
+	}
+}
+
+//! every mutant starts from a fresh parse, so it differs from the source on exactly ONE line (the line of the mutant), and it still parses
+func TestOneChangePerMutant(t *testing.T) {
+	original := strings.Split(fixture, "\n")
+	mutants, _, err := Enumerate([]byte(fixture), Rules, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mutant := range mutants {
+		if _, err := parser.ParseFile(token.NewFileSet(), "mutant.go", mutant.Source, 0); err != nil {
+			t.Errorf("mutant #%d doesn't parse : %v", mutant.ID, err)
+		}
+		lines := strings.Split(string(mutant.Source), "\n")
+		if len(lines) != len(original) {
+			t.Errorf("mutant #%d has %d lines, want %d", mutant.ID, len(lines), len(original))
+			continue
+		}
+		var changed []int
+		for i := range lines {
+			if lines[i] != original[i] {
+				changed = append(changed, i+1)
+			}
+		}
+		if len(changed) != 1 || changed[0] != mutant.Line {
+			t.Errorf("mutant #%d changed lines %v, want only line %d", mutant.ID, changed, mutant.Line)
+		}
+	}
+}
+
+func TestRuleSkips(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		source string
+	}{
+		{"string concatenation isn't swapped", SwapPlusMinus, "package p\n\nvar s = \"a\" + \"b\"\n"},
+		{"a loop condition is left to off-by-one", InvertComparison, "package p\n\nfunc f(n int) {\n\tfor i := 0; i < n; i++ {\n\t}\n}\n"},
+		{"err != nil is left to drop error check", InvertComparison, "package p\n\nfunc f(err error) bool {\n\treturn err != nil\n}\n"},
+		{"a loop without a condition", OffByOneLoop, "package p\n\nfunc f() {\n\tfor {\n\t}\n}\n"},
+		{"an if without err != nil", DropErrorCheck, "package p\n\nfunc f(ok bool) {\n\tif ok {\n\t}\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mutants, _, err := Enumerate([]byte(tt.source), []Rule{tt.rule}, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(mutants) != 0 {
+				t.Errorf("%s made %d mutant(s), want 0 : %s", tt.rule.Name, len(mutants), mutants[0].Description)
+			}
+		})
+	}
+}
+
+func TestEnumerateCap(t *testing.T) {
+	tests := []struct {
+		maxMutants  int
+		wantMutants int
+		wantSkipped int
+	}{
+		{0, 0, 5},
+		{3, 3, 2},
+		{5, 5, 0},
+		{50, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.maxMutants), func(t *testing.T) {
+			mutants, skipped, err := Enumerate([]byte(fixture), Rules, tt.maxMutants)
+			if err != nil || len(mutants) != tt.wantMutants || skipped != tt.wantSkipped {
+				t.Errorf("Enumerate = %d mutants, %d skipped, %v; want %d, %d, nil", len(mutants), skipped, err, tt.wantMutants, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestEnumerateInvalidSource(t *testing.T) {
+	if _, _, err := Enumerate([]byte("package p\n\nfunc {"), Rules, 10); err == nil {
+		t.Error("Enumerate of invalid Go = nil error, want a parse error")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+//! killProcessGroup -> there are no process groups like on unix, so only the command itself is killed. WaitDelay in GoTest still makes sure we don't wait forever for its output
+func killProcessGroup(cmd *exec.Cmd) {}
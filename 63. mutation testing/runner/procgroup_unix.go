@@ -0,0 +1,17 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+//! killProcessGroup -> the command gets its own process group (with the id of the command), and every process it starts joins that group
+//! on a timeout the whole group is killed : 'go test' and the test binary
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) //! a negative pid -> every process of the group
+	}
+}
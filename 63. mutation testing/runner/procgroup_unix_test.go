@@ -0,0 +1,81 @@
+//go:build unix
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//! a test with an endless loop : after the timeout, the TEST BINARY (a child of 'go test') must be dead too, not only 'go test'
+func TestGoTestKillsTheTestBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs 'go test', skipped with -short")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not in PATH")
+	}
+
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "pid")
+	files := map[string]string{
+		"go.mod":  "module hang\n\ngo 1.21\n",
+		"hang.go": "package hang\n",
+		//! the test binary writes its pid, then spins forever
+		"hang_test.go": "package hang\n\nimport (\n\t\"os\"\n\t\"strconv\"\n\t\"testing\"\n)\n\nfunc TestHang(t *testing.T) {\n\tos.WriteFile(" + strconv.Quote(pidFile) + ", []byte(strconv.Itoa(os.Getpid())), 0o644)\n\tfor {\n\t}\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//! cancel as soon as the test binary is running, instead of guessing how long the build takes
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			if _, err := os.Stat(pidFile); err == nil {
+				time.Sleep(100 * time.Millisecond) //! the pid is written, give the file a moment to be complete
+				cancel()
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	passed, output := GoTest(ctx, dir)
+	if passed {
+		t.Fatalf("GoTest passed, want a failure\n%s", output)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("the test binary never started : %v\n%s", err, output)
+	}
+	pid, err := strconv.Atoi(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//! signal 0 checks if the process exists without sending anything. A killed child of 'go test' is reaped quickly, because 'go test' is its parent and it's dead too (init adopts and reaps it)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := syscall.Kill(pid, 0)
+		if errors.Is(err, syscall.ESRCH) {
+			return
+		}
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL) //! don't leave it spinning after a failed test
+			t.Fatalf("the test binary (pid %d) is still running after GoTest returned : signal 0 = %v", pid, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,99 @@
+//! Package runner -> runs a package's own tests against one mutant of one of its files, and tells if the tests noticed the bug
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mutationtesting/mutator"
+)
+
+type Outcome string
+
+const (
+	Killed      Outcome = "killed"       //! the tests failed -> they found the bug
+	Survived    Outcome = "SURVIVED"     //! the tests passed -> they did NOT notice the bug
+	TimedOut    Outcome = "timed out"    //! the bug made the tests hang. Counted as killed
+	BuildFailed Outcome = "build failed" //! the mutant didn't compile. Counted as killed, but it says nothing about the tests
+)
+
+//! TestFunc runs the tests in a directory, with extra flags for 'go test'. The real one calls 'go test', a stub can return any outcome without running anything
+type TestFunc func(ctx context.Context, dir string, flags ...string) (passed bool, output string)
+
+type Runner struct {
+	Timeout time.Duration
+	Test    TestFunc
+}
+
+type Result struct {
+	Mutant  mutator.Mutant
+	Outcome Outcome
+	Output  string
+}
+
+//! Run tests the mutant of the file at path with the tests of the file's own package. The file on disk is never changed :
+//! the mutant is written to a temporary directory, and 'go test -overlay' reads it instead of the file. The package keeps its module, its imports and its _test.go files
+func (r Runner) Run(mutant mutator.Mutant, path string) (Result, error) {
+	path, err := filepath.Abs(path) //! the overlay needs absolute paths
+	if err != nil {
+		return Result{}, err
+	}
+	directory, err := os.MkdirTemp("", "mutant")
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(directory)
+
+	mutantPath := filepath.Join(directory, filepath.Base(path))
+	if err := os.WriteFile(mutantPath, mutant.Source, 0o644); err != nil {
+		return Result{}, err
+	}
+	overlay, err := json.Marshal(Overlay{Replace: map[string]string{path: mutantPath}})
+	if err != nil {
+		return Result{}, err
+	}
+	overlayPath := filepath.Join(directory, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		return Result{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+	passed, output := r.Test(ctx, filepath.Dir(path), "-overlay="+overlayPath)
+
+	result := Result{Mutant: mutant, Output: output}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Outcome = TimedOut
+	case passed:
+		result.Outcome = Survived
+	case strings.Contains(output, "[build failed]") || strings.Contains(output, "[setup failed]"):
+		result.Outcome = BuildFailed
+	default:
+		result.Outcome = Killed
+	}
+	return result, nil
+}
+
+//! Overlay -> the file 'go build -overlay' reads : the real path of a file -> the path of the file to use instead
+type Overlay struct {
+	Replace map[string]string
+}
+
+//! GoTest -> the real TestFunc
+func GoTest(ctx context.Context, dir string, flags ...string) (bool, string) {
+	args := append([]string{"test", "-count=1"}, flags...)
+	cmd := exec.CommandContext(ctx, "go", append(args, ".")...)
+	cmd.Dir = dir
+	//! 'go test' builds a test binary and starts it as a CHILD process. CommandContext only kills 'go' itself, and a mutant with an endless loop would keep the test binary spinning after the timeout
+	killProcessGroup(cmd)
+	cmd.WaitDelay = time.Second //! after the kill, don't wait long for the output
+	output, err := cmd.CombinedOutput()
+	return err == nil, string(output)
+}
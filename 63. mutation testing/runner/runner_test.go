@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mutationtesting/mutator"
+)
+
+var mutant = mutator.Mutant{ID: 1, Rule: "swap + and -", Line: 4, Description: "+ -> -", Source: []byte("package calc\n\nfunc Add(a, b int) int {\n\treturn a - b\n}\n")}
+
+//! the runner's classification, with stub test functions instead of 'go test'
+func TestRunOutcome(t *testing.T) {
+	tests := []struct {
+		name string
+		test TestFunc
+		want Outcome
+	}{
+		{"tests pass", func(ctx context.Context, dir string, flags ...string) (bool, string) { return true, "ok" }, Survived},
+		{"tests fail", func(ctx context.Context, dir string, flags ...string) (bool, string) { return false, "--- FAIL: TestAdd" }, Killed},
+		{"build failed", func(ctx context.Context, dir string, flags ...string) (bool, string) { return false, "FAIL mutant [build failed]" }, BuildFailed},
+		{"setup failed", func(ctx context.Context, dir string, flags ...string) (bool, string) { return false, "FAIL mutant [setup failed]" }, BuildFailed},
+		{"tests hang", func(ctx context.Context, dir string, flags ...string) (bool, string) { <-ctx.Done(); return false, "" }, TimedOut},
+		{"timeout wins over a pass", func(ctx context.Context, dir string, flags ...string) (bool, string) { <-ctx.Done(); return true, "ok" }, TimedOut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Runner{Timeout: 50 * time.Millisecond, Test: tt.test}.Run(mutant, "calc.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Outcome != tt.want {
+				t.Errorf("Outcome = %q, want %q", result.Outcome, tt.want)
+			}
+			if result.Mutant.ID != mutant.ID {
+				t.Errorf("Result.Mutant.ID = %d, want %d", result.Mutant.ID, mutant.ID)
+			}
+		})
+	}
+}
+
+//! the stub gets the package directory and an -overlay flag. The overlay replaces the real file with the mutant, and is gone after Run
+func TestRunWritesTheOverlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calc.go")
+	var gotDir, overlayPath string
+	var overlay Overlay
+	replaced := map[string]string{}
+	stub := func(ctx context.Context, dir string, flags ...string) (bool, string) {
+		gotDir = dir
+		if len(flags) != 1 || !strings.HasPrefix(flags[0], "-overlay=") {
+			t.Errorf("flags = %q, want one -overlay flag", flags)
+			return true, ""
+		}
+		overlayPath = strings.TrimPrefix(flags[0], "-overlay=")
+		data, err := os.ReadFile(overlayPath)
+		if err != nil {
+			t.Error(err)
+			return true, ""
+		}
+		if err := json.Unmarshal(data, &overlay); err != nil {
+			t.Error(err)
+		}
+		for from, to := range overlay.Replace {
+			content, _ := os.ReadFile(to)
+			replaced[from] = string(content)
+		}
+		return true, ""
+	}
+
+	if _, err := (Runner{Timeout: time.Second, Test: stub}).Run(mutant, path); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotDir != filepath.Dir(path) {
+		t.Errorf("tests ran in %s, want the package directory %s", gotDir, filepath.Dir(path))
+	}
+	if want := map[string]string{path: string(mutant.Source)}; !reflect.DeepEqual(replaced, want) {
+		t.Errorf("the overlay replaces %q, want %q", replaced, want)
+	}
+	if _, err := os.Stat(overlayPath); !os.IsNotExist(err) {
+		t.Errorf("the overlay %s still exists after Run", overlayPath)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Run wrote %s, the mutant must only be in the overlay", path)
+	}
+}
+
+//! GoTest for real : the classification of real 'go test' output
+func TestGoTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs 'go test', skipped with -short")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not in PATH")
+	}
+
+	source := "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n"
+	tests := []struct {
+		name   string
+		mutant string
+		test   string
+		want   Outcome
+	}{
+		{"tests pass", source, "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n", Survived},
+		{"tests fail", source, "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(2, 3) != 6 {\n\t\tt.Error(\"Add\")\n\t}\n}\n", Killed},
+		{"build failed", "package calc\n\nfunc Add(a, b int) int {\n\treturn a +\n}\n", "package calc\n", BuildFailed},
+		//! the tests import another package of the same module : they only build because the mutant is tested in the real module
+		{"tests use the module", "package calc\n\nfunc Add(a, b int) int {\n\treturn a - b\n}\n", "package calc\n\nimport (\n\t\"testing\"\n\n\t\"example/want\"\n)\n\nfunc TestAdd(t *testing.T) {\n\twant.Equal(t, Add(2, 3), 5)\n}\n", Killed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"go.mod":            "module example\n\ngo 1.21\n",
+				"calc/calc.go":      source,
+				"calc/calc_test.go": tt.test,
+				"want/want.go":      "package want\n\nimport \"testing\"\n\nfunc Equal(t *testing.T, got, want int) {\n\tif got != want {\n\t\tt.Errorf(\"got %d, want %d\", got, want)\n\t}\n}\n",
+			}
+			for name, content := range files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path := filepath.Join(dir, "calc", "calc.go")
+			m := mutator.Mutant{ID: 1, Source: []byte(tt.mutant)}
+			result, err := Runner{Timeout: time.Minute, Test: GoTest}.Run(m, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Outcome != tt.want {
+				t.Errorf("Outcome = %q, want %q\noutput :\n%s", result.Outcome, tt.want, result.Output)
+			}
+			if content, _ := os.ReadFile(path); string(content) != source {
+				t.Errorf("calc.go on disk changed to %q", content)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+//! Sorting with the 'sort' package : the ready-made functions for ints and strings, sort.Slice with a closure for anything else, and sort.Interface
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! ByName -> a new type for []Person, with the 3 methods of sort.Interface : Len, Less, Swap
+type ByName []Person
+
+func (people ByName) Len() int           { return len(people) }
+func (people ByName) Less(i, j int) bool { return people[i].Name < people[j].Name } //! should element i come before element j?
+func (people ByName) Swap(i, j int)      { people[i], people[j] = people[j], people[i] }
+
+func printPeople(people []Person) {
+	for _, person := range people {
+		fmt.Printf("  %-6s %d\n", person.Name, person.Age)
+	}
+}
+
+//! keepsOrder -> true when people with the same age are still in name order (the order they were created in)
+func keepsOrder(people []Person) bool {
+	for i := 1; i < len(people); i++ {
+		if people[i].Age == people[i-1].Age && people[i].Name < people[i-1].Name {
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	//! 1. ints and strings. They are sorted IN PLACE : the slice itself changes, nothing is returned
+	numbers := []int{42, 7, 19, 3, 25}
+	fmt.Println("before sort.Ints    :", numbers)
+	sort.Ints(numbers)
+	fmt.Println("after  sort.Ints    :", numbers)
+
+	names := []string{"John", "alice", "Jane", "Bob"}
+	fmt.Println("before sort.Strings :", names)
+	sort.Strings(names)
+	fmt.Println("after  sort.Strings :", names) //! [Bob Jane John alice] -> byte order, so every upper case letter comes before every lower case letter
+
+	fmt.Println("--------------------------------")
+
+	//! 2. binary search on a sorted slice : halves the search space every step, so it's very fast. The slice MUST be sorted
+	index := sort.SearchInts(numbers, 19)
+	fmt.Println("sort.SearchInts(numbers, 19) :", index, "->", numbers[index])
+
+	//! if the number is not there, SearchInts returns the index where it WOULD be inserted. So always check the value
+	index = sort.SearchInts(numbers, 20)
+	found := index < len(numbers) && numbers[index] == 20
+	fmt.Println("sort.SearchInts(numbers, 20) :", index, "found :", found)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. sort.Slice with a closure : the function 'less' uses the 'people' variable from outside -> a closure, like in the closure lesson
+	people := []Person{
+		{Name: "John", Age: 30},
+		{Name: "Jane", Age: 25},
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 25},
+		{Name: "Carol", Age: 28},
+	}
+	fmt.Println("before :")
+	printPeople(people)
+
+	byAge := append([]Person(nil), people...) //! a copy, so 'people' keeps the original order for the next examples
+	sort.Slice(byAge, func(i, j int) bool {
+		return byAge[i].Age < byAge[j].Age
+	})
+	fmt.Println("sort.Slice by Age :")
+	printPeople(byAge)
+
+	//! sort.SliceStable -> people with the same age keep their ORIGINAL order : Jane before Bob, John before Alice
+	stable := append([]Person(nil), people...)
+	sort.SliceStable(stable, func(i, j int) bool {
+		return stable[i].Age < stable[j].Age
+	})
+	fmt.Println("sort.SliceStable by Age (same age -> original order) :")
+	printPeople(stable)
+
+	//! with 5 people both look the same : for very small slices sort.Slice uses insertion sort, which happens to be stable
+	//! with more people the difference shows. P01, P02 ... are created in order, so inside one age group the names should still be in order
+	var many []Person
+	for i := 1; i <= 30; i++ {
+		many = append(many, Person{Name: fmt.Sprintf("P%02d", i), Age: 20 + i%3})
+	}
+	unstable := append([]Person(nil), many...)
+	sort.Slice(unstable, func(i, j int) bool { return unstable[i].Age < unstable[j].Age })
+	stableMany := append([]Person(nil), many...)
+	sort.SliceStable(stableMany, func(i, j int) bool { return stableMany[i].Age < stableMany[j].Age })
+	fmt.Println("30 people, original order kept inside each age : sort.Slice", keepsOrder(unstable), "| sort.SliceStable", keepsOrder(stableMany))
+
+	fmt.Println("--------------------------------")
+
+	//! 4. sort.Interface : sort.Sort works with any type which has Len, Less and Swap
+	byName := append([]Person(nil), people...)
+	sort.Sort(ByName(byName)) //! ByName(byName) -> a conversion, the same slice seen as the ByName type
+	fmt.Println("sort.Sort(ByName) :")
+	printPeople(byName)
+
+	sort.Sort(sort.Reverse(ByName(byName))) //! sort.Reverse flips Less, so the order is the opposite
+	fmt.Println("sort.Sort(sort.Reverse(ByName)) :")
+	printPeople(byName)
+
+	fmt.Println("sorted by name        :", sort.IsSorted(ByName(byName)))               //! false, it's reversed now
+	fmt.Println("sorted by name, desc  :", sort.IsSorted(sort.Reverse(ByName(byName)))) //! true
+}
+
+/*
+	sort.Slice       -> the quickest to write, the order of equal elements is not kept
+	sort.SliceStable -> equal elements keep their original order
+	sort.Interface   -> a named type with Len / Less / Swap, useful when the same order is needed in many places
+	(since Go 1.21, the 'slices' package has slices.Sort and slices.SortFunc too)
+*/
@@ -0,0 +1,72 @@
+//! JSON (JavaScript Object Notation) -> the most common text format to send data between programs, for example between a web server and a browser
+//! encoding/json : Marshal turns a Go value into JSON, Unmarshal turns JSON back into a Go value
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//! struct tags -> the text in backticks after a field. encoding/json reads the 'json' key of the tag :
+//!   json:"name"            -> use "name" in JSON instead of "Name"
+//!   json:"age,omitempty"   -> leave the field out when it has its zero value (0, "", nil ...)
+type Person struct {
+	Name     string `json:"name"`
+	Age      int    `json:"age,omitempty"`
+	Email    string `json:"email,omitempty"`
+	password string //! unexported (starts with a lower case letter) -> encoding/json can't see it, it's skipped SILENTLY
+}
+
+func main() {
+	//! 1. one Person
+	john := Person{Name: "John", Age: 30, Email: "john@example.com", password: "secret"}
+	data, err := json.Marshal(john) //! []byte
+	fmt.Println("json.Marshal :", string(data), err)
+	//! {"name":"John","age":30,"email":"john@example.com"} -> no password
+
+	//! omitempty : Age 0 and an empty Email are left out
+	baby := Person{Name: "Baby"}
+	data, _ = json.Marshal(baby)
+	fmt.Println("omitempty    :", string(data)) //! {"name":"Baby"}
+
+	fmt.Println("--------------------------------")
+
+	//! 2. a slice of Persons, with MarshalIndent -> readable output. "" is the prefix of every line, "  " is the indentation
+	people := []Person{
+		{Name: "John", Age: 30, Email: "john@example.com"},
+		{Name: "Jane", Age: 25},
+	}
+	data, _ = json.MarshalIndent(people, "", "  ")
+	fmt.Println(string(data))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. Unmarshal : JSON -> Go. We pass a POINTER, so Unmarshal can fill the variable
+	var jane Person
+	err = json.Unmarshal([]byte(`{"name":"Jane","age":25,"email":"jane@example.com"}`), &jane)
+	fmt.Printf("Unmarshal          : %+v %v\n", jane, err)
+
+	//! a missing field is NOT an error : the field just keeps its zero value
+	var noEmail Person
+	err = json.Unmarshal([]byte(`{"name":"Bob","age":40}`), &noEmail)
+	fmt.Printf("missing field      : %+v error : %v\n", noEmail, err)
+
+	//! an unknown field is not an error either, it's ignored
+	var extra Person
+	err = json.Unmarshal([]byte(`{"name":"Carol","phone":"+880 1711"}`), &extra)
+	fmt.Printf("unknown field      : %+v error : %v\n", extra, err)
+
+	//! a wrong type IS an error : "age" must be a number
+	var wrongType Person
+	err = json.Unmarshal([]byte(`{"name":"Dave","age":"forty"}`), &wrongType)
+	fmt.Printf("wrong type         : error : %v\n", err)
+
+	//! broken JSON is an error too
+	err = json.Unmarshal([]byte(`{"name":"Eve",}`), &wrongType)
+	fmt.Printf("broken JSON        : error : %v\n", err)
+
+	//! the unexported field is not filled from JSON either
+	var withPassword Person
+	json.Unmarshal([]byte(`{"name":"Frank","password":"123"}`), &withPassword)
+	fmt.Printf("unexported field   : password = %q\n", withPassword.password)
+}
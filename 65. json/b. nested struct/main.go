@@ -0,0 +1,56 @@
+//! A struct inside a struct becomes an object inside an object in JSON
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type Person struct {
+	Name    string  `json:"name"`
+	Age     int     `json:"age"`
+	Email   string  `json:"email,omitempty"`
+	Address Address `json:"address"` //! a nested struct -> "address": { ... }
+}
+
+//! FlatPerson EMBEDS Address (no field name, only the type). The fields of Address are promoted : in JSON they appear at the same level as "name"
+type FlatPerson struct {
+	Name string `json:"name"`
+	Address
+}
+
+func main() {
+	john := Person{
+		Name:    "John",
+		Age:     30,
+		Email:   "john@example.com",
+		Address: Address{City: "Dhaka", Country: "Bangladesh"},
+	}
+
+	data, _ := json.MarshalIndent(john, "", "  ")
+	fmt.Println(string(data))
+
+	//! round trip : Go -> JSON -> Go gives back exactly the same value
+	var back Person
+	err := json.Unmarshal(data, &back)
+	fmt.Println("round trip equal :", reflect.DeepEqual(john, back), err)
+	fmt.Println("back.Address.City :", back.Address.City)
+
+	fmt.Println("--------------------------------")
+
+	//! embedding : the Address fields are NOT inside an "address" object, they are flat
+	jane := FlatPerson{Name: "Jane", Address: Address{City: "Chittagong", Country: "Bangladesh"}}
+	data, _ = json.MarshalIndent(jane, "", "  ")
+	fmt.Println(string(data))
+
+	var janeBack FlatPerson
+	err = json.Unmarshal(data, &janeBack)
+	fmt.Println("round trip equal :", reflect.DeepEqual(jane, janeBack), err)
+	fmt.Println("janeBack.City :", janeBack.City) //! promoted field : the same as janeBack.Address.City
+}
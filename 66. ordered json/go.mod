@@ -0,0 +1,3 @@
+module orderedjson
+
+go 1.22
@@ -0,0 +1,232 @@
+//! Package jsonorder -> JSON in the order we choose (MarshalOrdered), and one canonical form to compare two documents (CanonicalJSON)
+//! other lessons import it to compare JSON answers in their tests, without caring about key order or spaces
+package jsonorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//! orderedMap -> a map which remembers the order its keys were added in. A Go map doesn't, and encoding/json writes map keys sorted
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]any{}}
+}
+
+func (m *orderedMap) Set(key string, value any) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+//! MarshalJSON -> encoding/json calls this method for any type which has it (the json.Marshaler interface). We write the keys in OUR order
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+//! MarshalOrdered -> marshals a struct with the fields of fieldOrder first, in that order, then the other fields in declaration order
+//! the names in fieldOrder are the JSON names (from the tags). The tags are respected : "-" hides a field, omitempty drops zero values
+func MarshalOrdered(v any, fieldOrder []string) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("MarshalOrdered: expected a struct, got %s", value.Kind())
+	}
+
+	fields := newOrderedMap() //! JSON name -> value, in declaration order
+	declared := map[string]bool{}
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		declared[name] = true
+		if strings.Contains(options, "omitempty") && value.Field(i).IsZero() {
+			continue
+		}
+		fields.Set(name, value.Field(i).Interface())
+	}
+
+	result := newOrderedMap()
+	for _, name := range fieldOrder {
+		fieldValue, ok := fields.values[name]
+		if !ok {
+			if !declared[name] {
+				return nil, fmt.Errorf("MarshalOrdered: %s has no field %q", structType.Name(), name)
+			}
+			continue //! declared, but left out because of omitempty
+		}
+		result.Set(name, fieldValue)
+	}
+	for _, name := range fields.keys {
+		result.Set(name, fields.values[name]) //! Set keeps the position of keys which are already there
+	}
+	return json.Marshal(result)
+}
+
+//! CanonicalJSON -> the same document always gives the same bytes : object keys sorted, no spaces or new lines, arrays in their original order, numbers in one spelling
+//! two documents which mean the same thing are equal after CanonicalJSON, so they can be compared with bytes.Equal
+//! duplicate keys are an error : json.Unmarshal would silently keep the last one, and the two "same" documents might not mean the same thing
+func CanonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() //! numbers arrive as their exact text, 12345678901234567890 doesn't lose digits in a float64. canonicalNumber then picks one spelling
+
+	var buffer bytes.Buffer
+	if err := canonicalValue(decoder, &buffer); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err == nil {
+		return nil, fmt.Errorf("CanonicalJSON: extra data after the document")
+	}
+	return buffer.Bytes(), nil
+}
+
+//! canonicalValue reads ONE value (with everything inside it) from the decoder and writes its canonical form
+func canonicalValue(decoder *json.Decoder, buffer *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '[' {
+			buffer.WriteByte('[')
+			for i := 0; decoder.More(); i++ {
+				if i > 0 {
+					buffer.WriteByte(',')
+				}
+				if err := canonicalValue(decoder, buffer); err != nil {
+					return err
+				}
+			}
+			decoder.Token() //! ']'
+			buffer.WriteByte(']')
+			return nil
+		}
+
+		//! an object : read every key and its canonical value, then write them sorted
+		members := map[string][]byte{}
+		var keys []string
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key := keyToken.(string)
+			if _, duplicate := members[key]; duplicate {
+				return fmt.Errorf("CanonicalJSON: duplicate key %q", key)
+			}
+			var member bytes.Buffer
+			if err := canonicalValue(decoder, &member); err != nil {
+				return err
+			}
+			members[key] = member.Bytes()
+			keys = append(keys, key)
+		}
+		decoder.Token() //! '}'
+
+		sort.Strings(keys)
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			name, _ := json.Marshal(key)
+			buffer.Write(name)
+			buffer.WriteByte(':')
+			buffer.Write(members[key])
+		}
+		buffer.WriteByte('}')
+		return nil
+
+	case json.Number:
+		number, err := canonicalNumber(t)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(number)
+	default: //! string, bool, nil
+		encoded, _ := json.Marshal(t)
+		buffer.Write(encoded)
+	}
+	return nil
+}
+
+//! the largest exponent canonicalNumber accepts. 1e999999999 is valid JSON, but turning it into digits would take gigabytes
+const maxExponent = 1000
+
+//! canonicalNumber -> one spelling for every number : 1, 1.0, 1e0 and 10e-1 all become "1", 0.50 and 5e-1 become "0.5", -0 becomes "0"
+//! big.Rat holds the number exactly (a fraction of two big integers), so nothing is rounded like in a float64
+func canonicalNumber(number json.Number) (string, error) {
+	text := number.String()
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exponent, err := strconv.Atoi(text[i+1:])
+		if err != nil || exponent > maxExponent || exponent < -maxExponent {
+			return "", fmt.Errorf("CanonicalJSON: number %s is out of range", text)
+		}
+	}
+
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return "", fmt.Errorf("CanonicalJSON: invalid number %s", text)
+	}
+	if value.IsInt() {
+		return value.Num().String(), nil
+	}
+
+	//! a JSON number is a decimal, so the denominator is 2^a * 5^b, and max(a, b) digits after the point are exact, with no trailing zero
+	denominator := new(big.Int).Set(value.Denom())
+	twos, fives := 0, 0
+	for denominator.Bit(0) == 0 {
+		denominator.Rsh(denominator, 1)
+		twos++
+	}
+	five, quotient, remainder := big.NewInt(5), new(big.Int), new(big.Int)
+	for {
+		quotient.QuoRem(denominator, five, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		denominator.Set(quotient)
+		fives++
+	}
+	return value.FloatString(max(twos, fives)), nil
+}
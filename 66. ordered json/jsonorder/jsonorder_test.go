@@ -0,0 +1,196 @@
+package jsonorder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSONKeyOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"keys sorted", `{"b":1,"a":2,"c":3}`, `{"a":2,"b":1,"c":3}`},
+		{"spaces and new lines removed", "{ \"b\" : 1 ,\n\t\"a\" : 2 }", `{"a":2,"b":1}`},
+		{"byte order, upper case first", `{"b":1,"B":2,"a":3}`, `{"B":2,"a":3,"b":1}`},
+		{"empty object", `{}`, `{}`},
+		{"escaped key is decoded first", `{"\u0062":1,"a":2}`, `{"a":2,"b":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON([]byte(tt.in))
+			if err != nil || string(got) != tt.want {
+				t.Errorf("CanonicalJSON(%s) = %s, %v; want %s, nil", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONNesting(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"nested objects are sorted too", `{"z":{"y":1,"x":{"w":2,"v":3}},"a":0}`, `{"a":0,"z":{"x":{"v":3,"w":2},"y":1}}`},
+		{"arrays keep their order", `{"tags":["b","a"]}`, `{"tags":["b","a"]}`},
+		{"objects inside arrays", `[{"b":1,"a":2},{"d":3,"c":4}]`, `[{"a":2,"b":1},{"c":4,"d":3}]`},
+		{"arrays inside arrays", `[[3,2],[],[1]]`, `[[3,2],[],[1]]`},
+		{"strings, bools and null", `{"s":"x\"y","t":true,"f":false,"n":null}`, `{"f":false,"n":null,"s":"x\"y","t":true}`},
+		{"a bare value", `"hello"`, `"hello"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON([]byte(tt.in))
+			if err != nil || string(got) != tt.want {
+				t.Errorf("CanonicalJSON(%s) = %s, %v; want %s, nil", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONNumbers(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1", "1"},
+		{"1.0", "1"},
+		{"1e0", "1"},
+		{"1E+0", "1"},
+		{"10e-1", "1"},
+		{"100", "100"},
+		{"1e2", "100"},
+		{"0.5", "0.5"},
+		{"0.50", "0.5"},
+		{"5e-1", "0.5"},
+		{"-0", "0"},
+		{"-0.0", "0"},
+		{"-2.50", "-2.5"},
+		{"0.1", "0.1"},
+		{"1.25e1", "12.5"},
+		{"12345678901234567890", "12345678901234567890"}, //! more digits than a float64 holds, nothing is lost
+		{"0.30000000000000000001", "0.30000000000000000001"},
+		{"1e-5", "0.00001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := CanonicalJSON([]byte(tt.in))
+			if err != nil || string(got) != tt.want {
+				t.Errorf("CanonicalJSON(%s) = %s, %v; want %s, nil", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+//! documents which mean the same thing must give the same bytes, whatever the key order, spacing or number spelling
+func TestCanonicalJSONEqualDocuments(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     string
+		second    string
+		wantEqual bool
+	}{
+		{"key order and spaces", `{"name": "John", "id": 7}`, `{"id":7,"name":"John"}`, true},
+		{"number spelling", `{"price":1,"ratio":0.5}`, `{"ratio":5e-1,"price":1.0}`, true},
+		{"nested number spelling", `{"a":[{"x":1e0}]}`, `{"a":[{"x":1.00}]}`, true},
+		{"array order", `{"tags":["a","b"]}`, `{"tags":["b","a"]}`, false},
+		{"different numbers", `{"id":1}`, `{"id":1.5}`, false},
+		{"number and string", `{"id":1}`, `{"id":"1"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := CanonicalJSON([]byte(tt.first))
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := CanonicalJSON([]byte(tt.second))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bytes.Equal(first, second); got != tt.wantEqual {
+				t.Errorf("equal = %v, want %v (%s vs %s)", got, tt.wantEqual, first, second)
+			}
+
+			//! canonical(canonical(x)) == canonical(x)
+			again, err := CanonicalJSON(first)
+			if err != nil || !bytes.Equal(again, first) {
+				t.Errorf("CanonicalJSON is not stable : %s -> %s, %v", first, again, err)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{"duplicate key", `{"name":"John","name":"Mallory"}`, `duplicate key "name"`},
+		{"duplicate key in a nested object", `{"a":{"b":1,"b":2}}`, `duplicate key "b"`},
+		{"two documents", `{"a":1} {"b":2}`, "extra data after the document"},
+		{"huge exponent", `1e999999999`, "out of range"},
+		{"huge negative exponent", `{"x":1e-999999999}`, "out of range"},
+		{"not JSON", `{"a":`, "EOF"},
+		{"empty", ``, "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON([]byte(tt.in))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CanonicalJSON(%s) = %s, %v; want an error containing %q", tt.in, got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+//! the Person of main.go : fields in a different order than the one we want, omitempty and a hidden field
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type Person struct {
+	Email    string   `json:"email"`
+	Age      int      `json:"age,omitempty"`
+	Name     string   `json:"name"`
+	Address  Address  `json:"address"`
+	Tags     []string `json:"tags"`
+	ID       int      `json:"id"`
+	Password string   `json:"-"`
+}
+
+func TestMarshalOrdered(t *testing.T) {
+	john := Person{Email: "john@example.com", Age: 30, Name: "John", Address: Address{City: "Dhaka", Country: "Bangladesh"}, Tags: []string{"b", "a"}, ID: 7, Password: "secret"}
+	tests := []struct {
+		name       string
+		value      any
+		fieldOrder []string
+		want       string
+		wantErr    string
+	}{
+		{"id, name first", john, []string{"id", "name"}, `{"id":7,"name":"John","email":"john@example.com","age":30,"address":{"city":"Dhaka","country":"Bangladesh"},"tags":["b","a"]}`, ""},
+		{"no order : declaration order", john, nil, `{"email":"john@example.com","age":30,"name":"John","address":{"city":"Dhaka","country":"Bangladesh"},"tags":["b","a"],"id":7}`, ""},
+		{"pointer, omitempty field skipped", &Person{Name: "Baby", ID: 8}, []string{"id", "age", "name"}, `{"id":8,"name":"Baby","email":"","address":{"city":"","country":""},"tags":null}`, ""},
+		{"unknown field", john, []string{"phone"}, "", `Person has no field "phone"`},
+		{"hidden field can't be ordered", john, []string{"Password"}, "", `Person has no field "Password"`},
+		{"not a struct", []int{1, 2}, nil, "", "expected a struct, got slice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalOrdered(tt.value, tt.fieldOrder)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("MarshalOrdered = %s, %v; want an error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || string(got) != tt.want {
+				t.Errorf("MarshalOrdered =\n%s, %v\nwant\n%s", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+//! Ordered JSON -> encoding/json writes struct fields in the order they are DECLARED, and map keys SORTED. Neither is always the order we want
+//! for an API response we may want a documented order ("id" first, "links" last ...), and to compare two JSON documents we need one canonical form
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"orderedjson/jsonorder"
+)
+
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type Person struct {
+	Email    string   `json:"email"`
+	Age      int      `json:"age,omitempty"`
+	Name     string   `json:"name"`
+	Address  Address  `json:"address"`
+	Tags     []string `json:"tags"`
+	ID       int      `json:"id"`
+	Password string   `json:"-"` //! "-" -> never in JSON
+}
+
+func main() {
+	john := Person{Email: "john@example.com", Age: 30, Name: "John", Address: Address{City: "Dhaka", Country: "Bangladesh"}, Tags: []string{"b", "a"}, ID: 7, Password: "secret"}
+
+	//! 1. what encoding/json does by itself
+	structJSON, _ := json.Marshal(john)
+	fmt.Println("struct -> declaration order :", string(structJSON))
+
+	mapJSON, _ := json.Marshal(map[string]any{"name": "John", "id": 7, "email": "john@example.com"})
+	fmt.Println("map    -> sorted keys       :", string(mapJSON))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. MarshalOrdered : "id" and "name" first, the rest in declaration order. Nested objects and arrays are marshalled normally
+	ordered, err := jsonorder.MarshalOrdered(john, []string{"id", "name"})
+	fmt.Println("id, name first :", string(ordered), err)
+
+	baby := Person{Name: "Baby", ID: 8}
+	ordered, err = jsonorder.MarshalOrdered(&baby, []string{"id", "age", "name"}) //! "age" is omitted (omitempty), so it's just skipped
+	fmt.Println("omitempty      :", string(ordered), err)
+
+	_, err = jsonorder.MarshalOrdered(john, []string{"id", "phone"})
+	fmt.Println("unknown field  :", err)
+
+	_, err = jsonorder.MarshalOrdered([]int{1, 2}, nil)
+	fmt.Println("not a struct   :", err)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. CanonicalJSON : two documents which mean the same thing -> the same bytes
+	first := []byte(`{"name": "John", "address": {"country": "Bangladesh", "city": "Dhaka"}, "tags": ["b", "a"], "id": 7}`)
+	second := []byte(`{
+		"id": 7,
+		"tags": ["b","a"],
+		"address": {"city":"Dhaka","country":"Bangladesh"},
+		"name": "John"
+	}`)
+	canonicalFirst, _ := jsonorder.CanonicalJSON(first)
+	canonicalSecond, _ := jsonorder.CanonicalJSON(second)
+	fmt.Println("canonical :", string(canonicalFirst))
+	fmt.Println("bytes.Equal(first, second)                     :", bytes.Equal(first, second))
+	fmt.Println("bytes.Equal(canonical first, canonical second) :", bytes.Equal(canonicalFirst, canonicalSecond))
+
+	//! arrays keep their order : ["a","b"] and ["b","a"] are different lists
+	reordered, _ := jsonorder.CanonicalJSON([]byte(`{"tags":["a","b"]}`))
+	original, _ := jsonorder.CanonicalJSON([]byte(`{"tags":["b","a"]}`))
+	fmt.Println("array order matters                            :", !bytes.Equal(reordered, original))
+
+	//! round trip : canonical(canonical(x)) == canonical(x), and our ordered output means the same as the plain struct output
+	again, _ := jsonorder.CanonicalJSON(canonicalFirst)
+	fmt.Println("canonical is stable                            :", bytes.Equal(again, canonicalFirst))
+	orderedJohn, _ := jsonorder.MarshalOrdered(john, []string{"id", "name"})
+	a, _ := jsonorder.CanonicalJSON(orderedJohn)
+	b, _ := jsonorder.CanonicalJSON(structJSON)
+	fmt.Println("ordered == plain, after canonicalization       :", bytes.Equal(a, b))
+
+	//! duplicate keys are rejected : json.Unmarshal would silently keep the LAST value
+	var person Person
+	json.Unmarshal([]byte(`{"name":"John","name":"Mallory"}`), &person)
+	fmt.Println("json.Unmarshal with a duplicate key            :", person.Name)
+	_, err = jsonorder.CanonicalJSON([]byte(`{"name":"John","name":"Mallory"}`))
+	fmt.Println("CanonicalJSON with a duplicate key             :", err)
+	_, err = jsonorder.CanonicalJSON([]byte(`{"a":{"b":1,"b":2}}`))
+	fmt.Println("duplicate key in a nested object               :", err)
+	_, err = jsonorder.CanonicalJSON([]byte(`{"a":1} {"b":2}`))
+	fmt.Println("two documents                                  :", err)
+}
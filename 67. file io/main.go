@@ -0,0 +1,112 @@
+//! File I/O (input / output) -> writing a file, reading it back, adding lines to the end, and removing it
+//! every step can fail (no permission, disk full, the file doesn't exist ...), so every error is checked
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! "John,20,john@example.com\n" -> one line per person
+func toLines(people []Person) string {
+	var builder strings.Builder
+	for _, person := range people {
+		fmt.Fprintf(&builder, "%s,%d,%s\n", person.Name, person.Age, person.Email)
+	}
+	return builder.String()
+}
+
+//! appendLine -> opens the file for adding at the end. defer file.Close() makes sure it's closed on EVERY return path, like in the defer lesson
+func appendLine(path, line string) error {
+	//! O_APPEND -> every write goes to the END of the file
+	//! O_CREATE -> create the file if it doesn't exist
+	//! O_WRONLY -> open only for writing
+	//! 0o644    -> the permission of a NEW file : the owner can read and write, everybody else can only read
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("append to %s: %w", path, err)
+	}
+	return nil
+}
+
+func run() error {
+	//! a temporary directory (like /tmp/file-io-123456), so the example doesn't leave files in the repo
+	directory, err := os.MkdirTemp("", "file-io")
+	if err != nil {
+		return fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(directory) //! runs at the end of run(), even if a step below fails
+
+	path := filepath.Join(directory, "people.txt") //! filepath.Join uses the right separator : / on Linux and macOS, \ on Windows
+
+	//! 1. write : os.WriteFile creates the file (or empties it if it exists) and writes everything at once
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 21, Email: "jane@example.com"},
+	}
+	if err := os.WriteFile(path, []byte(toLines(people)), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	fmt.Println("written :", path)
+
+	//! 2. read : os.ReadFile reads the whole file into memory. Fine for small files
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	fmt.Print("read back :\n", string(data))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. append
+	if err := appendLine(path, "Alice,28,alice@example.com"); err != nil {
+		return err
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	fmt.Print("after append :\n", string(data))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+	fmt.Println("size :", info.Size(), "bytes, permissions :", info.Mode())
+
+	fmt.Println("--------------------------------")
+
+	//! 4. remove
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove %s: %w", path, err)
+	}
+	fmt.Println("removed :", filepath.Base(path))
+
+	//! reading a file which doesn't exist -> an error we can check with errors.Is, like in the error wrapping lesson
+	_, err = os.ReadFile(path)
+	fmt.Println("read after remove, file doesn't exist :", errors.Is(err, os.ErrNotExist))
+
+	return nil
+}
+
+func main() {
+	//! all the work is in run(), which returns an error. main only prints it. This keeps the error handling in one place, and the deferred cleanup in run() still runs
+	if err := run(); err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1) //! os.Exit doesn't run deferred functions, that's why the defers are in run() and not in main()
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+//! Monitor -> every worker calls Beat("I'm alive") regularly. The supervisor calls Check regularly : a worker which has been silent for 'threshold' or longer is STALLED
+type Monitor struct {
+	mutex     sync.Mutex
+	lastBeat  map[int]time.Time
+	stalled   map[int]bool
+	threshold time.Duration
+	onStall   func(workerID int, silentFor time.Duration)
+	restart   func(workerID int) //! optional, nil -> only report
+	now       func() time.Time   //! time.Now by default. Replaced by a fake clock to check the timing exactly
+}
+
+func NewMonitor(threshold time.Duration, onStall func(workerID int, silentFor time.Duration), restart func(workerID int)) *Monitor {
+	return &Monitor{
+		lastBeat:  map[int]time.Time{},
+		stalled:   map[int]bool{},
+		threshold: threshold,
+		onStall:   onStall,
+		restart:   restart,
+		now:       time.Now,
+	}
+}
+
+//! Beat records that the worker is alive. A stalled worker which beats again has RECOVERED, so its stall state is cleared
+func (m *Monitor) Beat(workerID int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastBeat[workerID] = m.now()
+	delete(m.stalled, workerID)
+}
+
+//! Check is one round of the supervisor. It returns the workers which became stalled in this round
+//! a stalled worker is reported only ONCE, not in every round, until it beats again
+func (m *Monitor) Check() []int {
+	m.mutex.Lock()
+	now := m.now()
+	var newlyStalled []int
+	silence := map[int]time.Duration{}
+	for workerID, last := range m.lastBeat {
+		silentFor := now.Sub(last)
+		if silentFor >= m.threshold && !m.stalled[workerID] {
+			m.stalled[workerID] = true
+			newlyStalled = append(newlyStalled, workerID)
+			silence[workerID] = silentFor
+		}
+	}
+	m.mutex.Unlock() //! the callbacks run WITHOUT the lock : a restarted worker calls Beat right away, which needs the lock
+
+	sort.Ints(newlyStalled) //! map order is random, sorting makes the reports the same every time
+	for _, workerID := range newlyStalled {
+		if m.onStall != nil {
+			m.onStall(workerID, silence[workerID])
+		}
+		if m.restart != nil {
+			m.restart(workerID)
+		}
+	}
+	return newlyStalled
+}
+
+//! IsStalled -> is the worker stalled right now?
+func (m *Monitor) IsStalled(workerID int) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.stalled[workerID]
+}
+
+//! Run is the supervisor goroutine : Check every interval, until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+//! fakeClock -> time only moves when we call Advance
+type fakeClock struct {
+	mutex   sync.Mutex
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.current = c.current.Add(d)
+}
+
+//! recording -> a monitor with a fake clock, which remembers every report and every restart
+type recording struct {
+	*Monitor
+	clock    *fakeClock
+	mutex    sync.Mutex
+	reports  []int
+	silences []time.Duration
+	restarts []int
+}
+
+func newRecording(threshold time.Duration, withRestart bool) *recording {
+	r := &recording{clock: &fakeClock{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	var restart func(int)
+	if withRestart {
+		restart = func(workerID int) {
+			r.mutex.Lock()
+			r.restarts = append(r.restarts, workerID)
+			r.mutex.Unlock()
+			r.Beat(workerID) //! like a real restart : the new worker beats right away. This needs the lock, so Check must not hold it
+		}
+	}
+	r.Monitor = NewMonitor(threshold, func(workerID int, silentFor time.Duration) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.reports = append(r.reports, workerID)
+		r.silences = append(r.silences, silentFor)
+	}, restart)
+	r.now = r.clock.Now
+	return r
+}
+
+func (r *recording) Reports() []int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]int(nil), r.reports...)
+}
+
+//! silent >= threshold is stalled : exactly at the threshold already counts, 1ms before doesn't
+func TestStallAtThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		silence time.Duration
+		want    []int
+	}{
+		{"no time passed", 0, nil},
+		{"1ms before the threshold", 10*time.Second - time.Millisecond, nil},
+		{"1ns before the threshold", 10*time.Second - time.Nanosecond, nil},
+		{"exactly the threshold", 10 * time.Second, []int{1}},
+		{"after the threshold", time.Minute, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRecording(10*time.Second, false)
+			r.Beat(1)
+			r.clock.Advance(tt.silence)
+			if got := r.Check(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check after %v = %v, want %v", tt.silence, got, tt.want)
+			}
+			if !reflect.DeepEqual(r.Reports(), tt.want) {
+				t.Errorf("onStall reports = %v, want %v", r.Reports(), tt.want)
+			}
+			if got := r.IsStalled(1); got != (tt.want != nil) {
+				t.Errorf("IsStalled(1) = %v, want %v", got, tt.want != nil)
+			}
+		})
+	}
+}
+
+//! onStall gets how long the worker was really silent, not the threshold
+func TestStallReportsSilence(t *testing.T) {
+	r := newRecording(10*time.Second, false)
+	r.Beat(1)
+	r.clock.Advance(12 * time.Second)
+	r.Check()
+	if !reflect.DeepEqual(r.silences, []time.Duration{12 * time.Second}) {
+		t.Errorf("silences = %v, want [12s]", r.silences)
+	}
+}
+
+//! a worker which beats more often than the threshold is never reported, however long the program runs
+func TestHealthyWorkersNotReported(t *testing.T) {
+	r := newRecording(10*time.Second, false)
+	r.Beat(1)
+	r.Beat(2)
+	for round := 0; round < 100; round++ {
+		r.clock.Advance(5 * time.Second)
+		r.Beat(1)
+		if round%2 == 1 {
+			r.Beat(2) //! every 10s, right before the check : exactly at the threshold, but the beat comes first
+		}
+		if round == 0 {
+			continue
+		}
+		if got := r.Check(); got != nil {
+			t.Fatalf("round %d : Check = %v, want nothing", round, got)
+		}
+	}
+	if r.IsStalled(1) || r.IsStalled(2) {
+		t.Errorf("IsStalled = %v, %v; want false, false", r.IsStalled(1), r.IsStalled(2))
+	}
+}
+
+func TestUnknownWorkerNotStalled(t *testing.T) {
+	r := newRecording(time.Second, false)
+	r.clock.Advance(time.Hour)
+	if got := r.Check(); got != nil || r.IsStalled(7) {
+		t.Errorf("Check = %v, IsStalled(7) = %v; want nothing for a worker which never beat", got, r.IsStalled(7))
+	}
+}
+
+//! a stall is reported ONCE, not in every round, and the reports are sorted by worker
+func TestStallReportedOnce(t *testing.T) {
+	r := newRecording(10*time.Second, false)
+	for _, id := range []int{3, 1, 2} {
+		r.Beat(id)
+	}
+	r.clock.Advance(10 * time.Second)
+	if got := r.Check(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("first Check = %v, want [1 2 3]", got)
+	}
+	for round := 0; round < 3; round++ {
+		r.clock.Advance(time.Second)
+		if got := r.Check(); got != nil {
+			t.Errorf("Check in round %d = %v, want nothing (already reported)", round, got)
+		}
+	}
+	if !reflect.DeepEqual(r.Reports(), []int{1, 2, 3}) {
+		t.Errorf("reports = %v, want [1 2 3]", r.Reports())
+	}
+}
+
+//! a beat clears the stall state. If the worker goes silent again, it's reported again
+func TestRecoveryClearsStall(t *testing.T) {
+	r := newRecording(10*time.Second, false)
+	r.Beat(1)
+	r.clock.Advance(10 * time.Second)
+	r.Check()
+
+	r.Beat(1)
+	if r.IsStalled(1) {
+		t.Fatal("IsStalled(1) = true after a beat, want false")
+	}
+	r.clock.Advance(9 * time.Second)
+	if got := r.Check(); got != nil {
+		t.Errorf("Check 9s after recovering = %v, want nothing", got)
+	}
+	r.clock.Advance(time.Second)
+	if got := r.Check(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Check 10s after recovering = %v, want [1]", got)
+	}
+	if !reflect.DeepEqual(r.Reports(), []int{1, 1}) {
+		t.Errorf("reports = %v, want [1 1]", r.Reports())
+	}
+}
+
+//! restart is called once per stall, after onStall. The restarted worker beats inside the callback, which clears the stall state at once
+func TestRestart(t *testing.T) {
+	r := newRecording(10*time.Second, true)
+	r.Beat(1)
+	r.Beat(2)
+	r.clock.Advance(5 * time.Second)
+	r.Beat(1)
+	r.clock.Advance(5 * time.Second)
+
+	if got := r.Check(); !reflect.DeepEqual(got, []int{2}) {
+		t.Fatalf("Check = %v, want [2]", got)
+	}
+	if !reflect.DeepEqual(r.restarts, []int{2}) {
+		t.Errorf("restarts = %v, want [2]", r.restarts)
+	}
+	if r.IsStalled(2) {
+		t.Error("IsStalled(2) = true after the restart beat, want false")
+	}
+
+	//! the restarted worker starts a new silence from the restart
+	r.clock.Advance(9 * time.Second)
+	r.Beat(1)
+	if got := r.Check(); got != nil {
+		t.Errorf("Check 9s after the restart = %v, want nothing", got)
+	}
+	r.clock.Advance(time.Second)
+	if got := r.Check(); !reflect.DeepEqual(got, []int{2}) || !reflect.DeepEqual(r.restarts, []int{2, 2}) {
+		t.Errorf("Check = %v, restarts = %v; want [2], [2 2]", got, r.restarts)
+	}
+}
+
+//! Run checks every interval : a stalled worker is found without calling Check ourselves
+func TestRunChecks(t *testing.T) {
+	r := newRecording(10*time.Second, false)
+	r.Beat(1)
+	r.clock.Advance(10 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx, time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(r.Reports()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Run didn't report the stalled worker within 5s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !reflect.DeepEqual(r.Reports(), []int{1}) {
+		t.Errorf("reports = %v, want [1]", r.Reports())
+	}
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	monitor := NewMonitor(time.Second, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		monitor.Run(ctx, time.Millisecond)
+		close(stopped)
+	}()
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run still running 1s after cancel")
+	}
+}
+
+//! many goroutines beat at the same time, while the supervisor checks. Run with 'go test -race *.go'
+func TestConcurrentBeat(t *testing.T) {
+	monitor := NewMonitor(time.Hour, nil, nil)
+	var wg sync.WaitGroup
+	for id := 0; id < 20; id++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				monitor.Beat(id)
+				if i%100 == 0 {
+					monitor.Check()
+					monitor.IsStalled(id)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := monitor.Check(); got != nil {
+		t.Errorf("Check = %v, want nothing", got)
+	}
+	if len(monitor.lastBeat) != 20 {
+		t.Errorf("%d workers known, want 20", len(monitor.lastBeat))
+	}
+}
@@ -0,0 +1,81 @@
+//! Watchdog / heartbeat -> how do we know a worker goroutine is still working and not stuck forever (a deadlock, a network call without a timeout ...)?
+//! every worker sends a "heartbeat" regularly. A supervisor checks the time of the last heartbeat of each worker. Too long silent -> stalled -> report it, and maybe restart it
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//! worker -> does some work in a loop and beats after every piece. If hangAfter > 0, it gets stuck after that many pieces
+func worker(ctx context.Context, id int, monitor *Monitor, hangAfter int, log func(string, ...any)) {
+	for piece := 1; ; piece++ {
+		if hangAfter > 0 && piece > hangAfter {
+			log("worker %d is stuck", id)
+			<-ctx.Done() //! "hangs" : never beats again (only the end of the program releases it)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(50 * time.Millisecond): //! one piece of work
+		}
+		monitor.Beat(id)
+	}
+}
+
+func main() {
+	start := time.Now()
+	var logMutex sync.Mutex
+	log := func(format string, args ...any) {
+		logMutex.Lock()
+		defer logMutex.Unlock()
+		fmt.Printf("[+%5v] "+format+"\n", append([]any{time.Since(start).Round(10 * time.Millisecond)}, args...)...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	var monitor *Monitor
+	restart := func(workerID int) {
+		log("restarting worker %d", workerID)
+		monitor.Beat(workerID) //! the new worker counts as alive from now on
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker(ctx, workerID, monitor, 0, log) //! the restarted worker doesn't hang
+		}()
+	}
+	monitor = NewMonitor(300*time.Millisecond, func(workerID int, silentFor time.Duration) {
+		log("STALLED : worker %d, silent for %v", workerID, silentFor.Round(10*time.Millisecond))
+	}, restart)
+
+	//! 3 workers. Worker 2 hangs after 4 pieces of work
+	for id := 1; id <= 3; id++ {
+		hangAfter := 0
+		if id == 2 {
+			hangAfter = 4
+		}
+		monitor.Beat(id)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker(ctx, id, monitor, hangAfter, log)
+		}()
+	}
+
+	supervisorDone := make(chan struct{})
+	go func() {
+		monitor.Run(ctx, 50*time.Millisecond)
+		close(supervisorDone)
+	}()
+
+	time.Sleep(time.Second)
+	log("worker 2 stalled now : %v (it was restarted and beats again)", monitor.IsStalled(2))
+	cancel()
+	wg.Wait()
+	<-supervisorDone
+	log("all workers and the supervisor stopped")
+}
@@ -0,0 +1,120 @@
+//! bufio -> buffered input / output. Instead of reading byte by byte, it reads a big chunk once and hands it out piece by piece
+//! two tools : bufio.Reader (read until a character, like '\n') and bufio.Scanner (split the input into lines or words)
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//! readFullName -> the whole line, spaces included. fmt.Scanln(&name) would stop at the first space : "John Doe" -> "John"
+func readFullName(reader *bufio.Reader) (string, error) {
+	fmt.Print("Enter your full name: ")
+
+	line, err := reader.ReadString('\n') //! the returned line still ends with '\n'
+	//! io.EOF -> the input ended without a '\n' (or was empty, like `go run main.go < /dev/null`). What we got so far is still usable
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil //! removes the '\n' (and the '\r' of Windows)
+}
+
+//! printLines -> bufio.Scanner reads the file one line at a time, so even a huge file doesn't have to fit in memory
+func printLines(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file) //! splits by lines by default. Text() has NO '\n' at the end
+	lineNumber := 0
+	for scanner.Scan() { //! false at the end of the file OR on an error
+		lineNumber++
+		fmt.Printf("line %d : %s\n", lineNumber, scanner.Text())
+	}
+
+	//! always check Err() after the loop, to tell "the file ended" apart from "reading failed"
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan %s: %w", path, err)
+	}
+	return nil
+}
+
+//! countWords -> the same Scanner, but split by words. Spaces, tabs and newlines are all separators
+func countWords(text string) (int, map[string]int) {
+	scanner := bufio.NewScanner(strings.NewReader(text)) //! a Scanner can read from anything that is an io.Reader
+	scanner.Split(bufio.ScanWords)
+
+	total := 0
+	counts := map[string]int{}
+	for scanner.Scan() {
+		total++
+		counts[strings.ToLower(scanner.Text())]++
+	}
+	return total, counts
+}
+
+func run() error {
+	//! 1. bufio.Reader : a full line from the keyboard
+	reader := bufio.NewReader(os.Stdin)
+	name, err := readFullName(reader)
+	if err != nil {
+		return fmt.Errorf("read name: %w", err)
+	}
+	if name == "" {
+		name = "stranger"
+	}
+	fmt.Printf("Hello, %s! (%d characters)\n", name, len(name))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. bufio.Scanner : a file line by line
+	directory, err := os.MkdirTemp("", "bufio")
+	if err != nil {
+		return fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "people.txt")
+	content := "John Doe,20,john@example.com\nJane Smith,21,jane@example.com\nAlice Brown,28,alice@example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := printLines(path); err != nil {
+		return err
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 3. bufio.ScanWords : counting words in a multi-line string
+	text := `Go is simple.
+Go is fast,
+	and Go is fun.`
+
+	total, counts := countWords(text)
+	fmt.Println("total words :", total)
+	fmt.Println(`"go" :`, counts["go"], `"is" :`, counts["is"])
+	fmt.Println(`"fast," :`, counts["fast,"]) //! ScanWords splits only on spaces, so the punctuation stays with the word
+
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println("error :", err)
+		os.Exit(1)
+	}
+}
+
+/*
+	Try :
+		1. Run `echo "John Doe" | go run main.go` and compare with fmt.Scanln, which would keep only "John"
+		2. Use scanner.Split(bufio.ScanRunes) in countWords and count the characters instead
+		3. Write a line longer than 64 KB into the file. scanner.Err() returns bufio.ErrTooLong, fix it with scanner.Buffer
+*/
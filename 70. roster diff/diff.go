@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//! FieldChange -> one field of one person, before and after
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+//! PersonChange -> a person who is in both rosters, with the fields that differ (in declaration order)
+type PersonChange struct {
+	Email  string        `json:"email"`
+	Fields []FieldChange `json:"fields"`
+}
+
+//! RosterDiff -> the JSON form for the API. The slices are never nil, so they are written as [] and not null
+type RosterDiff struct {
+	Added   []Person       `json:"added"`
+	Removed []Person       `json:"removed"`
+	Changed []PersonChange `json:"changed"`
+}
+
+func (diff RosterDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+//! DuplicateEmailError -> the same email twice in one roster. We can't know which of the two people to compare, so the diff stops
+type DuplicateEmailError struct {
+	Roster string //! "old" or "new"
+	Email  string
+	First  int //! indexes in the roster
+	Second int
+}
+
+func (e *DuplicateEmailError) Error() string {
+	return fmt.Sprintf("%s roster: email %q at index %d and %d", e.Roster, e.Email, e.First, e.Second)
+}
+
+//! emailKey -> emails are matched case-insensitively : "John@Example.com" and "john@example.com" are the same person
+func emailKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+//! indexByEmail -> email key -> index in the roster
+func indexByEmail(roster string, people []Person) (map[string]int, error) {
+	index := make(map[string]int, len(people))
+	for i, person := range people {
+		key := emailKey(person.Email)
+		if first, ok := index[key]; ok {
+			return nil, &DuplicateEmailError{Roster: roster, Email: person.Email, First: first, Second: i}
+		}
+		index[key] = i
+	}
+	return index, nil
+}
+
+//! changedFields -> compares every EXPORTED field with reflection, so a new field in Person is diffed without touching this code
+//! a case-only email change ("John@..." -> "john@...") still matches the same person, and shows up here as an Email change
+func changedFields(old, new Person) []FieldChange {
+	oldValue := reflect.ValueOf(old)
+	newValue := reflect.ValueOf(new)
+	personType := oldValue.Type()
+
+	var changes []FieldChange
+	for i := 0; i < personType.NumField(); i++ {
+		field := personType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		before := oldValue.Field(i).Interface()
+		after := newValue.Field(i).Interface()
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, FieldChange{Field: field.Name, Old: before, New: after})
+		}
+	}
+	return changes
+}
+
+//! DiffRosters -> Added and Changed follow the order of the new roster, Removed the order of the old one
+func DiffRosters(old, new []Person) (RosterDiff, error) {
+	oldIndex, err := indexByEmail("old", old)
+	if err != nil {
+		return RosterDiff{}, err
+	}
+	newIndex, err := indexByEmail("new", new)
+	if err != nil {
+		return RosterDiff{}, err
+	}
+
+	diff := RosterDiff{Added: []Person{}, Removed: []Person{}, Changed: []PersonChange{}}
+	for _, person := range new {
+		i, ok := oldIndex[emailKey(person.Email)]
+		if !ok {
+			diff.Added = append(diff.Added, person)
+			continue
+		}
+		if fields := changedFields(old[i], person); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, PersonChange{Email: person.Email, Fields: fields})
+		}
+	}
+	for _, person := range old {
+		if _, ok := newIndex[emailKey(person.Email)]; !ok {
+			diff.Removed = append(diff.Removed, person)
+		}
+	}
+	return diff, nil
+}
+
+//! formatValue -> strings are quoted, so "" and " John" are visible in the report
+func formatValue(value any) string {
+	if text, ok := value.(string); ok {
+		return strconv.Quote(text)
+	}
+	return fmt.Sprint(value)
+}
+
+//! RenderText -> the human-readable report : + added, - removed, ~ changed, and a summary line at the end
+func RenderText(diff RosterDiff) string {
+	if diff.Empty() {
+		return "no changes\n"
+	}
+
+	var builder strings.Builder
+	for _, person := range diff.Added {
+		fmt.Fprintf(&builder, "+ %s <%s> (age %d)\n", person.Name, person.Email, person.Age)
+	}
+	for _, person := range diff.Removed {
+		fmt.Fprintf(&builder, "- %s <%s> (age %d)\n", person.Name, person.Email, person.Age)
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(&builder, "~ <%s>\n", change.Email)
+		for _, field := range change.Fields {
+			fmt.Fprintf(&builder, "    %s: %s -> %s\n", field.Field, formatValue(field.Old), formatValue(field.New))
+		}
+	}
+	fmt.Fprintf(&builder, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return builder.String()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	john = Person{Name: "John", Age: 20, Email: "john@example.com"}
+	jane = Person{Name: "Jane", Age: 21, Email: "jane@example.com"}
+	bob  = Person{Name: "Bob", Age: 35, Email: "bob@example.com"}
+)
+
+func TestDiffRosters(t *testing.T) {
+	roster := []Person{john, jane, bob}
+	tests := []struct {
+		name     string
+		old, new []Person
+		want     RosterDiff
+	}{
+		{"both empty", nil, nil, RosterDiff{Added: []Person{}, Removed: []Person{}, Changed: []PersonChange{}}},
+		{"empty old -> everybody is added", nil, roster, RosterDiff{Added: roster, Removed: []Person{}, Changed: []PersonChange{}}},
+		{"empty new -> everybody is removed", roster, nil, RosterDiff{Added: []Person{}, Removed: roster, Changed: []PersonChange{}}},
+		{"identical", roster, roster, RosterDiff{Added: []Person{}, Removed: []Person{}, Changed: []PersonChange{}}},
+		{"only the order changed", roster, []Person{bob, john, jane}, RosterDiff{Added: []Person{}, Removed: []Person{}, Changed: []PersonChange{}}},
+		{"age only", []Person{john}, []Person{{Name: "John", Age: 21, Email: "john@example.com"}}, RosterDiff{
+			Added: []Person{}, Removed: []Person{},
+			Changed: []PersonChange{{Email: "john@example.com", Fields: []FieldChange{{Field: "Age", Old: 20, New: 21}}}},
+		}},
+		{"fields in declaration order", []Person{john}, []Person{{Name: "John Doe", Age: 21, Email: "john@example.com"}}, RosterDiff{
+			Added: []Person{}, Removed: []Person{},
+			Changed: []PersonChange{{Email: "john@example.com", Fields: []FieldChange{{Field: "Name", Old: "John", New: "John Doe"}, {Field: "Age", Old: 20, New: 21}}}},
+		}},
+		{"emails match case-insensitively, the case change is reported", []Person{bob}, []Person{{Name: "Bob", Age: 35, Email: " BOB@example.com "}}, RosterDiff{
+			Added: []Person{}, Removed: []Person{},
+			Changed: []PersonChange{{Email: " BOB@example.com ", Fields: []FieldChange{{Field: "Email", Old: "bob@example.com", New: " BOB@example.com "}}}},
+		}},
+		{"added in new order, removed in old order", []Person{john, jane, bob}, []Person{{Name: "Zoe", Email: "zoe@example.com"}, jane, {Name: "Al", Email: "al@example.com"}}, RosterDiff{
+			Added:   []Person{{Name: "Zoe", Email: "zoe@example.com"}, {Name: "Al", Email: "al@example.com"}},
+			Removed: []Person{john, bob},
+			Changed: []PersonChange{},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffRosters(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffRosters =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffRostersDuplicateEmail(t *testing.T) {
+	twice := []Person{john, {Name: "Johnny", Age: 22, Email: "JOHN@example.com"}}
+	tests := []struct {
+		name     string
+		old, new []Person
+		want     DuplicateEmailError
+		wantText string
+	}{
+		{"in new", []Person{john}, twice, DuplicateEmailError{Roster: "new", Email: "JOHN@example.com", First: 0, Second: 1}, `new roster: email "JOHN@example.com" at index 0 and 1`},
+		{"in old", twice, []Person{john}, DuplicateEmailError{Roster: "old", Email: "JOHN@example.com", First: 0, Second: 1}, `old roster: email "JOHN@example.com" at index 0 and 1`},
+		{"old is checked first", twice, twice, DuplicateEmailError{Roster: "old", Email: "JOHN@example.com", First: 0, Second: 1}, `old roster: email "JOHN@example.com" at index 0 and 1`},
+		{"not next to each other", []Person{jane, john, bob, {Email: "jane@example.com"}}, nil, DuplicateEmailError{Roster: "old", Email: "jane@example.com", First: 0, Second: 3}, `old roster: email "jane@example.com" at index 0 and 3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := DiffRosters(tt.old, tt.new)
+			var duplicate *DuplicateEmailError
+			if !errors.As(err, &duplicate) {
+				t.Fatalf("DiffRosters error = %v, want a *DuplicateEmailError", err)
+			}
+			if *duplicate != tt.want || err.Error() != tt.wantText {
+				t.Errorf("error = %+v %q, want %+v %q", *duplicate, err.Error(), tt.want, tt.wantText)
+			}
+			if !reflect.DeepEqual(diff, RosterDiff{}) {
+				t.Errorf("diff = %+v, want the zero RosterDiff with an error", diff)
+			}
+		})
+	}
+}
+
+//! golden output : the exact text report, so a change in the format is noticed
+func TestRenderText(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []Person
+		want     string
+	}{
+		{"no changes", []Person{john}, []Person{john}, "no changes\n"},
+		{"every kind of change", []Person{john, jane, bob}, []Person{
+			{Name: "John Doe", Age: 21, Email: "john@example.com"},
+			{Name: "Bob", Age: 35, Email: "BOB@example.com"},
+			{Name: "Alice", Age: 28, Email: "alice@example.com"},
+		}, `+ Alice <alice@example.com> (age 28)
+- Jane <jane@example.com> (age 21)
+~ <john@example.com>
+    Name: "John" -> "John Doe"
+    Age: 20 -> 21
+~ <BOB@example.com>
+    Email: "bob@example.com" -> "BOB@example.com"
+1 added, 1 removed, 2 changed
+`},
+		{"strings are quoted, so spaces and empty names are visible", []Person{john}, []Person{{Name: "", Age: 20, Email: "john@example.com"}}, `~ <john@example.com>
+    Name: "John" -> ""
+0 added, 0 removed, 1 changed
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := DiffRosters(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := RenderText(diff); got != tt.want {
+				t.Errorf("RenderText =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []Person
+		want     string
+	}{
+		{"empty lists are [] and not null", nil, nil, `{"added":[],"removed":[],"changed":[]}`},
+		{"a change", []Person{john}, []Person{{Name: "John", Age: 21, Email: "john@example.com"}, jane},
+			`{"added":[{"name":"Jane","age":21,"email":"jane@example.com"}],"removed":[],"changed":[{"email":"john@example.com","fields":[{"field":"Age","old":20,"new":21}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, _ := DiffRosters(tt.old, tt.new)
+			data, err := json.Marshal(diff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("JSON = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+//! Roster diff -> what changed between two versions of a roster (the JSON list of people the person wizard writes)?
+//! people are matched by email : a new email is Added, a missing one is Removed, and the same email with other values is Changed
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+func readRoster(path string) ([]Person, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var people []Person
+	if err := json.Unmarshal(data, &people); err != nil {
+		return nil, fmt.Errorf("reading %s : %w", path, err)
+	}
+	return people, nil
+}
+
+//! runDiff -> the `diff` subcommand : go run main.go diff.go diff [-json] old.json new.json
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the diff as JSON instead of the text report")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("usage: diff [-json] old.json new.json")
+	}
+
+	old, err := readRoster(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := readRoster(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	diff, err := DiffRosters(old, new)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	fmt.Print(RenderText(diff))
+	return nil
+}
+
+func demo() {
+	old := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 21, Email: "jane@example.com"},
+		{Name: "Bob", Age: 35, Email: "bob@example.com"},
+	}
+	new := []Person{
+		{Name: "John Doe", Age: 21, Email: "john@example.com"},
+		{Name: "Bob", Age: 35, Email: "BOB@example.com"}, //! the same person : emails match case-insensitively
+		{Name: "Alice", Age: 28, Email: "alice@example.com"},
+	}
+
+	diff, err := DiffRosters(old, new)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Print(RenderText(diff))
+
+	fmt.Println("--------------------------------")
+
+	data, _ := json.MarshalIndent(diff, "", "  ")
+	fmt.Println(string(data))
+
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		return
+	}
+	demo()
+}
+
+/*
+	Try :
+		1. go run main.go diff.go diff old.json new.json
+		2. go run main.go diff.go diff -json old.json new.json
+		3. Add a Phone field to Person. The diff compares it without any change in diff.go
+*/
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadRoster(t *testing.T) {
+	people, err := readRoster("old.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Person{john, jane, bob}; !reflect.DeepEqual(people, want) {
+		t.Errorf("readRoster(old.json) = %v, want %v", people, want)
+	}
+
+	broken := filepath.Join(t.TempDir(), "broken.json")
+	os.WriteFile(broken, []byte(`[{"name": "John"`), 0o644)
+	if _, err := readRoster(broken); err == nil || !strings.Contains(err.Error(), "reading "+broken) {
+		t.Errorf("readRoster(broken) error = %v, want it to name the file", err)
+	}
+}
+
+func TestRunDiffErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no files", nil, "usage: diff [-json] old.json new.json"},
+		{"one file", []string{"old.json"}, "usage: diff [-json] old.json new.json"},
+		{"three files", []string{"old.json", "new.json", "x.json"}, "usage: diff [-json] old.json new.json"},
+		{"missing file", []string{"old.json", "missing.json"}, "no such file"},
+		{"unknown flag", []string{"-yaml", "old.json", "new.json"}, "flag provided but not defined: -yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runDiff(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runDiff(%q) error = %v, want it to contain %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
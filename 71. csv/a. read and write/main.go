@@ -0,0 +1,138 @@
+//! CSV (comma separated values) -> a table as plain text : one row per line, the columns separated by commas
+//! encoding/csv handles the hard parts for us : commas or quotes INSIDE a value ("Doe, John") are quoted and escaped correctly
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+var header = []string{"name", "age", "email"}
+
+//! recordToPerson -> one CSV row ([]string) into a Person. Every column is text, so the age must be converted and checked
+func recordToPerson(record []string) (Person, error) {
+	if len(record) != len(header) {
+		return Person{}, fmt.Errorf("want %d columns, got %d", len(header), len(record))
+	}
+	age, err := strconv.Atoi(record[1])
+	if err != nil {
+		return Person{}, fmt.Errorf("bad age %q: %w", record[1], err)
+	}
+	if age < 0 {
+		return Person{}, fmt.Errorf("bad age %d: can't be negative", age)
+	}
+	return Person{Name: record[0], Age: age, Email: record[2]}, nil
+}
+
+func personToRecord(person Person) []string {
+	return []string{person.Name, strconv.Itoa(person.Age), person.Email}
+}
+
+//! writePeople -> the header, then one row per person
+func writePeople(writer io.Writer, people []Person) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	for _, person := range people {
+		if err := csvWriter.Write(personToRecord(person)); err != nil {
+			return err
+		}
+	}
+	//! the Writer is buffered : Flush writes the rest, and Error reports a failed write
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+//! readPeople -> the valid rows become people, every bad row is printed and skipped
+func readPeople(reader io.Reader) (imported []Person, rejected int, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 //! -1 -> allow rows with a different number of columns, recordToPerson checks them
+
+	if _, err := csvReader.Read(); err != nil { //! skip the header
+		return nil, 0, fmt.Errorf("read header: %w", err)
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, rejected, err //! a broken CSV (like a quote that is never closed) : we can't continue
+		}
+
+		person, err := recordToPerson(record)
+		if err != nil {
+			line, _ := csvReader.FieldPos(0)
+			fmt.Printf("line %d rejected : %v\n", line, err)
+			rejected++
+			continue
+		}
+		imported = append(imported, person)
+	}
+	return imported, rejected, nil
+}
+
+func main() {
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Doe, Jane", Age: 21, Email: "jane@example.com"},         //! a comma inside the value
+		{Name: `Alice "Al" Brown`, Age: 28, Email: "alice@example.com"}, //! quotes inside the value
+	}
+
+	//! 1. write into memory (a bytes.Buffer). A file from os.Create works exactly the same, both are io.Writer
+	var buffer bytes.Buffer
+	if err := writePeople(&buffer, people); err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Print(buffer.String())
+
+	fmt.Println("--------------------------------")
+
+	//! 2. read it back
+	imported, rejected, err := readPeople(&buffer)
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	for _, person := range imported {
+		fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+	}
+	fmt.Printf("imported : %d, rejected : %d\n", len(imported), rejected)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. a file with problems : a short row and two bad ages
+	input := `name,age,email
+John,20,john@example.com
+Jane,21
+Bob,twenty,bob@example.com
+Alice,28,alice@example.com
+Eve,-3,eve@example.com
+`
+	imported, rejected, err = readPeople(bytes.NewBufferString(input))
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Printf("imported : %d, rejected : %d\n", len(imported), rejected)
+}
+
+/*
+	Try :
+		1. Set csvWriter.Comma = ';' in writePeople. Excel in many European countries expects ';'
+		2. Remove the line with FieldsPerRecord. What does the short row "Jane,21" give now?
+		3. Add a closing quote problem to the input, like `"John,20,john@example.com`
+*/
@@ -0,0 +1,126 @@
+//! two ways to read CSV :
+//! ReadAll -> every row at once, as [][]string. Simple, but the WHOLE file must fit in memory
+//! Read    -> one row per call. Only one row is in memory at a time, and we can stop early
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//! generateCSV -> a CSV with 'rows' people, built in memory for the example
+func generateCSV(rows int) string {
+	var builder strings.Builder
+	builder.WriteString("name,age,email\n")
+	for i := 1; i <= rows; i++ {
+		fmt.Fprintf(&builder, "person%d,%d,person%d@example.com\n", i, 18+i%50, i)
+	}
+	return builder.String()
+}
+
+//! averageAgeReadAll -> ReadAll, then loop over the slice
+func averageAgeReadAll(reader io.Reader) (float64, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, record := range records[1:] { //! records[0] is the header
+		age, err := strconv.Atoi(record[1])
+		if err != nil {
+			return 0, err
+		}
+		total += age
+	}
+	return float64(total) / float64(len(records)-1), nil
+}
+
+//! averageAgeStreaming -> Read() until io.EOF. ReuseRecord lets the reader use the same slice for every row, so fewer allocations
+func averageAgeStreaming(reader io.Reader) (float64, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.ReuseRecord = true //! the record is only valid until the next Read. Copy it if you want to keep it
+
+	if _, err := csvReader.Read(); err != nil { //! the header
+		return 0, err
+	}
+
+	total, count := 0, 0
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		age, err := strconv.Atoi(record[1])
+		if err != nil {
+			return 0, err
+		}
+		total += age
+		count++
+	}
+	return float64(total) / float64(count), nil
+}
+
+//! findByEmail -> streaming can stop as soon as the row is found. ReadAll would read the whole file first
+func findByEmail(reader io.Reader, email string) (record []string, rowsRead int, err error) {
+	csvReader := csv.NewReader(reader)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, rowsRead, nil
+		}
+		if err != nil {
+			return nil, rowsRead, err
+		}
+		rowsRead++
+		if record[2] == email {
+			return record, rowsRead, nil
+		}
+	}
+}
+
+func main() {
+	small := `name,age,email
+John,20,john@example.com
+Jane,21,jane@example.com
+Alice,28,alice@example.com
+`
+	//! 1. a small file : both give the same result, ReadAll is shorter to write
+	average, err := averageAgeReadAll(strings.NewReader(small))
+	fmt.Printf("ReadAll   average age : %.2f (error : %v)\n", average, err)
+	average, err = averageAgeStreaming(strings.NewReader(small))
+	fmt.Printf("Read()    average age : %.2f (error : %v)\n", average, err)
+
+	fmt.Println("--------------------------------")
+
+	//! 2. a big file : the same answer, but ReadAll keeps all 100000 rows in memory at the same time
+	big := generateCSV(100000)
+	fmt.Printf("big CSV : %d bytes\n", len(big))
+	average, err = averageAgeReadAll(strings.NewReader(big))
+	fmt.Printf("ReadAll   average age : %.2f (error : %v)\n", average, err)
+	average, err = averageAgeStreaming(strings.NewReader(big))
+	fmt.Printf("Read()    average age : %.2f (error : %v)\n", average, err)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. stopping early : only the rows until the match are read
+	record, rowsRead, err := findByEmail(strings.NewReader(big), "person42@example.com")
+	fmt.Printf("found : %v after reading %d of 100001 rows (error : %v)\n", record, rowsRead, err)
+}
+
+/*
+	When to use which :
+		ReadAll -> small files (a config, a short list), or when you need all rows together (sorting them, for example)
+		Read()  -> big or unknown sizes (uploads, logs, exports), when you process row by row, or when you can stop early
+
+	Try :
+		1. Change generateCSV(100000) to 1000000 and watch the memory of both versions (for example with /usr/bin/time -v)
+		2. Remove ReuseRecord = true. The result is the same, but every row gets a new slice
+*/
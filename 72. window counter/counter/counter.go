@@ -0,0 +1,155 @@
+//! Package counter answers "how many requests in the last <window>?" in three ways : fixed windows, a ring of per-second buckets, and timed samples
+//! the window counter lesson (72. window counter) compares them, and the HTTP server lesson (81. http server) counts its requests with SlidingWindowCounter
+package counter
+
+import (
+	"sync"
+	"time"
+
+	"slidingwindow/window"
+)
+
+//! WindowCounter -> "how many requests in the last <window>?". The time is always passed in, so a test can use any time it wants
+type WindowCounter interface {
+	Incr(now time.Time)
+	Count(now time.Time, window time.Duration) int
+}
+
+//! FixedWindowCounter -> one counter per fixed window (12:00:00-12:00:59, 12:01:00-12:01:59 ...), in a map keyed by the window start
+//! simple and small, but the count drops to 0 at every window start, no matter how many requests came one second before
+type FixedWindowCounter struct {
+	mu        sync.Mutex
+	size      time.Duration
+	maxWindow time.Duration
+	windows   map[int64]int //! window start (unix nanoseconds) -> count
+}
+
+func NewFixedWindowCounter(size, maxWindow time.Duration) *FixedWindowCounter {
+	return &FixedWindowCounter{size: size, maxWindow: maxWindow, windows: map[int64]int{}}
+}
+
+func (c *FixedWindowCounter) Incr(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := now.Truncate(c.size)
+	c.windows[start.UnixNano()]++
+
+	//! forget the windows which no Count can ask for anymore, so the map doesn't grow forever
+	oldest := start.Add(-c.maxWindow).UnixNano()
+	for key := range c.windows {
+		if key < oldest {
+			delete(c.windows, key)
+		}
+	}
+}
+
+//! Count -> the current window, plus as many earlier windows as 'window' covers (1m with 1m windows -> only the current one)
+func (c *FixedWindowCounter) Count(now time.Time, window time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	windows := int((window + c.size - 1) / c.size) //! rounded up
+	if windows < 1 {
+		windows = 1
+	}
+	start := now.Truncate(c.size)
+
+	total := 0
+	for i := 0; i < windows; i++ {
+		total += c.windows[start.Add(-time.Duration(i)*c.size).UnixNano()]
+	}
+	return total
+}
+
+type bucket struct {
+	second int64 //! which second this bucket counts right now
+	count  int
+}
+
+//! SlidingWindowCounter -> a ring of per-second buckets. Count adds up the buckets of the last <window> seconds, so the window moves every second
+//! the ring has one bucket per second of maxWindow. A bucket from an older second is simply reset when its slot is used again
+type SlidingWindowCounter struct {
+	mu      sync.Mutex
+	buckets []bucket
+}
+
+func NewSlidingWindowCounter(maxWindow time.Duration) *SlidingWindowCounter {
+	seconds := int(maxWindow / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &SlidingWindowCounter{buckets: make([]bucket, seconds)}
+}
+
+func (c *SlidingWindowCounter) slot(second int64) *bucket {
+	size := int64(len(c.buckets))
+	return &c.buckets[(second%size+size)%size] //! +size keeps the index positive for times before 1970
+}
+
+func (c *SlidingWindowCounter) Incr(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	second := now.Unix()
+	b := c.slot(second)
+	if b.second != second { //! the slot still holds an older second : start again from 0
+		b.second = second
+		b.count = 0
+	}
+	b.count++
+}
+
+//! Count -> the requests in the seconds (now-window, now]. A window longer than maxWindow is cut to maxWindow
+func (c *SlidingWindowCounter) Count(now time.Time, window time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seconds := int64(window / time.Second)
+	if seconds > int64(len(c.buckets)) {
+		seconds = int64(len(c.buckets))
+	}
+	newest := now.Unix()
+	oldest := newest - seconds + 1
+
+	total := 0
+	for _, b := range c.buckets {
+		//! old buckets are never cleaned up : they are just ignored here, because their second is too old
+		if b.count > 0 && b.second >= oldest && b.second <= newest {
+			total += b.count
+		}
+	}
+	return total
+}
+
+//! SampleWindowCounter -> the generic window of the sliding window lesson : one sample per request, with its exact time
+//! exact to the nanosecond instead of to the second, but the memory grows with the traffic, so maxSamples caps it
+type SampleWindowCounter struct {
+	mu      sync.Mutex
+	now     time.Time //! the time passed to the last Incr or Count : window.Sliding asks its clock for it
+	samples *window.Sliding[int]
+}
+
+func NewSampleWindowCounter(maxWindow time.Duration, maxSamples int) *SampleWindowCounter {
+	c := &SampleWindowCounter{}
+	//! the clock is only called inside Incr and Count, while c.mu is held, so it reads c.now without locking again
+	c.samples = window.New[int](maxWindow, maxSamples, func() time.Time { return c.now })
+	return c
+}
+
+func (c *SampleWindowCounter) Incr(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+	c.samples.Push(1, now)
+}
+
+//! Count -> the requests in (now-window, now]. A window longer than maxWindow is cut to maxWindow
+func (c *SampleWindowCounter) Count(now time.Time, window time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+	return c.samples.Sum(window)
+}
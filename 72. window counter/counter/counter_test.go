@@ -0,0 +1,217 @@
+package counter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+//! a fake clock : no sleeping, the tests just pass other times
+var t0 = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+//! one request at 12:00:00. The sliding window is (now - 1m, now], the fixed one is the clock minute
+func TestWindowBoundaries(t *testing.T) {
+	tests := []struct {
+		after       time.Duration
+		wantFixed   int
+		wantSliding int
+	}{
+		{0, 1, 1},
+		{30 * time.Second, 1, 1},
+		{59 * time.Second, 1, 1},
+		{59*time.Second + 999*time.Millisecond, 1, 1},
+		{60 * time.Second, 0, 0},
+		{90 * time.Second, 0, 0},
+	}
+	fixed, sliding := newCounters()
+	fixed.Incr(t0)
+	sliding.Incr(t0)
+	for _, tt := range tests {
+		now := t0.Add(tt.after)
+		if got := fixed.Count(now, time.Minute); got != tt.wantFixed {
+			t.Errorf("fixed.Count at +%v = %d, want %d", tt.after, got, tt.wantFixed)
+		}
+		if got := sliding.Count(now, time.Minute); got != tt.wantSliding {
+			t.Errorf("sliding.Count at +%v = %d, want %d", tt.after, got, tt.wantSliding)
+		}
+	}
+}
+
+//! 100 requests at 12:00:59 and 100 more at 12:01:00 : 200 requests in 2 seconds
+//! the fixed window sees only 100 in each minute and drops in one jump, the sliding window sees all 200 and goes down second by second
+func TestBoundaryBurst(t *testing.T) {
+	fixed, sliding := newCounters()
+	for _, counter := range []WindowCounter{fixed, sliding} {
+		incrMany(counter, t0.Add(59*time.Second), 100)
+		incrMany(counter, t0.Add(time.Minute), 100)
+	}
+
+	tests := []struct {
+		at          time.Duration
+		wantFixed   int
+		wantSliding int
+	}{
+		{59 * time.Second, 100, 100},
+		{60 * time.Second, 100, 200}, //! the burst : a limit of 100 per minute would let all 200 through with the fixed window
+		{90 * time.Second, 100, 200},
+		{118 * time.Second, 100, 200},
+		{119 * time.Second, 100, 100}, //! the 100 of 12:00:59 left the sliding window
+		{120 * time.Second, 0, 0},
+	}
+	for _, tt := range tests {
+		now := t0.Add(tt.at)
+		fixedCount := fixed.Count(now, time.Minute)
+		slidingCount := sliding.Count(now, time.Minute)
+		if fixedCount != tt.wantFixed || slidingCount != tt.wantSliding {
+			t.Errorf("at %s : fixed %d, sliding %d; want %d, %d", now.Format("15:04:05"), fixedCount, slidingCount, tt.wantFixed, tt.wantSliding)
+		}
+	}
+}
+
+//! the sliding window over a steady 1 request per second : always 60, no jumps. The fixed window goes 1, 2 ... 60 and falls back to 1
+func TestSlidingIsSmooth(t *testing.T) {
+	fixed, sliding := newCounters()
+	biggestStep := 0
+	previous := -1
+	fixedCounts := map[int]int{}
+	for second := 0; second < 180; second++ {
+		now := t0.Add(time.Duration(second) * time.Second)
+		fixed.Incr(now)
+		sliding.Incr(now)
+		fixedCounts[second] = fixed.Count(now, time.Minute)
+		count := sliding.Count(now, time.Minute)
+		if second >= 60 {
+			if count != 60 {
+				t.Fatalf("sliding at +%ds = %d, want 60", second, count)
+			}
+			biggestStep = max(biggestStep, abs(count-previous))
+		}
+		previous = count
+	}
+	if biggestStep != 0 {
+		t.Errorf("the sliding count changed by %d in one second, want 0", biggestStep)
+	}
+	if fixedCounts[119] != 60 || fixedCounts[120] != 1 {
+		t.Errorf("fixed at 12:01:59 and 12:02:00 = %d, %d; want 60, 1 : the count falls when the new window starts", fixedCounts[119], fixedCounts[120])
+	}
+}
+
+func TestExpiryAndGaps(t *testing.T) {
+	fixed, sliding := newCounters()
+	incrMany(sliding, t0, 5)
+	sliding.Incr(t0.Add(time.Minute)) //! the same ring slot as t0 : the old 5 must not be added
+	if got := sliding.Count(t0.Add(time.Minute), time.Minute); got != 1 {
+		t.Errorf("reused bucket = %d, want 1", got)
+	}
+	if got := sliding.Count(t0.Add(3*time.Minute), time.Minute); got != 0 {
+		t.Errorf("after 2 quiet minutes = %d, want 0", got)
+	}
+
+	later := t0.Add(24 * time.Hour)
+	sliding.Incr(later)
+	fixed.Incr(t0)
+	fixed.Incr(later)
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"sliding counts again after a day", sliding.Count(later, time.Minute), 1},
+		{"fixed counts again after a day", fixed.Count(later, time.Minute), 1},
+		{"fixed deletes the old windows", len(fixed.windows), 1},
+		{"sliding cuts a window over 60s to 60s", sliding.Count(later, time.Hour), 1},
+		{"sliding : a window shorter than a second is empty", sliding.Count(later, time.Millisecond), 0},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s : got %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+//! many goroutines at once. Run with -race to let the race detector check the mutex
+func TestConcurrentIncr(t *testing.T) {
+	fixed, sliding := newCounters()
+	samples := NewSampleWindowCounter(time.Minute, 10_000)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				now := t0.Add(time.Duration(i%30) * time.Second)
+				fixed.Incr(now)
+				sliding.Incr(now)
+				samples.Incr(now)
+			}
+		}()
+	}
+	wg.Wait()
+	end := t0.Add(29 * time.Second)
+	if got := fixed.Count(end, time.Minute); got != 8000 {
+		t.Errorf("fixed counted %d, want 8000", got)
+	}
+	if got := sliding.Count(end, time.Minute); got != 8000 {
+		t.Errorf("sliding counted %d, want 8000", got)
+	}
+	if got := samples.Count(end, time.Minute); got != 8000 {
+		t.Errorf("samples counted %d, want 8000", got)
+	}
+}
+
+//! the samples keep the exact time : a request half a second into 12:00:00 is still in the window at 12:01:00.2, the per-second buckets already dropped it
+func TestSampleWindowCounter(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxSamples int
+		incr       []time.Duration
+		at         time.Duration
+		window     time.Duration
+		want       int
+	}{
+		{"empty", 100, nil, 0, time.Minute, 0},
+		{"one request", 100, []time.Duration{0}, 0, time.Minute, 1},
+		{"59.999s later", 100, []time.Duration{0}, 59*time.Second + 999*time.Millisecond, time.Minute, 1},
+		{"exactly 60s later", 100, []time.Duration{0}, time.Minute, time.Minute, 0},
+		{"sub-second precision", 100, []time.Duration{500 * time.Millisecond}, time.Minute + 200*time.Millisecond, time.Minute, 1},
+		{"shorter window", 100, []time.Duration{0, 30 * time.Second, 50 * time.Second}, 55 * time.Second, 10 * time.Second, 1},
+		{"window cut to maxWindow", 100, []time.Duration{0, 30 * time.Second}, 70 * time.Second, time.Hour, 1},
+		{"maxSamples keeps the newest", 3, []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}, 5 * time.Second, time.Minute, 3},
+		{"a later request is not counted yet", 100, []time.Duration{0, 10 * time.Second}, 5 * time.Second, time.Minute, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := NewSampleWindowCounter(time.Minute, tt.maxSamples)
+			for _, at := range tt.incr {
+				samples.Incr(t0.Add(at))
+			}
+			if got := samples.Count(t0.Add(tt.at), tt.window); got != tt.want {
+				t.Errorf("Count at +%v over %v = %d, want %d", tt.at, tt.window, got, tt.want)
+			}
+		})
+	}
+
+	//! the same request, counted by the per-second buckets : 12:00:00.5 is in the second 12:00:00, which is out at 12:01:00
+	sliding := NewSlidingWindowCounter(time.Minute)
+	sliding.Incr(t0.Add(500 * time.Millisecond))
+	if got := sliding.Count(t0.Add(time.Minute+200*time.Millisecond), time.Minute); got != 0 {
+		t.Errorf("sliding buckets at 12:01:00.2 = %d, want 0", got)
+	}
+}
+
+func newCounters() (*FixedWindowCounter, *SlidingWindowCounter) {
+	return NewFixedWindowCounter(time.Minute, time.Hour), NewSlidingWindowCounter(time.Minute)
+}
+
+func incrMany(counter WindowCounter, now time.Time, n int) {
+	for i := 0; i < n; i++ {
+		counter.Incr(now)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
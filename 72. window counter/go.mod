@@ -0,0 +1,7 @@
+module windowcounter
+
+go 1.22
+
+require slidingwindow v0.0.0
+
+replace slidingwindow => "../106. sliding window"
@@ -0,0 +1,94 @@
+//! Rate counter -> "how many requests did the API get in the last minute?"
+//! two ways : fixed windows (one counter per clock minute) and a sliding window (per-second buckets, the last 60 seconds)
+//! plus the sliding window of its own lesson, which keeps one timed sample per request
+//! the counters are the counter package. The HTTP server lesson (81. http server) imports it for its real GET /metrics, the metrics type here is a small stand-in
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"windowcounter/counter"
+)
+
+//! metrics -> counts every request with the sliding counter, and serves GET /metrics as requests-per-minute
+type metrics struct {
+	requests *counter.SlidingWindowCounter
+	now      func() time.Time //! time.Now in the real server, a fake clock in the demo
+}
+
+func (m *metrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.requests.Incr(m.now())
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *metrics) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{
+		"requests_per_minute": m.requests.Count(m.now(), time.Minute),
+	})
+}
+
+func newCounters() (*counter.FixedWindowCounter, *counter.SlidingWindowCounter) {
+	return counter.NewFixedWindowCounter(time.Minute, time.Hour), counter.NewSlidingWindowCounter(time.Minute)
+}
+
+func incrMany(windowCounter counter.WindowCounter, now time.Time, n int) {
+	for i := 0; i < n; i++ {
+		windowCounter.Incr(now)
+	}
+}
+
+func main() {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) //! a fake clock : no sleeping, we just pass other times
+
+	//! 1. the boundary burst : 100 requests at 12:00:59 and 100 more at 12:01:00, 200 requests in 2 seconds
+	fixed, sliding := newCounters()
+	samples := counter.NewSampleWindowCounter(time.Minute, 10_000)
+	for _, windowCounter := range []counter.WindowCounter{fixed, sliding, samples} {
+		incrMany(windowCounter, t0.Add(59*time.Second), 100)
+		incrMany(windowCounter, t0.Add(time.Minute), 100)
+	}
+	//! with a limit of 100 per minute, the fixed window lets all 200 through : each minute only saw 100
+	//! the sliding count goes down smoothly, second by second. The fixed one only jumps at 12:02:00
+	//! the samples agree with the sliding buckets here, because every request came at a whole second
+	fmt.Println("requests in the last minute :")
+	for _, at := range []time.Duration{59 * time.Second, time.Minute, 119 * time.Second, 2 * time.Minute} {
+		now := t0.Add(at)
+		fmt.Printf("  at %s -> fixed : %3d, sliding : %3d, samples : %3d\n", now.Format("15:04:05"),
+			fixed.Count(now, time.Minute), sliding.Count(now, time.Minute), samples.Count(now, time.Minute))
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 2. the metrics endpoint, with a clock we move by hand
+	clock := t0
+	m := &metrics{requests: counter.NewSlidingWindowCounter(time.Minute), now: func() time.Time { return clock }}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "hello") })
+	mux.HandleFunc("/metrics", m.handleMetrics)
+	handler := m.middleware(mux)
+
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		clock = clock.Add(20 * time.Second)
+	}
+	for _, at := range []string{"after 3 requests", "40 seconds later"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		fmt.Print("GET /metrics ", at, " : ", recorder.Body.String()) //! the /metrics request itself is counted too
+		clock = clock.Add(40 * time.Second)
+	}
+}
+
+/*
+	Try :
+		1. Make the fixed windows 10 seconds (counter.NewFixedWindowCounter(10*time.Second, time.Hour)). How big is the burst problem now?
+		2. Add a Limiter with Allow(now) bool which uses the sliding counter and a limit of 100 per minute
+		3. Push 3 requests at 12:00:00.5 and count at 12:01:00.2. Why do the sliding buckets say 0 and the samples 3?
+*/
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"windowcounter/counter"
+)
+
+//! a fake clock : no sleeping, the test just moves the time
+var t0 = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+//! the metrics endpoint, with a clock we move by hand. The /metrics request itself is counted too
+func TestMetricsEndpoint(t *testing.T) {
+	clock := t0
+	m := &metrics{requests: counter.NewSlidingWindowCounter(time.Minute), now: func() time.Time { return clock }}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "hello") })
+	mux.HandleFunc("/metrics", m.handleMetrics)
+	handler := m.middleware(mux)
+
+	for i := 0; i < 3; i++ { //! at +0s, +20s and +40s
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		clock = clock.Add(20 * time.Second)
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want string
+	}{
+		{60 * time.Second, `{"requests_per_minute":3}` + "\n"},  //! +20s, +40s and this request. +0s just left the window
+		{100 * time.Second, `{"requests_per_minute":2}` + "\n"}, //! the request at +60s and this one
+		{200 * time.Second, `{"requests_per_minute":1}` + "\n"}, //! only this one
+	}
+	for _, tt := range tests {
+		clock = t0.Add(tt.at)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if recorder.Code != http.StatusOK || recorder.Body.String() != tt.want {
+			t.Errorf("GET /metrics at +%v = %d %q, want 200 %q", tt.at, recorder.Code, recorder.Body.String(), tt.want)
+		}
+		if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+//! ANSI escape codes : "\x1b[" + number + "m". The terminal doesn't print them, it changes the style of the text after them
+//! every style is switched off with its OWN reset code (not "\x1b[0m" which resets everything), so Bold(Green("x") + " y") stays bold after the green part
+const (
+	codeBold      = "\x1b[1m"
+	codeBoldOff   = "\x1b[22m"
+	codeRed       = "\x1b[31m"
+	codeGreen     = "\x1b[32m"
+	codeYellow    = "\x1b[33m"
+	codeDefaultFG = "\x1b[39m" //! back to the default text color
+)
+
+//! Printer -> callers ask for Red/Green/... and never write escape codes themselves. A disabled Printer returns plain text
+type Printer struct {
+	w       io.Writer
+	enabled bool
+}
+
+//! Colorize -> colors only for a real terminal, and only if NO_COLOR isn't set (https://no-color.org)
+func Colorize(w io.Writer) *Printer {
+	return &Printer{w: w, enabled: Enabled(w, os.Getenv, IsTerminal)}
+}
+
+//! NewPrinter -> colors on or off by hand, for tests and for a --color flag
+func NewPrinter(w io.Writer, enabled bool) *Printer {
+	return &Printer{w: w, enabled: enabled}
+}
+
+//! Enabled -> the detection, with the environment and the terminal check passed in, so a test can fake both
+func Enabled(w io.Writer, getenv func(string) string, isTerminal func(io.Writer) bool) bool {
+	if getenv("NO_COLOR") != "" { //! any non-empty value turns colors off
+		return false
+	}
+	return isTerminal(w)
+}
+
+//! IsTerminal -> a file which is a character device is a terminal. A pipe, a regular file or a bytes.Buffer is not
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *Printer) wrap(on, off string, a []any) string {
+	text := fmt.Sprint(a...)
+	if !p.enabled {
+		return text
+	}
+	return on + text + off
+}
+
+func (p *Printer) Red(a ...any) string    { return p.wrap(codeRed, codeDefaultFG, a) }
+func (p *Printer) Green(a ...any) string  { return p.wrap(codeGreen, codeDefaultFG, a) }
+func (p *Printer) Yellow(a ...any) string { return p.wrap(codeYellow, codeDefaultFG, a) }
+func (p *Printer) Bold(a ...any) string   { return p.wrap(codeBold, codeBoldOff, a) }
+
+func (p *Printer) Println(a ...any) (int, error) {
+	return fmt.Fprintln(p.w, a...)
+}
+
+func (p *Printer) Printf(format string, a ...any) (int, error) {
+	return fmt.Fprintf(p.w, format, a...)
+}
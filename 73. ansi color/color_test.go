@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	env := func(values map[string]string) func(string) string {
+		return func(name string) string { return values[name] }
+	}
+	terminal := func(io.Writer) bool { return true }
+	notTerminal := func(io.Writer) bool { return false }
+
+	tests := []struct {
+		name       string
+		getenv     func(string) string
+		isTerminal func(io.Writer) bool
+		want       bool
+	}{
+		{"terminal, NO_COLOR unset", env(nil), terminal, true},
+		{"terminal, NO_COLOR=1", env(map[string]string{"NO_COLOR": "1"}), terminal, false},
+		{"terminal, NO_COLOR with any value", env(map[string]string{"NO_COLOR": "no"}), terminal, false},
+		{"terminal, NO_COLOR empty counts as unset", env(map[string]string{"NO_COLOR": ""}), terminal, true},
+		{"not a terminal", env(nil), notTerminal, false},
+		{"not a terminal, NO_COLOR=1", env(map[string]string{"NO_COLOR": "1"}), notTerminal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enabled(os.Stdout, tt.getenv, tt.isTerminal); got != tt.want {
+				t.Errorf("Enabled = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	tests := []struct {
+		name string
+		w    io.Writer
+	}{
+		{"a bytes.Buffer", &bytes.Buffer{}},
+		{"io.Discard", io.Discard},
+		{"a regular file", tempFile(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if IsTerminal(tt.w) {
+				t.Errorf("IsTerminal(%s) = true, want false", tt.name)
+			}
+		})
+	}
+}
+
+func tempFile(t *testing.T) *os.File {
+	t.Helper()
+	file, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return file
+}
+
+//! the exact escape codes when enabled, and plain text when disabled
+func TestStyles(t *testing.T) {
+	colored := NewPrinter(io.Discard, true)
+	plain := NewPrinter(io.Discard, false)
+	tests := []struct {
+		name      string
+		gotColor  string
+		gotPlain  string
+		wantColor string
+		wantPlain string
+	}{
+		{"Red", colored.Red("x"), plain.Red("x"), "\x1b[31mx\x1b[39m", "x"},
+		{"Green", colored.Green("x"), plain.Green("x"), "\x1b[32mx\x1b[39m", "x"},
+		{"Yellow", colored.Yellow("x"), plain.Yellow("x"), "\x1b[33mx\x1b[39m", "x"},
+		{"Bold", colored.Bold("x"), plain.Bold("x"), "\x1b[1mx\x1b[22m", "x"},
+		{"values are joined like fmt.Sprint", colored.Red(2, "/", 3), plain.Red(2, "/", 3), "\x1b[31m2/3\x1b[39m", "2/3"},
+		//! nesting : the green reset (39) ends only the color, " y" is still bold
+		{"Bold around Green keeps bold for the rest", colored.Bold(colored.Green("x"), " y"), plain.Bold(plain.Green("x"), " y"), "\x1b[1m\x1b[32mx\x1b[39m y\x1b[22m", "x y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.gotColor != tt.wantColor {
+				t.Errorf("enabled = %q, want %q", tt.gotColor, tt.wantColor)
+			}
+			if tt.gotPlain != tt.wantPlain {
+				t.Errorf("disabled = %q, want %q", tt.gotPlain, tt.wantPlain)
+			}
+		})
+	}
+}
+
+//! disabled, the bytes written are exactly the visible characters
+func TestPrintlnDisabled(t *testing.T) {
+	var buffer bytes.Buffer
+	plain := NewPrinter(&buffer, false)
+	visible := "wrong   answer : append"
+	n, err := plain.Println(plain.Red("wrong  "), "answer :", plain.Bold("append"))
+	if err != nil || n != len(visible)+1 || buffer.String() != visible+"\n" {
+		t.Errorf("Println = %d, %v, wrote %q; want %d, nil, %q", n, err, buffer.String(), len(visible)+1, visible+"\n")
+	}
+}
+
+//! into a buffer (like a pipe or a file), Colorize sees it's not a terminal
+func TestColorizeBuffer(t *testing.T) {
+	var buffer bytes.Buffer
+	p := Colorize(&buffer)
+	p.Printf("%s %d\n", p.Green("correct"), 1)
+	if buffer.String() != "correct 1\n" {
+		t.Errorf("Colorize(buffer) wrote %q, want %q", buffer.String(), "correct 1\n")
+	}
+}
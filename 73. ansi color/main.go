@@ -0,0 +1,61 @@
+//! Colors in the terminal -> green for a right answer, red for a wrong one, bold yellow section headers
+//! but colors are only for humans : piped into a file (go run . > out.txt) the escape codes would be garbage, so they are switched off there
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type question struct {
+	text   string
+	answer string
+}
+
+//! section -> a header, like the lesson runner prints between the parts of a lesson
+func section(p *Printer, title string) {
+	p.Println(p.Bold(p.Yellow("== " + title + " ==")))
+}
+
+//! quiz -> the answers are given as a slice here, so the example runs without typing
+func quiz(p *Printer, questions []question, answers []string) int {
+	score := 0
+	for i, q := range questions {
+		if strings.EqualFold(strings.TrimSpace(answers[i]), q.answer) {
+			score++
+			p.Printf("%s %s -> %s\n", p.Green("correct"), q.text, answers[i])
+		} else {
+			p.Printf("%s %s -> %s (answer : %s)\n", p.Red("wrong  "), q.text, answers[i], p.Bold(q.answer))
+		}
+	}
+	return score
+}
+
+func main() {
+	//! 1. the real Printer : colors if stdout is a terminal and NO_COLOR isn't set. Try it with `| cat` and with NO_COLOR=1
+	stdout := Colorize(os.Stdout)
+	section(stdout, "Go quiz")
+	questions := []question{
+		{text: "Which keyword starts a goroutine?", answer: "go"},
+		{text: "What is the zero value of an int?", answer: "0"},
+		{text: "Which builtin adds to a slice?", answer: "append"},
+	}
+	score := quiz(stdout, questions, []string{"go", "nil", "Append"})
+	stdout.Printf("score : %s\n", stdout.Bold(score, "/", len(questions)))
+
+	fmt.Println("--------------------------------")
+
+	//! 2. the same quiz into a buffer (like a pipe or a file) : Colorize sees it's not a terminal
+	var buffer bytes.Buffer
+	quiz(Colorize(&buffer), questions[:2], []string{"go", "nil"})
+	fmt.Printf("into a buffer : %q\n", buffer.String())
+}
+
+/*
+	Try :
+		1. go run main.go color.go | cat          -> no colors, stdout is a pipe
+		2. NO_COLOR=1 go run main.go color.go     -> no colors
+		3. Add Blue ("\x1b[34m") and Underline ("\x1b[4m", off "\x1b[24m")
+*/
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQuiz(t *testing.T) {
+	questions := []question{
+		{text: "Which keyword starts a goroutine?", answer: "go"},
+		{text: "What is the zero value of an int?", answer: "0"},
+		{text: "Which builtin adds to a slice?", answer: "append"},
+	}
+	tests := []struct {
+		name      string
+		answers   []string
+		enabled   bool
+		wantScore int
+		want      string
+	}{
+		{"all right, case and spaces ignored", []string{"go", " 0 ", "Append"}, false, 3,
+			"correct Which keyword starts a goroutine? -> go\ncorrect What is the zero value of an int? ->  0 \ncorrect Which builtin adds to a slice? -> Append\n"},
+		{"one wrong", []string{"go", "nil", "append"}, false, 2,
+			"correct Which keyword starts a goroutine? -> go\nwrong   What is the zero value of an int? -> nil (answer : 0)\ncorrect Which builtin adds to a slice? -> append\n"},
+		{"colored", []string{"go", "nil", "append"}, true, 2,
+			"\x1b[32mcorrect\x1b[39m Which keyword starts a goroutine? -> go\n\x1b[31mwrong  \x1b[39m What is the zero value of an int? -> nil (answer : \x1b[1m0\x1b[22m)\n\x1b[32mcorrect\x1b[39m Which builtin adds to a slice? -> append\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			score := quiz(NewPrinter(&buffer, tt.enabled), questions, tt.answers)
+			if score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+			if buffer.String() != tt.want {
+				t.Errorf("quiz wrote\n%q\nwant\n%q", buffer.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSection(t *testing.T) {
+	var buffer bytes.Buffer
+	section(NewPrinter(&buffer, true), "Go quiz")
+	if want := "\x1b[1m\x1b[33m== Go quiz ==\x1b[39m\x1b[22m\n"; buffer.String() != want {
+		t.Errorf("section wrote %q, want %q", buffer.String(), want)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+)
+
+//! iter.Seq[T] is just a function type : func(yield func(T) bool)
+//! the iterator calls yield for every value. yield returns false when the for-range loop stops early, and then the iterator MUST return
+
+//! FilterIter -> only the values for which keep returns true
+func FilterIter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range seq {
+			if keep(value) && !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+//! MapIter -> every value changed by transform, possibly into another type
+func MapIter[T, U any](seq iter.Seq[T], transform func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for value := range seq {
+			if !yield(transform(value)) {
+				return
+			}
+		}
+	}
+}
+
+//! TakeIter -> at most n values. After the n-th one it returns, and the range over seq stops, so the source stops too
+func TakeIter[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for value := range seq {
+			if !yield(value) {
+				return
+			}
+			taken++
+			if taken == n {
+				return
+			}
+		}
+	}
+}
+
+//! Pager -> fetches 'size' people at a time, but All() shows the caller one flat sequence of people
+//! a page is only fetched when the loop needs its first person, so stopping early means no more fetches
+//! like bufio.Scanner : a fetch error ends the sequence, and Err() tells why it ended
+type Pager struct {
+	repo Repository
+	size int
+	err  error
+}
+
+//! a size of 0 would fetch the same empty page forever, so NewPager panics, like sliceutil.Chunk does for a size below 1
+func NewPager(repo Repository, size int) *Pager {
+	if size < 1 {
+		panic(fmt.Sprintf("NewPager: size %d must be at least 1", size))
+	}
+	return &Pager{repo: repo, size: size}
+}
+
+func (p *Pager) All() iter.Seq[Person] {
+	return func(yield func(Person) bool) {
+		p.err = nil //! every loop over All() starts again from the first page, so an error of an earlier loop is gone
+		for offset := 0; ; offset += p.size {
+			page, err := p.repo.FetchPage(offset, p.size)
+			if err != nil {
+				p.err = err
+				return
+			}
+			for _, person := range page {
+				if !yield(person) {
+					return
+				}
+			}
+			if len(page) < p.size { //! a short page is the last one
+				return
+			}
+		}
+	}
+}
+
+func (p *Pager) Err() error {
+	return p.err
+}
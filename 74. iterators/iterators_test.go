@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+//! failingRepository -> the first page works, then the "database" is gone
+type failingRepository struct{ inner *memoryRepository }
+
+func (r failingRepository) FetchPage(offset, limit int) ([]Person, error) {
+	if offset > 0 {
+		return nil, errors.New("connection lost")
+	}
+	return r.inner.FetchPage(offset, limit)
+}
+
+//! flakyRepository -> the first fetch fails, every later one works
+type flakyRepository struct {
+	inner   *memoryRepository
+	fetches int
+}
+
+func (r *flakyRepository) FetchPage(offset, limit int) ([]Person, error) {
+	r.fetches++
+	if r.fetches == 1 {
+		return nil, errors.New("connection lost")
+	}
+	return r.inner.FetchPage(offset, limit)
+}
+
+func isEven(n int) bool { return n%2 == 0 }
+func double(n int) int  { return n * 2 }
+
+func TestAdapters(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5, 6}
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"Filter", slices.Collect(FilterIter(slices.Values(numbers), isEven)), []int{2, 4, 6}},
+		{"Filter nothing kept", slices.Collect(FilterIter(slices.Values(numbers), func(int) bool { return false })), nil},
+		{"Map", slices.Collect(MapIter(slices.Values(numbers), double)), []int{2, 4, 6, 8, 10, 12}},
+		{"Take 3", slices.Collect(TakeIter(slices.Values(numbers), 3)), []int{1, 2, 3}},
+		{"Take more than there are", slices.Collect(TakeIter(slices.Values(numbers), 10)), numbers},
+		{"Take 0", slices.Collect(TakeIter(slices.Values(numbers), 0)), nil},
+		{"Take negative", slices.Collect(TakeIter(slices.Values(numbers), -1)), nil},
+		{"empty source", slices.Collect(MapIter(FilterIter(slices.Values([]int(nil)), isEven), double)), nil},
+		{"filter then take", slices.Collect(TakeIter(FilterIter(slices.Values(numbers), isEven), 2)), []int{2, 4}},
+		{"take then filter", slices.Collect(FilterIter(TakeIter(slices.Values(numbers), 2), isEven)), []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+//! Map changes the type : Person -> string
+func TestMapChangesType(t *testing.T) {
+	people := []Person{{Name: "John", Age: 20}, {Name: "Jane", Age: 12}}
+	got := slices.Collect(MapIter(FilterIter(slices.Values(people), isAdult), name))
+	if !reflect.DeepEqual(got, []string{"John"}) {
+		t.Errorf("names of adults = %v, want [John]", got)
+	}
+}
+
+//! every adapter stops pulling from its source when the loop stops : the source sees exactly as many values as needed
+func TestEarlyTerminationReachesTheSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		seq       func(source func(yield func(int) bool)) []int
+		wantPulls int
+	}{
+		{"Take 2", func(source func(yield func(int) bool)) []int { return slices.Collect(TakeIter(source, 2)) }, 2},
+		{"Map then Take 2", func(source func(yield func(int) bool)) []int {
+			return slices.Collect(TakeIter(MapIter(source, double), 2))
+		}, 2},
+		{"Filter then Take 2", func(source func(yield func(int) bool)) []int {
+			return slices.Collect(TakeIter(FilterIter(source, isEven), 2))
+		}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulls := 0
+			source := func(yield func(int) bool) {
+				for n := 1; n <= 100; n++ {
+					pulls++
+					if !yield(n) {
+						return
+					}
+				}
+			}
+			tt.seq(source)
+			if pulls != tt.wantPulls {
+				t.Errorf("the source handed out %d values, want %d", pulls, tt.wantPulls)
+			}
+		})
+	}
+}
+
+//! how many pages the Pager fetches : a page is only loaded when the loop needs its first person
+func TestPagerFetches(t *testing.T) {
+	tests := []struct {
+		name        string
+		people      int
+		size        int
+		take        int //! -1 -> all
+		wantPeople  int
+		wantFetches int
+	}{
+		{"Take 100 with pages of 100", 10000, 100, 100, 100, 1},
+		{"Take 150 -> 2 pages", 10000, 100, 150, 150, 2},
+		{"Take 0 -> nothing fetched", 10000, 100, 0, 0, 0},
+		{"all of 250 -> 3 pages, the last one short", 250, 100, -1, 250, 3},
+		{"all of 200 -> an empty 3rd page ends it", 200, 100, -1, 200, 3},
+		{"empty repository", 0, 100, -1, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository(tt.people)
+			pager := NewPager(repo, tt.size)
+			seq := pager.All()
+			if tt.take >= 0 {
+				seq = TakeIter(seq, tt.take)
+			}
+			got := slices.Collect(seq)
+			if len(got) != tt.wantPeople || repo.fetches != tt.wantFetches {
+				t.Errorf("%d people with %d fetches, want %d with %d", len(got), repo.fetches, tt.wantPeople, tt.wantFetches)
+			}
+			if pager.Err() != nil {
+				t.Errorf("Err() = %v, want nil", pager.Err())
+			}
+		})
+	}
+}
+
+//! a break in a for-range loop stops the fetches too
+func TestPagerBreak(t *testing.T) {
+	repo := newMemoryRepository(10000)
+	count := 0
+	for range NewPager(repo, 100).All() {
+		count++
+		if count == 150 {
+			break
+		}
+	}
+	if repo.fetches != 2 {
+		t.Errorf("break after 150 people : %d pages fetched, want 2", repo.fetches)
+	}
+}
+
+//! the iterator pipeline gives the same answer as a plain slice pipeline, for any page size
+func TestSameAsSlicePipeline(t *testing.T) {
+	repo := newMemoryRepository(10000)
+	var want []string
+	for _, person := range repo.people {
+		if isAdult(person) {
+			want = append(want, person.Name)
+		}
+		if len(want) == 42 {
+			break
+		}
+	}
+	for _, size := range []int{1, 7, 64, 1000} {
+		got := slices.Collect(MapIter(TakeIter(FilterIter(NewPager(repo, size).All(), isAdult), 42), name))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("pages of %d : got %v, want %v", size, got, want)
+		}
+	}
+}
+
+//! a fetch error ends the sequence, Err() says why
+func TestPagerError(t *testing.T) {
+	pager := NewPager(failingRepository{inner: newMemoryRepository(500)}, 100)
+	read := len(slices.Collect(pager.All()))
+	if read != 100 {
+		t.Errorf("read %d people, want the 100 of the first page", read)
+	}
+	if pager.Err() == nil || pager.Err().Error() != "connection lost" {
+		t.Errorf("Err() = %v, want connection lost", pager.Err())
+	}
+}
+
+//! a second loop over the same pager starts with no error : the one of the first loop is not reported again
+func TestPagerReuseResetsErr(t *testing.T) {
+	pager := NewPager(&flakyRepository{inner: newMemoryRepository(250)}, 100)
+	if read := len(slices.Collect(pager.All())); read != 0 || pager.Err() == nil {
+		t.Fatalf("first loop : read %d people, Err() = %v, want 0 and connection lost", read, pager.Err())
+	}
+	if read := len(slices.Collect(pager.All())); read != 250 || pager.Err() != nil {
+		t.Errorf("second loop : read %d people, Err() = %v, want 250 and <nil>", read, pager.Err())
+	}
+}
+
+func TestNewPagerRejectsSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewPager(repo, %d) didn't panic", size)
+				}
+			}()
+			NewPager(newMemoryRepository(10), size)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "iter"
+
+type node[T any] struct {
+	value T
+	next  *node[T]
+}
+
+//! LinkedList -> a singly linked list. All() lets a for-range loop walk it, without exposing the nodes
+type LinkedList[T any] struct {
+	head, tail *node[T]
+}
+
+func (l *LinkedList[T]) Push(value T) {
+	n := &node[T]{value: value}
+	if l.tail == nil {
+		l.head, l.tail = n, n
+		return
+	}
+	l.tail.next = n
+	l.tail = n
+}
+
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.value) { //! false -> the loop did break (or return), so we stop walking
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLinkedList(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{"empty", nil},
+		{"one", []string{"John"}},
+		{"three, in push order", []string{"John", "Jane", "Alice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list LinkedList[string]
+			for _, value := range tt.values {
+				list.Push(value)
+			}
+			if got := slices.Collect(list.All()); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("All() = %v, want %v", got, tt.values)
+			}
+		})
+	}
+}
+
+//! a break stops the walk, and the list can be walked again from the start
+func TestLinkedListBreak(t *testing.T) {
+	var list LinkedList[int]
+	for n := 1; n <= 5; n++ {
+		list.Push(n)
+	}
+	var seen []int
+	for value := range list.All() {
+		seen = append(seen, value)
+		if value == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(seen, []int{1, 2}) {
+		t.Errorf("values before break = %v, want [1 2]", seen)
+	}
+	if got := slices.Collect(list.All()); len(got) != 5 {
+		t.Errorf("walking again = %v, want all 5", got)
+	}
+}
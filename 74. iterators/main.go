@@ -0,0 +1,58 @@
+//! Iterator functions (range-over-func, Go 1.23) -> a for-range loop over a FUNCTION. The function hands out the values one by one with yield
+//! nothing is computed before the loop asks for it, so "the first 5 adults out of 10000 people" only loads what it needs
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func isAdult(person Person) bool { return person.Age >= 18 }
+func name(person Person) string  { return person.Name }
+
+func main() {
+	//! 1. a linked list in a for-range loop
+	var list LinkedList[string]
+	list.Push("John")
+	list.Push("Jane")
+	list.Push("Alice")
+	for value := range list.All() {
+		fmt.Print(value, " ")
+	}
+	fmt.Println()
+
+	fmt.Println("--------------------------------")
+
+	//! 2. the first 5 adults out of 10000 people, read in pages of 100
+	repo := newMemoryRepository(10000)
+	pager := NewPager(repo, 100)
+	for person := range TakeIter(FilterIter(pager.All(), isAdult), 5) {
+		fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+	}
+	fmt.Println("pages fetched :", repo.fetches, "of 100, error :", pager.Err())
+
+	names := slices.Collect(MapIter(TakeIter(FilterIter(NewPager(repo, 100).All(), isAdult), 3), name))
+	fmt.Println("names :", strings.Join(names, ", "))
+
+	fmt.Println("--------------------------------")
+
+	//! 3. the order of the adapters matters
+	numbers := newMemoryRepository(20)
+	filterThenTake := slices.Collect(MapIter(TakeIter(FilterIter(NewPager(numbers, 5).All(), isAdult), 3), name))
+	takeThenFilter := slices.Collect(MapIter(FilterIter(TakeIter(NewPager(numbers, 5).All(), 3), isAdult), name))
+	fmt.Println("filter then take :", filterThenTake)
+	fmt.Println("take then filter :", takeThenFilter)
+}
+
+/*
+	Try :
+		1. Write a ReduceIter(seq, initial, func(acc, value) acc) and compute the average age of all adults
+		2. Remove the `if !yield(...) { return }` check from LinkedList.All and break out of the loop. What happens? (Go panics : the iterator kept calling yield)
+*/
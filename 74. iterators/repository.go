@@ -0,0 +1,31 @@
+package main
+
+import "fmt"
+
+//! Repository -> where people come from, one page at a time (like LIMIT/OFFSET in SQL, or ?page=2 in an API)
+type Repository interface {
+	FetchPage(offset, limit int) ([]Person, error)
+}
+
+//! memoryRepository -> a fake database. fetches counts the FetchPage calls, so we can see how many pages were really loaded
+type memoryRepository struct {
+	people  []Person
+	fetches int
+}
+
+func newMemoryRepository(count int) *memoryRepository {
+	people := make([]Person, count)
+	for i := range people {
+		people[i] = Person{Name: fmt.Sprintf("person%d", i+1), Age: (i*7)%30 + 5, Email: fmt.Sprintf("person%d@example.com", i+1)}
+	}
+	return &memoryRepository{people: people}
+}
+
+func (r *memoryRepository) FetchPage(offset, limit int) ([]Person, error) {
+	r.fetches++
+	if offset >= len(r.people) {
+		return nil, nil
+	}
+	end := min(offset+limit, len(r.people))
+	return r.people[offset:end], nil
+}
@@ -0,0 +1,76 @@
+//! os.Args -> the words typed after the program name. `go run main.go add 3 4` gives os.Args = [<program path> add 3 4]
+//! a tiny calculator with subcommands : the first word picks the function, the next two are the numbers
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+//! the same arithmetic functions as in the functions lessons
+func add(number1 int, number2 int) int {
+	return number1 + number2
+}
+
+func sub(number1 int, number2 int) int {
+	return number1 - number2
+}
+
+func mul(number1 int, number2 int) int {
+	return number1 * number2
+}
+
+//! commands -> a map from the subcommand name to the function. The functions are values here, like in the higher order function lesson
+//! adding a new subcommand is one more line in this map, no new if/switch branch
+var commands = map[string]func(int, int) int{
+	"add": add,
+	"sub": sub,
+	"mul": mul,
+}
+
+//! usage -> printed to stderr, then exit with 1 : a non-zero exit code tells the shell (or a script) that something went wrong
+func usage(problem string) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names) //! a map has no order, sort for a stable message
+
+	fmt.Fprintln(os.Stderr, "error :", problem)
+	fmt.Fprintln(os.Stderr, "usage : go run main.go <command> <number1> <number2>")
+	fmt.Fprintln(os.Stderr, "commands :", names)
+	os.Exit(1)
+}
+
+func main() {
+	args := os.Args[1:] //! os.Args[0] is the program itself
+
+	if len(args) != 3 {
+		usage(fmt.Sprintf("want 3 arguments, got %d", len(args)))
+	}
+
+	operation, ok := commands[args[0]]
+	if !ok {
+		usage(fmt.Sprintf("unknown command %q", args[0]))
+	}
+
+	number1, err := strconv.Atoi(args[1])
+	if err != nil {
+		usage(fmt.Sprintf("%q is not a number", args[1]))
+	}
+	number2, err := strconv.Atoi(args[2])
+	if err != nil {
+		usage(fmt.Sprintf("%q is not a number", args[2]))
+	}
+
+	fmt.Println(operation(number1, number2))
+}
+
+/*
+	Try :
+		1. Add a "div" command. What should happen for `div 1 0`?
+		2. Run `go run main.go add 3 4; echo $?` and `go run main.go pow 3 4; echo $?` to see the exit codes
+		3. Build it once with `go build -o calc main.go`, then run `./calc mul 6 7`
+*/
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//! the cursor escape codes, like the color codes of the ANSI color lesson :
+//! "\x1b[H" moves the cursor to the top left corner (home), "\x1b[2J" clears the screen. Together : draw the next frame over the old one
+const redrawPrefix = "\x1b[H\x1b[2J"
+
+//! dashboardMetrics -> the fixed layout : always these rows in this order, even when the collector doesn't have a value (then "-")
+var dashboardMetrics = []string{"requests", "errors", "active_users"}
+
+const labelWidth = 14
+
+//! minWidth -> the narrowest box : the borders "| " and " |", the label, one space and one bar of the sparkline
+const minWidth = labelWidth + 6
+
+//! Snapshot -> everything one frame shows. A plain value, so RenderDashboard can't depend on anything else
+type Snapshot struct {
+	Counters map[string]int64
+	Recent   []float64 //! recent requests per second, oldest first
+	Uptime   time.Duration
+}
+
+//! Ring -> the last N values. When it's full, a new value overwrites the oldest one
+type Ring struct {
+	values []float64
+	next   int
+	full   bool
+}
+
+func NewRing(size int) *Ring {
+	return &Ring{values: make([]float64, size)}
+}
+
+func (r *Ring) Add(value float64) {
+	r.values[r.next] = value
+	r.next = (r.next + 1) % len(r.values)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+//! Values -> a copy, oldest first
+func (r *Ring) Values() []float64 {
+	if !r.full {
+		return append([]float64(nil), r.values[:r.next]...)
+	}
+	return append(append([]float64(nil), r.values[r.next:]...), r.values[:r.next]...)
+}
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+//! sparkline -> one bar per value, scaled between the smallest and the biggest value. Only the last 'width' values fit
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return "(no data)"
+	}
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range values {
+		index := 0
+		if high > low {
+			index = int((value - low) / (high - low) * float64(len(sparkBars)-1))
+		}
+		builder.WriteRune(sparkBars[index])
+	}
+	return builder.String()
+}
+
+//! fit -> exactly 'width' characters : cut with "…" when too long, padded with spaces when too short
+//! characters, not bytes : "▁" is 3 bytes but one character on the screen
+func fit(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	length := utf8.RuneCountInString(text)
+	if length > width {
+		return string([]rune(text)[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-length)
+}
+
+//! RenderDashboard -> a pure function : the same snapshot and width always give the same text, so it can be checked against golden output
+//! width is the full width of the box, borders included. A width below minWidth draws the minWidth box, so a narrow terminal can't break the layout
+func RenderDashboard(snapshot Snapshot, width int) string {
+	width = max(width, minWidth)
+	inner := width - 4 //! "| " and " |"
+	border := "+" + strings.Repeat("-", width-2) + "+\n"
+	row := func(label, value string) string {
+		return "| " + fit(fit(label, labelWidth)+" "+value, inner) + " |\n"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(border)
+	builder.WriteString(row("uptime", snapshot.Uptime.Round(time.Second).String()))
+	for _, name := range dashboardMetrics {
+		value := "-" //! a missing metric keeps its row, so the layout doesn't jump
+		if count, ok := snapshot.Counters[name]; ok {
+			value = fmt.Sprint(count)
+		}
+		builder.WriteString(row(name, value))
+	}
+	builder.WriteString(row("req/s", sparkline(snapshot.Recent, inner-labelWidth-1)))
+	builder.WriteString(border)
+	return builder.String()
+}
+
+//! Run -> redraws on every tick until ctx is done, and returns how many frames were drawn
+//! the ticks come in as a channel : time.NewTicker(time.Second).C in main, a channel we fill by hand in the tests
+func Run(ctx context.Context, w io.Writer, ticks <-chan time.Time, snapshot func() Snapshot, width int) int {
+	redraws := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return redraws
+		case _, ok := <-ticks:
+			if !ok {
+				return redraws
+			}
+			fmt.Fprint(w, redrawPrefix+RenderDashboard(snapshot(), width))
+			redraws++
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+var fullSnapshot = Snapshot{
+	Counters: map[string]int64{"requests": 1234, "errors": 5, "active_users": 12},
+	Recent:   []float64{0, 10, 20, 30, 40, 50, 60, 70},
+	Uptime:   65*time.Second + 400*time.Millisecond,
+}
+
+//! golden output for a few snapshots
+func TestRenderDashboard(t *testing.T) {
+	long := Snapshot{Counters: map[string]int64{"requests": 12345678901234567}, Recent: make([]float64, 100)}
+	long.Recent[99] = 1 //! only the newest values of the sparkline fit
+	narrow := `+------------------+
+| uptime         … |
+| requests       … |
+| errors         5 |
+| active_users   … |
+| req/s          ▁ |
++------------------+
+`
+
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		width    int
+		want     string
+	}{
+		{"full snapshot", fullSnapshot, 40, `+--------------------------------------+
+| uptime         1m5s                  |
+| requests       1234                  |
+| errors         5                     |
+| active_users   12                    |
+| req/s          ▁▂▃▄▅▆▇█              |
++--------------------------------------+
+`},
+		{"missing metrics and no data", Snapshot{}, 40, `+--------------------------------------+
+| uptime         0s                    |
+| requests       -                     |
+| errors         -                     |
+| active_users   -                     |
+| req/s          (no data)             |
++--------------------------------------+
+`},
+		{"long values and sparkline are cut", long, 30, `+----------------------------+
+| uptime         0s          |
+| requests       1234567890… |
+| errors         -           |
+| active_users   -           |
+| req/s          ▁▁▁▁▁▁▁▁▁▁█ |
++----------------------------+
+`},
+		{"all values equal -> lowest bar", Snapshot{Recent: []float64{7, 7, 7}}, 30, `+----------------------------+
+| uptime         0s          |
+| requests       -           |
+| errors         -           |
+| active_users   -           |
+| req/s          ▁▁▁         |
++----------------------------+
+`},
+		//! too narrow : the minWidth box, never a panic. One bar left, so the sparkline is a single lowest bar
+		{"minWidth", fullSnapshot, minWidth, narrow},
+		{"one below minWidth", fullSnapshot, minWidth - 1, narrow},
+		{"width 5", fullSnapshot, 5, narrow},
+		{"width 0", fullSnapshot, 0, narrow},
+		{"negative width", fullSnapshot, -3, narrow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderDashboard(tt.snapshot, tt.width)
+			if got != tt.want {
+				t.Errorf("RenderDashboard() =\n%s\nwant\n%s", got, tt.want)
+			}
+			//! every line has the same width, counted in characters (the bars are 3 bytes each)
+			width := max(tt.width, minWidth)
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+				if utf8.RuneCountInString(line) != width {
+					t.Errorf("line %q is %d characters, want %d", line, utf8.RuneCountInString(line), width)
+				}
+			}
+		})
+	}
+}
+
+//! a long metric NAME is cut too, so the values stay in one column
+func TestLongMetricName(t *testing.T) {
+	saved := dashboardMetrics
+	dashboardMetrics = append([]string{}, "very_long_metric_name_for_the_cache")
+	defer func() { dashboardMetrics = saved }()
+
+	got := RenderDashboard(Snapshot{Counters: map[string]int64{"very_long_metric_name_for_the_cache": 7}}, 30)
+	if !strings.Contains(got, "| very_long_met… 7           |") {
+		t.Errorf("the long name is not cut :\n%s", got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcde", 5, "abcde"},
+		{"abcdef", 5, "abcd…"},
+		{"▁▂▃", 4, "▁▂▃ "}, //! characters, not bytes
+		{"", 2, "  "},
+		{"abc", 1, "…"},
+		{"abc", 0, ""},
+		{"abc", -2, ""}, //! no negative strings.Repeat, no [:-3]
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := fit(tt.text, tt.width); got != tt.want {
+				t.Errorf("fit(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"scaled low to high", []float64{0, 7}, 5, "▁█"},
+		{"only the newest fit", []float64{9, 0, 7}, 2, "▁█"},
+		{"no data", nil, 5, "(no data)"},
+		{"width 0", []float64{1, 2}, 0, ""},
+		{"negative width", []float64{1, 2}, -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+//! the ring buffer keeps the newest values, oldest first
+func TestRing(t *testing.T) {
+	tests := []struct {
+		name  string
+		added []float64
+		want  []float64
+	}{
+		{"empty", nil, nil},
+		{"not full", []float64{1, 2}, []float64{1, 2}},
+		{"exactly full", []float64{1, 2, 3}, []float64{1, 2, 3}},
+		{"wrapped around", []float64{1, 2, 3, 4, 5}, []float64{3, 4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := NewRing(3)
+			for _, value := range tt.added {
+				ring.Add(value)
+			}
+			if got := ring.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRingValuesIsACopy(t *testing.T) {
+	ring := NewRing(3)
+	ring.Add(1)
+	ring.Values()[0] = 99
+	if got := ring.Values()[0]; got != 1 {
+		t.Errorf("after changing the copy, Values()[0] = %v, want 1", got)
+	}
+}
+
+//! the refresh loop, with ticks sent by hand and the output captured in a strings.Builder
+func TestRunRedrawsOnEveryTick(t *testing.T) {
+	ticks := make(chan time.Time)
+	var output strings.Builder
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+	go func() { done <- Run(ctx, &output, ticks, func() Snapshot { return fullSnapshot }, 40) }()
+	for i := 0; i < 3; i++ {
+		ticks <- time.Time{}
+	}
+	cancel()
+
+	if redraws := <-done; redraws != 3 {
+		t.Errorf("3 ticks -> %d redraws, want 3", redraws)
+	}
+	frame := redrawPrefix + RenderDashboard(fullSnapshot, 40)
+	if got := output.String(); got != strings.Repeat(frame, 3) {
+		t.Errorf("output is not 3 frames, each starting with cursor home + clear :\n%q", got)
+	}
+}
+
+func TestRunStops(t *testing.T) {
+	closed := make(chan time.Time)
+	close(closed)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		ticks <-chan time.Time
+	}{
+		{"closed tick channel", context.Background(), closed},
+		{"cancelled context", cancelled, make(chan time.Time)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output strings.Builder
+			if redraws := Run(tt.ctx, &output, tt.ticks, nil, 40); redraws != 0 || output.Len() != 0 {
+				t.Errorf("Run() = %d redraws, %d bytes written, want 0 and 0", redraws, output.Len())
+			}
+		})
+	}
+}
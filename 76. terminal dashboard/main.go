@@ -0,0 +1,109 @@
+//! Terminal dashboard -> a small fixed layout which is redrawn in place every second : counters, a sparkline of recent values and the uptime
+//! the drawing (RenderDashboard) is a pure function, and the loop (Run) gets its ticks from a channel, so both are tested without a terminal and without waiting
+//! the req/s sparkline is smoothed by the window package of the sliding window lesson : the average of the last 5 seconds instead of the raw, spiky counts
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"slidingwindow/window"
+)
+
+//! smoothing -> the req/s row shows the requests of the last 5 seconds divided by 5
+const smoothing = 5 * time.Second
+
+//! collector -> a fake metrics collector : simulate() pretends some requests came in
+type collector struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	requests *window.Sliding[int] //! every batch of requests with its time, for the smoothed rate
+	recent   *Ring
+	now      func() time.Time //! time.Now in main, a fake clock in the tests
+	started  time.Time
+}
+
+func newCollector(now func() time.Time) *collector {
+	return &collector{
+		counters: map[string]int64{"requests": 0, "active_users": 0},
+		requests: window.New[int](smoothing, 1000, now),
+		recent:   NewRing(60),
+		now:      now,
+		started:  now(),
+	}
+}
+
+func (c *collector) simulate() {
+	c.record(rand.IntN(100), int64(10+rand.IntN(5)), rand.IntN(4) == 0)
+}
+
+//! record -> one batch of requests. The sparkline gets the smoothed rate, not the batch itself
+//! in the first 5 seconds the window isn't full yet, so the rate starts low and climbs
+func (c *collector) record(requests int, activeUsers int64, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counters["requests"] += int64(requests)
+	c.counters["active_users"] = activeUsers
+	if failed {
+		c.counters["errors"]++ //! "errors" only exists after the first error : until then the dashboard shows "-"
+	}
+	c.requests.Push(requests, c.now())
+	c.recent.Add(float64(c.requests.Sum(smoothing)) / smoothing.Seconds())
+}
+
+func (c *collector) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counters := make(map[string]int64, len(c.counters)) //! a copy : the renderer must not read the map while simulate writes it
+	for name, value := range c.counters {
+		counters[name] = value
+	}
+	return Snapshot{Counters: counters, Recent: c.recent.Values(), Uptime: c.now().Sub(c.started)}
+}
+
+func main() {
+	duration := flag.Duration("duration", 5*time.Second, "how long the live dashboard runs (0 -> until Ctrl+C)")
+	flag.Parse()
+
+	//! Ctrl+C (SIGINT) cancels ctx, Run returns, and the program ends normally : deferred calls run, the cursor is below the dashboard
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	metrics := newCollector(time.Now)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(200 * time.Millisecond):
+				metrics.simulate()
+			}
+		}
+	}()
+
+	redraws := Run(ctx, os.Stdout, ticker.C, metrics.Snapshot, 40)
+	fmt.Println("stopped after", redraws, "redraws")
+}
+
+/*
+	Try :
+		1. go run . -duration 0, and stop it with Ctrl+C
+		2. Add a "p99 latency" row with a value in milliseconds
+		3. Color the errors row red with the Printer from the ANSI color lesson, and make sure fit() still counts only the visible characters
+		4. Change smoothing to 1 second and watch the sparkline jump again
+*/
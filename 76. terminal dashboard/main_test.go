@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+//! fakeClock -> record moves the time by hand, one batch every 200ms like simulate in main
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+//! the sparkline shows the requests of the last 5 seconds divided by 5 : it climbs while the window fills, then a spike is spread over 5 seconds
+func TestCollectorSmoothsTheRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := newCollector(clock.Now)
+
+	for i := range 60 {
+		requests := 10 //! 10 per 200ms -> 50 req/s
+		if i == 30 {
+			requests = 100 //! one spike
+		}
+		c.record(requests, 12, false)
+		clock.now = clock.now.Add(200 * time.Millisecond)
+	}
+
+	recent := c.Snapshot().Recent
+	tests := []struct {
+		name  string
+		index int
+		want  float64
+	}{
+		{"first batch", 0, 2}, //! 10 requests / 5s : the window isn't full yet
+		{"window not full", 11, 24},
+		{"window just full", 24, 50},
+		{"steady", 29, 50},
+		{"spike is spread out", 30, 68}, //! +90 requests / 5s, not a jump to 500
+		{"spike 4.8s ago", 54, 68},
+		{"spike exactly 5s ago left the window", 55, 50},
+		{"steady again", 59, 50},
+	}
+	for _, tt := range tests {
+		if got := recent[tt.index]; got != tt.want {
+			t.Errorf("%s : recent[%d] = %v, want %v", tt.name, tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestCollectorSnapshot(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := newCollector(clock.Now)
+
+	snapshot := c.Snapshot()
+	if _, ok := snapshot.Counters["errors"]; ok {
+		t.Error(`"errors" exists before the first error, want it missing so the dashboard shows "-"`)
+	}
+	if len(snapshot.Recent) != 0 {
+		t.Errorf("Recent = %v before any request, want empty", snapshot.Recent)
+	}
+
+	c.record(30, 11, true)
+	clock.now = clock.now.Add(90 * time.Second)
+	c.record(20, 14, false)
+
+	snapshot = c.Snapshot()
+	tests := []struct {
+		name string
+		got  int64
+		want int64
+	}{
+		{"requests add up", snapshot.Counters["requests"], 50},
+		{"active users is the last value", snapshot.Counters["active_users"], 14},
+		{"errors", snapshot.Counters["errors"], 1},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s : got %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+	if snapshot.Uptime != 90*time.Second {
+		t.Errorf("Uptime = %v, want 1m30s from the injected clock", snapshot.Uptime)
+	}
+	if got := snapshot.Recent[1]; got != 4 { //! the 30 requests are 90s old, only the 20 are in the window
+		t.Errorf("rate after a quiet minute = %v, want 4", got)
+	}
+
+	//! the snapshot is a copy : changing it doesn't change the collector
+	snapshot.Counters["requests"] = 0
+	if got := c.Snapshot().Counters["requests"]; got != 50 {
+		t.Errorf("requests after editing a snapshot = %d, want 50", got)
+	}
+}
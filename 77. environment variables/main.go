@@ -0,0 +1,103 @@
+//! Environment variables -> key=value settings given to a program from outside : `APP_PORT=9090 go run main.go`
+//! the same program can run with another port, name or debug mode on every machine, without changing the code
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type Config struct {
+	Name  string
+	Port  int
+	Debug bool
+}
+
+//! loadConfig -> reads APP_NAME, APP_PORT and APP_DEBUG. An unset variable keeps its default, an invalid one is an error
+func loadConfig() (Config, error) {
+	config := Config{Name: "learn-go", Port: 8080, Debug: false} //! the defaults
+
+	if name, ok := os.LookupEnv("APP_NAME"); ok {
+		config.Name = name
+	}
+
+	if value, ok := os.LookupEnv("APP_PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("APP_PORT=%q: not a number: %w", value, err)
+		}
+		if port < 1 || port > 65535 {
+			return Config{}, fmt.Errorf("APP_PORT=%d: must be between 1 and 65535", port)
+		}
+		config.Port = port
+	}
+
+	if value, ok := os.LookupEnv("APP_DEBUG"); ok {
+		debug, err := strconv.ParseBool(value) //! accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False
+		if err != nil {
+			return Config{}, fmt.Errorf("APP_DEBUG=%q: not a bool: %w", value, err)
+		}
+		config.Debug = debug
+	}
+
+	return config, nil
+}
+
+func printConfig(config Config, err error) {
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Printf("Config Name : %s Config Port : %d Config Debug : %t\n", config.Name, config.Port, config.Debug)
+}
+
+func main() {
+	//! 1. os.Getenv -> "" when the variable is not set. But it could also be SET to "" : Getenv can't tell them apart
+	fmt.Printf("HOME = %q\n", os.Getenv("HOME"))
+	fmt.Printf("SURELY_NOT_SET = %q\n", os.Getenv("SURELY_NOT_SET"))
+
+	//! 2. os.LookupEnv -> the ok idiom, like reading from a map : ok is false only when the variable doesn't exist
+	os.Setenv("EMPTY_VALUE", "")
+	_, ok := os.LookupEnv("EMPTY_VALUE")
+	fmt.Println("EMPTY_VALUE is set :", ok)
+	_, ok = os.LookupEnv("SURELY_NOT_SET")
+	fmt.Println("SURELY_NOT_SET is set :", ok)
+
+	fmt.Println("--------------------------------")
+
+	//! 3. the config : first the defaults (unless the shell set the variables), then overridden with os.Setenv
+	//! os.Setenv changes the environment of THIS program only (and the programs it starts), never of the shell
+	printConfig(loadConfig())
+
+	os.Setenv("APP_NAME", "people-api")
+	os.Setenv("APP_PORT", "9090")
+	os.Setenv("APP_DEBUG", "true")
+	printConfig(loadConfig())
+
+	fmt.Println("--------------------------------")
+
+	//! 4. invalid values -> a wrapped error which says which variable and which value
+	os.Setenv("APP_PORT", "abc")
+	_, err := loadConfig()
+	fmt.Println("error :", err)
+	fmt.Println("is a strconv.ErrSyntax :", errors.Is(err, strconv.ErrSyntax)) //! %w keeps the original error
+
+	os.Setenv("APP_PORT", "70000")
+	printConfig(loadConfig())
+
+	os.Setenv("APP_PORT", "9090")
+	os.Setenv("APP_DEBUG", "yes")
+	printConfig(loadConfig())
+
+	os.Unsetenv("APP_DEBUG")
+	printConfig(loadConfig())
+}
+
+/*
+	Try :
+		1. APP_PORT=3000 APP_DEBUG=1 go run main.go
+		2. Add APP_TIMEOUT, parsed with time.ParseDuration ("5s", "1m30s")
+		3. Collect ALL the invalid variables with errors.Join, instead of stopping at the first one
+*/
@@ -0,0 +1,103 @@
+//! Embedding is not inheritance -> an embedded struct's methods are PROMOTED (p.Log works), and the outer type can "override" one by declaring its own
+//! but Go has no virtual methods : code inside the embedded type, or code which takes the embedded type, always calls the embedded version
+//! polymorphism in Go comes from interfaces
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+//! Logger -> the "base". Log writes one line, Info adds a level and calls Log
+type Logger struct {
+	Out io.Writer
+}
+
+//! a nil *Logger discards the message instead of panicking, so an embedded nil pointer is safe to call
+func (l *Logger) Log(msg string) {
+	if l == nil {
+		return
+	}
+	fmt.Fprintln(l.Out, msg)
+}
+
+func (l *Logger) Info(msg string) {
+	l.Log("INFO " + msg) //! l is a *Logger here, ALWAYS. It knows nothing about a PrefixLogger around it
+}
+
+//! PrefixLogger -> embeds *Logger and "overrides" Log. Every other method (Info, Out) is promoted from Logger
+type PrefixLogger struct {
+	*Logger
+	Prefix string
+}
+
+func (p *PrefixLogger) Log(msg string) {
+	p.Logger.Log(p.Prefix + msg) //! calling the "base" : the embedded field's name is its type name
+}
+
+//! ModuleLogger -> a second level of embedding : ModuleLogger -> PrefixLogger -> Logger
+type ModuleLogger struct {
+	*PrefixLogger
+	Module string
+}
+
+func (m *ModuleLogger) Log(msg string) {
+	m.PrefixLogger.Log("[" + m.Module + "] " + msg)
+}
+
+//! logTwice -> takes the embedded type. Whatever wraps the Logger, this calls Logger.Log
+func logTwice(l *Logger, msg string) {
+	l.Log(msg)
+	l.Log(msg)
+}
+
+//! the interface-based design : these functions take ANY type with a Log method, and call THAT type's Log
+type LogWriter interface {
+	Log(msg string)
+}
+
+func Info(l LogWriter, msg string) {
+	l.Log("INFO " + msg)
+}
+
+func NotifyAll(loggers []interface{ Log(string) }, msg string) {
+	for _, logger := range loggers {
+		logger.Log(msg)
+	}
+}
+
+func main() {
+	//! 1. it looks like inheritance ...
+	base := &Logger{Out: os.Stdout}
+	app := &PrefixLogger{Logger: base, Prefix: "[app] "}
+	app.Log("started")                   //! PrefixLogger.Log
+	app.Logger.Log("started, base only") //! Logger.Log, called explicitly
+
+	//! 2. ... but it isn't. Info is promoted from Logger, and inside it l.Log is Logger.Log : the prefix is lost
+	app.Info("promoted Info skips the override")
+	logTwice(app.Logger, "a *Logger parameter skips it too")
+
+	//! 3. the interface fix : Info(l LogWriter, ...) calls the Log of the value it got
+	Info(app, "interface Info uses the override")
+
+	fmt.Println("--------------------------------")
+
+	//! 4. two levels of embedding : each Log calls the one below it
+	db := &ModuleLogger{PrefixLogger: app, Module: "db"}
+	db.Log("connected")
+	db.Info("promoted Info skips both prefixes")
+	Info(db, "interface Info keeps both")
+
+	//! 5. a nil embedded pointer : the promoted call reaches Logger.Log with a nil receiver, which discards the message
+	silent := &PrefixLogger{Prefix: "[silent] "} //! Logger is nil
+	silent.Log("lost")
+	silent.Info("lost")
+}
+
+/*
+	Try :
+		1. Change Logger.Log to a value receiver (l Logger). What happens with the nil embedded pointer now?
+		2. Remove the `if l == nil` check and call silent.Log. Read the panic message
+		3. Add a JSONLogger with its own Log, and pass it to NotifyAll
+*/
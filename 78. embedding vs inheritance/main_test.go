@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWhichLogRuns(t *testing.T) {
+	var buffer bytes.Buffer
+	base := &Logger{Out: &buffer}
+	app := &PrefixLogger{Logger: base, Prefix: "[app] "}
+	db := &ModuleLogger{PrefixLogger: app, Module: "db"}
+	silent := &PrefixLogger{Prefix: "[silent] "} //! Logger is nil
+
+	tests := []struct {
+		name  string
+		write func()
+		want  string
+	}{
+		{"override adds the prefix", func() { app.Log("hi") }, "[app] hi\n"},
+		{"explicit base call has no prefix", func() { app.Logger.Log("hi") }, "hi\n"},
+		{"promoted Info is not virtual", func() { app.Info("hi") }, "INFO hi\n"},
+		{"function taking *Logger is not virtual", func() { logTwice(app.Logger, "hi") }, "hi\nhi\n"},
+		{"interface Info dispatches to PrefixLogger", func() { Info(app, "hi") }, "[app] INFO hi\n"},
+		{"two levels of embedding compose", func() { db.Log("connected") }, "[app] [db] connected\n"},
+		{"two levels, interface Info", func() { Info(db, "query") }, "[app] [db] INFO query\n"},
+		{"two levels, promoted Info skips both", func() { db.Info("query") }, "INFO query\n"},
+		{"NotifyAll calls each Log", func() {
+			NotifyAll([]interface{ Log(string) }{base, app, db}, "shutdown")
+		}, "shutdown\n[app] shutdown\n[app] [db] shutdown\n"},
+		{"nil embedded *Logger is a no-op", func() { silent.Log("lost"); silent.Info("lost") }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer.Reset()
+			tt.write()
+			if got := buffer.String(); got != tt.want {
+				t.Errorf("wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+//! a nil *Logger at the bottom of any wrapper -> nothing written, no panic
+func TestNilLoggerInside(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger LogWriter
+	}{
+		{"*Logger", (*Logger)(nil)},
+		{"*PrefixLogger", &PrefixLogger{}},
+		{"*ModuleLogger", &ModuleLogger{PrefixLogger: &PrefixLogger{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Info(tt.logger, "lost")
+		})
+	}
+}
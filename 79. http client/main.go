@@ -0,0 +1,98 @@
+//! HTTP client -> ask another server for data (GET), check the answer, and decode the JSON body into a struct
+//! to run without internet, the program starts its own small test server with httptest and fetches from it
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+//! client -> http.Get uses http.DefaultClient, which has NO timeout : a server which never answers blocks us forever
+//! our own client gives up after 2 seconds, for the whole request (connecting, sending, waiting, reading the body)
+var client = &http.Client{Timeout: 2 * time.Second}
+
+func fetchUser(url string) (Person, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return Person{}, fmt.Errorf("fetch user: %w", err) //! err already contains the method and the url : Get "http://...": ...
+	}
+	defer response.Body.Close() //! always close the body, otherwise the connection can't be reused
+
+	//! a 404 or 500 is NOT an error for client.Get : the server answered. We have to check the status ourselves
+	if response.StatusCode != http.StatusOK {
+		return Person{}, fmt.Errorf("fetch user %s: unexpected status %s", url, response.Status)
+	}
+
+	var person Person
+	if err := json.NewDecoder(response.Body).Decode(&person); err != nil {
+		return Person{}, fmt.Errorf("fetch user %s: decode: %w", url, err)
+	}
+	return person, nil
+}
+
+func printResult(person Person, err error) {
+	if err != nil {
+		fmt.Println("error :", err)
+		return
+	}
+	fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"John","age":20,"email":"john@example.com"}`)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"John","age":"twenty"}`) //! age is a string : Decode fails
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second): //! longer than the client waits
+			fmt.Fprint(w, `{"name":"Late"}`)
+		case <-r.Context().Done(): //! the client gave up, so the server stops waiting too
+		}
+	})
+
+	server := httptest.NewServer(mux) //! a real HTTP server on a free local port, like http://127.0.0.1:41234
+	defer server.Close()
+
+	//! 1. the happy path
+	printResult(fetchUser(server.URL + "/users/1"))
+
+	//! 2. the server answers, but not with 200 OK
+	printResult(fetchUser(server.URL + "/users/2")) //! no handler -> 404
+
+	//! 3. the server answers 200, but the JSON doesn't fit the struct
+	printResult(fetchUser(server.URL + "/broken"))
+
+	fmt.Println("--------------------------------")
+
+	//! 4. a slow server : the client gives up after 2 seconds
+	start := time.Now()
+	_, err := fetchUser(server.URL + "/slow")
+	fmt.Println("error :", err)
+	fmt.Println("gave up after about", time.Since(start).Round(time.Second))
+
+	//! the timeout error is a net.Error with Timeout() == true, so the caller can retry or show "try again later"
+	var timeoutErr interface{ Timeout() bool }
+	fmt.Println("is a timeout :", errors.As(err, &timeoutErr) && timeoutErr.Timeout())
+}
+
+/*
+	Try :
+		1. Change the client Timeout to 10 seconds. How long does the slow request take now?
+		2. Add a /users endpoint which returns a JSON list, and a fetchUsers function for it
+		3. Use http.NewRequestWithContext and context.WithTimeout instead of the client Timeout
+*/
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//! Doc -> the documentation of one package, already turned into plain strings, so the HTML (or text) renderer needs no go/ast
+type Doc struct {
+	Path     string //! the directory, with / separators
+	Name     string
+	Synopsis string //! the first sentence of the package comment
+	Text     string //! the whole package comment
+	Funcs    []Func
+	Types    []Type
+	Examples []Example //! examples of the package itself (func Example())
+	Notes    []string  //! what was skipped and why
+}
+
+type Func struct {
+	Name      string
+	Signature string //! "func Add(a, b int) int", without the body
+	Doc       string
+	Examples  []Example
+}
+
+type Type struct {
+	Name     string
+	Decl     string //! "type Greeter struct { ... }"
+	Doc      string
+	Funcs    []Func //! constructors : functions returning the type, like NewGreeter
+	Methods  []Func
+	Examples []Example
+}
+
+type Example struct {
+	Name   string //! the suffix after the underscore : "" for ExampleHello, "second" for ExampleHello_second
+	Code   string
+	Output string
+}
+
+//! PackageDoc -> parses every .go file in dir (tests too, for the examples) and extracts the exported API with go/doc
+//! files which the build constraints exclude (//go:build special, _windows.go on Linux ...) are skipped with a note
+func PackageDoc(dir string) (Doc, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Doc{}, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	var notes []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		//! the same check `go build` does, for the current GOOS, GOARCH and no extra tags
+		match, err := build.Default.MatchFile(dir, name)
+		if err != nil {
+			return Doc{}, err
+		}
+		if !match {
+			notes = append(notes, name+": skipped, excluded by build constraints")
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return Doc{}, err
+		}
+		if !strings.HasSuffix(name, "_test.go") && !hasExported(file) {
+			notes = append(notes, name+": no exported symbols")
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return Doc{}, fmt.Errorf("%s: no Go files", dir)
+	}
+
+	//! NewFromFiles checks that all files are one package (package x and package x_test are allowed), and attaches every ExampleXxx to Xxx
+	pkg, err := doc.NewFromFiles(fset, files, filepath.ToSlash(dir))
+	if err != nil {
+		return Doc{}, err
+	}
+
+	result := Doc{
+		Path:     filepath.ToSlash(dir),
+		Name:     pkg.Name,
+		Synopsis: pkg.Synopsis(pkg.Doc),
+		Text:     strings.TrimSpace(pkg.Doc),
+		Examples: examples(fset, pkg.Examples),
+		Notes:    notes,
+	}
+	for _, f := range pkg.Funcs {
+		result.Funcs = append(result.Funcs, function(fset, f))
+	}
+	for _, t := range pkg.Types {
+		typ := Type{Name: t.Name, Decl: source(fset, t.Decl), Doc: strings.TrimSpace(t.Doc), Examples: examples(fset, t.Examples)}
+		for _, f := range t.Funcs {
+			typ.Funcs = append(typ.Funcs, function(fset, f))
+		}
+		for _, m := range t.Methods {
+			typ.Methods = append(typ.Methods, function(fset, m))
+		}
+		result.Types = append(result.Types, typ)
+	}
+	return result, nil
+}
+
+//! hasExported -> does the file declare at least one exported func, method, type, const or var?
+func hasExported(file *ast.File) bool {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Name.IsExported() {
+				return true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						return true
+					}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if name.IsExported() {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func function(fset *token.FileSet, f *doc.Func) Func {
+	decl := *f.Decl //! a copy, so removing the body doesn't change the parsed file
+	decl.Body = nil
+	decl.Doc = nil
+	return Func{Name: f.Name, Signature: source(fset, &decl), Doc: strings.TrimSpace(f.Doc), Examples: examples(fset, f.Examples)}
+}
+
+//! source -> go/format prints a node as gofmt'ed Go code
+func source(fset *token.FileSet, node any) string {
+	if decl, ok := node.(*ast.GenDecl); ok {
+		copied := *decl
+		copied.Doc = nil
+		node = &copied
+	}
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, fset, node); err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return buffer.String()
+}
+
+//! examples -> the example body without the braces and one level of indentation, and the expected output
+func examples(fset *token.FileSet, list []*doc.Example) []Example {
+	var result []Example
+	for _, ex := range list {
+		code := source(fset, ex.Code)
+		code = strings.TrimSuffix(strings.TrimPrefix(code, "{\n"), "\n}")
+		lines := strings.Split(code, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimPrefix(line, "\t")
+		}
+		result = append(result, Example{Name: ex.Suffix, Code: strings.TrimSpace(strings.Join(lines, "\n")), Output: strings.TrimSpace(ex.Output)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+//! examples are attached to the right function or method
+func TestExamples(t *testing.T) {
+	greet := loadDocs(t, "testdata/greet")[0]
+	hello, tag := greet.Funcs[0], greet.Funcs[1]
+	greeter := greet.Types[0]
+
+	tests := []struct {
+		name   string
+		owner  string
+		wantIn string
+		got    []Example
+		want   []Example
+	}{
+		{"ExampleHello belongs to Hello", hello.Name, "Hello", hello.Examples, []Example{{Code: `fmt.Println(greet.Hello("  John "))`, Output: "Hello, John!"}}},
+		{"ExampleTag belongs to Tag", tag.Name, "Tag", tag.Examples, []Example{{Code: `fmt.Println(greet.Tag("John"))`, Output: "<John>"}}},
+		{"ExampleGreeter_Greet belongs to the method", greeter.Methods[0].Name, "Greet", greeter.Methods[0].Examples, []Example{{Code: "greeter := greet.NewGreeter(\"Hi\")\nfmt.Println(greeter.Greet(\"Jane\"))", Output: "Hi, Jane!"}}},
+		{"the constructor has no example", greeter.Funcs[0].Name, "NewGreeter", greeter.Funcs[0].Examples, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.owner != tt.wantIn {
+				t.Fatalf("found %s, want %s", tt.owner, tt.wantIn)
+			}
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("examples = %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+//! only the exported API, and a note for each file which adds nothing to it
+func TestPackageDoc(t *testing.T) {
+	tests := []struct {
+		dir       string
+		wantName  string
+		wantFuncs []string
+		wantTypes []string
+		wantNotes []string
+	}{
+		{"testdata/greet", "greet", []string{"Hello", "Tag"}, []string{"Greeter"}, []string{
+			"greet_special.go: skipped, excluded by build constraints",
+			"internal.go: no exported symbols",
+		}},
+		{"testdata/mathx", "mathx", []string{"Add"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			d := loadDocs(t, tt.dir)[0]
+			var funcs, types []string
+			for _, f := range d.Funcs {
+				funcs = append(funcs, f.Name)
+			}
+			for _, typ := range d.Types {
+				types = append(types, typ.Name)
+			}
+			if d.Name != tt.wantName || d.Path != tt.dir {
+				t.Errorf("package %s at %s, want %s at %s", d.Name, d.Path, tt.wantName, tt.dir)
+			}
+			if !reflect.DeepEqual(funcs, tt.wantFuncs) || !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("funcs %v, types %v, want %v, %v", funcs, types, tt.wantFuncs, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(d.Notes, tt.wantNotes) {
+				t.Errorf("notes = %q, want %q", d.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestPackageDocErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{"missing directory", "testdata/missing"},
+		{"a file, not a directory", "testdata/mathx/mathx.go"},
+		{"no Go files", t.TempDir()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PackageDoc(tt.dir); err == nil {
+				t.Errorf("PackageDoc(%q) : no error", tt.dir)
+			}
+		})
+	}
+}
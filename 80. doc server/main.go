@@ -0,0 +1,51 @@
+//! Doc server -> a tiny local documentation browser. It reads the doc comments and the exported functions and types of packages
+//! with go/parser and go/doc (the same packages godoc and pkg.go.dev use), and shows them as simple HTML pages, examples included
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+func main() {
+	serve := flag.String("serve", "", "serve the docs on this address, like localhost:6060")
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"testdata/mathx", "testdata/greet"} //! not in path order on purpose : the index sorts them
+	}
+
+	var docs []Doc
+	for _, dir := range dirs {
+		d, err := PackageDoc(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		docs = append(docs, d)
+	}
+
+	if *serve != "" {
+		fmt.Println("serving on http://" + *serve)
+		if err := http.ListenAndServe(*serve, Handler(docs)); err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, d := range docs {
+		fmt.Print(RenderText(d))
+		fmt.Println("--------------------------------")
+	}
+}
+
+/*
+	Try :
+		1. go run main.go docs.go render.go -serve localhost:6060, and open http://localhost:6060
+		2. Run it with -tags special : go run -tags special main.go docs.go render.go. Is greet_special.go still skipped? (yes : build.Default doesn't know the tags of THIS run)
+		3. Add constants and variables (pkg.Consts, pkg.Vars) to Doc and to the templates
+*/
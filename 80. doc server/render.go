@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//! RenderText -> a plain text form of a Doc. Easy to read in the terminal, and easy to compare with golden output
+func RenderText(d Doc) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "package %s (%s)\n", d.Name, d.Path)
+	if d.Text != "" {
+		for _, line := range strings.Split(d.Text, "\n") {
+			if line == "" {
+				builder.WriteString("\n") //! no trailing spaces on an empty line
+				continue
+			}
+			fmt.Fprintf(&builder, "    %s\n", line)
+		}
+	}
+	writeFunc := func(f Func, indent string) {
+		fmt.Fprintf(&builder, "%s%s\n", indent, f.Signature)
+		if f.Doc != "" {
+			fmt.Fprintf(&builder, "%s    %s\n", indent, f.Doc)
+		}
+		for _, ex := range f.Examples {
+			fmt.Fprintf(&builder, "%s    example%s -> %s\n", indent, exampleLabel(ex), ex.Output)
+		}
+	}
+	for _, f := range d.Funcs {
+		writeFunc(f, "")
+	}
+	for _, t := range d.Types {
+		fmt.Fprintf(&builder, "type %s\n", t.Name)
+		if t.Doc != "" {
+			fmt.Fprintf(&builder, "    %s\n", t.Doc)
+		}
+		for _, f := range t.Funcs {
+			writeFunc(f, "    ")
+		}
+		for _, m := range t.Methods {
+			writeFunc(m, "    ")
+		}
+	}
+	for _, note := range d.Notes {
+		fmt.Fprintf(&builder, "note: %s\n", note)
+	}
+	return builder.String()
+}
+
+func exampleLabel(ex Example) string {
+	if ex.Name == "" {
+		return ""
+	}
+	return " (" + ex.Name + ")"
+}
+
+//! html/template escapes every value for us : "<John>" in a doc comment or an example becomes &lt;John&gt;, never a real tag
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html><head><title>Packages</title></head><body>
+<h1>Packages</h1>
+<ul>
+{{- range .}}
+<li><a href="/pkg/{{.Path}}">{{.Path}}</a> {{.Synopsis}}</li>
+{{- end}}
+</ul>
+</body></html>
+`))
+
+var packageTemplate = template.Must(template.New("package").Parse(`<!DOCTYPE html>
+<html><head><title>{{.Name}}</title></head><body>
+<p><a href="/">all packages</a></p>
+<h1>package {{.Name}}</h1>
+<p>{{.Text}}</p>
+{{- define "func"}}
+<h3>{{.Name}}</h3>
+<pre>{{.Signature}}</pre>
+<p>{{.Doc}}</p>
+{{- range .Examples}}
+<details><summary>Example {{.Name}}</summary>
+<pre>{{.Code}}</pre>
+<p>Output:</p>
+<pre>{{.Output}}</pre>
+</details>
+{{- end}}
+{{- end}}
+{{- range .Funcs}}{{template "func" .}}{{end}}
+{{- range .Types}}
+<h2>type {{.Name}}</h2>
+<pre>{{.Decl}}</pre>
+<p>{{.Doc}}</p>
+{{- range .Funcs}}{{template "func" .}}{{end}}
+{{- range .Methods}}{{template "func" .}}{{end}}
+{{- end}}
+{{- if .Notes}}
+<h2>Notes</h2>
+<ul>{{range .Notes}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+</body></html>
+`))
+
+//! sortedByPath -> the index lists the packages in path order, whatever order the directories were given in
+func sortedByPath(docs []Doc) []Doc {
+	sorted := append([]Doc(nil), docs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	return sorted
+}
+
+func RenderIndex(w io.Writer, docs []Doc) error {
+	return indexTemplate.Execute(w, sortedByPath(docs))
+}
+
+func RenderPackage(w io.Writer, d Doc) error {
+	return packageTemplate.Execute(w, d)
+}
+
+//! Handler -> "/" is the index, "/pkg/<path>" one package page
+func Handler(docs []Doc) http.Handler {
+	byPath := map[string]Doc{}
+	for _, d := range docs {
+		byPath[d.Path] = d
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		RenderIndex(w, docs)
+	})
+	mux.HandleFunc("/pkg/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		d, ok := byPath[r.PathValue("path")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		RenderPackage(w, d)
+	})
+	return mux
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! go test *.go -update -> rewrites the golden files, after a change of the output which is on purpose
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+//! checkGolden -> compares got with testdata/<name>, byte for byte
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(golden) {
+		t.Errorf("output doesn't match %s (run 'go test *.go -update' if the change is on purpose)\n got:\n%s", path, got)
+	}
+}
+
+func loadDocs(t *testing.T, dirs ...string) []Doc {
+	t.Helper()
+	var docs []Doc
+	for _, dir := range dirs {
+		d, err := PackageDoc(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+//! golden output : a package of several files with an unexported-only file and a build-tag file, and a one-file package
+func TestRenderTextGolden(t *testing.T) {
+	tests := []struct {
+		dir    string
+		golden string
+	}{
+		{"testdata/greet", "greet.golden"},
+		{"testdata/mathx", "mathx.golden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			checkGolden(t, tt.golden, RenderText(loadDocs(t, tt.dir)[0]))
+		})
+	}
+}
+
+func TestRenderHTMLGolden(t *testing.T) {
+	docs := loadDocs(t, "testdata/mathx", "testdata/greet")
+	tests := []struct {
+		name   string
+		render func(*strings.Builder) error
+		golden string
+	}{
+		{"index", func(w *strings.Builder) error { return RenderIndex(w, docs) }, "index.html.golden"},
+		{"package greet", func(w *strings.Builder) error { return RenderPackage(w, docs[1]) }, "greet.html.golden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var page strings.Builder
+			if err := tt.render(&page); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, page.String())
+		})
+	}
+}
+
+//! HTML escaping : "<John>" from a doc comment and an example output must not become a tag
+func TestHTMLEscaping(t *testing.T) {
+	var page strings.Builder
+	if err := RenderPackage(&page, loadDocs(t, "testdata/greet")[0]); err != nil {
+		t.Fatal(err)
+	}
+	html := page.String()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"doc comment", "Tag wraps name in angle brackets, like &lt;John&gt;."},
+		{"example output", "<pre>&lt;John&gt;</pre>"},
+		{"code", "fmt.Println(greet.Hello(&#34;  John &#34;))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("the page doesn't contain %q", tt.want)
+			}
+		})
+	}
+	if strings.Contains(html, "<John>") {
+		t.Error("the page contains an unescaped <John>")
+	}
+}
+
+//! the index lists the packages in path order, and sortedByPath leaves its input alone
+func TestSortedByPath(t *testing.T) {
+	docs := []Doc{{Path: "testdata/mathx"}, {Path: "testdata/greet"}}
+	got := sortedByPath(docs)
+	if got[0].Path != "testdata/greet" || got[1].Path != "testdata/mathx" {
+		t.Errorf("sortedByPath() = %v, want greet before mathx", got)
+	}
+	if !reflect.DeepEqual(docs, []Doc{{Path: "testdata/mathx"}, {Path: "testdata/greet"}}) {
+		t.Errorf("sortedByPath changed its input : %v", docs)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(loadDocs(t, "testdata/mathx", "testdata/greet"))
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/", http.StatusOK, "testdata/greet"},
+		{"/pkg/testdata/greet", http.StatusOK, "Greeter says hello with a fixed greeting word."},
+		{"/pkg/testdata/mathx", http.StatusOK, "<h1>package mathx</h1>"},
+		{"/pkg/testdata/nothing", http.StatusNotFound, ""},
+		{"/nothing", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != tt.wantCode {
+				t.Errorf("GET %s -> %d, want %d", tt.path, recorder.Code, tt.wantCode)
+			}
+			if !strings.Contains(recorder.Body.String(), tt.wantBody) {
+				t.Errorf("GET %s : the body doesn't contain %q", tt.path, tt.wantBody)
+			}
+		})
+	}
+}
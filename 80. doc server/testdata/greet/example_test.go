@@ -0,0 +1,23 @@
+package greet_test
+
+import (
+	"fmt"
+
+	"greet"
+)
+
+func ExampleHello() {
+	fmt.Println(greet.Hello("  John "))
+	// Output: Hello, John!
+}
+
+func ExampleGreeter_Greet() {
+	greeter := greet.NewGreeter("Hi")
+	fmt.Println(greeter.Greet("Jane"))
+	// Output: Hi, Jane!
+}
+
+func ExampleTag() {
+	fmt.Println(greet.Tag("John"))
+	// Output: <John>
+}
@@ -0,0 +1,22 @@
+// Package greet builds greetings for people.
+//
+// It is a small fixture package for the doc server lesson.
+package greet
+
+// Greeter says hello with a fixed greeting word.
+type Greeter struct {
+	Word string
+}
+
+// NewGreeter returns a Greeter using word, or "Hello" when word is empty.
+func NewGreeter(word string) *Greeter {
+	if word == "" {
+		word = "Hello"
+	}
+	return &Greeter{Word: word}
+}
+
+// Greet returns "<word>, <name>!".
+func (g *Greeter) Greet(name string) string {
+	return g.Word + ", " + name + "!"
+}
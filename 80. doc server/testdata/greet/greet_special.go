@@ -0,0 +1,8 @@
+//go:build special
+
+package greet
+
+// Special only exists with -tags special.
+func Special() string {
+	return "special"
+}
@@ -0,0 +1,6 @@
+package greet
+
+// shout is unexported, so it is not part of the documentation.
+func shout(text string) string {
+	return text + "!"
+}
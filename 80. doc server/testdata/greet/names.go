@@ -0,0 +1,13 @@
+package greet
+
+import "strings"
+
+// Hello greets name with the default greeting.
+func Hello(name string) string {
+	return NewGreeter("").Greet(strings.TrimSpace(name))
+}
+
+// Tag wraps name in angle brackets, like <John>.
+func Tag(name string) string {
+	return "<" + name + ">"
+}
@@ -0,0 +1,8 @@
+package mathx
+
+import "fmt"
+
+func ExampleAdd() {
+	fmt.Println(Add(2, 3))
+	// Output: 5
+}
@@ -0,0 +1,7 @@
+// Package mathx has small integer helpers.
+package mathx
+
+// Add returns a + b.
+func Add(a, b int) int {
+	return a + b
+}
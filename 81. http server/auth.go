@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenTTL  = 15 * time.Minute
+	tokenBytes       = 32 //! 256 random bits : guessing a token is hopeless
+	maxTokenAttempts = 3  //! a collision of 256 random bits never happens, unless the random source is broken
+)
+
+var (
+	errNoToken      = errors.New("missing bearer token")
+	errUnknownToken = errors.New("unknown token")
+	errTokenExpired = errors.New("token expired")
+)
+
+//! authConfig -> who may log in (name -> PIN), how long a token lives (0 -> defaultTokenTTL), and whether the GET routes work without a token
+type authConfig struct {
+	pins        map[string]string
+	tokenTTL    time.Duration
+	publicReads bool
+}
+
+//! parseUsers -> "john:1234,jane:4321" into name -> PIN, for the -users flag
+func parseUsers(text string) (map[string]string, error) {
+	pins := map[string]string{}
+	for _, pair := range strings.Split(text, ",") {
+		name, pin, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || name == "" || pin == "" {
+			return nil, fmt.Errorf("user %q: want name:PIN", pair)
+		}
+		if _, exists := pins[name]; exists {
+			return nil, fmt.Errorf("user %q: listed twice", name)
+		}
+		pins[name] = pin
+	}
+	return pins, nil
+}
+
+type session struct {
+	name    string
+	expires time.Time
+}
+
+//! tokenStore -> the tokens of the logged in users, each with its own expiry time. The zero value is ready to use
+//! now and random can be replaced in the tests : a fake clock to let tokens expire without waiting, a reader which repeats itself to force a collision
+type tokenStore struct {
+	mu       sync.Mutex
+	sessions map[string]session
+	now      func() time.Time //! nil -> time.Now
+	random   io.Reader        //! nil -> crypto/rand.Reader
+}
+
+func (s *tokenStore) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+//! issue -> a new random token for name. A token which already exists is never handed out twice : then a new one is drawn
+func (s *tokenStore) issue(name string, ttl time.Duration) (string, time.Time, error) {
+	random := s.random
+	if random == nil {
+		random = rand.Reader
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions == nil {
+		s.sessions = map[string]session{}
+	}
+	now := s.clock()
+	for token, session := range s.sessions { //! forget the expired tokens, so the map doesn't grow forever
+		if !now.Before(session.expires) {
+			delete(s.sessions, token)
+		}
+	}
+
+	buffer := make([]byte, tokenBytes)
+	for range maxTokenAttempts {
+		if _, err := io.ReadFull(random, buffer); err != nil {
+			return "", time.Time{}, fmt.Errorf("generate token: %w", err)
+		}
+		token := base64.RawURLEncoding.EncodeToString(buffer) //! opaque text, safe in a header
+		if _, taken := s.sessions[token]; taken {
+			continue
+		}
+		expires := now.Add(ttl)
+		s.sessions[token] = session{name: name, expires: expires}
+		return token, expires, nil
+	}
+	return "", time.Time{}, fmt.Errorf("generate token: %d collisions in a row", maxTokenAttempts)
+}
+
+//! lookup -> the name the token belongs to. An expired token is removed
+func (s *tokenStore) lookup(token string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[token]
+	if !ok {
+		return "", errUnknownToken
+	}
+	if !s.clock().Before(session.expires) {
+		delete(s.sessions, token)
+		return "", errTokenExpired
+	}
+	return session.name, nil
+}
+
+type loginRequest struct {
+	Name string `json:"name"`
+	PIN  string `json:"pin"`
+}
+
+type loginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+//! handleLogin -> POST /login with {"name":..., "pin":...} returns a token for the Authorization header
+func (s *server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var login loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+	//! the same answer for an unknown name and a wrong PIN, and a compare which takes as long for a wrong first digit as for a wrong last one
+	pin, ok := s.auth.pins[login.Name]
+	if !ok || subtle.ConstantTimeCompare([]byte(pin), []byte(login.PIN)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("wrong name or PIN"))
+		return
+	}
+
+	ttl := s.auth.tokenTTL
+	if ttl == 0 {
+		ttl = defaultTokenTTL
+	}
+	token, expires, err := s.tokens.issue(login.Name, ttl)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, loginResponse{Token: token, ExpiresAt: expires})
+}
+
+//! userKey -> the context key of the logged in name. An unexported type, so no other package can use the same key by accident
+type userKey struct{}
+
+//! userFrom -> the name requireToken put into the context, "" for a public request
+func userFrom(ctx context.Context) string {
+	name, _ := ctx.Value(userKey{}).(string)
+	return name
+}
+
+//! requireToken -> a middleware : it wraps a handler, and only calls it with a valid "Authorization: Bearer <token>" header
+//! the name of the token goes into the request context, so the handler knows WHO made the request
+func (s *server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			unauthorized(w, errNoToken)
+			return
+		}
+		name, err := s.tokens.lookup(token)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), userKey{}, name)))
+	}
+}
+
+//! readable -> the GET routes : public when the config says so, otherwise behind requireToken like the rest
+func (s *server) readable(next http.HandlerFunc) http.HandlerFunc {
+	if s.auth.publicReads {
+		return next
+	}
+	return s.requireToken(next)
+}
+
+//! unauthorized -> 401 with a WWW-Authenticate header, which tells the client what kind of credentials to send
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="people"`)
+	writeError(w, http.StatusUnauthorized, err)
+}
+
+//! auditf -> one line in the audit log, starting with the name of the logged in user
+func (s *server) auditf(r *http.Request, format string, args ...any) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Printf("%s "+format, append([]any{userFrom(r.Context())}, args...)...)
+}
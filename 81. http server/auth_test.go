@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//! login -> a token through POST /login, the test fails without one
+func login(t *testing.T, s *server, name, pin string) string {
+	t.Helper()
+	recorder := serve(s, http.MethodPost, "/login", fmt.Sprintf(`{"name":%q,"pin":%q}`, name, pin))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("login %s : status = %d, body %s", name, recorder.Code, recorder.Body)
+	}
+	var answer loginResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&answer); err != nil {
+		t.Fatalf("decode login answer: %v", err)
+	}
+	return answer.Token
+}
+
+//! fakeClock -> the time only moves when the test says so
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func decodeError(t *testing.T, body *bytes.Buffer) string {
+	t.Helper()
+	var got map[string]string
+	if err := json.NewDecoder(body).Decode(&got); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	return got["error"]
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantError  string //! checked when the status is not 200
+	}{
+		{"right PIN", `{"name":"john","pin":"1234"}`, http.StatusOK, ""},
+		{"wrong PIN", `{"name":"john","pin":"4321"}`, http.StatusUnauthorized, "wrong name or PIN"},
+		{"unknown name, the same answer", `{"name":"bob","pin":"1234"}`, http.StatusUnauthorized, "wrong name or PIN"},
+		{"empty PIN", `{"name":"john"}`, http.StatusUnauthorized, "wrong name or PIN"},
+		{"invalid JSON", `{"name":`, http.StatusBadRequest, "invalid JSON: unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+			s := newTestServer()
+			s.auth.tokenTTL = time.Minute
+			s.tokens.now = clock.Now
+			recorder := serve(s, http.MethodPost, "/login", tt.body)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if recorder.Code != http.StatusOK {
+				if got := decodeError(t, recorder.Body); got != tt.wantError {
+					t.Errorf("error = %q, want %q", got, tt.wantError)
+				}
+				return
+			}
+			var answer loginResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&answer); err != nil {
+				t.Fatal(err)
+			}
+			if len(answer.Token) != 43 { //! 32 bytes in base64 without padding
+				t.Errorf("token %q has %d characters, want 43", answer.Token, len(answer.Token))
+			}
+			if want := clock.Now().Add(time.Minute); !answer.ExpiresAt.Equal(want) {
+				t.Errorf("expires_at = %v, want %v", answer.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+//! every route which changes something, with and without a valid token
+func TestMutationsNeedToken(t *testing.T) {
+	routes := []struct {
+		path, contentType, body string
+		wantStatus              int //! with a valid token
+	}{
+		{"/person", "application/json", `{"name":"Jane","age":21}`, http.StatusCreated},
+		{"/people/import", "text/csv", "name,age,email\nBob,30,bob@example.com\n", http.StatusOK},
+	}
+	authorizations := []struct {
+		name          string
+		authorization func(token string) string
+		wantError     string //! "" -> the request is allowed
+	}{
+		{"valid token", func(token string) string { return "Bearer " + token }, ""},
+		{"no header", func(string) string { return "" }, "missing bearer token"},
+		{"no Bearer prefix", func(token string) string { return token }, "missing bearer token"},
+		{"another scheme", func(string) string { return "Basic am9objoxMjM0" }, "missing bearer token"},
+		{"unknown token", func(string) string { return "Bearer not-a-token" }, "unknown token"},
+	}
+	for _, route := range routes {
+		for _, tt := range authorizations {
+			t.Run(route.path+" "+tt.name, func(t *testing.T) {
+				s := newTestServer()
+				request := httptest.NewRequest(http.MethodPost, route.path, strings.NewReader(route.body))
+				request.Header.Set("Content-Type", route.contentType)
+				if authorization := tt.authorization(login(t, s, "john", "1234")); authorization != "" {
+					request.Header.Set("Authorization", authorization)
+				}
+				recorder := httptest.NewRecorder()
+				s.routes().ServeHTTP(recorder, request)
+
+				if tt.wantError == "" {
+					if recorder.Code != route.wantStatus {
+						t.Errorf("status = %d, want %d (body %s)", recorder.Code, route.wantStatus, recorder.Body)
+					}
+					return
+				}
+				if recorder.Code != http.StatusUnauthorized {
+					t.Fatalf("status = %d, want 401", recorder.Code)
+				}
+				if got := recorder.Header().Get("WWW-Authenticate"); !strings.HasPrefix(got, "Bearer") {
+					t.Errorf("WWW-Authenticate = %q, want Bearer", got)
+				}
+				if got := decodeError(t, recorder.Body); got != tt.wantError {
+					t.Errorf("error = %q, want %q", got, tt.wantError)
+				}
+				if people := listPeople(t, s); len(people) != 0 {
+					t.Errorf("GET /people = %+v, want nobody", people)
+				}
+			})
+		}
+	}
+}
+
+//! with the fake clock : the token works until just before its expiry time, and is gone from then on
+func TestTokenExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	s := newTestServer()
+	s.auth.tokenTTL = 10 * time.Minute
+	s.tokens.now = clock.Now
+	token := login(t, s, "john", "1234")
+
+	clock.Advance(10*time.Minute - time.Nanosecond)
+	if recorder := serveAs(s, token, http.MethodPost, "/person", `{"name":"Jane","age":21}`); recorder.Code != http.StatusCreated {
+		t.Errorf("just before the expiry : status = %d, want 201", recorder.Code)
+	}
+
+	clock.Advance(time.Nanosecond)
+	recorder := serveAs(s, token, http.MethodPost, "/person", `{"name":"Bob","age":30}`)
+	if recorder.Code != http.StatusUnauthorized || decodeError(t, recorder.Body) != "token expired" {
+		t.Errorf("at the expiry : status = %d, want 401 token expired", recorder.Code)
+	}
+	//! the expired token was removed, so the next try doesn't know it at all
+	recorder = serveAs(s, token, http.MethodPost, "/person", `{"name":"Bob","age":30}`)
+	if got := decodeError(t, recorder.Body); got != "unknown token" {
+		t.Errorf("after the expiry : error = %q, want unknown token", got)
+	}
+}
+
+func TestDefaultTokenTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	s := newTestServer() //! tokenTTL 0
+	s.tokens.now = clock.Now
+	token := login(t, s, "john", "1234")
+
+	clock.Advance(defaultTokenTTL)
+	if _, err := s.tokens.lookup(token); !errors.Is(err, errTokenExpired) {
+		t.Errorf("after defaultTokenTTL : lookup err = %v, want %v", err, errTokenExpired)
+	}
+}
+
+//! the GET routes are public or need a token, as the config says. POST /login is always public
+func TestReadsPerConfig(t *testing.T) {
+	for _, publicReads := range []bool{true, false} {
+		for _, path := range []string{"/hello", "/person", "/people"} {
+			t.Run(fmt.Sprintf("public %v %s", publicReads, path), func(t *testing.T) {
+				s := newTestServer()
+				s.auth.publicReads = publicReads
+
+				want := http.StatusOK
+				if !publicReads {
+					want = http.StatusUnauthorized
+				}
+				if recorder := serve(s, http.MethodGet, path, ""); recorder.Code != want {
+					t.Errorf("without a token : status = %d, want %d", recorder.Code, want)
+				}
+				if recorder := serveAs(s, login(t, s, "jane", "4321"), http.MethodGet, path, ""); recorder.Code != http.StatusOK {
+					t.Errorf("with a token : status = %d, want 200", recorder.Code)
+				}
+			})
+		}
+	}
+}
+
+//! the handler knows who made the request : the audit log names the user of the token
+func TestAuditLog(t *testing.T) {
+	var audit bytes.Buffer
+	s := newTestServer()
+	s.audit = log.New(&audit, "audit: ", 0)
+
+	serveAs(s, login(t, s, "jane", "4321"), http.MethodPost, "/person", `{"name":"Bob","age":30}`)
+	serveCSV(t, s, readFixture(t, "mixed.csv")) //! as john
+
+	//! refused : no audit line
+	serveAs(s, "", http.MethodPost, "/person", `{"name":"Eve","age":30}`)
+
+	want := "audit: jane created \"Bob\"\naudit: john imported 2 people, 2 rows failed\n"
+	if got := audit.String(); got != want {
+		t.Errorf("audit log =\n%s\nwant\n%s", got, want)
+	}
+}
+
+//! many logins at the same time (run with -race) : every token is different and works
+func TestConcurrentLogins(t *testing.T) {
+	s := newTestServer()
+	const logins = 50
+	answers := make([]*httptest.ResponseRecorder, logins)
+	var wg sync.WaitGroup
+	for i := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answers[i] = serve(s, http.MethodPost, "/login", `{"name":"john","pin":"1234"}`) //! t.Fatal only in the test's own goroutine, so the checks come after Wait
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, recorder := range answers {
+		var answer loginResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&answer); recorder.Code != http.StatusOK || err != nil {
+			t.Fatalf("login : status = %d, decode error %v", recorder.Code, err)
+		}
+		token := answer.Token
+		if seen[token] {
+			t.Fatalf("token %q was handed out twice", token)
+		}
+		seen[token] = true
+		if name, err := s.tokens.lookup(token); name != "john" || err != nil {
+			t.Errorf("lookup(%q) = %q, %v, want john", token, name, err)
+		}
+	}
+}
+
+//! a random source which repeats itself : the second token collides with the first one and is drawn again
+func TestTokenCollisionRetry(t *testing.T) {
+	same := bytes.Repeat([]byte{1}, tokenBytes)
+	other := bytes.Repeat([]byte{2}, tokenBytes)
+
+	s := newTestServer()
+	s.tokens.random = bytes.NewReader(bytes.Join([][]byte{same, same, other}, nil))
+	first := login(t, s, "john", "1234")
+	second := login(t, s, "jane", "4321")
+	if first == second {
+		t.Fatalf("both logins got the token %q", first)
+	}
+	for token, want := range map[string]string{first: "john", second: "jane"} {
+		if name, err := s.tokens.lookup(token); name != want || err != nil {
+			t.Errorf("lookup(%q) = %q, %v, want %s", token, name, err, want)
+		}
+	}
+
+	//! only collisions : the login fails instead of handing out a token twice
+	s.tokens.random = bytes.NewReader(bytes.Repeat(same, maxTokenAttempts))
+	recorder := serve(s, http.MethodPost, "/login", `{"name":"jane","pin":"4321"}`)
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", recorder.Code)
+	}
+	if got := decodeError(t, recorder.Body); got != "generate token: 3 collisions in a row" {
+		t.Errorf("error = %q", got)
+	}
+}
+
+func TestParseUsers(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"john:1234", map[string]string{"john": "1234"}, false},
+		{"john:1234, jane:4321", map[string]string{"john": "1234", "jane": "4321"}, false},
+		{"john", nil, true},
+		{"john:", nil, true},
+		{":1234", nil, true},
+		{"john:1234,john:4321", nil, true},
+		{"", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseUsers(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseUsers(%q) error = %v, want error %v", tt.text, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUsers(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
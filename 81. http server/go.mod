@@ -0,0 +1,18 @@
+module httpserver
+
+go 1.22
+
+require (
+	orderedjson v0.0.0
+	shutdownorder v0.0.0
+	windowcounter v0.0.0
+)
+
+require slidingwindow v0.0.0 // indirect
+
+replace (
+	orderedjson => "../66. ordered json"
+	shutdownorder => "../51. shutdown order"
+	slidingwindow => "../106. sliding window"
+	windowcounter => "../72. window counter"
+)
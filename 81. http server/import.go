@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//! defaultMaxImportSize -> the biggest CSV body POST /people/import reads when server.maxImportSize is 0
+const defaultMaxImportSize = 1 << 20 //! 1 MiB
+
+var csvHeader = []string{"name", "age", "email"}
+
+//! ImportError -> one rejected row. Field is empty when the whole row is wrong (a wrong number of columns)
+type ImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	Created int           `json:"created"`
+	Failed  int           `json:"failed"`
+	Errors  []ImportError `json:"errors"`
+}
+
+//! status -> 200 when every row was created (or there were no rows), 422 when every row failed, 207 Multi-Status when some rows failed
+func (r ImportReport) status() int {
+	switch {
+	case r.Failed == 0:
+		return http.StatusOK
+	case r.Created == 0:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusMultiStatus
+	}
+}
+
+//! recordToPerson -> one CSV row into a Person. Every column is text, so the age is converted first, then the same rules as POST /person apply
+func recordToPerson(record []string) (Person, *ValidationError) {
+	if len(record) != len(csvHeader) {
+		return Person{}, &ValidationError{Message: fmt.Sprintf("want %d columns, got %d", len(csvHeader), len(record))}
+	}
+	age, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return Person{}, &ValidationError{Field: "age", Message: fmt.Sprintf("%q is not a number", record[1])}
+	}
+	person := Person{Name: strings.TrimSpace(record[0]), Age: age, Email: strings.TrimSpace(record[2])}
+	var invalid *ValidationError
+	if errors.As(validatePerson(person), &invalid) {
+		return Person{}, invalid
+	}
+	return person, nil
+}
+
+//! handleImport -> POST /people/import with a text/csv body : a header line, then one person per row
+//! the rows are read one at a time from the body (never the whole body at once), and every bad row goes into the report
+//! the valid people are stored only after the whole body was read : a body which is too big or not valid CSV creates nobody
+func (s *server) handleImport(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "text/csv" {
+		writeError(w, http.StatusUnsupportedMediaType, errors.New("Content-Type must be text/csv"))
+		return
+	}
+
+	maxSize := s.maxImportSize
+	if maxSize == 0 {
+		maxSize = defaultMaxImportSize
+	}
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, maxSize)) //! reading past maxSize returns an *http.MaxBytesError
+	reader.FieldsPerRecord = -1                                      //! rows with a wrong number of columns are reported, not a reason to stop
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, errors.New("empty body: want a header line "+strings.Join(csvHeader, ",")))
+		return
+	}
+	if err != nil {
+		writeReadError(w, err)
+		return
+	}
+	if !slices.Equal(header, csvHeader) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("header is %q, want %q", strings.Join(header, ","), strings.Join(csvHeader, ",")))
+		return
+	}
+
+	report := ImportReport{Errors: []ImportError{}} //! an empty list, not null, in the JSON
+	var valid []Person
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			writeReadError(w, err)
+			return
+		}
+		person, invalid := recordToPerson(record)
+		if invalid != nil {
+			line, _ := reader.FieldPos(0)
+			report.Errors = append(report.Errors, ImportError{Line: line, Field: invalid.Field, Message: invalid.Message})
+			report.Failed++
+			continue
+		}
+		valid = append(valid, person)
+	}
+
+	s.people.Add(valid...)
+	report.Created = len(valid)
+	s.auditf(r, "imported %d people, %d rows failed", report.Created, report.Failed)
+	writeJSON(w, report.status(), report)
+}
+
+//! writeReadError -> the body couldn't be read to the end : too big (413), or not valid CSV, like a quote that is never closed (400)
+func writeReadError(w http.ResponseWriter, err error) {
+	var tooBig *http.MaxBytesError
+	if errors.As(err, &tooBig) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("body is larger than %d bytes", tooBig.Limit))
+		return
+	}
+	writeError(w, http.StatusBadRequest, fmt.Errorf("invalid CSV: %w", err))
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! serveCSV -> POST /people/import as john
+func serveCSV(t *testing.T, s *server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/people/import", strings.NewReader(body))
+	request.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	request.Header.Set("Authorization", "Bearer "+login(t, s, "john", "1234"))
+	s.routes().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+//! listPeople -> what GET /people returns : the rows must be retrievable after the import
+func listPeople(t *testing.T, s *server) []Person {
+	t.Helper()
+	recorder := serve(s, http.MethodGet, "/people", "")
+	var people []Person
+	if err := json.NewDecoder(recorder.Body).Decode(&people); err != nil {
+		t.Fatalf("decode GET /people: %v", err)
+	}
+	return people
+}
+
+func TestImport(t *testing.T) {
+	jane := Person{Name: "Jane", Age: 21, Email: "jane@example.com"}
+	tests := []struct {
+		fixture    string
+		wantStatus int
+		wantReport ImportReport
+		wantPeople []Person
+	}{
+		{
+			fixture:    "valid.csv",
+			wantStatus: http.StatusOK,
+			wantReport: ImportReport{Created: 3, Errors: []ImportError{}},
+			wantPeople: []Person{jane, {Name: "Doe, John", Age: 35, Email: "john.doe@example.com"}, {Name: "Baby"}},
+		},
+		{
+			fixture:    "invalid.csv",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantReport: ImportReport{Failed: 4, Errors: []ImportError{
+				{Line: 2, Field: "name", Message: "must not be empty"},
+				{Line: 3, Field: "age", Message: `"twenty" is not a number`},
+				{Line: 4, Field: "age", Message: "must be 0 or more"},
+				{Line: 5, Message: "want 3 columns, got 2"},
+			}},
+			wantPeople: []Person{},
+		},
+		{
+			fixture:    "mixed.csv",
+			wantStatus: http.StatusMultiStatus,
+			wantReport: ImportReport{Created: 2, Failed: 2, Errors: []ImportError{
+				{Line: 3, Field: "age", Message: "must be 0 or more"},
+				{Line: 5, Field: "age", Message: `"abc" is not a number`},
+			}},
+			wantPeople: []Person{jane, {Name: "Bob", Age: 30, Email: "bob@example.com"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			s := newTestServer()
+			recorder := serveCSV(t, s, readFixture(t, tt.fixture))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			var report ImportReport
+			if err := json.NewDecoder(recorder.Body).Decode(&report); err != nil {
+				t.Fatalf("decode report: %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.wantReport) {
+				t.Errorf("report = %+v, want %+v", report, tt.wantReport)
+			}
+			if got := listPeople(t, s); !reflect.DeepEqual(got, tt.wantPeople) {
+				t.Errorf("GET /people = %+v, want %+v", got, tt.wantPeople)
+			}
+		})
+	}
+}
+
+//! these bodies are refused as a whole : an error JSON, and nobody is created
+func TestImportRefused(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxImportSize int64
+		body          string
+		wantStatus    int
+		wantError     string
+	}{
+		{"empty body", 0, "", http.StatusBadRequest, "empty body: want a header line name,age,email"},
+		{"wrong header", 0, "name,email\nJane,jane@example.com\n", http.StatusBadRequest, `header is "name,email", want "name,age,email"`},
+		{"broken quote", 0, "name,age,email\nJane,21,jane@example.com\n\"Bob,30,bob@example.com\n", http.StatusBadRequest, `invalid CSV: parse error on line 3, column 25: extraneous or missing " in quoted-field`},
+		//! the first row fits, the second doesn't : the first one is not created either
+		{"larger than the limit", 50, "name,age,email\nJane,21,jane@example.com\nBob,30,bob@example.com\n", http.StatusRequestEntityTooLarge, "body is larger than 50 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+			s.maxImportSize = tt.maxImportSize
+			recorder := serveCSV(t, s, tt.body)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			var got map[string]string
+			if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if got["error"] != tt.wantError {
+				t.Errorf("error = %q, want %q", got["error"], tt.wantError)
+			}
+			if people := listPeople(t, s); len(people) != 0 {
+				t.Errorf("GET /people = %+v, want nobody", people)
+			}
+		})
+	}
+}
+
+func TestImportNeedsCSV(t *testing.T) {
+	s := newTestServer()
+	recorder := serveAs(s, login(t, s, "john", "1234"), http.MethodPost, "/people/import", readFixture(t, "valid.csv")) //! no Content-Type
+
+	if recorder.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+//! the default limit is used when maxImportSize is 0 : a body just below it is read to the end
+func TestImportDefaultLimit(t *testing.T) {
+	row := "Jane,21,jane@example.com\n"
+	rows := (defaultMaxImportSize - len("name,age,email\n")) / len(row)
+	recorder := serveCSV(t, newTestServer(), "name,age,email\n"+strings.Repeat(row, rows))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d (body %.100s)", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	recorder = serveCSV(t, newTestServer(), "name,age,email\n"+strings.Repeat(row, rows+1))
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("one row more : status = %d, want %d", recorder.Code, http.StatusRequestEntityTooLarge)
+	}
+}
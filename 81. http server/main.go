@@ -0,0 +1,153 @@
+//! HTTP server -> handlers answer requests : plain text, a Person as JSON, a Person sent to us as JSON, and many people sent as CSV (import.go)
+//! the routes which change something need a token from POST /login (auth.go)
+//! the handlers are methods of a server struct : whatever they need later (a database, a logger) becomes a field, instead of a global variable
+//! Ctrl+C or SIGTERM shuts the server down gracefully : the running requests finish, then the people are saved (shutdown.go)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"shutdownorder/lifecycle"
+)
+
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+type server struct {
+	person        Person      //! the person GET /person returns
+	people        personStore //! the people created by POST /person and POST /people/import
+	maxImportSize int64       //! the biggest CSV body in bytes, 0 -> defaultMaxImportSize
+	auth          authConfig
+	tokens        tokenStore
+	audit         *log.Logger //! who changed what, nil -> no audit log
+	metrics       requestMetrics
+}
+
+//! routes -> "METHOD /path" patterns (Go 1.22+). A GET request to a POST-only path gets 405 Method Not Allowed automatically
+//! the whole mux is wrapped in countRequests, so GET /metrics sees every request (metrics.go)
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", s.handleLogin)
+	mux.HandleFunc("GET /hello", s.readable(s.handleHello))
+	mux.HandleFunc("GET /person", s.readable(s.handleGetPerson))
+	mux.HandleFunc("GET /people", s.readable(s.handleListPeople))
+	mux.HandleFunc("POST /person", s.requireToken(s.handleCreatePerson))
+	mux.HandleFunc("POST /people/import", s.requireToken(s.handleImport))
+	mux.HandleFunc("GET /metrics", s.readable(s.handleMetrics))
+	return s.countRequests(mux)
+}
+
+func (s *server) handleHello(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "Hello, World!")
+}
+
+func (s *server) handleGetPerson(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.person)
+}
+
+func (s *server) handleCreatePerson(w http.ResponseWriter, r *http.Request) {
+	var person Person
+	if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+	if err := validatePerson(person); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.people.Add(person)
+	s.auditf(r, "created %q", person.Name)
+	writeJSON(w, http.StatusCreated, person) //! 201 Created : a new resource was made
+}
+
+//! writeJSON -> the header must be set BEFORE WriteHeader, and WriteHeader before the body
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "the address to listen on")
+	maxImport := flag.Int64("max-import", defaultMaxImportSize, "the biggest CSV body POST /people/import accepts, in bytes")
+	users := flag.String("users", "john:1234", "who may log in, as name:PIN pairs separated by commas")
+	tokenTTL := flag.Duration("token-ttl", defaultTokenTTL, "how long a token from POST /login is valid")
+	publicReads := flag.Bool("public-reads", true, "the GET routes work without a token")
+	dataPath := flag.String("data", "", "a JSON file the people are loaded from at the start and saved to at shutdown (empty -> only in memory)")
+	shutdownTimeout := flag.Duration("shutdown-timeout", defaultShutdownTimeout, "how long the shutdown may take")
+	flag.Parse()
+
+	pins, err := parseUsers(*users)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := &server{
+		person:        Person{Name: "John", Age: 20, Email: "john@example.com"},
+		maxImportSize: *maxImport,
+		auth:          authConfig{pins: pins, tokenTTL: *tokenTTL, publicReads: *publicReads},
+		audit:         log.New(os.Stderr, "audit: ", log.LstdFlags),
+	}
+
+	//! the resources in the order they are opened. The HTTP server is registered by serveUntilDone, after them, so it's closed first
+	resources := lifecycle.New()
+	if *dataPath != "" {
+		if err := s.people.ReadFile(*dataPath); err != nil {
+			log.Fatal(err)
+		}
+		resources.Register("people file", func(ctx context.Context) error { return s.people.WriteFile(*dataPath) })
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err) //! like "address already in use"
+	}
+
+	//! Ctrl+C sends SIGINT, "kill" and container runtimes send SIGTERM : both end ctx
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println("listening on http://" + *addr)
+	results, err := serveUntilDone(ctx, listener, s.routes(), resources, *shutdownTimeout)
+	for _, result := range results {
+		log.Printf("shutdown %s : err = %v, took %v", result.Name, result.Err, result.Duration)
+	}
+	if err != nil {
+		log.Print(err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+/*
+	Try (in a second terminal) :
+		1. curl localhost:8080/hello
+		2. curl localhost:8080/person
+		3. curl -i -X POST -d '{"name":"Jane","age":21,"email":"jane@example.com"}' localhost:8080/person -> 401, no token
+		4. curl -X POST -d '{"name":"john","pin":"1234"}' localhost:8080/login, then TOKEN=<the token from the answer>
+		5. Run 3. again with -H "Authorization: Bearer $TOKEN"
+		6. curl -i -X POST -H "Authorization: Bearer $TOKEN" -d '{"name":"Jane","age":-1}' localhost:8080/person
+		7. curl -i -X DELETE localhost:8080/person
+		8. curl -i -X POST -H "Authorization: Bearer $TOKEN" -H 'Content-Type: text/csv' --data-binary @people.csv localhost:8080/people/import, with a few bad rows in people.csv
+		9. curl localhost:8080/people
+		10. Restart with -max-import 20 and run 8. again
+		11. Restart with -token-ttl 10s -public-reads=false. What answers 2. before and after the token expired?
+		12. curl localhost:8080/metrics a few times. When does the count go down again?
+		13. Restart with -data people.json, create a person, stop the server with Ctrl+C and start it again. Is the person still in 9.?
+*/
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"orderedjson/jsonorder"
+)
+
+//! httptest.NewRecorder is a fake ResponseWriter : the handler writes into it, and we read the status, headers and body back
+//! the requests go straight to the mux, no port is opened
+func newTestServer() *server {
+	return &server{
+		person: Person{Name: "John", Age: 20, Email: "john@example.com"},
+		auth:   authConfig{pins: map[string]string{"john": "1234", "jane": "4321"}, publicReads: true},
+	}
+}
+
+//! serve -> a request without a token
+func serve(s *server, method, path, body string) *httptest.ResponseRecorder {
+	return serveAs(s, "", method, path, body)
+}
+
+//! serveAs -> a request with "Authorization: Bearer <token>", or without the header when token is ""
+func serveAs(s *server, token, method, path, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	s.routes().ServeHTTP(recorder, request)
+	return recorder
+}
+
+//! assertJSON -> the body is the same JSON document as want. Both go through jsonorder.CanonicalJSON (66. ordered json) :
+//! the key order, the spaces and the new line at the end don't matter, but a missing, extra or changed field does. Decoding into a struct would ignore an extra field
+func assertJSON(t *testing.T, body *bytes.Buffer, want string) {
+	t.Helper()
+	got, err := jsonorder.CanonicalJSON(body.Bytes())
+	if err != nil {
+		t.Fatalf("body %q is not JSON : %v", body, err)
+	}
+	canonicalWant, err := jsonorder.CanonicalJSON([]byte(want))
+	if err != nil {
+		t.Fatalf("want %q is not JSON : %v", want, err)
+	}
+	if !bytes.Equal(got, canonicalWant) {
+		t.Errorf("body = %s, want %s", got, canonicalWant)
+	}
+}
+
+//! errorJSON -> the body of an error answer. json.Marshal escapes the message, so any text works
+func errorJSON(message string) string {
+	data, _ := json.Marshal(map[string]string{"error": message})
+	return string(data)
+}
+
+func TestHello(t *testing.T) {
+	recorder := serve(newTestServer(), http.MethodGet, "/hello", "")
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if got := recorder.Body.String(); got != "Hello, World!\n" {
+		t.Errorf("body = %q, want %q", got, "Hello, World!\n")
+	}
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", got)
+	}
+}
+
+func TestGetPerson(t *testing.T) {
+	s := newTestServer()
+	recorder := serve(s, http.MethodGet, "/person", "")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	assertJSON(t, recorder.Body, `{"name":"John","age":20,"email":"john@example.com"}`)
+}
+
+func TestCreatePerson(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string //! checked when the status is 201 : the created person, every field written out
+		wantError  string //! checked when the status is 400
+	}{
+		{
+			name:       "valid",
+			body:       `{"name":"Jane","age":21,"email":"jane@example.com"}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"name":"Jane","age":21,"email":"jane@example.com"}`,
+		},
+		{name: "age zero is fine", body: `{"name":"Baby","age":0}`, wantStatus: http.StatusCreated, wantBody: `{"name":"Baby","age":0,"email":""}`},
+		{name: "negative age", body: `{"name":"Jane","age":-1}`, wantStatus: http.StatusBadRequest, wantError: "age must be 0 or more"},
+		{name: "no name", body: `{"age":21}`, wantStatus: http.StatusBadRequest, wantError: "name must not be empty"},
+		{name: "invalid JSON", body: `{"name":`, wantStatus: http.StatusBadRequest, wantError: "invalid JSON: unexpected EOF"},
+		{name: "wrong type", body: `{"age":"twenty"}`, wantStatus: http.StatusBadRequest, wantError: "invalid JSON: json: cannot unmarshal string into Go struct field Person.age of type int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+			recorder := serveAs(s, login(t, s, "john", "1234"), http.MethodPost, "/person", tt.body)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if recorder.Code == http.StatusCreated {
+				assertJSON(t, recorder.Body, tt.wantBody)
+				return
+			}
+			assertJSON(t, recorder.Body, errorJSON(tt.wantError))
+		})
+	}
+}
+
+//! a created person is stored : GET /people returns it
+func TestCreatedPersonIsListed(t *testing.T) {
+	s := newTestServer()
+	token := login(t, s, "john", "1234")
+	serveAs(s, token, http.MethodPost, "/person", `{"name":"Jane","age":21,"email":"jane@example.com"}`)
+	serveAs(s, token, http.MethodPost, "/person", `{"name":"Jane","age":-1}`) //! refused, not stored
+
+	assertJSON(t, serve(s, http.MethodGet, "/people", "").Body, `[{"name":"Jane","age":21,"email":"jane@example.com"}]`)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	recorder := serve(newTestServer(), http.MethodDelete, "/person", "")
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	recorder := serve(newTestServer(), http.MethodGet, "/nothing", "")
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
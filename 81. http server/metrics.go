@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"windowcounter/counter"
+)
+
+//! requestMetrics -> counts every request with the sliding window counter of the window counter lesson (72. window counter)
+//! the zero value works, like personStore and tokenStore : the counter is made on the first request
+type requestMetrics struct {
+	once     sync.Once
+	requests *counter.SlidingWindowCounter
+	now      func() time.Time //! nil -> time.Now. A fake clock in the tests
+}
+
+func (m *requestMetrics) sliding() *counter.SlidingWindowCounter {
+	m.once.Do(func() { m.requests = counter.NewSlidingWindowCounter(time.Minute) })
+	return m.requests
+}
+
+func (m *requestMetrics) clock() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+	return m.now()
+}
+
+//! countRequests -> a middleware around the whole mux : every request is counted, also a 401, a 404 and GET /metrics itself
+func (s *server) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.metrics.sliding().Incr(s.metrics.clock())
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]int{
+		"requests_per_minute": s.metrics.sliding().Count(s.metrics.clock(), time.Minute),
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+//! GET /metrics with a clock we move by hand : the count is the requests of the last 60 seconds, the /metrics request itself included
+func TestMetrics(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s := newTestServer()
+	s.metrics.now = clock.Now
+	start := clock.Now()
+
+	//! +0s : a found, a not found and a refused request. All three are counted
+	serve(s, http.MethodGet, "/hello", "")
+	serve(s, http.MethodGet, "/nothing-here", "")
+	serve(s, http.MethodPost, "/person", `{"name":"Jane"}`)
+	clock.Advance(30 * time.Second)
+	serve(s, http.MethodGet, "/person", "") //! +30s
+
+	tests := []struct {
+		at   time.Duration
+		want string
+	}{
+		{30 * time.Second, `{"requests_per_minute":5}`},  //! 4 requests and this one
+		{59 * time.Second, `{"requests_per_minute":6}`},  //! the one before is counted too
+		{60 * time.Second, `{"requests_per_minute":4}`},  //! the 3 of +0s left the window
+		{119 * time.Second, `{"requests_per_minute":2}`}, //! the /metrics of +60s and this one. +59s just left
+		{300 * time.Second, `{"requests_per_minute":1}`}, //! only this one
+	}
+	for _, tt := range tests {
+		clock.now = start.Add(tt.at)
+		recorder := serve(s, http.MethodGet, "/metrics", "")
+		if recorder.Code != http.StatusOK {
+			t.Errorf("GET /metrics at +%v = %d, want 200", tt.at, recorder.Code)
+		}
+		assertJSON(t, recorder.Body, tt.want)
+		if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+	}
+}
+
+//! with -public-reads=false, GET /metrics needs a token like the other GET routes. The refused request is still counted
+func TestMetricsNeedsTokenWhenReadsArePrivate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s := newTestServer()
+	s.auth.publicReads = false
+	s.metrics.now = clock.Now
+
+	if recorder := serve(s, http.MethodGet, "/metrics", ""); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("GET /metrics without a token = %d, want 401", recorder.Code)
+	}
+	token := login(t, s, "john", "1234")
+	recorder := serveAs(s, token, http.MethodGet, "/metrics", "")
+	if recorder.Code != http.StatusOK {
+		t.Errorf("GET /metrics with a token = %d, want 200", recorder.Code)
+	}
+	assertJSON(t, recorder.Body, `{"requests_per_minute":3}`)
+}
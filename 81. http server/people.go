@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+//! ValidationError -> which field is wrong and why. The CSV import reports both, POST /person only the message
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+//! validatePerson -> the rules for every person we store, whether it comes as JSON or as a CSV row
+func validatePerson(person Person) error {
+	if person.Name == "" {
+		return &ValidationError{Field: "name", Message: "must not be empty"}
+	}
+	if person.Age < 0 {
+		return &ValidationError{Field: "age", Message: "must be 0 or more"}
+	}
+	return nil
+}
+
+//! personStore -> the people created through the API, in memory. The zero value is an empty store
+//! the handlers run in their own goroutine per request, so every access takes the mutex
+type personStore struct {
+	mu     sync.Mutex
+	people []Person
+}
+
+func (s *personStore) Add(people ...Person) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.people = append(s.people, people...)
+}
+
+//! All -> a copy : the caller can't change the store, and can read it after the mutex is released
+func (s *personStore) All() []Person {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Person{}, s.people...)
+}
+
+//! ReadFile -> loads the people saved by WriteFile. A missing file is not an error : the server simply starts empty
+func (s *personStore) ReadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var people []Person
+	if err := json.Unmarshal(data, &people); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	s.Add(people...)
+	return nil
+}
+
+//! WriteFile -> saves every person as JSON. The data goes into a temporary file first, which is renamed over the old one :
+//! a crash in the middle leaves the old file, never a half written one
+func (s *personStore) WriteFile(path string) error {
+	data, err := json.MarshalIndent(s.All(), "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name()) //! fails after the rename, which is fine : then there is nothing left to remove
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil { //! on the disk before the rename
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (s *server) handleListPeople(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.people.All())
+}
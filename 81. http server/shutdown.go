@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"shutdownorder/lifecycle"
+)
+
+//! defaultShutdownTimeout -> how long the closers of a shutdown may take together
+const defaultShutdownTimeout = 10 * time.Second
+
+//! serveUntilDone -> answers requests on listener until ctx is done (Ctrl+C or SIGTERM in main), then closes everything registered in resources
+//! the HTTP server is registered LAST, so it's closed FIRST : Shutdown stops taking new requests and waits for the running ones,
+//! and only then the resources registered before it (the people file) are closed. Like in the shutdown order lesson (51. shutdown order)
+//! the error is not nil when the server stopped by itself (the listener broke). The resources are closed in that case too
+func serveUntilDone(ctx context.Context, listener net.Listener, handler http.Handler, resources *lifecycle.Lifecycle, timeout time.Duration) ([]lifecycle.ShutdownResult, error) {
+	httpServer := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	resources.Register("http server", httpServer.Shutdown)
+
+	served := make(chan error, 1)
+	go func() { served <- httpServer.Serve(listener) }()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-served: //! Serve only returns early with an error
+	}
+
+	//! a new context : ctx is already done, and the closers still need their time
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return resources.Shutdown(shutdownCtx), err
+}
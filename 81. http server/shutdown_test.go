@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"shutdownorder/lifecycle"
+)
+
+//! startServer -> serveUntilDone on a free port in the background. Cancel the context to shut it down, then read the results from the channel
+func startServer(t *testing.T, handler http.Handler, resources *lifecycle.Lifecycle) (string, context.CancelFunc, <-chan []lifecycle.ShutdownResult) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0") //! port 0 -> any free port
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan []lifecycle.ShutdownResult, 1)
+	go func() {
+		results, err := serveUntilDone(ctx, listener, handler, resources, 5*time.Second)
+		if err != nil {
+			t.Errorf("serveUntilDone : %v", err)
+		}
+		done <- results
+	}()
+	return "http://" + listener.Addr().String(), cancel, done
+}
+
+func resultNames(results []lifecycle.ShutdownResult) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	return names
+}
+
+//! a real server on a real port : create a person, cancel, and the people file has the person. The server was closed before the file was written
+func TestShutdownSavesPeople(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.json")
+	s := newTestServer()
+	resources := lifecycle.New()
+	resources.Register("people file", func(ctx context.Context) error { return s.people.WriteFile(path) })
+	url, cancel, done := startServer(t, s.routes(), resources)
+
+	token := login(t, s, "john", "1234")
+	request, err := http.NewRequest(http.MethodPost, url+"/person", strings.NewReader(`{"name":"Jane","age":21,"email":"jane@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /person : status = %d, want 201", response.StatusCode)
+	}
+
+	cancel()
+	results := <-done
+	if got, want := resultNames(results), []string{"http server", "people file"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("closed %q, want %q : the server first, then the file", got, want)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("%s : %v", result.Name, result.Err)
+		}
+	}
+
+	var restarted personStore
+	if err := restarted.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := restarted.All(), []Person{{Name: "Jane", Age: 21, Email: "jane@example.com"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("people after a restart = %v, want %v", got, want)
+	}
+	if _, err := http.Get(url + "/hello"); err == nil {
+		t.Error("GET /hello after the shutdown worked, want the connection refused")
+	}
+}
+
+//! a request which is still running when the shutdown starts gets its answer : http.Server.Shutdown waits for it
+func TestShutdownWaitsForRunningRequest(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		fmt.Fprint(w, "finished")
+	})
+	url, cancel, done := startServer(t, slow, lifecycle.New())
+
+	answered := make(chan string, 1)
+	go func() {
+		response, err := http.Get(url)
+		if err != nil {
+			answered <- "error : " + err.Error()
+			return
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		answered <- string(body)
+	}()
+
+	<-entered
+	cancel()
+	select {
+	case results := <-done:
+		t.Fatalf("shutdown finished while a request was still running : %+v", results)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if got := <-answered; got != "finished" {
+		t.Errorf("the running request got %q, want \"finished\"", got)
+	}
+	if results := <-done; len(results) != 1 || results[0].Err != nil {
+		t.Errorf("results = %+v, want the http server closed without an error", results)
+	}
+}
+
+//! the server stops by itself when the listener breaks : the error comes back, and the resources are still closed
+func TestServeListenerError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.Close()
+
+	resources := lifecycle.New()
+	saved := false
+	resources.Register("people file", func(ctx context.Context) error {
+		saved = true
+		return nil
+	})
+	results, err := serveUntilDone(context.Background(), listener, http.NotFoundHandler(), resources, time.Second)
+	if !errors.Is(err, net.ErrClosed) {
+		t.Errorf("err = %v, want net.ErrClosed", err)
+	}
+	if got, want := resultNames(results), []string{"http server", "people file"}; !reflect.DeepEqual(got, want) || !saved {
+		t.Errorf("closed %q (saved %t), want %q", got, saved, want)
+	}
+}
+
+func TestPeopleFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string //! "" -> no file
+		want    []Person
+		wantErr bool
+	}{
+		{"missing file starts empty", "", nil, false},
+		{"saved people", `[{"name":"Jane","age":21,"email":"jane@example.com"}]`, []Person{{Name: "Jane", Age: 21, Email: "jane@example.com"}}, false},
+		{"empty list", `[]`, nil, false},
+		{"broken JSON", `[{"name":`, nil, true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("people-%d.json", i))
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var store personStore
+			err := store.ReadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFile error = %v, want error %t", err, tt.wantErr)
+			}
+			if got := store.All(); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("people = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//! WriteFile replaces the old file and leaves no temporary file behind
+func TestWriteFileReplaces(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "people.json")
+	var store personStore
+	store.Add(Person{Name: "Jane", Age: 21})
+	if err := store.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	store.Add(Person{Name: "Bob", Age: 30})
+	if err := store.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded personStore
+	if err := loaded.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.All(); !reflect.DeepEqual(got, store.All()) {
+		t.Errorf("loaded %v, want %v", got, store.All())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("files in the directory = %d, want only people.json", len(entries))
+	}
+}
@@ -0,0 +1,103 @@
+//! Walkthrough -> a lesson as a list of steps. The runner shows one step, its output and an explanation, then waits for Enter
+//! the first lesson in this form : slice declaration (15. slice/a. slice declaration)
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+//! sliceSteps -> the six ways to declare a slice, from the slice declaration lesson
+var sliceSteps = []Step{
+	{
+		Title: "Slice from an array",
+		Run: func(w io.Writer) error {
+			arr := [5]string{"a", "b", "c", "d", "e"}
+			sliced := arr[1:4]
+			fmt.Fprintln(w, "arr        :", arr)
+			fmt.Fprintln(w, "arr[1:4]   :", sliced, "len", len(sliced), "cap", cap(sliced))
+			return nil
+		},
+		Explain: `A slice is a pointer, a length and a capacity. arr[1:4] points to index 1 and has the elements 1, 2, 3 (length 3).
+The capacity counts from index 1 to the END of the array : 1, 2, 3, 4 -> 4.`,
+	},
+	{
+		Title: "Slice from a slice",
+		Run: func(w io.Writer) error {
+			arr := [5]int{1, 2, 3, 4, 5}
+			sliced := arr[1:4]
+			again := sliced[1:2]
+			fmt.Fprintln(w, "sliced      :", sliced)
+			fmt.Fprintln(w, "sliced[1:2] :", again, "len", len(again), "cap", cap(again))
+			return nil
+		},
+		Explain: `sliced[1:2] points into the SAME array, at the value 3. Its capacity still reaches the end of the array : 3, 4, 5 -> 3.`,
+	},
+	{
+		Title: "Slice literal",
+		Run: func(w io.Writer) error {
+			numbers := []int{1, 2, 3}
+			fmt.Fprintln(w, "numbers :", numbers, "len", len(numbers), "cap", cap(numbers))
+			return nil
+		},
+		Explain: `No size between the brackets -> a slice. Go makes an array of 3 behind it, so length and capacity are both 3.`,
+	},
+	{
+		Title: "make with a length",
+		Run: func(w io.Writer) error {
+			numbers := make([]int, 5)
+			fmt.Fprintln(w, "make([]int, 5) :", numbers)
+			numbers[0] = 10
+			numbers[4] = 50
+			fmt.Fprintln(w, "after setting  :", numbers, "len", len(numbers), "cap", cap(numbers))
+			return nil
+		},
+		Explain: `make fills the slice with zero values. Every index from 0 to length-1 can be set.`,
+	},
+	{
+		Title: "make with a length and a capacity",
+		Run: func(w io.Writer) error {
+			numbers := make([]int, 3, 5)
+			fmt.Fprintln(w, "make([]int, 3, 5) :", numbers, "len", len(numbers), "cap", cap(numbers))
+			return nil
+		},
+		Explain: `Only the length counts for indexing : numbers[3] = 40 would panic (index out of range), even though the capacity is 5.
+The extra capacity is used by append, in the slice appending lesson.`,
+	},
+	{
+		Title: "nil slice",
+		Run: func(w io.Writer) error {
+			var numbers []int
+			fmt.Fprintln(w, "numbers :", numbers, "len", len(numbers), "cap", cap(numbers), "nil", numbers == nil)
+			return nil
+		},
+		Explain: `A declared but not created slice is nil : no array behind it, length 0, capacity 0. len, cap, range and append all work on it.`,
+	},
+}
+
+func main() {
+	fast := flag.Bool("fast", false, "run every step without pausing")
+	start := flag.Int("step", 1, "the step to start at")
+	flag.Parse()
+
+	runner := &Runner{Steps: sliceSteps, In: os.Stdin, Out: os.Stdout, Fast: *fast}
+	err := runner.Run(*start)
+	if errors.Is(err, ErrAborted) {
+		fmt.Println("bye, run it again with -step <number> to continue")
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+}
+
+/*
+	Try :
+		1. go run main.go walkthrough.go              -> press Enter between the steps, type 5 to jump, q to quit
+		2. go run main.go walkthrough.go -fast        -> the whole lesson at once
+		3. Turn the closure lesson (10. closure) into steps too
+*/
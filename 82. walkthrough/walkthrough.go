@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//! Step -> one part of a lesson : a title, the code to run, and what to notice in its output
+type Step struct {
+	Title   string
+	Explain string
+	Run     func(w io.Writer) error
+}
+
+//! ErrAborted -> the user typed q, or the input ended (Ctrl+D, or a script without more lines). Not a failure : the caller just stops
+var ErrAborted = errors.New("walkthrough aborted")
+
+//! Runner -> prints the title, runs the step, shows its output and the explanation, then waits for Enter
+//! In and Out are plain io.Reader / io.Writer, so a test drives it with a strings.Reader and a bytes.Buffer instead of a terminal
+type Runner struct {
+	Steps []Step
+	In    io.Reader
+	Out   io.Writer
+	Fast  bool //! no pauses : every step runs one after another
+}
+
+//! Run -> starts at step 'start' (1-based, like the numbers the user sees)
+func (r *Runner) Run(start int) error {
+	if start < 1 || start > len(r.Steps) {
+		return fmt.Errorf("no step %d, there are %d steps", start, len(r.Steps))
+	}
+	input := bufio.NewReader(r.In)
+
+	for i := start - 1; i < len(r.Steps); {
+		step := r.Steps[i]
+		fmt.Fprintf(r.Out, "== Step %d/%d : %s ==\n", i+1, len(r.Steps), step.Title)
+
+		//! the output is collected first, so it can be shown indented, and is still shown when the step fails
+		var output bytes.Buffer
+		err := step.Run(&output)
+		for _, line := range strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n") {
+			if line != "" {
+				fmt.Fprintf(r.Out, "  | %s\n", line)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Title, err)
+		}
+		fmt.Fprintln(r.Out, step.Explain)
+
+		if r.Fast || i == len(r.Steps)-1 {
+			i++
+			continue
+		}
+		next, err := r.prompt(input, i)
+		if err != nil {
+			return err
+		}
+		i = next
+	}
+	return nil
+}
+
+//! prompt -> Enter : the next step, a number : jump to that step, q : quit. Asks again after a wrong answer
+func (r *Runner) prompt(input *bufio.Reader, current int) (int, error) {
+	for {
+		fmt.Fprintf(r.Out, "[Enter] next, [1-%d] jump, [q] quit > ", len(r.Steps))
+		line, err := input.ReadString('\n')
+		if err != nil && line == "" { //! io.EOF with nothing typed : the input is over
+			fmt.Fprintln(r.Out)
+			return 0, ErrAborted
+		}
+		answer := strings.TrimSpace(line)
+
+		switch {
+		case answer == "":
+			return current + 1, nil
+		case answer == "q":
+			return 0, ErrAborted
+		}
+		number, convErr := strconv.Atoi(answer)
+		if convErr == nil && number >= 1 && number <= len(r.Steps) {
+			return number - 1, nil
+		}
+		fmt.Fprintf(r.Out, "%q is not a step number\n", answer)
+		if err != nil { //! the last line had no '\n' : don't ask again, there's nothing more to read
+			return 0, ErrAborted
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+//! script -> runs the runner with typed input, like a fake terminal, and returns what it printed
+func script(steps []Step, input string, fast bool, start int) (string, error) {
+	var output bytes.Buffer
+	runner := &Runner{Steps: steps, In: strings.NewReader(input), Out: &output, Fast: fast}
+	err := runner.Run(start)
+	return output.String(), err
+}
+
+//! titles -> the step titles in the order they ran. Without a real terminal nobody types Enter after the prompt, so a title can follow "> " on the same line
+var titlePattern = regexp.MustCompile(`== Step \d+/\d+ : (.*?) ==`)
+
+func titles(output string) []string {
+	var result []string
+	for _, match := range titlePattern.FindAllStringSubmatch(output, -1) {
+		result = append(result, match[1])
+	}
+	return result
+}
+
+func printing(title, line, explain string) Step {
+	return Step{Title: title, Run: func(w io.Writer) error { fmt.Fprintln(w, line); return nil }, Explain: explain}
+}
+
+var fourSteps = []Step{
+	printing("one", "1", "first"),
+	printing("two", "2", "second"),
+	printing("three", "3", "third"),
+	printing("four", "4", "fourth"),
+}
+
+func TestRunnerScripted(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		fast        bool
+		start       int
+		wantTitles  []string
+		wantAborted bool
+		wantPauses  int
+		wantWrong   int //! "is not a step number" lines
+	}{
+		{"Enter after each step -> steps in order", "\n\n\n", false, 1, []string{"one", "two", "three", "four"}, false, 3, 0},
+		{"fast mode -> no pauses, no input needed", "", true, 1, []string{"one", "two", "three", "four"}, false, 0, 0},
+		{"jump to 3, back to 1, quit", "3\n1\nq\n", false, 1, []string{"one", "three", "one"}, true, 3, 0},
+		{"wrong numbers -> asked again", "7\nabc\n\n\n\n", false, 1, []string{"one", "two", "three", "four"}, false, 5, 2},
+		{"a wrong last line without newline -> aborted", "0", false, 1, []string{"one"}, true, 1, 1},
+		{"EOF at the first pause -> aborted cleanly", "", false, 1, []string{"one"}, true, 1, 0},
+		{"start at the last step", "", false, 4, []string{"four"}, false, 0, 0},
+		{"start in the middle", "\n", false, 3, []string{"three", "four"}, false, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := script(fourSteps, tt.input, tt.fast, tt.start)
+			if got := titles(output); !reflect.DeepEqual(got, tt.wantTitles) {
+				t.Errorf("steps ran = %v, want %v", got, tt.wantTitles)
+			}
+			if aborted := errors.Is(err, ErrAborted); aborted != tt.wantAborted || (err != nil && !aborted) {
+				t.Errorf("err = %v, want aborted : %v", err, tt.wantAborted)
+			}
+			if pauses := strings.Count(output, "> "); pauses != tt.wantPauses {
+				t.Errorf("%d pauses, want %d", pauses, tt.wantPauses)
+			}
+			if wrong := strings.Count(output, "is not a step number"); wrong != tt.wantWrong {
+				t.Errorf("%d wrong answers reported, want %d", wrong, tt.wantWrong)
+			}
+		})
+	}
+}
+
+//! the step's output is indented under its title, the explanation follows it
+func TestRunnerOutput(t *testing.T) {
+	output, err := script(fourSteps[:2], "", true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "== Step 1/2 : one ==\n  | 1\nfirst\n== Step 2/2 : two ==\n  | 2\nsecond\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestMissingStartStep(t *testing.T) {
+	for _, start := range []int{0, 5, -1} {
+		t.Run(fmt.Sprint(start), func(t *testing.T) {
+			_, err := script(fourSteps, "", false, start)
+			if err == nil || errors.Is(err, ErrAborted) {
+				t.Errorf("Run(%d) err = %v, want a real error", start, err)
+			}
+		})
+	}
+}
+
+func TestStepError(t *testing.T) {
+	failing := []Step{
+		fourSteps[0],
+		{Title: "broken", Run: func(w io.Writer) error { fmt.Fprintln(w, "half done"); return errors.New("disk full") }, Explain: "never shown"},
+		fourSteps[2],
+		fourSteps[3],
+	}
+	output, err := script(failing, "", true, 1)
+	if err == nil || err.Error() != "step 2 (broken): disk full" {
+		t.Errorf("err = %v, want step 2 (broken): disk full", err)
+	}
+	if !strings.Contains(output, "  | half done") || strings.Contains(output, "never shown") {
+		t.Errorf("the failed step's output should be shown, its explanation not :\n%s", output)
+	}
+	if got := titles(output); !reflect.DeepEqual(got, []string{"one", "broken"}) {
+		t.Errorf("steps ran = %v, want no step after the failure", got)
+	}
+}
+
+//! the slice walkthrough itself : every step runs, and shows the values of the slice declaration lesson
+func TestSliceSteps(t *testing.T) {
+	output, err := script(sliceSteps, "", true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(titles(output)); got != len(sliceSteps) {
+		t.Errorf("%d steps ran, want %d", got, len(sliceSteps))
+	}
+	for _, line := range []string{
+		"  | arr[1:4]   : [b c d] len 3 cap 4",
+		"  | sliced[1:2] : [3] len 1 cap 3",
+		"  | numbers : [1 2 3] len 3 cap 3",
+		"  | after setting  : [10 0 0 0 50] len 5 cap 5",
+		"  | make([]int, 3, 5) : [0 0 0] len 3 cap 5",
+		"  | numbers : [] len 0 cap 0 nil true",
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("the output doesn't contain %q", line)
+		}
+	}
+}
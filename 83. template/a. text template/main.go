@@ -0,0 +1,78 @@
+//! text/template -> a text with "holes" ({{...}}) which are filled from Go data. Good for reports, emails, config files, generated code
+//! the template is parsed once, and can then be executed with different data as often as we want
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! {{.}}            -> the current data. At the top it's the roster, inside range it's one Person
+//! {{len .}}        -> calls the builtin len
+//! {{range .}}      -> a loop, {{end}} closes it
+//! {{if ge .Age 18}} -> "if .Age >= 18". ge, gt, le, lt, eq, ne are builtin functions
+//! {{- and -}}      -> remove the spaces and newlines before / after, so the output has no empty lines
+const rosterTemplate = `ROSTER ({{len .}} people)
+========================
+{{range .}}
+{{- upper .Name}} ({{.Age}}) - {{if ge .Age 18}}adult{{else}}minor{{end}}
+  {{.Email}}
+{{end -}}
+`
+
+func main() {
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 16, Email: "jane@example.com"},
+		{Name: "Alice", Age: 28, Email: "alice@example.com"},
+	}
+
+	//! Funcs must be called BEFORE Parse : the parser has to know that "upper" is a function
+	report, err := template.New("roster").Funcs(template.FuncMap{
+		"upper": strings.ToUpper,
+	}).Parse(rosterTemplate)
+	if err != nil {
+		fmt.Println("parse error :", err)
+		return
+	}
+
+	//! 1. straight to stdout. Execute takes any io.Writer
+	if err := report.Execute(os.Stdout, people); err != nil {
+		fmt.Println("execute error :", err)
+		return
+	}
+
+	fmt.Println("--------------------------------")
+
+	//! 2. into a bytes.Buffer, when we need the text as a string (to send it in an email, to compare it in a test ...)
+	var buffer bytes.Buffer
+	if err := report.Execute(&buffer, people[:1]); err != nil {
+		fmt.Println("execute error :", err)
+		return
+	}
+	fmt.Print(buffer.String())
+	fmt.Println("length :", buffer.Len(), "bytes")
+
+	fmt.Println("--------------------------------")
+
+	//! 3. mistakes : a field that doesn't exist is found only when the template runs
+	broken := template.Must(template.New("broken").Parse("{{.Phone}}\n")) //! Must panics on a parse error, fine for templates written in the code
+	err = broken.Execute(&buffer, people[0])
+	fmt.Println("execute error :", err)
+}
+
+/*
+	Try :
+		1. Add a line with the number of adults. Hint : write a countAdults function and register it with Funcs
+		2. Use {{range $i, $p := .}} and print the number of each person
+		3. Remove the '-' in {{- upper .Name}} and look at the empty lines
+*/
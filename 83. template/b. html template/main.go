@@ -0,0 +1,47 @@
+//! html/template -> the same template language as text/template, but it knows it's writing HTML
+//! every value is ESCAPED for the place it goes into : a name like <script>...</script> is shown as text, never run by the browser
+package main
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	texttemplate "text/template"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+const page = `<li title="{{.Name}}"><a href="/people?name={{.Name}}">{{.Name}}</a> ({{.Age}})</li>
+`
+
+func main() {
+	//! a name somebody typed into a form. With text/template it becomes real HTML, and the browser would run the script (XSS attack)
+	attacker := Person{Name: `<script>alert("hacked")</script>`, Age: 30, Email: "mallory@example.com"}
+
+	text := texttemplate.Must(texttemplate.New("page").Parse(page))
+	html := htmltemplate.Must(htmltemplate.New("page").Parse(page))
+
+	fmt.Println("text/template :")
+	text.Execute(os.Stdout, attacker)
+
+	//! html/template escapes differently in every context :
+	//! in the attribute -> &lt;script&gt; ... &#34;
+	//! in the URL       -> %3cscript%3e ... (URL encoding)
+	//! in the text      -> &lt;script&gt; ...
+	fmt.Println("html/template :")
+	html.Execute(os.Stdout, attacker)
+
+	//! a normal name looks the same with both
+	fmt.Println("a normal name :")
+	html.Execute(os.Stdout, Person{Name: "John", Age: 20})
+}
+
+/*
+	Try :
+		1. Save both outputs as .html files and open them in a browser
+		2. Wrap the name in htmltemplate.HTML(...) before executing. Why is that dangerous?
+*/
@@ -0,0 +1,47 @@
+package main
+
+//! Number -> the types SumBy can add up, like in the generics constraints lesson
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+//! GroupBy -> every element goes into the slice of its key. Inside a group the elements keep their input order
+func GroupBy[T any, K comparable](xs []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, x := range xs {
+		k := key(x)
+		groups[k] = append(groups[k], x)
+	}
+	return groups
+}
+
+//! CountBy -> how many elements have each key. The same as len() of every group, without building the slices
+func CountBy[T any, K comparable](xs []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, x := range xs {
+		counts[key(x)]++
+	}
+	return counts
+}
+
+//! SumBy -> the sum of val(x) for each key
+func SumBy[T any, K comparable, N Number](xs []T, key func(T) K, val func(T) N) map[K]N {
+	sums := make(map[K]N)
+	for _, x := range xs {
+		sums[key(x)] += val(x)
+	}
+	return sums
+}
+
+//! GroupBy2 -> two levels : first by key1, then every group again by key2. groups[a][b] are the elements with key1 a and key2 b
+func GroupBy2[T any, K1, K2 comparable](xs []T, key1 func(T) K1, key2 func(T) K2) map[K1]map[K2][]T {
+	groups := make(map[K1]map[K2][]T)
+	for _, x := range xs {
+		k1, k2 := key1(x), key2(x)
+		if groups[k1] == nil { //! the inner map is created the first time its key1 is seen
+			groups[k1] = make(map[K2][]T)
+		}
+		groups[k1][k2] = append(groups[k1][k2], x)
+	}
+	return groups
+}
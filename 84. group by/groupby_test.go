@@ -0,0 +1,184 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+var (
+	john  = Person{Name: "John", Age: 20, Email: "john@example.com"}
+	jane  = Person{Name: "Jane", Age: 16, Email: "jane@mail.com"}
+	alice = Person{Name: "Alice", Age: 25, Email: "alice@example.com"}
+)
+
+func TestGroupBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		people []Person
+		key    func(Person) string
+		want   map[string][]Person
+	}{
+		{"empty input", []Person{}, ageBand, map[string][]Person{}},
+		{"nil input", nil, ageBand, map[string][]Person{}},
+		{"single group", []Person{john, alice}, ageBand, map[string][]Person{"18-29": {john, alice}}},
+		{"two groups, input order kept", []Person{john, jane, alice}, emailDomain, map[string][]Person{"example.com": {john, alice}, "mail.com": {jane}}},
+		{"all distinct keys -> one element per group", []Person{john, jane}, func(p Person) string { return p.Name }, map[string][]Person{"John": {john}, "Jane": {jane}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupBy(tt.people, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountAndSumBy(t *testing.T) {
+	tests := []struct {
+		name       string
+		people     []Person
+		wantCounts map[string]int
+		wantSums   map[string]int
+	}{
+		{"empty input", nil, map[string]int{}, map[string]int{}},
+		{"three people", []Person{john, jane, alice}, map[string]int{"0-17": 1, "18-29": 2}, map[string]int{"0-17": 16, "18-29": 45}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountBy(tt.people, ageBand); !reflect.DeepEqual(got, tt.wantCounts) {
+				t.Errorf("CountBy() = %v, want %v", got, tt.wantCounts)
+			}
+			if got := SumBy(tt.people, ageBand, age); !reflect.DeepEqual(got, tt.wantSums) {
+				t.Errorf("SumBy() = %v, want %v", got, tt.wantSums)
+			}
+		})
+	}
+}
+
+func TestGroupBy2(t *testing.T) {
+	tests := []struct {
+		name   string
+		people []Person
+		want   map[string]map[string][]Person
+	}{
+		{"empty input", []Person{}, map[string]map[string][]Person{}},
+		{"two levels", []Person{john, jane, alice}, map[string]map[string][]Person{
+			"0-17":  {"mail.com": {jane}},
+			"18-29": {"example.com": {john, alice}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupBy2(tt.people, ageBand, emailDomain); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyFunctions(t *testing.T) {
+	tests := []struct {
+		person     Person
+		wantBand   string
+		wantDomain string
+	}{
+		{Person{Age: 17, Email: "a@example.com"}, "0-17", "example.com"},
+		{Person{Age: 18, Email: "b@mail.com"}, "18-29", "mail.com"},
+		{Person{Age: 30, Email: "c@work.org"}, "30-49", "work.org"},
+		{Person{Age: 50, Email: "no-at-sign"}, "50+", "(none)"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.person.Age), func(t *testing.T) {
+			if got := ageBand(tt.person); got != tt.wantBand {
+				t.Errorf("ageBand() = %q, want %q", got, tt.wantBand)
+			}
+			if got := emailDomain(tt.person); got != tt.wantDomain {
+				t.Errorf("emailDomain() = %q, want %q", got, tt.wantDomain)
+			}
+		})
+	}
+}
+
+//! numberOf -> 42 for "person42"
+func numberOf(person Person) int {
+	var number int
+	fmt.Sscanf(person.Name, "person%d", &number)
+	return number
+}
+
+//! groups keep the input order : person numbers only go up inside every group
+func TestGroupsPreserveInputOrder(t *testing.T) {
+	for domain, group := range GroupBy(generatePeople(1000, 42), emailDomain) {
+		if !slices.IsSortedFunc(group, func(a, b Person) int { return cmp.Compare(numberOf(a), numberOf(b)) }) {
+			t.Errorf("the %s group is not in input order", domain)
+		}
+	}
+}
+
+//! pointers with nil entries : the key and value functions must handle nil themselves, the helpers just call them
+func TestNilSafeKeyFunctions(t *testing.T) {
+	pointers := []*Person{&john, nil, &jane}
+	safeBand := func(p *Person) string {
+		if p == nil {
+			return "unknown"
+		}
+		return ageBand(*p)
+	}
+	safeAge := func(p *Person) int {
+		if p == nil {
+			return 0
+		}
+		return p.Age
+	}
+	if got, want := CountBy(pointers, safeBand), map[string]int{"0-17": 1, "18-29": 1, "unknown": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountBy() = %v, want %v", got, want)
+	}
+	if got, want := SumBy(pointers, safeBand, safeAge), map[string]int{"0-17": 16, "18-29": 20, "unknown": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SumBy() = %v, want %v", got, want)
+	}
+}
+
+//! the same answers as hand-written loops, on other random data
+func TestSameAsLoops(t *testing.T) {
+	halfAge := func(p Person) float64 { return float64(p.Age) / 2 }
+	for seed := uint64(1); seed <= 20; seed++ {
+		random := generatePeople(500, seed)
+		wantCounts := map[string]int{}
+		wantSums := map[string]float64{}
+		wantNested := map[string]map[string]int{}
+		for _, person := range random {
+			band, domain := ageBand(person), emailDomain(person)
+			wantCounts[band]++
+			wantSums[domain] += halfAge(person)
+			if wantNested[band] == nil {
+				wantNested[band] = map[string]int{}
+			}
+			wantNested[band][domain]++
+		}
+		gotNested := map[string]map[string]int{}
+		for band, byDomain := range GroupBy2(random, ageBand, emailDomain) {
+			gotNested[band] = map[string]int{}
+			for domain, group := range byDomain {
+				gotNested[band][domain] = len(group)
+			}
+		}
+		if got := CountBy(random, ageBand); !reflect.DeepEqual(got, wantCounts) {
+			t.Errorf("seed %d : CountBy() = %v, want %v", seed, got, wantCounts)
+		}
+		if got := SumBy(random, emailDomain, halfAge); !reflect.DeepEqual(got, wantSums) {
+			t.Errorf("seed %d : SumBy() = %v, want %v", seed, got, wantSums)
+		}
+		if !reflect.DeepEqual(gotNested, wantNested) {
+			t.Errorf("seed %d : GroupBy2() sizes = %v, want %v", seed, gotNested, wantNested)
+		}
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	if got := sortedKeys(map[string]int{"50+": 1, "0-17": 2, "30-49": 3, "18-29": 4}); !reflect.DeepEqual(got, []string{"0-17", "18-29", "30-49", "50+"}) {
+		t.Errorf("sortedKeys() = %v", got)
+	}
+}
@@ -0,0 +1,102 @@
+//! Group by -> "how many people per age band?", "total age per email domain?" : the GROUP BY of SQL, for slices of structs
+//! written once with generics, it works for any struct and any key type
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+var domains = []string{"example.com", "mail.com", "work.org"}
+
+//! generatePeople -> a fixed seed gives the same people on every run
+func generatePeople(count int, seed uint64) []Person {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	people := make([]Person, count)
+	for i := range people {
+		people[i] = Person{
+			Name:  fmt.Sprintf("person%d", i+1),
+			Age:   rng.IntN(70) + 5,
+			Email: fmt.Sprintf("person%d@%s", i+1, domains[rng.IntN(len(domains))]),
+		}
+	}
+	return people
+}
+
+func ageBand(person Person) string {
+	switch {
+	case person.Age < 18:
+		return "0-17"
+	case person.Age < 30:
+		return "18-29"
+	case person.Age < 50:
+		return "30-49"
+	default:
+		return "50+"
+	}
+}
+
+func emailDomain(person Person) string {
+	_, domain, found := strings.Cut(person.Email, "@")
+	if !found {
+		return "(none)"
+	}
+	return domain
+}
+
+func age(person Person) int { return person.Age }
+
+//! sortedKeys -> a map has no order, so the keys are sorted for an output which is the same on every run
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+func main() {
+	people := generatePeople(1000, 42)
+
+	//! 1. one level : count and average age per age band
+	counts := CountBy(people, ageBand)
+	ageSums := SumBy(people, ageBand, age)
+
+	//! tabwriter -> lines up the columns : cells end with \t, and the padding is added when Flush is called
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "age band\tpeople\taverage age\t")
+	for _, band := range sortedKeys(counts) {
+		fmt.Fprintf(table, "%s\t%d\t%.1f\t\n", band, counts[band], float64(ageSums[band])/float64(counts[band]))
+	}
+	table.Flush()
+
+	fmt.Println("--------------------------------")
+
+	//! 2. two levels : age band, then email domain
+	nested := GroupBy2(people, ageBand, emailDomain)
+	table = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "age band\t"+strings.Join(domains, "\t")+"\t")
+	for _, band := range sortedKeys(nested) {
+		fmt.Fprint(table, band, "\t")
+		for _, domain := range domains {
+			fmt.Fprint(table, len(nested[band][domain]), "\t")
+		}
+		fmt.Fprintln(table)
+	}
+	table.Flush()
+}
+
+/*
+	Try :
+		1. Add MaxBy, which keeps the oldest person of every group
+		2. Group by the first letter of the name : GroupBy(people, func(p Person) byte { return p.Name[0] })
+		3. What happens with SumBy(people, ageBand, func(p Person) string { return p.Name })? (it doesn't compile : string is not a Number)
+*/
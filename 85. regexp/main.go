@@ -0,0 +1,98 @@
+//! regexp -> regular expressions : a small language to describe text patterns, like "letters, then @, then a domain"
+//! a pattern is compiled once into a *regexp.Regexp, and then used as often as we want
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! MustCompile -> panics if the pattern itself is wrong. Fine for fixed patterns in package variables : the mistake shows up at start, not later
+//! ^ and $ -> the WHOLE string must match, not just a part of it
+var (
+	//! some letters, digits or ._%+- , then @, then a domain with at least one dot and a 2+ letter ending
+	emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+	//! Bangladeshi mobile numbers : 01 + operator digit 3-9 + 8 digits, optionally with +880 / 880 in front instead of the 0
+	phonePattern = regexp.MustCompile(`^(?:\+?880|0)1[3-9]\d{8}$`)
+
+	//! YYYY-MM-DD, month 01-12 and day 01-31. The parentheses are capture groups : year, month and day can be read back
+	datePattern = regexp.MustCompile(`^(\d{4})-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
+)
+
+func isValidEmail(email string) bool { return emailPattern.MatchString(email) }
+func isValidPhone(phone string) bool { return phonePattern.MatchString(phone) }
+func isValidDate(date string) bool   { return datePattern.MatchString(date) }
+
+func main() {
+	//! 1. validating the Email field of people
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 21, Email: "jane.doe+news@mail.co.uk"},
+		{Name: "Bob", Age: 35, Email: "bob@localhost"},
+		{Name: "Alice", Age: 28, Email: "alice.example.com"},
+		{Name: "Eve", Age: 30, Email: "eve@ example.com"},
+	}
+	for _, person := range people {
+		fmt.Printf("%-6s %-26s valid : %t\n", person.Name, person.Email, isValidEmail(person.Email))
+	}
+
+	fmt.Println("--------------------------------")
+
+	for _, phone := range []string{"01712345678", "+8801812345678", "8801912345678", "01212345678", "0171234567"} {
+		fmt.Printf("phone %-16s valid : %t\n", phone, isValidPhone(phone))
+	}
+	for _, date := range []string{"2024-02-29", "2024-13-01", "2024-1-5", "2024-02-31"} {
+		fmt.Printf("date  %-16s valid : %t\n", date, isValidDate(date))
+	}
+	//! 2024-02-31 matches : a pattern only checks the SHAPE. For real dates use time.Parse (see the time lesson)
+
+	fmt.Println("--------------------------------")
+
+	//! 2. FindAllString -> every match in a text. -1 means "no limit"
+	text := "Contact john@example.com or jane@mail.com, not bob@localhost. Call 01712345678 on 2024-06-01."
+	findEmails := regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`) //! no ^ and $ : matches inside the text
+	fmt.Println("emails :", findEmails.FindAllString(text, -1))
+
+	//! 3. FindStringSubmatch -> [whole match, group 1, group 2, ...]
+	parts := datePattern.FindStringSubmatch("2024-06-01")
+	fmt.Printf("match : %q year : %s month : %s day : %s\n", parts[0], parts[1], parts[2], parts[3])
+
+	//! 4. ReplaceAllString -> $1, $2 ... in the replacement are the capture groups
+	maskPhone := regexp.MustCompile(`(01[3-9])\d{5}(\d{3})`)
+	fmt.Println("masked :", maskPhone.ReplaceAllString(text, "$1*****$2"))
+
+	fmt.Println("--------------------------------")
+
+	//! 5. compile once vs compile in the loop. Compiling is the expensive part
+	const runs = 10000
+	start := time.Now()
+	for i := 0; i < runs; i++ {
+		emailPattern.MatchString("john@example.com")
+	}
+	once := time.Since(start)
+
+	start = time.Now()
+	for i := 0; i < runs; i++ {
+		regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`).MatchString("john@example.com")
+	}
+	inLoop := time.Since(start)
+
+	fmt.Printf("compiled once    : %v\n", once)
+	fmt.Printf("compiled in loop : %v\n", inLoop)
+	fmt.Printf("about %.0fx slower\n", float64(inLoop)/float64(once))
+}
+
+/*
+	Try :
+		1. Write isValidPostcode for 4-digit Bangladeshi postcodes like 1205
+		2. Use FindAllStringSubmatch on the text to get the user and the domain of every email
+		3. Try regexp.Compile (not Must) with a broken pattern like "(" and print the error
+*/
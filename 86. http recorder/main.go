@@ -0,0 +1,122 @@
+//! HTTP recorder -> record real HTTP answers once into a JSON "cassette" file, then replay them later without any server
+//! useful for lessons and tests which talk to a server : the replay is offline and gives the same answer every time
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//! answer -> what the tests compare : the status, the Content-Type and the body
+type answer struct {
+	Status      int
+	ContentType string
+	Body        string
+}
+
+func do(client *http.Client, method, url, body string) (answer, error) {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return answer{}, err
+	}
+	request.Header.Set("Authorization", "Bearer secret-token-123")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return answer{}, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return answer{}, err
+	}
+	return answer{response.StatusCode, response.Header.Get("Content-Type"), string(data)}, nil
+}
+
+func newServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"John","age":20,"email":"john@example.com"}`)
+	})
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"created":%s}`, body) //! echoes the request body : proves the recorder still sends it
+	})
+	return httptest.NewServer(mux)
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "cassettes")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(dir)
+	cassette := filepath.Join(dir, "users.json")
+
+	//! 1. record : real requests to a real (test) server
+	server := newServer()
+	recorder, err := NewRecorder(ModeRecord, cassette, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	client := &http.Client{Transport: recorder}
+
+	const newUser = `{"name":"Jane","age":22,"email":"jane@example.com"}`
+	recordedGet, _ := do(client, http.MethodGet, server.URL+"/users/1", "")
+	recordedPost, _ := do(client, http.MethodPost, server.URL+"/users", newUser)
+	fmt.Println("recorded GET  :", recordedGet.Status, recordedGet.Body)
+	fmt.Println("recorded POST :", recordedPost.Status, recordedPost.Body)
+
+	//! 2. stop the server : from now on nothing answers on its address
+	server.Close()
+	_, err = do(&http.Client{}, http.MethodGet, server.URL+"/users/1", "")
+	fmt.Println("server stopped, a normal client fails :", err != nil)
+	fmt.Println("--------------------------------")
+
+	//! 3. replay : same requests, answers from the cassette
+	player, err := NewRecorder(ModeReplay, cassette, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+	client = &http.Client{Transport: player}
+
+	replayedGet, _ := do(client, http.MethodGet, server.URL+"/users/1", "")
+	replayedPost, _ := do(client, http.MethodPost, server.URL+"/users", newUser)
+	fmt.Println("replayed GET  :", replayedGet.Status, replayedGet.Body)
+	fmt.Println("replayed POST :", replayedPost.Status, replayedPost.Body)
+	fmt.Println("--------------------------------")
+
+	//! 4. a request which is not on the cassette
+	_, err = do(client, http.MethodGet, server.URL+"/users/2", "")
+	fmt.Println("error :", err)
+
+	//! 5. a broken cassette fails in NewRecorder, before any request
+	saved, _ := os.ReadFile(cassette)
+	corrupt := filepath.Join(dir, "corrupt.json")
+	os.WriteFile(corrupt, saved[:len(saved)/2], 0o644) //! half a file, like after a crash
+	_, err = NewRecorder(ModeReplay, corrupt, nil)
+	fmt.Println("error :", err)
+}
+
+/*
+	Try :
+		1. Print the cassette (fmt.Println(string(saved))) and read it : method, url, the saved headers, the body hash
+		2. Use the recorder as the Transport of the client in the HTTP client lesson (79. http client)
+		3. Replay with the same request twice in the cassette, the second answer different. Which one comes back? How would you play them in order?
+*/
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	ModeRecord Mode = iota //! real requests, every request/response pair is saved to the cassette
+	ModeReplay             //! no network : the responses come from the cassette
+)
+
+//! savedHeaders -> only these headers are saved. Cookies, dates and the rest would make the cassette noisy (and may be secret)
+var savedHeaders = []string{"Accept", "Authorization", "Content-Type"}
+
+const redacted = "REDACTED"
+
+//! ErrUnmatched -> replay mode got a request which is not on the cassette
+var ErrUnmatched = errors.New("no recorded interaction")
+
+type RecordedRequest struct {
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Body     string            `json:"body,omitempty"`
+	BodyHash string            `json:"body_hash"` //! sha256 of the body, part of the match key
+}
+
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+//! Cassette -> the JSON file, like a tape in an old cassette recorder : record once, play back as often as we want
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+//! Recorder -> an http.RoundTripper. http.Client calls RoundTrip for every request, so plugging it in is one line :
+//! client := &http.Client{Transport: recorder}
+type Recorder struct {
+	mode     Mode
+	path     string
+	inner    http.RoundTripper
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+//! NewRecorder -> in replay mode the cassette is loaded now, so a missing or broken file is an error here and not on the first request
+//! inner is the real transport for record mode. nil means http.DefaultTransport
+func NewRecorder(mode Mode, cassettePath string, inner http.RoundTripper) (*Recorder, error) {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: cassettePath, inner: inner}
+	if mode == ModeReplay {
+		data, err := os.ReadFile(cassettePath)
+		if err != nil {
+			return nil, fmt.Errorf("load cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("load cassette %s: corrupt: %w", cassettePath, err)
+		}
+	}
+	return r, nil
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+//! readBody -> reads the whole body and puts a fresh reader back, so the body can still be sent (or read by the caller)
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func pickHeaders(header http.Header) map[string]string {
+	picked := map[string]string{}
+	for _, name := range savedHeaders {
+		if value := header.Get(name); value != "" {
+			picked[name] = value
+		}
+	}
+	if _, ok := picked["Authorization"]; ok {
+		picked["Authorization"] = redacted //! never write a token or a password into a file which may end up in git
+	}
+	return picked
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	recorded := RecordedRequest{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Headers:  pickHeaders(req.Header),
+		Body:     string(body),
+		BodyHash: hashBody(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	response, err := r.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err //! a failed request is not recorded
+	}
+	body, err := readBody(&response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: response.StatusCode, Headers: pickHeaders(response.Header), Body: string(body)},
+	})
+	//! the whole cassette is written after every request, so the file is complete even if the program stops early
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("save cassette: %w", err)
+	}
+	return response, nil
+}
+
+//! replay -> the first interaction with the same method, URL and body hash. Headers are not part of the match
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.cassette.Interactions {
+		saved := interaction.Request
+		if saved.Method != recorded.Method || saved.URL != recorded.URL || saved.BodyHash != recorded.BodyHash {
+			continue
+		}
+		header := http.Header{}
+		for name, value := range interaction.Response.Headers {
+			header.Set(name, value)
+		}
+		code := interaction.Response.StatusCode
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+			StatusCode:    code,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s (body hash %.12s)", ErrUnmatched, recorded.Method, recorded.URL, recorded.BodyHash)
+}
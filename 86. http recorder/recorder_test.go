@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const newUser = `{"name":"Jane","age":22,"email":"jane@example.com"}`
+
+//! recordCassette -> records a GET and a POST against a fresh httptest server, then stops the server
+func recordCassette(t *testing.T) (cassette, url string, recorded [2]answer) {
+	t.Helper()
+	cassette = filepath.Join(t.TempDir(), "users.json")
+	server := newServer()
+	defer server.Close()
+
+	recorder, err := NewRecorder(ModeRecord, cassette, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: recorder}
+	if recorded[0], err = do(client, http.MethodGet, server.URL+"/users/1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if recorded[1], err = do(client, http.MethodPost, server.URL+"/users", newUser); err != nil {
+		t.Fatal(err)
+	}
+	return cassette, server.URL, recorded
+}
+
+func replayClient(t *testing.T, cassette string) *http.Client {
+	t.Helper()
+	player, err := NewRecorder(ModeReplay, cassette, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Transport: player}
+}
+
+func TestRecordMode(t *testing.T) {
+	_, _, recorded := recordCassette(t)
+	tests := []struct {
+		name string
+		got  answer
+		want answer
+	}{
+		{"GET -> the real answer", recorded[0], answer{200, "application/json", `{"name":"John","age":20,"email":"john@example.com"}`}},
+		{"POST -> the request body reached the server", recorded[1], answer{201, "application/json", `{"created":` + newUser + `}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCassetteFile(t *testing.T) {
+	cassette, _, _ := recordCassette(t)
+	saved, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		contains string
+		want     bool
+	}{
+		{"both interactions", `"method": "POST"`, true},
+		{"Authorization is redacted", `"Authorization": "REDACTED"`, true},
+		{"the token never reaches the file", "secret-token-123", false},
+		{"the body hash of an empty body", `"body_hash": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Contains(string(saved), tt.contains); got != tt.want {
+				t.Errorf("the cassette contains %q : %v, want %v\n%s", tt.contains, got, tt.want, saved)
+			}
+		})
+	}
+	if count := strings.Count(string(saved), `"method"`); count != 2 {
+		t.Errorf("%d interactions on the cassette, want 2", count)
+	}
+}
+
+//! the server is stopped before the replay : every answer comes from the cassette
+func TestReplay(t *testing.T) {
+	cassette, url, recorded := recordCassette(t)
+	if _, err := do(&http.Client{}, http.MethodGet, url+"/users/1", ""); err == nil {
+		t.Fatal("the server still answers, the replay would prove nothing")
+	}
+	client := replayClient(t, cassette)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   answer
+	}{
+		{"GET is identical", http.MethodGet, "/users/1", "", recorded[0]},
+		{"POST is identical", http.MethodPost, "/users", newUser, recorded[1]},
+		{"replay can be repeated", http.MethodGet, "/users/1", "", recorded[0]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := do(client, tt.method, url+tt.path, tt.body)
+			if err != nil || got != tt.want {
+				t.Errorf("got %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	cassette, url, _ := recordCassette(t)
+	client := replayClient(t, cassette)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"unknown URL", http.MethodGet, "/users/2", ""},
+		{"same URL, other body", http.MethodPost, "/users", `{"name":"Jim"}`},
+		{"same URL, other method", http.MethodDelete, "/users/1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := do(client, tt.method, url+tt.path, tt.body)
+			if !errors.Is(err, ErrUnmatched) { //! client.Do wraps it in *url.Error, errors.Is still finds it
+				t.Errorf("err = %v, want ErrUnmatched", err)
+			}
+		})
+	}
+}
+
+//! broken cassettes fail in NewRecorder, before any request
+func TestNewRecorderErrors(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte(`{"interactions": [{"request": `), 0o644); err != nil { //! half a file, like after a crash
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.json")
+
+	tests := []struct {
+		name    string
+		mode    Mode
+		path    string
+		wantErr func(error) bool
+	}{
+		{"corrupt cassette", ModeReplay, corrupt, func(err error) bool { return err != nil && strings.Contains(err.Error(), "corrupt") }},
+		{"missing cassette", ModeReplay, missing, func(err error) bool { return errors.Is(err, fs.ErrNotExist) }},
+		{"record mode doesn't need a cassette yet", ModeRecord, missing, func(err error) bool { return err == nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRecorder(tt.mode, tt.path, nil); !tt.wantErr(err) {
+				t.Errorf("NewRecorder() err = %v", err)
+			}
+		})
+	}
+}
+
+//! failingTransport -> every request fails, like a server which is down
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestFailedRequestIsNotRecorded(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "users.json")
+	recorder, err := NewRecorder(ModeRecord, cassette, failingTransport{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := do(&http.Client{Transport: recorder}, http.MethodGet, "http://example.invalid/users/1", ""); err == nil {
+		t.Error("the failed request returned no error")
+	}
+	if _, err := os.Stat(cassette); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("a cassette was written for a failed request : %v", err)
+	}
+}
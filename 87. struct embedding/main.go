@@ -0,0 +1,112 @@
+//! Struct embedding -> put a type inside a struct WITHOUT a field name. Its fields and methods are PROMOTED : usable as if they were the outer struct's own
+//! it looks like inheritance ("Employee is a Person"), but it's composition : an Employee HAS a Person inside, reachable as emp.Person
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+func (person Person) printDetails() {
+	fmt.Println(`Person Name :`, person.Name, `Person Age :`, person.Age, `Person Email :`, person.Email)
+}
+
+func (person Person) greet() {
+	fmt.Println("Hello, I'm", person.Name)
+}
+
+//! Employee -> 'Person' has no field name, only a type : that's embedding. The field's name is the type name, 'Person'
+type Employee struct {
+	Person
+	Salary  float64
+	Company string
+}
+
+//! Employee has its own printDetails, so it SHADOWS the promoted Person.printDetails. It's not an override : Person's method still exists, unchanged
+func (employee Employee) printDetails() {
+	employee.Person.printDetails() //! calling the inner method explicitly, like super.printDetails() in other languages
+	fmt.Println(`Employee Company :`, employee.Company, `Employee Salary :`, employee.Salary)
+}
+
+type Department struct {
+	Name    string
+	Company string
+}
+
+//! Manager -> a second level : Manager -> Employee -> Person. And a second embedded struct, Department
+type Manager struct {
+	Employee
+	Department
+	Reports int
+}
+
+//! printPerson takes a Person. There's no "is a" in Go : an Employee or a Manager can't be passed, only the Person inside them
+func printPerson(person Person) {
+	fmt.Println("printPerson :", person.Name)
+}
+
+func main() {
+	emp := Employee{
+		Person:  Person{Name: "John", Age: 20, Email: "john@example.com"}, //! in a literal, the embedded field is set by its type name
+		Salary:  50000,
+		Company: "Acme",
+	}
+
+	//! promoted fields : emp.Name is a shortcut for emp.Person.Name
+	fmt.Println("emp.Name        :", emp.Name)
+	fmt.Println("emp.Person.Name :", emp.Person.Name)
+	emp.Age++ //! writing works too, it changes emp.Person.Age
+	fmt.Println("emp.Age         :", emp.Person.Age)
+	fmt.Println("--------------------------------")
+
+	//! promoted method : Employee has no greet, so emp.greet() is emp.Person.greet()
+	emp.greet()
+
+	//! shadowed method : Employee's own printDetails wins. The inner one is still reachable with the full path
+	emp.printDetails()
+	fmt.Println("--------------------------------")
+	emp.Person.printDetails()
+	fmt.Println("--------------------------------")
+
+	manager := Manager{
+		Employee: Employee{
+			Person:  Person{Name: "Jane", Age: 30, Email: "jane@example.com"},
+			Salary:  90000,
+			Company: "Acme",
+		},
+		Department: Department{Name: "Sales", Company: "Acme Europe"},
+		Reports:    5,
+	}
+
+	//! two levels : Salary comes from Employee, Age from Person inside Employee
+	fmt.Println("manager.Salary :", manager.Salary, "manager.Age :", manager.Age)
+
+	//! the SHALLOWEST name wins : Department.Name is one level down, Person.Name is two levels down
+	fmt.Println("manager.Name   :", manager.Name, "-> the department, not the person!")
+	fmt.Println("person's name  :", manager.Employee.Name)
+
+	//! a name collision : Employee.Company and Department.Company are both ONE level down. Go doesn't pick one :
+	//! fmt.Println(manager.Company) -> compile error : ambiguous selector manager.Company
+	fmt.Println("employer       :", manager.Employee.Company)
+	fmt.Println("department of  :", manager.Department.Company)
+
+	//! printDetails : Manager has none, Employee's (one level down) shadows Person's (two levels down)
+	manager.printDetails()
+	fmt.Println("--------------------------------")
+
+	//! not inheritance : a Manager is not a Person. We pass the Person inside it
+	//! printPerson(manager)      -> compile error : cannot use manager (variable of struct type Manager) as Person value
+	printPerson(manager.Person) //! promoted too : manager.Person is manager.Employee.Person
+	printPerson(emp.Person)
+}
+
+/*
+	Try :
+		1. Uncomment fmt.Println(manager.Company), read the error, then add a Company field to Manager itself. Does it compile now? Which one is used?
+		2. Remove Employee's printDetails. What does emp.printDetails() print now?
+		3. Call emp.greet() after giving Employee its own greet() that also calls employee.Person.greet()
+		4. See how methods on the inner type never call the outer "override" in the embedding vs inheritance lesson (78. embedding vs inheritance)
+*/
@@ -0,0 +1,85 @@
+//! Package approx compares floating point numbers with a tolerance, because == on floats is almost never what we want
+package approx
+
+import "math"
+
+//! minNormal -> the smallest normal float64. Below it are the denormals (subnormals), which lose precision as they get closer to 0
+const minNormal = 0x1p-1022
+
+//! Equal -> absolute tolerance : a and b are equal when they are at most epsilon apart
+//! good when we know the scale of the values (money in cents, probabilities, values around 0)
+func Equal(a, b, epsilon float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return false //! NaN is never equal to anything, not even to NaN
+	}
+	if a == b {
+		return true //! catches +Inf == +Inf, and 0 == -0
+	}
+	return math.Abs(a-b) <= epsilon //! Inf - anything finite is Inf, never <= epsilon
+}
+
+//! EqualRel -> relative tolerance : the allowed difference grows with the size of the numbers. relTol 1e-9 means "the same in about 9 digits"
+//! good when the scale is unknown : 1e20 and 1e20+1e5 are equal, 1 and 1.1 are not
+//! near zero a relative tolerance shrinks to nothing (relTol * 0 is 0), so the scale never goes below minNormal :
+//! zero and the denormals next to it still compare equal. For values like 1e-20 vs 0, use Equal with an absolute epsilon
+func EqualRel(a, b, relTol float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false //! one infinity, or two with different signs
+	}
+	scale := math.Max(math.Max(math.Abs(a), math.Abs(b)), minNormal)
+	return math.Abs(a-b) <= relTol*scale
+}
+
+//! SliceEqual -> same length, and every pair Equal with the absolute epsilon
+func SliceEqual(a, b []float64, epsilon float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i], epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+//! ordered -> turns the bits of a float into an int64 which sorts like the floats do
+//! positive floats already sort like their bits. Negative ones have the sign bit set and sort backwards, so they are flipped below zero
+func ordered(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		bits = math.MinInt64 - bits //! -0 becomes 0, the same as +0
+	}
+	return bits
+}
+
+//! ULPs -> how many representable float64 values lie between a and b (units in the last place). 0 : the same number, 1 : neighbours
+//! NaN has no place in the order, the distance is math.MaxUint64
+func ULPs(a, b float64) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.MaxUint64
+	}
+	x, y := ordered(a), ordered(b)
+	if x < y {
+		x, y = y, x
+	}
+	return uint64(x) - uint64(y) //! unsigned subtraction : -Inf to +Inf doesn't overflow
+}
+
+//! KahanSum -> adds the values while keeping the lost low bits of every addition in 'compensation', and adds them back in the next step
+func KahanSum(values []float64) float64 {
+	var sum, compensation float64
+	for _, value := range values {
+		y := value - compensation
+		t := sum + y
+		compensation = (t - sum) - y //! (t - sum) is what really got added, y is what we wanted to add. The difference was lost
+		sum = t
+	}
+	return sum
+}
@@ -0,0 +1,136 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+//! variables, not constants : the constant 0.1 + 0.2 is computed exactly by the compiler, and is exactly 0.3
+var point1, point2 = 0.1, 0.2
+
+func TestEqual(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	tests := []struct {
+		name string
+		a, b float64
+		want bool
+	}{
+		{name: "0.1+0.2 vs 0.3", a: point1 + point2, b: 0.3, want: true},
+		{name: "too far apart", a: 1, b: 1.001, want: false},
+		{name: "signed zeros", a: 0, b: math.Copysign(0, -1), want: true},
+		{name: "same infinity", a: inf, b: inf, want: true},
+		{name: "opposite infinities", a: inf, b: -inf, want: false},
+		{name: "infinity vs max float", a: inf, b: math.MaxFloat64, want: false},
+		{name: "NaN vs NaN", a: nan, b: nan, want: false},
+		{name: "NaN vs number", a: nan, b: 1, want: false},
+		{name: "denormals", a: math.SmallestNonzeroFloat64, b: 0, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b, 1e-9); got != tt.want {
+				t.Errorf("Equal(%v, %v, 1e-9) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualRel(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	tests := []struct {
+		name string
+		a, b float64
+		want bool
+	}{
+		{name: "large values", a: 1e20, b: 1e20 + 1e5, want: true},
+		{name: "large values too far apart", a: 1e20, b: 1.0001e20, want: false},
+		{name: "small values", a: 1e-20, b: 1.0000000001e-20, want: true},
+		{name: "small vs zero", a: 1e-20, b: 0, want: false},
+		{name: "signed zeros", a: 0, b: math.Copysign(0, -1), want: true},
+		{name: "denormal vs zero", a: math.SmallestNonzeroFloat64, b: 0, want: true},
+		{name: "denormal neighbours", a: 3 * math.SmallestNonzeroFloat64, b: -3 * math.SmallestNonzeroFloat64, want: true},
+		{name: "same infinity", a: -inf, b: -inf, want: true},
+		{name: "infinity vs max float", a: inf, b: math.MaxFloat64, want: false},
+		{name: "NaN vs NaN", a: nan, b: nan, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualRel(tt.a, tt.b, 1e-9); got != tt.want {
+				t.Errorf("EqualRel(%v, %v, 1e-9) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliceEqual(t *testing.T) {
+	if !SliceEqual([]float64{point1 + point2, 1}, []float64{0.3, 1}, 1e-9) {
+		t.Error("SliceEqual of close values = false, want true")
+	}
+	if SliceEqual([]float64{1, 2}, []float64{1, 2, 3}, 1e-9) {
+		t.Error("SliceEqual of different lengths = true, want false")
+	}
+	if SliceEqual([]float64{math.NaN()}, []float64{math.NaN()}, 1e-9) {
+		t.Error("SliceEqual with NaN = true, want false")
+	}
+	if !SliceEqual(nil, []float64{}, 1e-9) {
+		t.Error("SliceEqual(nil, empty) = false, want true")
+	}
+}
+
+func TestULPs(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b float64
+		want uint64
+	}{
+		{name: "same number", a: 1, b: 1, want: 0},
+		{name: "adjacent above 1", a: 1, b: math.Nextafter(1, 2), want: 1},
+		{name: "adjacent below 1", a: math.Nextafter(1, 0), b: 1, want: 1},
+		{name: "two steps", a: 1, b: math.Nextafter(math.Nextafter(1, 2), 2), want: 2},
+		{name: "signed zeros", a: 0, b: math.Copysign(0, -1), want: 0},
+		{name: "across zero", a: -math.SmallestNonzeroFloat64, b: math.SmallestNonzeroFloat64, want: 2},
+		{name: "largest float to infinity", a: math.MaxFloat64, b: math.Inf(1), want: 1},
+		{name: "0.1+0.2 vs 0.3", a: point1 + point2, b: 0.3, want: 1},
+		{name: "NaN", a: math.NaN(), b: 1, want: math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ULPs(tt.a, tt.b); got != tt.want {
+				t.Errorf("ULPs(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := ULPs(tt.b, tt.a); got != tt.want {
+				t.Errorf("ULPs(%v, %v) = %d, want %d (the order must not matter)", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKahanSum(t *testing.T) {
+	//! 1e-16 is less than half the gap between 1 and the next float : 1 + 1e-16 rounds back to 1, every time
+	values := []float64{1}
+	for range 10 {
+		values = append(values, 1e-16)
+	}
+	const want = 1 + 1e-15
+
+	naive := 0.0
+	for _, value := range values {
+		naive += value
+	}
+	kahan := KahanSum(values)
+
+	if naive != 1 {
+		t.Fatalf("naive sum = %v, the case expects every 1e-16 to be lost", naive)
+	}
+	if math.Abs(kahan-want) >= math.Abs(naive-want) {
+		t.Errorf("KahanSum = %v, naive = %v, want Kahan closer to %v", kahan, naive, want)
+	}
+	if ULPs(kahan, want) > 1 {
+		t.Errorf("KahanSum = %v, %d ULPs from %v", kahan, ULPs(kahan, want), want)
+	}
+	if got := KahanSum(nil); got != 0 {
+		t.Errorf("KahanSum(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,3 @@
+module floatingpoint
+
+go 1.22
@@ -0,0 +1,64 @@
+//! Floating point -> a float64 can't hold 0.1 exactly (just like 1/3 can't be written exactly in decimal), so float math has tiny errors
+//! that's why floats are compared with a tolerance, never with ==. The helpers are in the approx package (approx/approx.go)
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"floatingpoint/approx"
+)
+
+func main() {
+	//! 1. the classic : variables, because the CONSTANT 0.1 + 0.2 is computed exactly by the compiler
+	a, b := 0.1, 0.2
+	sum := a + b
+	fmt.Println("0.1 + 0.2 == 0.3 :", sum == 0.3)
+	fmt.Printf("0.1 + 0.2        : %.17g\n", sum) //! %.17g shows every digit a float64 has
+	fmt.Printf("0.3              : %.17g\n", 0.3)
+	fmt.Println("ULPs apart       :", approx.ULPs(sum, 0.3), "-> they are neighbours")
+	fmt.Println("approx.Equal     :", approx.Equal(sum, 0.3, 1e-9))
+	fmt.Println("--------------------------------")
+
+	//! 2. errors add up : 0.1 ten thousand times
+	values := make([]float64, 10000)
+	naive := 0.0
+	for i := range values {
+		values[i] = 0.1
+		naive += 0.1
+	}
+	kahan := approx.KahanSum(values)
+	fmt.Printf("naive sum : %.17g (%d ULPs from 1000)\n", naive, approx.ULPs(naive, 1000))
+	fmt.Printf("Kahan sum : %.17g (%d ULPs from 1000)\n", kahan, approx.ULPs(kahan, 1000))
+	fmt.Println("--------------------------------")
+
+	//! 3. absolute vs relative tolerance, both with 1e-9
+	pairs := [][2]float64{
+		{sum, 0.3},
+		{1e20, 1e20 + 1e5}, //! 1e5 apart, but that's the 15th digit
+		{1e-20, 2e-20},     //! twice as big, but only 1e-20 apart
+		{1e-20, 0},
+	}
+	fmt.Printf("%-24s %-24s %-8s %s\n", "a", "b", "Equal", "EqualRel")
+	for _, pair := range pairs {
+		fmt.Printf("%-24.17g %-24.17g %-8v %v\n", pair[0], pair[1], approx.Equal(pair[0], pair[1], 1e-9), approx.EqualRel(pair[0], pair[1], 1e-9))
+	}
+	fmt.Println("--------------------------------")
+
+	//! 4. the special values
+	nan, inf := math.NaN(), math.Inf(1)
+	negativeZero := math.Copysign(0, -1)
+	fmt.Println("NaN == NaN        :", nan == nan, "approx.Equal :", approx.Equal(nan, nan, 1e-9))
+	fmt.Println("+Inf == +Inf      :", inf == inf, "approx.Equal :", approx.Equal(inf, inf, 1e-9))
+	fmt.Println("0 == -0           :", 0 == negativeZero, "ULPs :", approx.ULPs(0, negativeZero))
+	fmt.Println("1 and the next one:", approx.ULPs(1, math.Nextafter(1, 2)), "ULP, gap", math.Nextafter(1, 2)-1)
+	fmt.Println("slices            :", approx.SliceEqual([]float64{sum, 1}, []float64{0.3, 1}, 1e-9))
+}
+
+/*
+	Try :
+		1. Change a, b := 0.1, 0.2 into a const. Is 0.1 + 0.2 == 0.3 now? (yes : constants are exact until they become a float64)
+		2. Sum 0.1 a million times. How many ULPs is the naive sum off now? And Kahan?
+		3. Which tolerance would you use for money? For the distance between two cities in meters?
+		4. go test -v ./... runs the tests of the approx package
+*/
@@ -0,0 +1,84 @@
+//! Method sets -> which methods belong to a type decides which interfaces it satisfies. And a value and a pointer don't always have the same methods
+//!
+//! | receiver of the method      | in the method set of T (a value) | in the method set of *T (a pointer) |
+//! | --------------------------- | -------------------------------- | ----------------------------------- |
+//! | func (t T) Method()         | yes                              | yes                                 |
+//! | func (t *T) Method()        | NO                               | yes                                 |
+//!
+//! so : a value receiver -> both T and *T satisfy the interface. A pointer receiver -> only *T does
+package main
+
+import "fmt"
+
+type Notifier interface {
+	Notify()
+}
+
+//! User -> Notify has a POINTER receiver, because it changes the user : it counts the notifications
+type User struct {
+	Name  string
+	Email string
+	Sent  int
+}
+
+func (user *User) Notify() {
+	user.Sent++
+	fmt.Println("email to", user.Email, "-> sent", user.Sent, "time(s)")
+}
+
+//! Guest -> the flipped case : Notify has a VALUE receiver, it only reads the guest
+type Guest struct {
+	Name string
+}
+
+func (guest Guest) Notify() {
+	fmt.Println("banner for guest", guest.Name)
+}
+
+func sendAll(notifiers []Notifier) {
+	for _, notifier := range notifiers {
+		notifier.Notify()
+	}
+}
+
+func main() {
+	user := User{Name: "John", Email: "john@example.com"}
+
+	//! calling the method directly works on a value : user is a variable (addressable), so Go calls (&user).Notify() for us
+	user.Notify()
+
+	//! but an interface is stricter. A User VALUE doesn't have Notify in its method set :
+	//! var notifier Notifier = user
+	//! -> compile error : cannot use user (variable of struct type User) as Notifier value in variable declaration: User does not implement Notifier (method Notify has pointer receiver)
+	//! why : the interface would hold a COPY of user. Notify would change the copy, and the change would be lost silently. Go refuses instead
+	var notifier Notifier = &user //! a pointer works : *User has Notify
+	notifier.Notify()
+	fmt.Println("user.Sent :", user.Sent, "-> the interface holds a pointer to OUR user")
+	fmt.Println("--------------------------------")
+
+	//! the flipped case : with a value receiver, the value AND the pointer satisfy Notifier
+	guest := Guest{Name: "Jane"}
+	var fromValue Notifier = guest
+	var fromPointer Notifier = &guest //! *Guest gets the value methods too : Go reads the value through the pointer
+	fromValue.Notify()
+	fromPointer.Notify()
+	fmt.Println("--------------------------------")
+
+	//! one slice, both kinds. Each element only needs the Notify method in its method set
+	notifiers := []Notifier{
+		&user,
+		guest,
+		&Guest{Name: "Jim"},
+		&User{Name: "Jack", Email: "jack@example.com"},
+	}
+	sendAll(notifiers)
+	fmt.Println("user.Sent :", user.Sent)
+}
+
+/*
+	Try :
+		1. Uncomment var notifier Notifier = user and read the whole error message
+		2. Change User's Notify to a value receiver. Does user.Sent still go up when notified through the interface?
+		3. Add User{Name: "Joe"} (a value, no &) to the notifiers slice. Why does it fail while guest works?
+		4. Compare with the nil interface pitfall lesson (31. nil interface pitfall) : a nil *User in a Notifier is not a nil Notifier
+*/
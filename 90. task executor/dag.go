@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//! CycleError -> the tasks wait for each other in a circle, so none of them can ever start. Path is the circle : a -> c -> b -> a
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " -> ")
+}
+
+func sortedNames(tasks map[string]Task) []string {
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//! validate -> every dependency must exist, and there must be no cycle. Checked BEFORE anything runs
+//! depth-first search with three colors : white (not visited), gray (on the current path), black (done, no cycle below)
+//! reaching a gray task again means we walked in a circle
+func validate(tasks map[string]Task) error {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := map[string]int{}
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		color[name] = gray
+		path = append(path, name)
+		deps := append([]string(nil), tasks[name].Deps...)
+		sort.Strings(deps) //! the same cycle is reported on every run, whatever the map order
+		for _, dep := range deps {
+			if _, ok := tasks[dep]; !ok {
+				return fmt.Errorf("task %q depends on unknown task %q", name, dep)
+			}
+			switch color[dep] {
+			case gray:
+				start := 0
+				for path[start] != dep {
+					start++
+				}
+				cycle := append(append([]string(nil), path[start:]...), dep)
+				return &CycleError{Path: cycle}
+			case white:
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = black
+		return nil
+	}
+
+	for _, name := range sortedNames(tasks) {
+		if color[name] == white {
+			if err := visit(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
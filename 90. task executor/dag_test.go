@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//! deps -> a graph without work, for validate
+func deps(edges map[string][]string) map[string]Task {
+	tasks := map[string]Task{}
+	for name, list := range edges {
+		tasks[name] = Task{Deps: list}
+	}
+	return tasks
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		tasks     map[string]Task
+		wantCycle []string //! nil -> no cycle expected
+		wantErr   string   //! "" -> no error expected
+	}{
+		{"empty graph", deps(nil), nil, ""},
+		{"diamond", deps(map[string][]string{"a": nil, "b": {"a"}, "c": {"a"}, "d": {"b", "c"}}), nil, ""},
+		{"self dependency", deps(map[string][]string{"a": {"a"}}), []string{"a", "a"}, "dependency cycle: a -> a"},
+		{"cycle of three, the same path on every run", deps(map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}, "d": nil}), []string{"a", "c", "b", "a"}, "dependency cycle: a -> c -> b -> a"},
+		{"cycle below a valid task", deps(map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"b"}}), []string{"b", "c", "b"}, "dependency cycle: b -> c -> b"},
+		{"unknown dependency", deps(map[string][]string{"a": {"missing"}}), nil, `task "a" depends on unknown task "missing"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.tasks)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("validate() = %v, want %s", err, tt.wantErr)
+			}
+			var cycle *CycleError
+			if errors.As(err, &cycle) != (tt.wantCycle != nil) {
+				t.Fatalf("CycleError : %v, want %v", errors.As(err, &cycle), tt.wantCycle != nil)
+			}
+			if tt.wantCycle != nil && !reflect.DeepEqual(cycle.Path, tt.wantCycle) {
+				t.Errorf("cycle path = %s, want %s", strings.Join(cycle.Path, ","), strings.Join(tt.wantCycle, ","))
+			}
+		})
+	}
+}
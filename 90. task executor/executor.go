@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+//! Task -> a unit of work. Deps are the names of the tasks which must finish first
+type Task struct {
+	Deps []string
+	Run  func(ctx context.Context) error
+}
+
+type Status int
+
+const (
+	StatusDone     Status = iota
+	StatusFailed          //! Run returned an error
+	StatusSkipped         //! a dependency (direct or not) failed, so it never ran
+	StatusCanceled        //! the context was canceled before or while it ran
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusDone:
+		return "done"
+	case StatusFailed:
+		return "failed"
+	case StatusSkipped:
+		return "skipped"
+	case StatusCanceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+type TaskResult struct {
+	Status   Status
+	Err      error
+	Duration time.Duration //! 0 for a task which never started
+}
+
+//! Report -> one result per task, and the order the tasks STARTED in
+type Report struct {
+	Results    map[string]TaskResult
+	StartOrder []string
+}
+
+type finished struct {
+	name     string
+	err      error
+	duration time.Duration
+}
+
+//! Execute -> runs every task once, as soon as all its dependencies are done, with at most 'workers' tasks at the same time
+//! the error is nil when every task is done, ctx.Err() after a cancellation, and the joined task errors after failures
+func Execute(ctx context.Context, tasks map[string]Task, workers int) (Report, error) {
+	if workers < 1 {
+		return Report{}, fmt.Errorf("workers must be at least 1, got %d", workers)
+	}
+	if err := validate(tasks); err != nil {
+		return Report{}, err
+	}
+
+	//! waiting[name] -> how many dependencies are not done yet. dependents[name] -> who waits for name
+	waiting := map[string]int{}
+	dependents := map[string][]string{}
+	for name, task := range tasks {
+		waiting[name] = len(task.Deps)
+		for _, dep := range task.Deps {
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var ready []string
+	for _, name := range sortedNames(tasks) {
+		if waiting[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	report := Report{Results: map[string]TaskResult{}}
+	done := make(chan finished)
+	running := 0
+	canceled := ctx.Done()
+
+	//! skip -> a failed task's dependents, and THEIR dependents, will never run
+	var skip func(name string)
+	skip = func(name string) {
+		for _, dependent := range dependents[name] {
+			if _, ok := report.Results[dependent]; !ok {
+				report.Results[dependent] = TaskResult{Status: StatusSkipped}
+				skip(dependent)
+			}
+		}
+	}
+
+	for len(ready) > 0 || running > 0 {
+		//! start as many ready tasks as there are free workers, unless we are canceled
+		for ctx.Err() == nil && running < workers && len(ready) > 0 {
+			name := ready[0]
+			ready = ready[1:]
+			report.StartOrder = append(report.StartOrder, name)
+			running++
+			go func(name string, task Task) {
+				start := time.Now()
+				err := task.Run(ctx)
+				done <- finished{name: name, err: err, duration: time.Since(start)}
+			}(name, tasks[name])
+		}
+		if running == 0 {
+			break //! canceled with nothing running : the ready tasks will never start
+		}
+
+		select {
+		case <-canceled:
+			canceled = nil //! a closed channel is always ready : stop selecting on it, and just wait for the running tasks
+			continue
+		case result := <-done:
+			running--
+			switch {
+			case result.err == nil:
+				report.Results[result.name] = TaskResult{Status: StatusDone, Duration: result.duration}
+				var newlyReady []string
+				for _, dependent := range dependents[result.name] {
+					waiting[dependent]--
+					if _, skipped := report.Results[dependent]; !skipped && waiting[dependent] == 0 {
+						newlyReady = append(newlyReady, dependent)
+					}
+				}
+				sort.Strings(newlyReady)
+				ready = append(ready, newlyReady...)
+			case ctx.Err() != nil:
+				report.Results[result.name] = TaskResult{Status: StatusCanceled, Err: result.err, Duration: result.duration}
+			default:
+				report.Results[result.name] = TaskResult{Status: StatusFailed, Err: result.err, Duration: result.duration}
+				skip(result.name)
+			}
+		}
+	}
+
+	//! whatever has no result yet never started : it was canceled
+	var failures []error
+	for _, name := range sortedNames(tasks) {
+		result, ok := report.Results[name]
+		if !ok {
+			report.Results[name] = TaskResult{Status: StatusCanceled}
+			continue
+		}
+		if result.Status == StatusFailed {
+			failures = append(failures, fmt.Errorf("task %s: %w", name, result.Err))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, errors.Join(failures...)
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//! recorder -> remembers which tasks ran, to check "ran once" and "never ran"
+type recorder struct {
+	mutex sync.Mutex
+	runs  map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{runs: map[string]int{}}
+}
+
+func (r *recorder) task(name string, err error, deps ...string) Task {
+	return Task{Deps: deps, Run: func(ctx context.Context) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.runs[name]++
+		return err
+	}}
+}
+
+func statuses(report Report) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(report.Results)) {
+		parts = append(parts, name+"="+report.Results[name].Status.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestExecute(t *testing.T) {
+	diskFull := errors.New("disk full")
+	tests := []struct {
+		name         string
+		tasks        func(r *recorder) map[string]Task
+		workers      int
+		wantErr      string
+		wantStatuses string
+		wantRuns     map[string]int
+		wantStart    []string //! nil -> any order
+	}{
+		{
+			//! b and c both need a, d needs b and c. a must run ONCE
+			name: "diamond",
+			tasks: func(r *recorder) map[string]Task {
+				return map[string]Task{"a": r.task("a", nil), "b": r.task("b", nil, "a"), "c": r.task("c", nil, "a"), "d": r.task("d", nil, "b", "c")}
+			},
+			workers:      4,
+			wantStatuses: "a=done b=done c=done d=done",
+			wantRuns:     map[string]int{"a": 1, "b": 1, "c": 1, "d": 1},
+			wantStart:    []string{"a", "b", "c", "d"},
+		},
+		{
+			//! b fails, so d (needs b) and f (needs d) are skipped. c and e don't need b and still run
+			name: "failure skips only the downstream tasks",
+			tasks: func(r *recorder) map[string]Task {
+				return map[string]Task{
+					"a": r.task("a", nil), "b": r.task("b", diskFull, "a"), "c": r.task("c", nil, "a"),
+					"d": r.task("d", nil, "b"), "e": r.task("e", nil, "c"), "f": r.task("f", nil, "d", "e"),
+				}
+			},
+			workers:      2,
+			wantErr:      "task b: disk full",
+			wantStatuses: "a=done b=failed c=done d=skipped e=done f=skipped",
+			wantRuns:     map[string]int{"a": 1, "b": 1, "c": 1, "e": 1},
+		},
+		{
+			name: "two failures -> both errors, in name order",
+			tasks: func(r *recorder) map[string]Task {
+				return map[string]Task{"x": r.task("x", errors.New("boom")), "y": r.task("y", diskFull), "z": r.task("z", nil, "x", "y")}
+			},
+			workers:      2,
+			wantErr:      "task x: boom\ntask y: disk full",
+			wantStatuses: "x=failed y=failed z=skipped",
+			wantRuns:     map[string]int{"x": 1, "y": 1},
+		},
+		{
+			name:         "no tasks",
+			tasks:        func(r *recorder) map[string]Task { return map[string]Task{} },
+			workers:      1,
+			wantStatuses: "",
+			wantRuns:     map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRecorder()
+			report, err := Execute(context.Background(), tt.tasks(r), tt.workers)
+			if (err == nil && tt.wantErr != "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+			if got := statuses(report); got != tt.wantStatuses {
+				t.Errorf("statuses = %s, want %s", got, tt.wantStatuses)
+			}
+			if !reflect.DeepEqual(r.runs, tt.wantRuns) {
+				t.Errorf("runs = %v, want %v", r.runs, tt.wantRuns)
+			}
+			if tt.wantStart != nil && !reflect.DeepEqual(report.StartOrder, tt.wantStart) {
+				t.Errorf("start order = %v, want %v", report.StartOrder, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestFailedTaskError(t *testing.T) {
+	diskFull := errors.New("disk full")
+	r := newRecorder()
+	report, err := Execute(context.Background(), map[string]Task{"b": r.task("b", diskFull)}, 1)
+	if !errors.Is(err, diskFull) {
+		t.Errorf("errors.Is(err, diskFull) = false for %v", err)
+	}
+	if got := report.Results["b"].Err; got != diskFull {
+		t.Errorf("Results[b].Err = %v, want the task's own error", got)
+	}
+}
+
+//! invalid input is refused BEFORE anything runs
+func TestExecuteRefuses(t *testing.T) {
+	r := newRecorder()
+	tests := []struct {
+		name    string
+		tasks   map[string]Task
+		workers int
+		wantErr string
+	}{
+		{"cycle -> nothing ran, not even d", map[string]Task{
+			"a": r.task("a", nil, "c"), "b": r.task("b", nil, "a"), "c": r.task("c", nil, "b"), "d": r.task("d", nil),
+		}, 2, "dependency cycle: a -> c -> b -> a"},
+		{"unknown dependency", map[string]Task{"a": r.task("a", nil, "missing")}, 1, `task "a" depends on unknown task "missing"`},
+		{"zero workers", map[string]Task{"a": r.task("a", nil)}, 0, "workers must be at least 1, got 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Execute(context.Background(), tt.tasks, tt.workers)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %s", err, tt.wantErr)
+			}
+			if len(r.runs) != 0 {
+				t.Errorf("tasks ran : %v", r.runs)
+			}
+		})
+	}
+}
+
+//! n independent tasks : a probe counts how many run at the same moment
+func TestParallelismBoundedByWorkers(t *testing.T) {
+	for _, workers := range []int{1, 3, 8} {
+		t.Run(fmt.Sprint(workers, " workers"), func(t *testing.T) {
+			var current, peak atomic.Int32
+			probe := Task{Run: func(ctx context.Context) error {
+				now := current.Add(1)
+				for {
+					old := peak.Load()
+					if now <= old || peak.CompareAndSwap(old, now) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				current.Add(-1)
+				return nil
+			}}
+			tasks := map[string]Task{}
+			for i := range 8 {
+				tasks[fmt.Sprint("t", i)] = probe
+			}
+			if _, err := Execute(context.Background(), tasks, workers); err != nil {
+				t.Fatal(err)
+			}
+			if got := peak.Load(); got != int32(workers) {
+				t.Errorf("at most %d tasks at the same time, want %d", got, workers)
+			}
+		})
+	}
+}
+
+//! cancellation in the middle : a cancels the run, b is running and stops, c never starts
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	report, err := Execute(ctx, map[string]Task{
+		"a": {Run: func(ctx context.Context) error { cancel(); return nil }},
+		"b": step(time.Minute, "a"),
+		"c": step(0, "b"),
+	}, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if got := statuses(report); got != "a=done b=canceled c=canceled" {
+		t.Errorf("statuses = %s", got)
+	}
+	if !reflect.DeepEqual(report.StartOrder, []string{"a"}) {
+		t.Errorf("start order = %v, want only a : b and c never started", report.StartOrder)
+	}
+}
+
+func TestAlreadyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := newRecorder()
+	report, err := Execute(ctx, map[string]Task{"a": r.task("a", nil)}, 1)
+	if !errors.Is(err, context.Canceled) || statuses(report) != "a=canceled" || len(r.runs) != 0 {
+		t.Errorf("err = %v, statuses = %s, runs = %v, want canceled and nothing ran", err, statuses(report), r.runs)
+	}
+}
+
+//! the site build of main : every task runs after its dependencies, archive last
+func TestSiteBuild(t *testing.T) {
+	tasks := siteBuild()
+	report, err := Execute(context.Background(), tasks, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := map[string]int{}
+	for i, name := range report.StartOrder {
+		position[name] = i
+	}
+	for name, task := range tasks {
+		for _, dep := range task.Deps {
+			if position[dep] > position[name] {
+				t.Errorf("%s started before its dependency %s", name, dep)
+			}
+		}
+	}
+	if last := report.StartOrder[len(report.StartOrder)-1]; last != "archive" {
+		t.Errorf("last task = %s, want archive", last)
+	}
+}
+
+func TestStatusString(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   string
+	}{
+		{StatusDone, "done"},
+		{StatusFailed, "failed"},
+		{StatusSkipped, "skipped"},
+		{StatusCanceled, "canceled"},
+		{Status(9), "Status(9)"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.want {
+			t.Errorf("Status(%d).String() = %q, want %q", int(tt.status), got, tt.want)
+		}
+	}
+}
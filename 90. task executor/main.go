@@ -0,0 +1,60 @@
+//! Task executor -> runs tasks which depend on each other, like the steps of a build : a task starts as soon as ALL its dependencies are done,
+//! independent tasks run at the same time (up to a number of workers), and when a task fails, only the tasks which need it are skipped
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+//! step -> a fake build step which just takes some time
+func step(d time.Duration, deps ...string) Task {
+	return Task{Deps: deps, Run: func(ctx context.Context) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}
+}
+
+//! siteBuild -> a fake build of this lesson site : parse the lessons, render the pages, build the index, pack everything
+func siteBuild() map[string]Task {
+	return map[string]Task{
+		"parse":          step(30 * time.Millisecond),
+		"copy assets":    step(50 * time.Millisecond),
+		"render basics":  step(40*time.Millisecond, "parse"),
+		"render structs": step(20*time.Millisecond, "parse"),
+		"render http":    step(30*time.Millisecond, "parse"),
+		"index":          step(10*time.Millisecond, "render basics", "render structs", "render http"),
+		"archive":        step(20*time.Millisecond, "index", "copy assets"),
+	}
+}
+
+func printReport(report Report) {
+	for i, name := range report.StartOrder {
+		result := report.Results[name]
+		fmt.Printf("%d. %-15s %-8s %v\n", i+1, name, result.Status, result.Duration.Round(10*time.Millisecond))
+	}
+}
+
+func main() {
+	start := time.Now()
+	report, err := Execute(context.Background(), siteBuild(), 2)
+	printReport(report)
+	fmt.Println("total :", time.Since(start).Round(10*time.Millisecond), "with 2 workers")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error :", err)
+		os.Exit(1)
+	}
+}
+
+/*
+	Try :
+		1. Run the site build with 1 worker, then with 10. How much faster does it get, and why not faster still?
+		2. Make "render structs" fail. Which tasks are skipped? Does "copy assets" still run?
+		3. Make "parse" depend on "archive" and read the cycle error
+*/
@@ -0,0 +1,38 @@
+//! String similarity -> how close are two strings? Useful to find "John" when the user types "Jhon"
+//! Levenshtein counts the edits between two strings, Jaro-Winkler gives a similarity from 0 to 1 which rewards a common start
+package main
+
+import (
+	"fmt"
+)
+
+var people = []Person{
+	{Name: "John Smith", Age: 20, Email: "john@example.com"},
+	{Name: "Jane Doe", Age: 21, Email: "jane@example.com"},
+	{Name: "Johan Berg", Age: 35, Email: "johan@example.com"},
+	{Name: "Jon Snow", Age: 25, Email: "jon@example.com"},
+	{Name: "Martha Stewart", Age: 40, Email: "martha@example.com"},
+}
+
+func main() {
+	for _, query := range []string{"Jhon", "smiht", "Jnae"} {
+		fmt.Printf("%-6s ->", query)
+		for _, match := range FuzzyFind(people, query, 3) {
+			fmt.Printf(" %s (%.2f)", match.Person.Name, match.Score)
+		}
+		fmt.Println()
+	}
+	fmt.Println("--------------------------------")
+
+	fmt.Printf("%-8s %-8s %-12s %s\n", "a", "b", "Levenshtein", "Jaro-Winkler")
+	for _, pair := range [][2]string{{"john", "jhon"}, {"john", "jon"}, {"john", "jane"}, {"martha", "marhta"}} {
+		fmt.Printf("%-8s %-8s %-12d %.3f\n", pair[0], pair[1], Levenshtein(pair[0], pair[1]), JaroWinkler(pair[0], pair[1]))
+	}
+}
+
+/*
+	Try :
+		1. Search for "Jon". Why does "Jon Snow" come first and not "John Smith"?
+		2. Change minScore to 0.7. Which new matches show up for "Jhon"? Are they useful?
+		3. Write FuzzyFind with Levenshtein instead : a match when the distance is at most 2. Compare the results for "smiht"
+*/
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+type Match struct {
+	Person Person
+	Score  float64 //! the best Jaro-Winkler similarity, 0 to 1
+}
+
+//! minScore -> below this a name is "not similar". 0.8 keeps "Jhon" -> John, and drops "Jane" for "John"
+const minScore = 0.8
+
+//! nameScore -> the query against the full name AND against each part, so "smith" finds "John Smith"
+func nameScore(name, query string) float64 {
+	name = strings.ToLower(name)
+	best := JaroWinkler(name, query)
+	for _, part := range strings.Fields(name) {
+		best = max(best, JaroWinkler(part, query))
+	}
+	return best
+}
+
+//! FuzzyFind -> the people whose name is similar to query, best first, at most maxResults of them (0 or less : no limit)
+//! people with the same score keep the order they have in the slice
+func FuzzyFind(people []Person, query string, maxResults int) []Match {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var matches []Match
+	for _, person := range people {
+		if score := nameScore(person.Name, query); score >= minScore {
+			matches = append(matches, Match{Person: person, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if maxResults > 0 && len(matches) > maxResults {
+		matches = matches[:maxResults]
+	}
+	return matches
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func names(matches []Match) []string {
+	var result []string
+	for _, match := range matches {
+		result = append(result, match.Person.Name)
+	}
+	return result
+}
+
+func TestFuzzyFind(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		maxResults int
+		want       []string
+	}{
+		{"Jhon -> John first", "Jhon", 0, []string{"John Smith", "Jon Snow", "Johan Berg"}},
+		{"a last name : smiht -> John Smith", "smiht", 0, []string{"John Smith"}},
+		{"case and spaces don't matter", "  MARTHA ", 0, []string{"Martha Stewart"}},
+		{"maxResults limits the result", "Jhon", 2, []string{"John Smith", "Jon Snow"}},
+		{"nothing similar -> no match", "xyz", 0, nil},
+		{"empty query -> no match", "", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(FuzzyFind(people, tt.query, tt.maxResults)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FuzzyFind(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyFindTiesKeepSliceOrder(t *testing.T) {
+	twins := []Person{{Name: "John", Email: "first@example.com"}, {Name: "Jane"}, {Name: "John", Email: "second@example.com"}}
+	found := FuzzyFind(twins, "john", 0)
+	if len(found) != 2 || found[0].Person.Email != "first@example.com" || found[1].Person.Email != "second@example.com" {
+		t.Errorf("FuzzyFind() = %+v, want both Johns in slice order", found)
+	}
+}
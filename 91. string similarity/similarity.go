@@ -0,0 +1,99 @@
+package main
+
+import "unicode/utf8"
+
+//! Levenshtein -> the fewest single-character edits (insert, delete, replace) which turn a into b. "kitten" -> "sitting" : 3
+//! it works on runes, not bytes : "café" -> "cafe" is 1 edit, not 2
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra //! the rows are as long as the shorter string
+	}
+
+	//! the full table would be (len(a)+1) x (len(b)+1). Each row only needs the row above it, so two rows are enough
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j //! "" -> b[:j] : j inserts
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i //! a[:i] -> "" : i deletes
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(
+				previous[j]+1,      //! delete
+				current[j-1]+1,     //! insert
+				previous[j-1]+cost, //! replace (or keep)
+			)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+//! Jaro -> 1 for the same string, 0 for nothing in common. Counts the characters the strings share (close enough to each other),
+//! and how many of those are in a different order (transpositions)
+func Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1 //! a character matches only if it's at most 'window' places away
+	window = max(window, 0)
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i, r := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && rb[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	//! walk both lists of matched characters together : every pair which differs is half a transposition
+	transpositions, j := 0, 0
+	for i, r := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if r != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+//! JaroWinkler -> Jaro, plus a bonus for a common prefix (up to 4 characters). People make fewer typos at the start of a name
+func JaroWinkler(a, b string) float64 {
+	jaro := Jaro(a, b)
+	prefix := 0
+	for prefix < 4 && a != "" && b != "" {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			break
+		}
+		prefix++
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"john", "john", 0},
+		{"café", "cafe", 1}, //! é is one rune : one edit, not two
+		{"naïve", "naive", 1},
+		{"অমর", "অমল", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Levenshtein(tt.b, tt.a); got != tt.want {
+				t.Errorf("Levenshtein(%q, %q) = %d, want %d : not symmetric", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+//! the values from Winkler's paper, and the edge cases
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.840},
+		{"dixon", "dicksonx", 0.813},
+		{"john", "jhon", 0.925},
+		{"john", "john", 1},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"", "john", 0},
+		{"naïve", "naive", 0.893},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := JaroWinkler(tt.a, tt.b); !near(got, tt.want) {
+				t.Errorf("JaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+//! the prefix bonus : only for a common start, and for 4 characters at most
+func TestPrefixBonus(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      string
+		wantBonus float64 //! JaroWinkler - Jaro, as a share of (1 - Jaro)
+	}{
+		{"john / jhon : 1 common character", "john", "jhon", 0.1},
+		{"no common start -> no bonus", "john", "ohn", 0},
+		{"the bonus stops at 4 characters", "abcdefgh", "abcdefxy", 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jaro := Jaro(tt.a, tt.b)
+			if jaro == 0 {
+				t.Fatalf("Jaro(%q, %q) = 0, the bonus can't be seen", tt.a, tt.b)
+			}
+			if got := JaroWinkler(tt.a, tt.b) - jaro; !near(got, tt.wantBonus*(1-jaro)) {
+				t.Errorf("bonus = %.3f, want %.3f", got, tt.wantBonus*(1-jaro))
+			}
+		})
+	}
+}
+
+//! both metrics are O(len(a) * len(b)), but Jaro-Winkler only looks inside its window and allocates less
+func BenchmarkMetrics(b *testing.B) {
+	var names []string
+	for i := range 10000 {
+		names = append(names, fmt.Sprintf("person%05d smith", i))
+	}
+	metrics := []struct {
+		name   string
+		metric func(a, b string) float64
+	}{
+		{"Levenshtein", func(a, b string) float64 { return float64(Levenshtein(a, b)) }},
+		{"JaroWinkler", JaroWinkler},
+	}
+	for _, m := range metrics {
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.metric(names[i%len(names)], "persen01234 smyth")
+			}
+		})
+	}
+}
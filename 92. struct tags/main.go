@@ -0,0 +1,99 @@
+//! Struct tags -> a string after a field, in backquotes. Go itself ignores it, but code can read it with the reflect package
+//! encoding/json reads `json:"..."` this way (65. json). Here we read our OWN tags : validate and label
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type Person struct {
+	Name  string `validate:"required" label:"Full Name"`
+	Age   int    `label:"Age"`
+	Email string `validate:"required" label:"Email Address"`
+	Phone string //! no tags at all : not required, and the label is the field name
+}
+
+//! printFields -> every field of a struct with its label and its value
+func printFields(v any) {
+	t := reflect.TypeOf(v)      //! the TYPE : field names, field types, tags
+	value := reflect.ValueOf(v) //! the VALUE : what is stored in this variable
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		label := field.Tag.Get("label") //! Get returns "" for a missing tag
+		if label == "" {
+			label = field.Name
+		}
+		fmt.Printf("%-14s (%-6s) : %#v\n", label, field.Type, value.Field(i)) //! %#v shows strings in quotes, so an empty one is visible
+	}
+}
+
+//! validateRequired -> the names of the string fields tagged validate:"required" which are empty
+//! it takes 'any', so it works for every struct, not only Person. A pointer to a struct works too
+func validateRequired(v any) []string {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem() //! the struct the pointer points to
+	}
+	if value.Kind() != reflect.Struct {
+		return nil //! only a struct has fields
+	}
+	t := value.Type()
+
+	var missing []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("validate") != "required" || field.Type.Kind() != reflect.String {
+			continue
+		}
+		if value.Field(i).String() == "" {
+			missing = append(missing, field.Name)
+		}
+	}
+	return missing
+}
+
+//! Product -> a different struct : validateRequired doesn't need to know it
+type Product struct {
+	Title string  `validate:"required"`
+	Price float64 `validate:"required"` //! not a string : ignored
+}
+
+func main() {
+	complete := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	incomplete := Person{Age: 21}
+
+	printFields(complete)
+	fmt.Println("--------------------------------")
+
+	//! a tag is just a string of key:"value" pairs, read with Get
+	field, _ := reflect.TypeOf(complete).FieldByName("Email")
+	fmt.Println("the whole tag   :", field.Tag)
+	fmt.Println("validate        :", field.Tag.Get("validate"))
+	value, ok := field.Tag.Lookup("missing") //! Lookup tells "not there" apart from an empty value
+	fmt.Printf("missing         : %q %v\n", value, ok)
+	fmt.Println("--------------------------------")
+
+	fmt.Println("complete        :", validateRequired(complete))
+	fmt.Println("incomplete      :", validateRequired(incomplete))
+	fmt.Println("pointer         :", validateRequired(&incomplete))
+	fmt.Println("another struct  :", validateRequired(Product{Price: 9.99}))
+	fmt.Println("not a struct    :", validateRequired("John"))
+	fmt.Println("--------------------------------")
+
+	//! the labels make the messages friendly
+	personType := reflect.TypeOf(incomplete)
+	for _, name := range validateRequired(incomplete) {
+		field, _ := personType.FieldByName(name)
+		fmt.Println(field.Tag.Get("label"), "is required")
+	}
+}
+
+/*
+	Try :
+		1. Add a validate:"required" tag to Phone. What does validateRequired(complete) return now?
+		2. Support validate:"required,email" : split the tag on "," and check that an email contains "@"
+		3. Make printFields work with a pointer too, like validateRequired
+		4. Write the tag without quotes : `validate:required`. What does go vet say?
+*/
@@ -0,0 +1,94 @@
+//! Anonymous struct -> a struct type without a name, declared right where it's used. Good for a value we need only once
+//! and struct comparison : when is == allowed on structs, and what to do when it isn't
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+//! Team -> has a slice field. Slices can't be compared with ==, so neither can Team
+type Team struct {
+	Name    string
+	Members []string
+}
+
+func main() {
+	//! 1. a one-off config value : the type is written inline, no 'type Config struct' needed
+	config := struct {
+		Host    string
+		Port    int
+		Verbose bool
+	}{
+		Host:    "localhost",
+		Port:    8080,
+		Verbose: true,
+	}
+	fmt.Println("config :", config.Host, config.Port, config.Verbose)
+	fmt.Printf("config : %+v\n", config) //! %+v prints the field names too
+	fmt.Println("--------------------------------")
+
+	//! 2. a slice of anonymous structs : rows of data, like the cases of a table-driven test
+	cases := []struct {
+		a, b int
+		want int
+	}{
+		{a: 1, b: 2, want: 3},
+		{a: -1, b: 1, want: 0},
+		{a: 10, b: 5, want: 15},
+	}
+	for _, c := range cases {
+		fmt.Println(c.a, "+", c.b, "=", c.a+c.b, "want", c.want, "ok :", c.a+c.b == c.want)
+	}
+	fmt.Println("--------------------------------")
+
+	//! 3. comparing structs with == : allowed when EVERY field is comparable (numbers, strings, bools, pointers, arrays, other such structs)
+	//! two structs are equal when all their fields are equal
+	person1 := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	person2 := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	person3 := Person{Name: "Jane", Age: 21, Email: "jane@example.com"}
+	fmt.Println("person1 == person2 :", person1 == person2)
+	fmt.Println("person1 == person3 :", person1 == person3)
+
+	team1 := Team{Name: "Go", Members: []string{"John", "Jane"}}
+	team2 := Team{Name: "Go", Members: []string{"John", "Jane"}}
+	//! a slice field makes a struct NOT comparable :
+	//! fmt.Println(team1 == team2)
+	//! -> compile error : invalid operation: team1 == team2 (struct containing []string cannot be compared)
+	//! the workaround : reflect.DeepEqual compares field by field, and slices element by element
+	fmt.Println("reflect.DeepEqual(team1, team2) :", reflect.DeepEqual(team1, team2))
+	team2.Members[1] = "Jim"
+	fmt.Println("after team2 changed             :", reflect.DeepEqual(team1, team2))
+	fmt.Println("--------------------------------")
+
+	//! 4. assignment COPIES a struct : p2 is a new Person with the same values. Changing p2 doesn't touch p1
+	p1 := Person{Name: "John", Age: 20, Email: "john@example.com"}
+	p2 := p1
+	p2.Name = "X"
+	fmt.Println(`Person Name :`, p1.Name, `Person Age :`, p1.Age, `Person Email :`, p1.Email)
+	fmt.Println(`Person Name :`, p2.Name, `Person Age :`, p2.Age, `Person Email :`, p2.Email)
+
+	//! a pointer SHARES the struct : p3 points to p1, so a change through p3 is a change of p1
+	p3 := &p1
+	p3.Name = "Y"
+	fmt.Println("p1.Name after p3.Name = \"Y\" :", p1.Name)
+
+	//! careful : the copy is shallow. A slice field is copied as a slice header, so both copies share the elements
+	team3 := team1
+	team3.Members[0] = "Jack"
+	fmt.Println("team1.Members after team3 changed :", team1.Members)
+}
+
+/*
+	Try :
+		1. Uncomment fmt.Println(team1 == team2) and read the error. Then change Members to an array, [2]string. Does it compile now?
+		2. Compare two anonymous structs with the same fields : struct{ A int }{1} == struct{ A int }{1}
+		3. Write a function which takes a Person (not a pointer) and changes its Name. Does the caller see it? (14. pass by value or reference)
+		4. Use a map[string]int field instead of the slice. Can == compare it? Can reflect.DeepEqual?
+*/
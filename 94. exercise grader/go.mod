@@ -0,0 +1,7 @@
+module exercisegrader
+
+go 1.22
+
+require osexec v0.0.0
+
+replace osexec => "../38. os exec"
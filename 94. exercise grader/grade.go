@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"osexec/testjson"
+)
+
+//! Failure -> a failed test and the lines which explain why
+type Failure struct {
+	Test   string `json:"test"`
+	Output string `json:"output"`
+}
+
+//! ExerciseReport -> the grade of one exercise directory. Score is a percentage, Earned and Possible are weights
+type ExerciseReport struct {
+	Name     string    `json:"name"`
+	Score    float64   `json:"score"`
+	Earned   float64   `json:"earned"`
+	Possible float64   `json:"possible"`
+	Passed   []string  `json:"passed"`
+	Failed   []Failure `json:"failed"`
+	Skipped  []string  `json:"skipped"`
+	Error    string    `json:"error,omitempty"` //! the exercise didn't build, or go test couldn't run
+}
+
+//! Report -> every exercise, and the total : the average of the exercise scores, so each exercise counts the same
+type Report struct {
+	Exercises     []ExerciseReport `json:"exercises"`
+	Total         float64          `json:"total"`
+	PassThreshold float64          `json:"pass_threshold"`
+	Passed        bool             `json:"passed"`
+}
+
+//! maxOutput -> the failure output is cut after this many bytes, a panicking test can print a lot
+const maxOutput = 300
+
+//! weightsFile -> optional, in the exercise directory : {"TestAdd": 2, "TestEdgeCases": 3}. A test which isn't listed weighs 1
+const weightsFile = "weights.json"
+
+func loadWeights(dir string) (map[string]float64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, weightsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]float64{}, nil //! no file : every test weighs the same
+	}
+	if err != nil {
+		return nil, err
+	}
+	var weights map[string]float64
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return nil, fmt.Errorf("%s: %w", weightsFile, err)
+	}
+	for name, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("%s: %s has a negative weight", weightsFile, name)
+		}
+	}
+	return weights, nil
+}
+
+//! failureLine -> the lines worth showing : "add_test.go:12: add(2, 2) = 4, want 5" and "panic: ...". Stack traces hold machine paths and addresses, so they are left out
+var failureLine = regexp.MustCompile(`^\w+_test\.go:\d+: `)
+
+//! recoveredSuffix -> "panic: ... [recovered]" : newer Go versions add more words inside the brackets, so the note is dropped to keep reports the same everywhere
+var recoveredSuffix = regexp.MustCompile(` \[recovered.*\]$`)
+
+func failureOutput(lines []string) string {
+	var kept []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if failureLine.MatchString(line) {
+			kept = append(kept, line)
+		}
+		if strings.HasPrefix(line, "panic: ") {
+			kept = append(kept, recoveredSuffix.ReplaceAllString(line, ""))
+		}
+	}
+	output := strings.Join(kept, "\n")
+	if len(output) > maxOutput {
+		output = output[:maxOutput] + "..."
+	}
+	return output
+}
+
+//! score -> grades the results of the top-level tests. Subtests are part of their parent test and not graded on their own
+//! a test in weights.json which never reported (a panic stopped the run before it) counts as failed : it didn't pass
+func score(name string, results []testjson.Result, weights map[string]float64) ExerciseReport {
+	report := ExerciseReport{Name: name, Passed: []string{}, Failed: []Failure{}, Skipped: []string{}}
+	weight := func(test string) float64 {
+		if w, ok := weights[test]; ok {
+			return w
+		}
+		return 1
+	}
+
+	seen := map[string]bool{}
+	for _, result := range results {
+		if strings.Contains(result.Name, "/") {
+			continue
+		}
+		seen[result.Name] = true
+		report.Possible += weight(result.Name)
+		switch result.Status {
+		case "pass":
+			report.Earned += weight(result.Name)
+			report.Passed = append(report.Passed, result.Name)
+		case "skip":
+			report.Skipped = append(report.Skipped, result.Name) //! a skipped test earns nothing : the work isn't done
+		default:
+			report.Failed = append(report.Failed, Failure{Test: result.Name, Output: failureOutput(result.Output)})
+		}
+	}
+
+	var notRun []string
+	for test := range weights {
+		if !seen[test] {
+			notRun = append(notRun, test)
+		}
+	}
+	sort.Strings(notRun)
+	for _, test := range notRun {
+		report.Possible += weight(test)
+		report.Failed = append(report.Failed, Failure{Test: test, Output: "did not run"})
+	}
+
+	if report.Possible > 0 {
+		report.Score = round1(100 * report.Earned / report.Possible)
+	}
+	return report
+}
+
+//! round1 -> one decimal, so the report is stable and easy to read : 66.7 and not 66.66666666666667
+func round1(value float64) float64 {
+	return float64(int64(value*10+0.5)) / 10
+}
+
+//! goTestFiles -> the .go files of an exercise. Without a go.mod, go test gets the files by name, like in the os/exec lesson
+func goTestFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .go files in %s", dir)
+	}
+	for i, file := range files {
+		files[i] = filepath.Base(file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+//! gradeExercise -> runs 'go test -json' in dir and scores the result. A problem with the exercise (it doesn't build) is in the report, not an error :
+//! the learner gets 0 for that exercise and the other exercises are still graded
+func gradeExercise(ctx context.Context, dir string) ExerciseReport {
+	name := filepath.Base(dir)
+	fail := func(err error) ExerciseReport {
+		report := score(name, nil, nil)
+		report.Error = err.Error()
+		return report
+	}
+
+	weights, err := loadWeights(dir)
+	if err != nil {
+		return fail(err)
+	}
+	files, err := goTestFiles(dir)
+	if err != nil {
+		return fail(err)
+	}
+
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test", "-json", "-count=1"}, files...)...)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fail(err) //! go is missing, or the context ended. Exit code 1 just means a test failed
+	}
+
+	collector := testjson.NewCollector()
+	scanner := bufio.NewScanner(&output)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		collector.Add(scanner.Text())
+	}
+	results := collector.Results()
+	if len(results) == 0 && err != nil {
+		var errorLines []string
+		for _, line := range collector.Package {
+			if buildErrorLine.MatchString(line) {
+				errorLines = append(errorLines, line)
+			}
+		}
+		return fail(fmt.Errorf("build failed: %s", strings.Join(errorLines, "; ")))
+	}
+	return score(name, results, weights)
+}
+
+//! buildErrorLine -> "./broken.go:4:13: undefined: two". The other build lines are headers like "# command-line-arguments"
+var buildErrorLine = regexp.MustCompile(`\.go:\d+:\d+: `)
+
+//! Grade -> every exercise, then the total and the pass/fail decision
+func Grade(ctx context.Context, dirs []string, passThreshold float64) Report {
+	report := Report{Exercises: []ExerciseReport{}, PassThreshold: passThreshold}
+	var sum float64
+	for _, dir := range dirs {
+		exercise := gradeExercise(ctx, dir)
+		report.Exercises = append(report.Exercises, exercise)
+		sum += exercise.Score
+	}
+	if len(dirs) > 0 {
+		report.Total = round1(sum / float64(len(dirs)))
+	}
+	report.Passed = report.Total >= passThreshold
+	return report
+}
+
+//! WriteJSON -> the report as indented JSON, the format other tools (and the golden file) read
+func WriteJSON(w io.Writer, report Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+//! RenderTable -> the report for people
+func RenderTable(w io.Writer, report Report) {
+	fmt.Fprintf(w, "%-16s %7s %7s\n", "EXERCISE", "SCORE", "WEIGHT")
+	for _, exercise := range report.Exercises {
+		fmt.Fprintf(w, "%-16s %6.1f%% %4g/%g\n", exercise.Name, exercise.Score, exercise.Earned, exercise.Possible)
+		if exercise.Error != "" {
+			fmt.Fprintln(w, "    error :", exercise.Error)
+		}
+		for _, failure := range exercise.Failed {
+			fmt.Fprintln(w, "    FAIL", failure.Test)
+			for _, line := range strings.Split(failure.Output, "\n") {
+				if line != "" {
+					fmt.Fprintln(w, "        ", line)
+				}
+			}
+		}
+		for _, skipped := range exercise.Skipped {
+			fmt.Fprintln(w, "    SKIP", skipped)
+		}
+	}
+	result := "PASSED"
+	if !report.Passed {
+		result = "FAILED"
+	}
+	fmt.Fprintf(w, "total %.1f%%, pass threshold %.1f%% -> %s\n", report.Total, report.PassThreshold, result)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"osexec/testjson"
+)
+
+//! go test -update -> rewrites the golden file, after a change of the report which is on purpose
+var update = flag.Bool("update", false, "rewrite "+goldenPath)
+
+const goldenPath = "testdata/report.golden.json"
+
+func TestScore(t *testing.T) {
+	results := []testjson.Result{{Name: "TestA", Status: "pass"}, {Name: "TestB", Status: "fail"}}
+	tests := []struct {
+		name         string
+		results      []testjson.Result
+		weights      map[string]float64
+		wantScore    float64
+		wantEarned   float64
+		wantPossible float64
+		wantFailed   int
+		wantSkipped  []string
+	}{
+		{"weights : 1 of 4 earned", results, map[string]float64{"TestA": 1, "TestB": 3}, 25, 1, 4, 1, []string{}},
+		{"no weights : equal weights", []testjson.Result{{Name: "TestA", Status: "pass"}, {Name: "TestB", Status: "pass"}, {Name: "TestC", Status: "fail"}}, map[string]float64{}, 66.7, 2, 3, 1, []string{}},
+		{"a test missing in weights.json weighs 1", results, map[string]float64{"TestB": 2}, 33.3, 1, 3, 1, []string{}},
+		{"subtests aren't graded on their own", []testjson.Result{{Name: "TestA", Status: "pass"}, {Name: "TestA/case_1", Status: "fail"}}, nil, 100, 1, 1, 0, []string{}},
+		{"a skipped test earns nothing", []testjson.Result{{Name: "TestA", Status: "skip"}}, nil, 0, 0, 1, 0, []string{"TestA"}},
+		{"a weighted test which never ran fails", nil, map[string]float64{"TestA": 2}, 0, 0, 2, 1, []string{}},
+		{"no tests : 0, not a division by zero", nil, nil, 0, 0, 0, 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := score("x", tt.results, tt.weights)
+			if got.Score != tt.wantScore || got.Earned != tt.wantEarned || got.Possible != tt.wantPossible {
+				t.Errorf("score = %v (%v/%v), want %v (%v/%v)", got.Score, got.Earned, got.Possible, tt.wantScore, tt.wantEarned, tt.wantPossible)
+			}
+			if len(got.Failed) != tt.wantFailed {
+				t.Errorf("failed = %v, want %d failures", got.Failed, tt.wantFailed)
+			}
+			if !reflect.DeepEqual(got.Skipped, tt.wantSkipped) {
+				t.Errorf("skipped = %q, want %q", got.Skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+//! the fixture exercises : passing, failing, skipped, panicking, and one which doesn't build. Each one runs a real 'go test'
+func TestGradeFixtures(t *testing.T) {
+	report := Grade(context.Background(), fixtures, 60)
+	byName := map[string]ExerciseReport{}
+	for _, exercise := range report.Exercises {
+		byName[exercise.Name] = exercise
+	}
+
+	if add := byName["01-add"]; add.Score != 100 || add.Possible != 4 {
+		t.Errorf("01-add = %v (%v possible), want 100 (4) : weighted subtests all pass", add.Score, add.Possible)
+	}
+	if strs := byName["02-strings"]; strs.Score != 33.3 || len(strs.Skipped) != 1 {
+		t.Errorf("02-strings = %v, skipped %q, want 33.3 with one skip", strs.Score, strs.Skipped)
+	}
+	stack := byName["03-stack"]
+	if len(stack.Failed) != 2 || !strings.HasPrefix(stack.Failed[0].Output, "panic: runtime error") {
+		t.Errorf("03-stack failures = %+v, want the panic output first", stack.Failed)
+	} else if stack.Failed[1].Test != "TestPopOrder" || stack.Failed[1].Output != "did not run" {
+		t.Errorf("03-stack second failure = %+v, want TestPopOrder : did not run", stack.Failed[1])
+	}
+	if broken := byName["04-broken"]; broken.Score != 0 || !strings.Contains(broken.Error, "undefined: two") {
+		t.Errorf("04-broken = %v, error %q, want 0 with the compiler error", broken.Score, broken.Error)
+	}
+	if report.Total != 45.8 || report.Passed {
+		t.Errorf("total = %v passed %v, want 45.8 false : the average of the exercises", report.Total, report.Passed)
+	}
+
+	//! the JSON schema must stay the same : other tools read it. The golden file is the expected report, byte for byte
+	var got bytes.Buffer
+	if err := WriteJSON(&got, report); err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(goldenPath, got.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != string(golden) {
+		t.Errorf("report JSON doesn't match %s (run 'go test -update' if the change is on purpose)\n got:\n%s", goldenPath, got.String())
+	}
+}
+
+//! the exit code, with an injected exit function. 01-add and 02-strings average 66.7%
+func TestRunExitCode(t *testing.T) {
+	jsonPath := filepath.Join(t.TempDir(), "report.json")
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"above the threshold", []string{"-pass-threshold", "60", "-json", jsonPath, fixtures[0], fixtures[1]}, 0},
+		{"below the threshold", []string{"-pass-threshold", "70", fixtures[0], fixtures[1]}, 1},
+		{"no exercises", nil, 2},
+		{"bad flag", []string{"-pass-threshold", "lots"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := -1
+			run(tt.args, io.Discard, io.Discard, func(c int) { code = c })
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d", code, tt.want)
+			}
+		})
+	}
+	if _, err := os.Stat(jsonPath); err != nil {
+		t.Errorf("-json file not written : %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+//! Exercise grader -> runs the tests of a learner's exercises with 'go test -json', weights each test, and writes a score report
+//! as a table for people and as JSON for other tools. It exits with 1 when the total is below the pass threshold, so a script can use it
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+//! run -> the grade command. exit is a parameter : main passes os.Exit, the tests pass a function which only remembers the code
+func run(args []string, stdout, stderr io.Writer, exit func(int)) {
+	flags := flag.NewFlagSet("grade", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	threshold := flags.Float64("pass-threshold", 60, "the lowest total score (percent) which passes")
+	jsonPath := flags.String("json", "", "also write the report as JSON to this file")
+	timeout := flags.Duration("timeout", 2*time.Minute, "stop grading after this time")
+	if err := flags.Parse(args); err != nil {
+		exit(2)
+		return
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "usage : grade [-pass-threshold 60] [-json report.json] exercise-dir...")
+		exit(2)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	report := Grade(ctx, flags.Args(), *threshold)
+	RenderTable(stdout, report)
+
+	if *jsonPath != "" {
+		var data bytes.Buffer
+		WriteJSON(&data, report)
+		if err := os.WriteFile(*jsonPath, data.Bytes(), 0o644); err != nil {
+			fmt.Fprintln(stderr, "error :", err)
+			exit(1)
+			return
+		}
+	}
+	if !report.Passed {
+		exit(1)
+		return
+	}
+	exit(0)
+}
+
+var fixtures = []string{
+	"testdata/exercises/01-add",
+	"testdata/exercises/02-strings",
+	"testdata/exercises/03-stack",
+	"testdata/exercises/04-broken",
+}
+
+func main() {
+	//! go run . grade [flags] dir... -> the real command
+	if len(os.Args) > 1 && os.Args[1] == "grade" {
+		run(os.Args[2:], os.Stdout, os.Stderr, os.Exit)
+		return
+	}
+
+	report := Grade(context.Background(), fixtures, 60)
+	RenderTable(os.Stdout, report)
+}
+
+/*
+	Try :
+		1. go run . grade -pass-threshold 40 testdata/exercises/* ; echo "exit code $?"
+		2. Fix Reverse in 02-strings (reverse []rune instead of []byte). What is the new total?
+		3. Give TestPopEmpty a weight of 10 in 03-stack/weights.json. How much does one panicking test cost now?
+		4. Add the failed test names of all exercises to the end of the table, sorted by how many points they cost
+*/
@@ -0,0 +1,5 @@
+package exercise
+
+func Add(a, b int) int {
+	return a + b
+}
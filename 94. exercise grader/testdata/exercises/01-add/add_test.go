@@ -0,0 +1,19 @@
+package exercise
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if got := Add(2, 3); got != 5 {
+		t.Errorf("Add(2, 3) = %d, want 5", got)
+	}
+}
+
+func TestAddNegative(t *testing.T) {
+	for _, c := range []struct{ a, b, want int }{{-1, -2, -3}, {-5, 5, 0}} {
+		t.Run("", func(t *testing.T) {
+			if got := Add(c.a, c.b); got != c.want {
+				t.Errorf("Add(%d, %d) = %d, want %d", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
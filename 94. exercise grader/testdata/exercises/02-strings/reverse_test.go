@@ -0,0 +1,19 @@
+package exercise
+
+import "testing"
+
+func TestReverse(t *testing.T) {
+	if got := Reverse("John"); got != "nhoJ" {
+		t.Errorf("Reverse(%q) = %q, want %q", "John", got, "nhoJ")
+	}
+}
+
+func TestReverseUnicode(t *testing.T) {
+	if got := Reverse("café"); got != "éfac" {
+		t.Errorf("Reverse(%q) = %q, want %q", "café", got, "éfac")
+	}
+}
+
+func TestIsPalindrome(t *testing.T) {
+	t.Skip("IsPalindrome is not written yet")
+}
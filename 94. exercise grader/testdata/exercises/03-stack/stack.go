@@ -0,0 +1,16 @@
+package exercise
+
+type Stack struct {
+	items []int
+}
+
+func (s *Stack) Push(value int) {
+	s.items = append(s.items, value)
+}
+
+//! Pop has a bug on purpose : it doesn't check for an empty stack
+func (s *Stack) Pop() int {
+	last := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return last
+}
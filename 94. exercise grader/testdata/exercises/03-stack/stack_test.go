@@ -0,0 +1,26 @@
+package exercise
+
+import "testing"
+
+func TestPush(t *testing.T) {
+	var s Stack
+	s.Push(1)
+	s.Push(2)
+	if len(s.items) != 2 {
+		t.Errorf("len = %d after 2 pushes, want 2", len(s.items))
+	}
+}
+
+func TestPopEmpty(t *testing.T) {
+	var s Stack
+	s.Pop()
+}
+
+func TestPopOrder(t *testing.T) {
+	var s Stack
+	s.Push(1)
+	s.Push(2)
+	if got := s.Pop(); got != 2 {
+		t.Errorf("Pop() = %d, want 2", got)
+	}
+}
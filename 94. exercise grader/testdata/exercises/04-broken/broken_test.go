@@ -0,0 +1,9 @@
+package exercise
+
+import "testing"
+
+func TestDouble(t *testing.T) {
+	if Double(2) != 4 {
+		t.Error("Double(2) should be 4")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+type Result struct {
+	Index int
+	Value int
+}
+
+type Strategy int
+
+const (
+	Mutex        Strategy = iota //! one shared slice, every append under a lock
+	PerGoroutine                 //! each goroutine fills its own slice, merged at the end
+	Channel                      //! goroutines send, ONE owner goroutine appends
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case Mutex:
+		return "mutex"
+	case PerGoroutine:
+		return "per-goroutine"
+	case Channel:
+		return "channel"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+//! CollectResults -> calls produce(i) for i from 0 to n-1 on several goroutines, and returns every result
+//! the indexes are split into one block per goroutine (as many goroutines as CPUs)
+//!
+//! the order of the results :
+//! - PerGoroutine : always by index, 0 to n-1. The blocks are merged in order
+//! - Mutex, Channel : the blocks are mixed, but inside a block the results keep their order (a goroutine appends its results one after another)
+func CollectResults(n int, produce func(i int) Result, strategy Strategy) []Result {
+	return collect(n, runtime.GOMAXPROCS(0), produce, strategy)
+}
+
+//! block -> the indexes of goroutine w : [start, end)
+func block(n, workers, w int) (int, int) {
+	return w * n / workers, (w + 1) * n / workers
+}
+
+func collect(n, workers int, produce func(i int) Result, strategy Strategy) []Result {
+	if n <= 0 {
+		return []Result{}
+	}
+	workers = max(1, min(workers, n)) //! no goroutine without work
+
+	switch strategy {
+	case Mutex:
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		results := make([]Result, 0, n)
+		for w := range workers {
+			start, end := block(n, workers, w)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := start; i < end; i++ {
+					result := produce(i) //! the work happens OUTSIDE the lock, only the append is protected
+					mutex.Lock()
+					results = append(results, result)
+					mutex.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		return results
+
+	case PerGoroutine:
+		parts := make([][]Result, workers) //! parts[w] belongs to goroutine w only : nothing is shared, nothing to lock
+		var wg sync.WaitGroup
+		for w := range workers {
+			start, end := block(n, workers, w)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				part := make([]Result, 0, end-start)
+				for i := start; i < end; i++ {
+					part = append(part, produce(i))
+				}
+				parts[w] = part
+			}()
+		}
+		wg.Wait() //! after Wait, every part is finished and visible to us
+		results := make([]Result, 0, n)
+		for _, part := range parts {
+			results = append(results, part...)
+		}
+		return results
+
+	case Channel:
+		ch := make(chan Result, workers)
+		var wg sync.WaitGroup
+		for w := range workers {
+			start, end := block(n, workers, w)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := start; i < end; i++ {
+					ch <- produce(i)
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(ch) //! ends the range below once every producer is done
+		}()
+		results := make([]Result, 0, n)
+		for result := range ch { //! only this goroutine touches results : it OWNS the slice
+			results = append(results, result)
+		}
+		return results
+	}
+	panic(fmt.Sprintf("unknown strategy %v", strategy))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+//! sorted -> the results ordered by index, to compare them as a multiset : the same results, whatever the order
+func sorted(results []Result) []Result {
+	sorted := slices.Clone(results)
+	slices.SortFunc(sorted, func(a, b Result) int { return a.Index - b.Index })
+	return sorted
+}
+
+//! blocksInOrder -> inside each goroutine's block, the indexes only go up
+func blocksInOrder(results []Result, n, workers int) bool {
+	last := make([]int, workers)
+	for w := range last {
+		last[w] = -1
+	}
+	for _, result := range results {
+		w := 0
+		for start, end := block(n, workers, w); result.Index < start || result.Index >= end; start, end = block(n, workers, w) {
+			w++
+		}
+		if result.Index < last[w] {
+			return false
+		}
+		last[w] = result.Index
+	}
+	return true
+}
+
+func squares(n int) []Result {
+	want := make([]Result, 0, n)
+	for i := range n {
+		want = append(want, square(i))
+	}
+	return want
+}
+
+//! go test -race . -> every strategy runs with several goroutines here, so the race detector checks all three
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name       string
+		n, workers int
+	}{
+		{"many results", 1000, 8},
+		{"one goroutine", 100, 1},
+		{"uneven blocks", 1001, 7},
+		{"more goroutines than results", 3, 64},
+		{"one result", 1, 4},
+	}
+	for _, strategy := range strategies {
+		for _, tt := range tests {
+			t.Run(strategy.String()+"/"+tt.name, func(t *testing.T) {
+				results := collect(tt.n, tt.workers, square, strategy)
+				if !slices.Equal(sorted(results), squares(tt.n)) {
+					t.Errorf("results = %v, want every square below %d exactly once", results, tt.n)
+				}
+				if !blocksInOrder(results, tt.n, max(1, min(tt.workers, tt.n))) {
+					t.Errorf("a goroutine's results are out of order : %v", results)
+				}
+			})
+		}
+	}
+}
+
+func TestCollectEmpty(t *testing.T) {
+	for _, strategy := range strategies {
+		for _, n := range []int{0, -1} {
+			if got := CollectResults(n, square, strategy); got == nil || len(got) != 0 {
+				t.Errorf("%v with n = %d : %#v, want an empty, non-nil slice", strategy, n, got)
+			}
+		}
+	}
+}
+
+func TestPerGoroutineKeepsIndexOrder(t *testing.T) {
+	if got := collect(1000, 8, square, PerGoroutine); !slices.Equal(got, squares(1000)) {
+		t.Error("per-goroutine results are not in index order")
+	}
+}
+
+func TestUnknownStrategyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Strategy(9) didn't panic")
+		}
+	}()
+	collect(10, 2, square, Strategy(9))
+}
+
+//! go test -run xxx -bench . -benchmem -> every strategy for small and big results, and few and many goroutines
+func BenchmarkCollect(b *testing.B) {
+	for _, n := range []int{100, 100_000} {
+		for _, workers := range []int{1, 4, 16, 64} {
+			for _, strategy := range strategies {
+				b.Run(fmt.Sprintf("n=%d/workers=%d/%v", n, workers, strategy), func(b *testing.B) {
+					b.ReportAllocs()
+					for range b.N {
+						collect(n, workers, square, strategy)
+					}
+				})
+			}
+		}
+	}
+}
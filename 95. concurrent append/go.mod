@@ -0,0 +1,3 @@
+module concurrentappend
+
+go 1.22
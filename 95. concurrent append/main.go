@@ -0,0 +1,33 @@
+//! Concurrent append -> many goroutines produce results, and we want them all in ONE slice
+//! append to a shared slice from several goroutines is a data race (see racy.go). Here are three correct designs, with the same results
+package main
+
+import "fmt"
+
+//! raceDemo -> set by racy.go, which is only built with -tags racedemo
+var raceDemo func()
+
+func square(i int) Result {
+	return Result{Index: i, Value: i * i}
+}
+
+var strategies = []Strategy{Mutex, PerGoroutine, Channel}
+
+func main() {
+	if raceDemo != nil {
+		raceDemo()
+		fmt.Println("--------------------------------")
+	}
+
+	fmt.Println("channel       :", CollectResults(10, square, Channel))
+	fmt.Println("per-goroutine :", CollectResults(10, square, PerGoroutine))
+	fmt.Println("mutex         :", CollectResults(10, square, Mutex))
+}
+
+/*
+	Try :
+		1. go run -race -tags racedemo .   -> the race detector reports the racy append, and results are lost
+		2. go test -race .                 -> the three correct designs : no race reported
+		3. go test -run xxx -bench .       -> which design is the fastest with 64 goroutines? Why is the channel the slowest?
+		4. A fourth design : results := make([]Result, n), and each goroutine writes results[i] = produce(i). Why is that not a race?
+*/
@@ -0,0 +1,38 @@
+//go:build racedemo
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+//! racyCollect -> the WRONG way : every goroutine appends to the same slice without a lock. Only built with -tags racedemo
+//! append reads len and the array pointer, writes the new element, and stores a new len. Two goroutines can read the same len,
+//! write into the same slot, and one result is lost. When append grows the array, a goroutine can even write into the OLD array
+func racyCollect(n int, produce func(i int) Result) []Result {
+	var results []Result
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			//! results = append(results, produce(i)) is the same race, written in steps so we can see them (like 26. mutex/a. data race)
+			current := results                    //! 1. read the slice (pointer, len, cap)
+			runtime.Gosched()                     //! lets another goroutine run right here, so the problem shows up even on one CPU
+			results = append(current, produce(i)) //! 2. append to what we read and write the slice back. DATA RACE
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func init() {
+	raceDemo = func() {
+		for range 3 {
+			results := racyCollect(10000, square)
+			fmt.Println("racy append : expected 10000 results, got", len(results))
+		}
+	}
+}
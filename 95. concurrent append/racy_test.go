@@ -0,0 +1,18 @@
+//go:build racedemo
+
+package main
+
+import "testing"
+
+//! only built with -tags racedemo, like racy.go
+//!
+//!	go test -tags racedemo -run Racy .        -> passes : results are lost, the test shows it
+//!	go test -race -tags racedemo -run Racy .  -> FAILS with "race detected during execution of test" : the race detector finds it first
+func TestRacyCollectLosesResults(t *testing.T) {
+	const n = 10000
+	if got := len(racyCollect(n, square)); got >= n {
+		t.Errorf("racy append kept all %d results : run it again, a race doesn't show up every time", got)
+	} else {
+		t.Logf("racy append : expected %d results, got %d", n, got)
+	}
+}
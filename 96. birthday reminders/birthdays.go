@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+//! Person -> the BirthDate version from the time lesson (58. time) : a birth date never gets out of date, an age does
+type Person struct {
+	Name      string
+	BirthDate time.Time
+	Email     string
+}
+
+type Reminder struct {
+	Person    Person
+	Date      time.Time //! the birthday, at midnight in the location of 'from'
+	Age       int       //! the age the person turns on Date
+	DaysUntil int       //! 0 : today
+}
+
+//! startOfDay -> midnight of t's day, in t's location. Birthdays are about days, the hour doesn't matter
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+//! birthdayIn -> the birthday in a given year
+//! February 29 policy : in a year without February 29, the birthday is on February 28, the last day of the birth month
+//! (some countries count March 1 for legal ages, this program doesn't)
+func birthdayIn(birthDate time.Time, year int, loc *time.Location) time.Time {
+	month, day := birthDate.Month(), birthDate.Day()
+	if month == time.February && day == 29 && !isLeap(year) {
+		day = 28
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+//! nextBirthday -> the first birthday on or after 'today'. In late December, next year's January birthdays come after this year's
+func nextBirthday(birthDate, today time.Time) time.Time {
+	birthday := birthdayIn(birthDate, today.Year(), today.Location())
+	if birthday.Before(today) {
+		birthday = birthdayIn(birthDate, today.Year()+1, today.Location())
+	}
+	return birthday
+}
+
+//! UpcomingBirthdays -> the birthdays from the day of 'from' (included) until from + window (excluded), soonest first
+//! a window of 30 days is today and the 29 days after it. People without a BirthDate (the zero time) are left out
+//! the window counts whole days (a rest of hours is dropped) : across a daylight saving change, today.Add(30 * 24h) would not be a midnight
+func UpcomingBirthdays(people []Person, from time.Time, window time.Duration) []Reminder {
+	today := startOfDay(from)
+	end := today.AddDate(0, 0, int(window/(24*time.Hour)))
+
+	reminders := []Reminder{}
+	for _, person := range people {
+		if person.BirthDate.IsZero() {
+			continue
+		}
+		birthday := nextBirthday(person.BirthDate, today)
+		if !birthday.Before(end) {
+			continue
+		}
+		reminders = append(reminders, Reminder{
+			Person:    person,
+			Date:      birthday,
+			Age:       birthday.Year() - person.BirthDate.Year(),
+			DaysUntil: int((birthday.Sub(today) + 12*time.Hour) / (24 * time.Hour)), //! rounded to whole days : a daylight saving day has 23 or 25 hours
+		})
+	}
+	sort.SliceStable(reminders, func(i, j int) bool {
+		if !reminders[i].Date.Equal(reminders[j].Date) {
+			return reminders[i].Date.Before(reminders[j].Date)
+		}
+		return reminders[i].Person.Name < reminders[j].Person.Name //! the same day : by name, so the order is always the same
+	})
+	return reminders
+}
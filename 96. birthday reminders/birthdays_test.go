@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const days = 24 * time.Hour
+
+func names(reminders []Reminder) []string {
+	var result []string
+	for _, reminder := range reminders {
+		result = append(result, reminder.Person.Name)
+	}
+	return result
+}
+
+//! want -> one expected reminder : who, on which date, how old, in how many days
+type want struct {
+	name      string
+	date      time.Time
+	age       int
+	daysUntil int
+}
+
+func TestUpcomingBirthdays(t *testing.T) {
+	tests := []struct {
+		name   string
+		people []Person
+		from   time.Time
+		window time.Duration
+		want   []want
+	}{
+		//! on December 22, January birthdays are NEXT year's, and the days are counted across the year
+		{"year wrap-around", roster, date(2025, time.December, 22), 30 * days, []want{
+			{"Jane", date(2025, time.December, 25), 27, 3},
+			{"John", date(2026, time.January, 3), 31, 12},
+			{"Jill", date(2026, time.January, 19), 25, 28},
+		}},
+		//! February 29 : on February 28 in other years
+		{"leap day in 2025 -> February 28", roster, date(2025, time.February, 20), 30 * days, []want{{"Jim", date(2025, time.February, 28), 25, 8}}},
+		{"leap day in 2028 -> February 29", roster, date(2028, time.February, 20), 30 * days, []want{{"Jim", date(2028, time.February, 29), 28, 9}}},
+		{"2100 is not a leap year -> February 28", roster, date(2100, time.February, 1), 30 * days, []want{{"Jim", date(2100, time.February, 28), 100, 27}}},
+		//! the window : from the day of 'from' (included) to from + window (excluded)
+		{"a birthday today is included, at any hour", roster, date(2025, time.December, 20).Add(15 * time.Hour), 5 * days, []want{{"Jack", date(2025, time.December, 20), 35, 0}}},
+		{"the last day of the window is included", roster, date(2025, time.December, 21), 5 * days, []want{{"Jane", date(2025, time.December, 25), 27, 4}}},
+		{"the end of the window is excluded", roster, date(2025, time.December, 20), 5 * days, []want{{"Jack", date(2025, time.December, 20), 35, 0}}},
+		{"the same day -> by name", []Person{roster[2], {Name: "Ann", BirthDate: date(1996, time.February, 29)}}, date(2025, time.February, 28), days, []want{
+			{"Ann", date(2025, time.February, 28), 29, 0},
+			{"Jim", date(2025, time.February, 28), 25, 0},
+		}},
+		//! nobody to remind
+		{"empty roster", nil, date(2025, time.January, 1), 30 * days, nil},
+		{"a window of 0", roster, date(2025, time.December, 20), 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []want
+			for _, reminder := range UpcomingBirthdays(tt.people, tt.from, tt.window) {
+				got = append(got, want{reminder.Person.Name, reminder.Date, reminder.Age, reminder.DaysUntil})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpcomingBirthdays() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+//! over a whole year everybody with a BirthDate is reminded once, Joe (no BirthDate) never
+func TestWholeYear(t *testing.T) {
+	got := names(UpcomingBirthdays(roster, date(2025, time.January, 1), 365*days))
+	if want := []string{"John", "Jill", "Jim", "Jack", "Jane"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+}
+
+//! a day with a daylight saving change has 23 or 25 hours : the days are still counted right
+func TestDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database :", err)
+	}
+	people := []Person{{Name: "John", BirthDate: date(1995, time.April, 2)}}
+	reminders := UpcomingBirthdays(people, time.Date(2025, time.March, 29, 12, 0, 0, 0, berlin), 30*days) //! March 30 2025 has 23 hours in Berlin
+	if len(reminders) != 1 || reminders[0].DaysUntil != 4 {
+		t.Errorf("reminders = %+v, want John in 4 days", reminders)
+	}
+}
+
+//! the window end is a midnight even when a day of the window has 23 hours : March 8 2026 in New York
+func TestWindowEndAcrossDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database :", err)
+	}
+	people := []Person{
+		{Name: "Jane", BirthDate: date(1998, time.March, 30)},
+		{Name: "John", BirthDate: date(1995, time.March, 31)},
+	}
+	got := names(UpcomingBirthdays(people, time.Date(2026, time.March, 1, 0, 0, 0, 0, newYork), 30*days))
+	if want := []string{"Jane"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v : March 31 is the 31st day, outside a 30 day window", got, want)
+	}
+}
+
+func TestIsLeap(t *testing.T) {
+	tests := []struct {
+		year int
+		want bool
+	}{
+		{2024, true}, {2025, false}, {2100, false}, {2000, true},
+	}
+	for _, tt := range tests {
+		if got := isLeap(tt.year); got != tt.want {
+			t.Errorf("isLeap(%d) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//! WriteCalendar -> the reminders as a small iCalendar (.ics) file, one all-day event per birthday. Calendar apps can import it
+//! iCalendar wants "\r\n" at the end of every line, not just "\n"
+func WriteCalendar(w io.Writer, reminders []Reminder) error {
+	var builder strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&builder, format+"\r\n", args...)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//learn-GoLang//birthday reminders//EN")
+	for _, reminder := range reminders {
+		uid := reminder.Person.Email
+		if uid == "" {
+			uid = strings.ReplaceAll(strings.ToLower(reminder.Person.Name), " ", "-")
+		}
+		line("BEGIN:VEVENT")
+		line("UID:%s-%d", uid, reminder.Date.Year()) //! unique per person AND year : importing next year's file adds new events
+		line("DTSTART;VALUE=DATE:%s", reminder.Date.Format("20060102"))
+		line("SUMMARY:%s turns %d", reminder.Person.Name, reminder.Age)
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//! go test *.go -update -> rewrites the golden file, after a change of the calendar which is on purpose
+var update = flag.Bool("update", false, "rewrite "+goldenPath)
+
+const goldenPath = "testdata/birthdays.golden.ics"
+
+//! the calendar file, compared with golden output (a name without email gets a UID from the name)
+func TestWriteCalendarGolden(t *testing.T) {
+	people := []Person{roster[1], {Name: "Mary Ann", BirthDate: date(1995, time.January, 3)}}
+	var got strings.Builder
+	if err := WriteCalendar(&got, UpcomingBirthdays(people, date(2025, time.December, 22), 30*days)); err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(goldenPath, []byte(got.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != string(golden) {
+		t.Errorf("calendar doesn't match %s (run 'go test *.go -update' if the change is on purpose)\n got:\n%s", goldenPath, got.String())
+	}
+}
+
+//! iCalendar wants "\r\n" at the end of every line
+func TestCalendarLineEndings(t *testing.T) {
+	var got strings.Builder
+	if err := WriteCalendar(&got, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//learn-GoLang//birthday reminders//EN\r\nEND:VCALENDAR\r\n"
+	if got.String() != want {
+		t.Errorf("empty calendar = %q, want %q", got.String(), want)
+	}
+}
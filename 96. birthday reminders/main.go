@@ -0,0 +1,83 @@
+//! Birthday reminders -> a small project : who has a birthday in the next 30 days? Uses the time lesson's Person with a BirthDate,
+//! sorting, a table with text/tabwriter, and an .ics calendar file which calendar apps can import
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var roster = []Person{
+	{Name: "John", BirthDate: date(1995, time.January, 3), Email: "john@example.com"},
+	{Name: "Jane", BirthDate: date(1998, time.December, 25), Email: "jane@example.com"},
+	{Name: "Jim", BirthDate: date(2000, time.February, 29), Email: "jim@example.com"},
+	{Name: "Jack", BirthDate: date(1990, time.December, 20), Email: "jack@example.com"},
+	{Name: "Joe", Email: "joe@example.com"}, //! no BirthDate : never reminded
+	{Name: "Jill", BirthDate: date(2001, time.January, 19), Email: "jill@example.com"},
+}
+
+func printTable(reminders []Reminder) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DATE\tIN\tNAME\tTURNS")
+	for _, reminder := range reminders {
+		in := fmt.Sprintf("%d days", reminder.DaysUntil)
+		switch reminder.DaysUntil {
+		case 0:
+			in = "today"
+		case 1:
+			in = "tomorrow"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\n", reminder.Date.Format("Mon Jan 02"), in, reminder.Person.Name, reminder.Age)
+	}
+	writer.Flush()
+}
+
+func main() {
+	fromText := flag.String("from", "", "the first day, YYYY-MM-DD (default : today)")
+	days := flag.Int("days", 30, "how many days to look ahead")
+	calendarPath := flag.String("calendar", "", "also write the reminders to this .ics file")
+	flag.Parse()
+
+	from := time.Now()
+	if *fromText != "" {
+		parsed, err := time.Parse("2006-01-02", *fromText)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error : -from :", err)
+			os.Exit(1)
+		}
+		from = parsed
+	}
+
+	reminders := UpcomingBirthdays(roster, from, time.Duration(*days)*24*time.Hour)
+	fmt.Printf("birthdays from %s, the next %d days :\n", from.Format("Jan 02 2006"), *days)
+	printTable(reminders)
+
+	if *calendarPath != "" {
+		file, err := os.Create(*calendarPath)
+		if err == nil {
+			err = WriteCalendar(file, reminders)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error :", err)
+			os.Exit(1)
+		}
+		fmt.Println("calendar written to", *calendarPath)
+	}
+}
+
+/*
+	Try :
+		1. go run main.go birthdays.go calendar.go -from 2025-12-20 -days 45 -calendar birthdays.ics, and import the file into a calendar app
+		2. Change the February 29 policy to March 1. Which tests fail?
+		3. Add a -week flag : only the birthdays of the next 7 days, with the weekday in the table
+*/
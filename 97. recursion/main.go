@@ -0,0 +1,119 @@
+//! Recursion -> a function which calls itself. Every recursive function needs two parts :
+//! 1. a BASE case, which returns without calling itself (otherwise it never stops)
+//! 2. a RECURSIVE case, which calls itself with a SMALLER problem, one step closer to the base case
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//! factorial -> 5! = 5 * 4 * 3 * 2 * 1 = 120, and 5! = 5 * 4!
+func factorial(n int) int {
+	if n <= 1 { //! base case : 0! and 1! are 1
+		return 1
+	}
+	return n * factorial(n-1) //! recursive case : a smaller problem
+}
+
+//! fib -> the fibonacci numbers 0, 1, 1, 2, 3, 5, 8 ... : each one is the sum of the two before it
+//! the naive version is SLOW : fib(n-1) and fib(n-2) both calculate fib(n-3) again, and so on. The calls double at every level
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+//! memoFib -> the same function, but with a cache. It's a closure (10. closure) : the returned function remembers 'cache' between calls
+//! every fib(k) is calculated only ONCE, then read from the map
+func memoFib() func(int) int {
+	cache := map[int]int{}
+
+	var f func(int) int //! declared first, so the function can call itself by name
+	f = func(n int) int {
+		if n < 2 {
+			return n
+		}
+		if value, ok := cache[n]; ok {
+			return value
+		}
+		value := f(n-1) + f(n-2)
+		cache[n] = value
+		return value
+	}
+	return f
+}
+
+//! sumSlice -> the sum of a slice is its first element plus the sum of the rest (15. slice : numbers[1:] is the rest, without copying)
+func sumSlice(numbers []int) int {
+	if len(numbers) == 0 { //! base case : an empty slice sums to 0
+		return 0
+	}
+	return numbers[0] + sumSlice(numbers[1:])
+}
+
+var errNegative = errors.New("negative input")
+
+//! safeFactorial -> factorial(-3) would return 1, which is wrong. The check is in a wrapper, so the recursive function stays simple
+func safeFactorial(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("factorial(%d): %w", n, errNegative)
+	}
+	return factorial(n), nil
+}
+
+func safeFib(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("fib(%d): %w", n, errNegative)
+	}
+	return memoFib()(n), nil
+}
+
+func main() {
+	for _, n := range []int{0, 1, 5, 10} {
+		fmt.Printf("factorial(%d) = %d\n", n, factorial(n))
+	}
+	fmt.Println("--------------------------------")
+
+	var first []int
+	for n := 0; n <= 10; n++ {
+		first = append(first, fib(n))
+	}
+	fmt.Println("fib(0) to fib(10) :", first)
+
+	start := time.Now()
+	naive := fib(35)
+	naiveTime := time.Since(start)
+
+	start = time.Now()
+	memo := memoFib()(35)
+	memoTime := time.Since(start)
+
+	fmt.Println("naive    fib(35) =", naive, "in", naiveTime) //! about 30 million calls
+	fmt.Println("memoized fib(35) =", memo, "in", memoTime)   //! 35 calculations, the rest are map reads
+	fmt.Println("--------------------------------")
+
+	fmt.Println("sumSlice([1 2 3 4 5]) =", sumSlice([]int{1, 2, 3, 4, 5}))
+	fmt.Println("sumSlice([])          =", sumSlice([]int{}))
+	fmt.Println("--------------------------------")
+
+	if _, err := safeFactorial(-3); err != nil {
+		fmt.Println("error :", err, "| is errNegative :", errors.Is(err, errNegative))
+	}
+	if value, err := safeFib(50); err == nil {
+		fmt.Println("safeFib(50) =", value) //! the naive fib(50) would take minutes
+	}
+	if _, err := safeFib(-1); err != nil {
+		fmt.Println("error :", err)
+	}
+}
+
+/*
+	Try :
+		1. Remove the base case of factorial and run it. What happens? (the stack grows until Go stops the program : goroutine stack exceeds limit)
+		2. Time fib(40) naive. How much slower than fib(35)?
+		3. factorial(21) is wrong. Why? (int overflow : 21! doesn't fit in 64 bits)
+		4. Write a recursive reverse(s []int) []int
+*/
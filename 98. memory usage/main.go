@@ -0,0 +1,87 @@
+//! Memory usage -> how much memory does our code really use? runtime.MemStats tells us, before and after a piece of code
+//! the earlier lessons SAID things like "preallocate the slice" or "pointers cost more". Here we can SEE it, without writing benchmarks
+package main
+
+import "fmt"
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+const n = 1_000_000
+
+//! sink -> keeps the result alive after fn returns. Without it, the compiler may optimize the work away, and the heap delta would be only garbage
+var sink any
+
+func main() {
+	//! 1. values vs pointers : one big array of Persons, or one array of pointers AND a million small Persons
+	values := Measure("[]Person (values)", func() {
+		people := make([]Person, 0, n)
+		for i := range n {
+			people = append(people, Person{Name: "John", Age: i, Email: "john@example.com"})
+		}
+		sink = people
+	})
+	pointers := Measure("[]*Person (pointers)", func() {
+		people := make([]*Person, 0, n)
+		for i := range n {
+			people = append(people, &Person{Name: "John", Age: i, Email: "john@example.com"})
+		}
+		sink = people
+	})
+	fmt.Println(values)
+	fmt.Println(pointers)
+	fmt.Println("--------------------------------")
+
+	//! 2. preallocated vs grown by append : append doubles (then grows by 1.25x) the array and copies it, every time it's full
+	sink = nil
+	preallocated := Measure("preallocated make(0, n)", func() {
+		people := make([]Person, 0, n)
+		for i := range n {
+			people = append(people, Person{Age: i})
+		}
+		sink = people
+	})
+	sink = nil
+	grown := Measure("grown by append", func() {
+		var people []Person
+		for i := range n {
+			people = append(people, Person{Age: i})
+		}
+		sink = people
+	})
+	fmt.Println(preallocated)
+	fmt.Println(grown)
+	fmt.Println("--------------------------------")
+
+	//! 3. finding a person by id : a slice index vs a map
+	sink = nil
+	slice := Measure("[]Person, index = id", func() {
+		people := make([]Person, n)
+		for i := range people {
+			people[i] = Person{Age: i}
+		}
+		sink = people
+	})
+	sink = nil
+	byID := Measure("map[int]Person", func() {
+		people := make(map[int]Person, n)
+		for i := range n {
+			people[i] = Person{Age: i}
+		}
+		sink = people
+	})
+	fmt.Println(slice)
+	fmt.Println(byID)
+	sink = nil
+}
+
+/*
+	Try :
+		1. Change n to 1000. Are the differences still there? Do the GCs still happen?
+		2. In "grown by append", print cap(people) every time it changes. How many times does the array move?
+		3. Remove 'sink = people'. What happens to the heap delta?
+		4. Give Person a []string Tags field. How much bigger does []Person get?
+*/
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+//! Snapshot -> the few runtime.MemStats numbers we look at
+type Snapshot struct {
+	HeapAlloc  uint64 //! bytes on the heap right now : live objects, plus garbage the GC hasn't collected yet
+	TotalAlloc uint64 //! bytes EVER allocated. Only grows, freeing doesn't make it smaller
+	Mallocs    uint64 //! objects ever allocated
+	NumGC      uint32 //! finished garbage collections
+}
+
+//! StatsReader -> where the snapshots come from. The real one asks the runtime, the tests use a fake with fixed numbers
+type StatsReader interface {
+	ReadStats() Snapshot
+}
+
+type runtimeReader struct{}
+
+func (runtimeReader) ReadStats() Snapshot {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats) //! stops the world for a moment : fine for a lesson, not for a hot loop
+	return Snapshot{HeapAlloc: stats.HeapAlloc, TotalAlloc: stats.TotalAlloc, Mallocs: stats.Mallocs, NumGC: stats.NumGC}
+}
+
+//! Delta -> what changed while fn ran
+type Delta struct {
+	Label      string
+	HeapAlloc  int64 //! can be negative : the GC may free more during fn than fn allocates
+	TotalAlloc uint64
+	Mallocs    uint64
+	NumGC      uint32
+}
+
+//! Probe -> a reader and a way to force a GC, both replaceable
+type Probe struct {
+	Reader StatsReader
+	GC     func()
+}
+
+var defaultProbe = Probe{Reader: runtimeReader{}, GC: runtime.GC}
+
+//! Measure -> forces a GC first, so old garbage doesn't count, then snapshots before and after fn
+func Measure(label string, fn func()) Delta {
+	return defaultProbe.Measure(label, fn)
+}
+
+func (p Probe) Measure(label string, fn func()) Delta {
+	p.GC()
+	before := p.Reader.ReadStats()
+	fn()
+	after := p.Reader.ReadStats()
+	return Delta{
+		Label:      label,
+		HeapAlloc:  int64(after.HeapAlloc) - int64(before.HeapAlloc),
+		TotalAlloc: after.TotalAlloc - before.TotalAlloc,
+		Mallocs:    after.Mallocs - before.Mallocs,
+		NumGC:      after.NumGC - before.NumGC,
+	}
+}
+
+//! formatBytes -> 1536 -> "1.5 KiB". KiB is 1024 bytes, the unit the runtime really uses
+func formatBytes(n int64) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	value, units := float64(n), []string{"B", "KiB", "MiB", "GiB"}
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%s%d B", sign, n)
+	}
+	return fmt.Sprintf("%s%.1f %s", sign, value, units[unit])
+}
+
+func (d Delta) String() string {
+	return fmt.Sprintf("%-30s heap %10s   allocated %10s   mallocs %9d   GCs %d",
+		d.Label, formatBytes(d.HeapAlloc), formatBytes(int64(d.TotalAlloc)), d.Mallocs, d.NumGC)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+//! fakeReader -> returns the snapshots one after another, so Measure can be tested with exact numbers
+type fakeReader struct {
+	snapshots []Snapshot
+}
+
+func (f *fakeReader) ReadStats() Snapshot {
+	snapshot := f.snapshots[0]
+	f.snapshots = f.snapshots[1:]
+	return snapshot
+}
+
+func TestProbeMeasure(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after Snapshot
+		want          Delta
+	}{
+		{"heap delta can be negative", Snapshot{HeapAlloc: 10_000, TotalAlloc: 50_000, Mallocs: 10, NumGC: 3}, Snapshot{HeapAlloc: 8_464, TotalAlloc: 4_150_000, Mallocs: 52, NumGC: 5},
+			Delta{Label: "grow []Person", HeapAlloc: -1536, TotalAlloc: 4_100_000, Mallocs: 42, NumGC: 2}},
+		{"nothing changed", Snapshot{HeapAlloc: 100, TotalAlloc: 200, Mallocs: 3, NumGC: 1}, Snapshot{HeapAlloc: 100, TotalAlloc: 200, Mallocs: 3, NumGC: 1},
+			Delta{Label: "grow []Person"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcCalls, runs := 0, 0
+			probe := Probe{
+				Reader: &fakeReader{snapshots: []Snapshot{tt.before, tt.after}},
+				GC:     func() { gcCalls++ },
+			}
+			got := probe.Measure("grow []Person", func() { runs++ })
+			if got != tt.want {
+				t.Errorf("Measure() = %+v, want %+v", got, tt.want)
+			}
+			if gcCalls != 1 || runs != 1 {
+				t.Errorf("%d forced GCs and %d runs of fn, want 1 and 1", gcCalls, runs)
+			}
+		})
+	}
+}
+
+//! the GC is forced BEFORE the first snapshot, so old garbage doesn't count
+func TestGCBeforeFirstSnapshot(t *testing.T) {
+	var order []string
+	reader := readerFunc(func() Snapshot { order = append(order, "read"); return Snapshot{} })
+	probe := Probe{Reader: reader, GC: func() { order = append(order, "gc") }}
+	probe.Measure("order", func() { order = append(order, "fn") })
+	if want := []string{"gc", "read", "fn", "read"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+type readerFunc func() Snapshot
+
+func (f readerFunc) ReadStats() Snapshot { return f() }
+
+func TestDeltaString(t *testing.T) {
+	tests := []struct {
+		delta Delta
+		want  string
+	}{
+		{Delta{Label: "grow []Person", HeapAlloc: -1536, TotalAlloc: 4_100_000, Mallocs: 42, NumGC: 2},
+			"grow []Person                  heap   -1.5 KiB   allocated    3.9 MiB   mallocs        42   GCs 2"},
+		{Delta{Label: "nothing"},
+			"nothing                        heap        0 B   allocated        0 B   mallocs         0   GCs 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.delta.Label, func(t *testing.T) {
+			if got := tt.delta.String(); got != tt.want {
+				t.Errorf("String() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 << 20, "5.0 MiB"},
+		{-3 << 30, "-3.0 GiB"},
+		{2048 << 30, "2048.0 GiB"}, //! GiB is the biggest unit
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatBytes(tt.n); got != tt.want {
+				t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+//! the real runtime : loose comparisons, the exact numbers change between runs and Go versions
+func TestRealRuntime(t *testing.T) {
+	const n = 100_000
+	defer func() { sink = nil }()
+
+	preallocated := Measure("preallocated", func() {
+		people := make([]Person, 0, n)
+		for i := range n {
+			people = append(people, Person{Age: i})
+		}
+		sink = people
+	})
+	sink = nil
+	grown := Measure("grown", func() {
+		var people []Person
+		for i := range n {
+			people = append(people, Person{Age: i})
+		}
+		sink = people
+	})
+	sink = nil
+	pointers := Measure("pointers", func() {
+		people := make([]*Person, 0, n)
+		for i := range n {
+			people = append(people, &Person{Age: i})
+		}
+		sink = people
+	})
+
+	if preallocated.Mallocs >= grown.Mallocs {
+		t.Errorf("preallocated : %d mallocs, grown : %d, want fewer", preallocated.Mallocs, grown.Mallocs)
+	}
+	if preallocated.TotalAlloc >= grown.TotalAlloc {
+		t.Errorf("preallocated : %d bytes, grown : %d, want fewer", preallocated.TotalAlloc, grown.TotalAlloc)
+	}
+	if pointers.Mallocs < n {
+		t.Errorf("pointers : %d mallocs, want at least one per person (%d)", pointers.Mallocs, n)
+	}
+}
@@ -0,0 +1,3 @@
+module blankidentifier
+
+go 1.22
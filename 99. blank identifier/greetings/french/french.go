@@ -0,0 +1,15 @@
+//! Package french -> nothing to call from outside, only an init function. Importing it is enough to register the French greeter
+package french
+
+import (
+	"fmt"
+
+	"blankidentifier/registry"
+)
+
+func init() {
+	fmt.Println("french : init ran, registering the French greeter")
+	registry.Register("french", func(name string) string {
+		return "Bonjour, " + name
+	})
+}
@@ -0,0 +1,97 @@
+//! Blank identifier -> '_' is a name which throws the value away. Go refuses unused variables and unused imports, '_' is how we say "I don't need this, on purpose"
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"blankidentifier/registry"
+
+	_ "blankidentifier/greetings/french" //! 3. imported ONLY for its init function : we never write french.Something
+)
+
+type Person struct {
+	Name  string
+	Age   int
+	Email string
+}
+
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+func (c *Circle) Area() float64 {
+	return 3.14159 * c.Radius * c.Radius
+}
+
+func (c *Circle) Perimeter() float64 {
+	return 2 * 3.14159 * c.Radius
+}
+
+//! 4. compile-time check : "*Circle must implement Shape". If a method is missing or renamed, THIS line fails to compile, not some far away call
+//! (*Circle)(nil) is a nil pointer of type *Circle : no Circle is created, and '_' throws the value away
+var _ Shape = (*Circle)(nil)
+
+//! var _ Shape = Circle{} //! compile error : Circle does not implement Shape (method Area has pointer receiver)
+
+func divmod(a, b int) (int, int) {
+	return a / b, a % b
+}
+
+func main() {
+	//! 1. ignoring one of several return values
+	quotient, _ := divmod(17, 5) //! we only want the quotient, the remainder is thrown away
+	fmt.Println("17 / 5 =", quotient)
+	_, remainder := divmod(17, 5)
+	fmt.Println("17 % 5 =", remainder)
+
+	//! quotient, remainder := divmod(17, 5) without using remainder -> compile error : declared and not used: remainder
+
+	number, _ := strconv.Atoi("42") //! possible, but careful : ignoring an error hides problems. "abc" would give 0 and no hint why
+	fmt.Println("Atoi(\"42\") =", number)
+	bad, _ := strconv.Atoi("abc")
+	fmt.Println("Atoi(\"abc\") =", bad, "-> the error was thrown away, so we only see a 0")
+	fmt.Println("--------------------------------")
+
+	//! 2. ignoring the index in a range loop
+	people := []Person{
+		{Name: "John", Age: 20, Email: "john@example.com"},
+		{Name: "Jane", Age: 22, Email: "jane@example.com"},
+		{Name: "Jim", Age: 25, Email: "jim@example.com"},
+	}
+	totalAge := 0
+	for _, p := range people { //! for i, p := range people without using i -> compile error : declared and not used: i
+		totalAge += p.Age
+	}
+	fmt.Println("total age :", totalAge)
+
+	for i := range people { //! only the index : no '_' needed, the value can simply be left out
+		fmt.Println(i, people[i].Name)
+	}
+	fmt.Println("--------------------------------")
+
+	//! 3. import for side effect : the "french : init ran" line was printed BEFORE main started (see 16. types of functions/b. init function)
+	fmt.Println("languages :", registry.Languages())
+	greeting, ok := registry.Greet("french", "John")
+	fmt.Println(greeting, ok)
+	_, ok = registry.Greet("german", "John") //! nobody imported a german package, so nothing registered it
+	fmt.Println("german registered :", ok)
+	fmt.Println("--------------------------------")
+
+	//! 4. the interface check passed at compile time, so this works
+	var shape Shape = &Circle{Radius: 2}
+	fmt.Printf("circle area : %.2f, perimeter : %.2f\n", shape.Area(), shape.Perimeter())
+}
+
+/*
+	Try :
+		1. Remove the '_' in front of the french import. What does the compiler say?
+		2. Comment out the import completely. Which languages are registered now?
+		3. Rename Perimeter to Circumference on *Circle. Which line fails to compile?
+		4. Add a greetings/bangla package which registers itself the same way, and import it with '_'
+*/
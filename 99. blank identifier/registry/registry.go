@@ -0,0 +1,27 @@
+//! Package registry -> a list of greeters, filled by other packages from their init functions
+package registry
+
+import "sort"
+
+var greeters = map[string]func(name string) string{}
+
+func Register(language string, greet func(name string) string) {
+	greeters[language] = greet
+}
+
+func Greet(language, name string) (string, bool) {
+	greet, ok := greeters[language]
+	if !ok {
+		return "", false
+	}
+	return greet(name), true
+}
+
+func Languages() []string {
+	languages := make([]string, 0, len(greeters))
+	for language := range greeters {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
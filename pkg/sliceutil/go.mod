@@ -0,0 +1,3 @@
+module github.com/Faizul-Bitto/learn-GoLang/pkg/sliceutil
+
+go 1.22
@@ -0,0 +1,64 @@
+//! Package sliceutil -> small generic slice helpers, shared by the lessons
+//! Insert, Delete and Unique always return a NEW slice, so the caller's slice and its array are never changed (see the copy and aliasing lesson)
+//! the standard library has similar functions in the slices package. These ones are written by hand to show how they work
+package sliceutil
+
+import "fmt"
+
+//! Insert -> a new slice with v at index i. i can be len(s) to add at the end
+//! an index out of range panics, like s[i] does
+func Insert[T any](s []T, i int, v T) []T {
+	if i < 0 || i > len(s) {
+		panic(fmt.Sprintf("sliceutil.Insert: index %d out of range [0:%d]", i, len(s)))
+	}
+	result := make([]T, 0, len(s)+1)
+	result = append(result, s[:i]...)
+	result = append(result, v)
+	return append(result, s[i:]...)
+}
+
+//! Delete -> a new slice without the element at index i
+//! an index out of range is an error (and s comes back unchanged), not a panic : the index often comes from the user
+func Delete[T any](s []T, i int) ([]T, error) {
+	if i < 0 || i >= len(s) {
+		return s, fmt.Errorf("sliceutil.Delete: index %d out of range [0:%d]", i, len(s))
+	}
+	result := make([]T, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...), nil
+}
+
+//! Reverse -> reverses s IN PLACE, by swapping from both ends towards the middle. Nothing is returned, the caller's slice changes
+func Reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+//! Unique -> a new slice with every value once, in the order they first appear. T must be comparable, because the seen values are map keys
+func Unique[T comparable](s []T) []T {
+	seen := make(map[T]bool, len(s))
+	result := make([]T, 0, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+//! Chunk -> s cut into pieces of 'size' elements. The last piece can be shorter
+//! the pieces point into s (no copy), but their capacity is cut with s[low:high:max], so an append to a piece can't overwrite the next one
+//! a size below 1 panics : there is no sensible answer
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic(fmt.Sprintf("sliceutil.Chunk: size %d must be at least 1", size))
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for low := 0; low < len(s); low += size {
+		high := min(low+size, len(s))
+		chunks = append(chunks, s[low:high:high])
+	}
+	return chunks
+}
@@ -0,0 +1,173 @@
+package sliceutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		index int
+		value int
+		want  []int
+	}{
+		{"empty slice", []int{}, 0, 9, []int{9}},
+		{"nil slice", nil, 0, 9, []int{9}},
+		{"single element, front", []int{1}, 0, 9, []int{9, 1}},
+		{"single element, end", []int{1}, 1, 9, []int{1, 9}},
+		{"middle", []int{1, 2, 3}, 1, 9, []int{1, 9, 2, 3}},
+		{"last index", []int{1, 2, 3}, 3, 9, []int{1, 2, 3, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := append([]int(nil), tt.input...)
+			got := Insert(tt.input, tt.index, tt.value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Insert(%v, %d, %d) = %v, want %v", tt.input, tt.index, tt.value, got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.input, before) && len(before) > 0 {
+				t.Errorf("Insert changed its input : %v, was %v", tt.input, before)
+			}
+		})
+	}
+}
+
+func TestInsertOutOfRangePanics(t *testing.T) {
+	for _, index := range []int{-1, 4} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Insert at %d did not panic", index)
+				}
+			}()
+			Insert([]int{1, 2, 3}, index, 9)
+		}()
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		index   int
+		want    []string
+		wantErr bool
+	}{
+		{"empty slice", []string{}, 0, []string{}, true},
+		{"nil slice", nil, 0, nil, true},
+		{"single element", []string{"a"}, 0, []string{}, false},
+		{"first", []string{"a", "b", "c"}, 0, []string{"b", "c"}, false},
+		{"middle", []string{"a", "b", "c"}, 1, []string{"a", "c"}, false},
+		{"last", []string{"a", "b", "c"}, 2, []string{"a", "b"}, false},
+		{"index == len", []string{"a", "b", "c"}, 3, []string{"a", "b", "c"}, true},
+		{"negative index", []string{"a", "b", "c"}, -1, []string{"a", "b", "c"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Delete(tt.input, tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete(%v, %d) error = %v, wantErr %v", tt.input, tt.index, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Delete(%v, %d) = %v, want %v", tt.input, tt.index, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteKeepsInput(t *testing.T) {
+	input := []int{1, 2, 3}
+	got, _ := Delete(input, 0)
+	got[0] = 100
+	if !reflect.DeepEqual(input, []int{1, 2, 3}) {
+		t.Errorf("Delete result shares the input's array : input is %v", input)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{"empty slice", []int{}, []int{}},
+		{"nil slice", nil, nil},
+		{"single element", []int{1}, []int{1}},
+		{"two elements", []int{1, 2}, []int{2, 1}},
+		{"odd length", []int{1, 2, 3, 4, 5}, []int{5, 4, 3, 2, 1}},
+		{"even length", []int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reverse(tt.input)
+			if !reflect.DeepEqual(tt.input, tt.want) {
+				t.Errorf("Reverse = %v, want %v", tt.input, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"empty slice", []string{}, []string{}},
+		{"nil slice", nil, []string{}},
+		{"single element", []string{"go"}, []string{"go"}},
+		{"no duplicates", []string{"a", "b"}, []string{"a", "b"}},
+		{"keeps first order", []string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+		{"all the same", []string{"x", "x", "x"}, []string{"x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unique(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unique(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		size  int
+		want  [][]int
+	}{
+		{"empty slice", []int{}, 2, [][]int{}},
+		{"single element", []int{1}, 2, [][]int{{1}}},
+		{"exact fit", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"short last chunk", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"size 1", []int{1, 2}, 1, [][]int{{1}, {2}}},
+		{"size bigger than slice", []int{1, 2}, 5, [][]int{{1, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chunk(tt.input, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk(%v, %d) = %v, want %v", tt.input, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkAppendDoesNotOverwrite(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	chunks := Chunk(input, 2)
+	_ = append(chunks[0], 100)
+	if !reflect.DeepEqual(input, []int{1, 2, 3, 4}) {
+		t.Errorf("append to the first chunk overwrote the input : %v", input)
+	}
+}
+
+func TestChunkBadSizePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk with size 0 did not panic")
+		}
+	}()
+	Chunk([]int{1}, 0)
+}